@@ -57,111 +57,60 @@ type biosConfigurationResetterProvider struct {
 }
 
 func biosConfiguration(ctx context.Context, generic []biosConfigurationGetterProvider) (biosConfig map[string]string, metadata Metadata, err error) {
-	metadata = newMetadata()
-Loop:
+	calls := make([]providerCall[map[string]string], 0, len(generic))
 	for _, elem := range generic {
 		if elem.BiosConfigurationGetter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-			break Loop
-		default:
-			metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, elem.name)
-			biosConfig, vErr := elem.GetBiosConfiguration(ctx)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				err = multierror.Append(err, vErr)
-				continue
-			}
-			metadata.SuccessfulProvider = elem.name
-			return biosConfig, metadata, nil
-		}
+		calls = append(calls, providerCall[map[string]string]{name: elem.name, call: elem.GetBiosConfiguration})
 	}
 
-	return biosConfig, metadata, multierror.Append(err, errors.New("failure to get bios configuration"))
+	return dispatchRead(ctx, providerTimeout(ctx), "GetBiosConfiguration", calls, "failure to get bios configuration")
 }
 
 func setBiosConfiguration(ctx context.Context, generic []biosConfigurationSetterProvider, biosConfig map[string]string) (metadata Metadata, err error) {
-	metadata = newMetadata()
-Loop:
+	calls := make([]providerCall[struct{}], 0, len(generic))
 	for _, elem := range generic {
 		if elem.BiosConfigurationSetter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-			break Loop
-		default:
-			metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, elem.name)
-			vErr := elem.SetBiosConfiguration(ctx, biosConfig)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				err = multierror.Append(err, vErr)
-				continue
-			}
-			metadata.SuccessfulProvider = elem.name
-			return metadata, nil
-		}
+		call := withoutResult(func(ctx context.Context) error {
+			return elem.SetBiosConfiguration(ctx, biosConfig)
+		})
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: call})
 	}
 
-	return metadata, multierror.Append(err, errors.New("failure to set bios configuration"))
+	_, metadata, err = dispatch(ctx, providerTimeout(ctx), "SetBiosConfiguration", calls, "failure to set bios configuration")
+	return metadata, err
 }
 
 func setBiosConfigurationFromFile(ctx context.Context, generic []biosConfigurationFileSetterProvider, cfg string) (metadata Metadata, err error) {
-	metadata = newMetadata()
-Loop:
+	calls := make([]providerCall[struct{}], 0, len(generic))
 	for _, elem := range generic {
 		if elem.BiosConfigurationFileSetter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-			break Loop
-		default:
-			metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, elem.name)
-			vErr := elem.SetBiosConfigurationFromFile(ctx, cfg)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				err = multierror.Append(err, vErr)
-				continue
-			}
-			metadata.SuccessfulProvider = elem.name
-			return metadata, nil
-		}
+		call := withoutResult(func(ctx context.Context) error {
+			return elem.SetBiosConfigurationFromFile(ctx, cfg)
+		})
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: call})
 	}
 
-	return metadata, multierror.Append(err, errors.New("failure to set bios configuration from file"))
+	_, metadata, err = dispatch(ctx, providerTimeout(ctx), "SetBiosConfigurationFromFile", calls, "failure to set bios configuration from file")
+	return metadata, err
 }
 
 func resetBiosConfiguration(ctx context.Context, generic []biosConfigurationResetterProvider) (metadata Metadata, err error) {
-	metadata = newMetadata()
-Loop:
+	calls := make([]providerCall[struct{}], 0, len(generic))
 	for _, elem := range generic {
 		if elem.BiosConfigurationResetter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-			break Loop
-		default:
-			metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, elem.name)
-			vErr := elem.ResetBiosConfiguration(ctx)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				err = multierror.Append(err, vErr)
-				continue
-			}
-			metadata.SuccessfulProvider = elem.name
-			return metadata, nil
-		}
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: withoutResult(elem.ResetBiosConfiguration)})
 	}
 
-	return metadata, multierror.Append(err, errors.New("failure to reset bios configuration"))
+	_, metadata, err = dispatch(ctx, providerTimeout(ctx), "ResetBiosConfiguration", calls, "failure to reset bios configuration")
+	return metadata, err
 }
 
 // GetBiosConfigurationInterfaces retrieves the BIOS configuration using the first
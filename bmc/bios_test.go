@@ -87,7 +87,7 @@ func TestSetBiosConfigurationInterfaces(t *testing.T) {
 			errMsg:  "foobar",
 			expectedMetadata: Metadata{
 				ProvidersAttempted:   []string{"mock"},
-				FailedProviderDetail: map[string]string{},
+				FailedProviderDetail: map[string]string{"mock": "foobar"},
 			},
 		},
 	}
@@ -102,7 +102,7 @@ func TestSetBiosConfigurationInterfaces(t *testing.T) {
 				assert.ErrorContains(t, err, tt.errMsg)
 			}
 
-			assert.Equal(t, tt.expectedMetadata, metadata)
+			assert.Equal(t, tt.expectedMetadata, withoutAttempts(metadata))
 		})
 	}
 }
@@ -147,7 +147,7 @@ func TestSetBiosConfigurationFromFileInterfaces(t *testing.T) {
 				assert.ErrorContains(t, err, tt.errMsg)
 			}
 
-			assert.Equal(t, tt.expectedMetadata, metadata)
+			assert.Equal(t, tt.expectedMetadata, withoutAttempts(metadata))
 		})
 	}
 }
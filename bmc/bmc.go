@@ -21,6 +21,15 @@ type Metadata struct {
 	SuccessfulCloseConns []string
 	// FailedProviderDetail holds the failed providers error messages for called methods
 	FailedProviderDetail map[string]string
+	// Attempts records the duration, outcome and error of each provider attempt, in the order they returned
+	Attempts []ProviderAttempt
+	// ProvidersCancelled is a slice of providers that were cancelled when another provider succeeded first,
+	// it is only populated by read operations run with WithConcurrentReads.
+	ProvidersCancelled []string
+	// ProviderResults holds the value each provider returned for read operations run with WithVerifiedReads.
+	ProviderResults map[string]string
+	// Inconsistent is set when the providers queried with WithVerifiedReads returned different values.
+	Inconsistent bool
 }
 
 func newMetadata() Metadata {
@@ -47,6 +56,20 @@ func (m *Metadata) RegisterSpanAttributes(host string, span trace.Span) {
 		attribute.String("attempted-providers", strings.Join(m.ProvidersAttempted, ",")),
 	)
 
+	span.SetAttributes(
+		attribute.String("cancelled-providers", strings.Join(m.ProvidersCancelled, ",")),
+	)
+
+	if m.ProviderResults != nil {
+		span.SetAttributes(attribute.Bool("inconsistent-results", m.Inconsistent))
+
+		for p, r := range m.ProviderResults {
+			span.SetAttributes(
+				attribute.String("provider-result-"+p, r),
+			)
+		}
+	}
+
 	for p, e := range m.FailedProviderDetail {
 		span.SetAttributes(
 			attribute.String("provider-errs-"+p, e),
@@ -0,0 +1,204 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// BMCNetworkConfigurator retrieves and sets the BMC management network configuration.
+type BMCNetworkConfigurator interface {
+	GetBMCNetwork(ctx context.Context) (config *BMCNetworkConfig, err error)
+	// SetBMCNetwork applies the given configuration to the BMC management interface,
+	// settings left unset in the configuration are not changed.
+	//
+	// The BMC may become unreachable at its current address once the configuration is applied.
+	SetBMCNetwork(ctx context.Context, config BMCNetworkConfig) (err error)
+}
+
+type bmcNetworkConfiguratorProvider struct {
+	name string
+	BMCNetworkConfigurator
+}
+
+// BMCNetworkConfig describes the BMC management network interface configuration.
+//
+// When setting the configuration, DHCPv4 and a static IPv4 address are exclusive, as are DHCPv6
+// and static IPv6 addresses, leaving both unset keeps the current addressing, nil DNSServers and
+// VLAN and an empty Hostname are not changed.
+type BMCNetworkConfig struct {
+	// InterfaceID identifies the management interface, when empty the first interface is used.
+	InterfaceID string
+	// MACAddress is the management interface MAC address, it is ignored when setting the configuration.
+	MACAddress string
+	Hostname   string
+	DHCPv4     bool
+	IPv4       *BMCIPv4Address
+	DHCPv6     bool
+	IPv6       []BMCIPv6Address
+	// IPv6Gateway is the static IPv6 default gateway.
+	IPv6Gateway string
+	DNSServers  []string
+	VLAN        *BMCVLAN
+}
+
+// BMCIPv4Address is an IPv4 address with its subnet mask and gateway.
+type BMCIPv4Address struct {
+	Address    string
+	SubnetMask string
+	Gateway    string
+}
+
+// BMCIPv6Address is an IPv6 address with its prefix length.
+type BMCIPv6Address struct {
+	Address      string
+	PrefixLength int
+}
+
+// BMCVLAN is the 802.1Q VLAN tagging configuration of the management interface.
+type BMCVLAN struct {
+	Enabled bool
+	ID      int
+}
+
+// Validate returns an error when the configuration cannot be applied.
+func (c *BMCNetworkConfig) Validate() error {
+	if c.DHCPv4 && c.IPv4 != nil {
+		return errors.New("DHCPv4 and a static IPv4 address are exclusive")
+	}
+
+	if c.IPv4 != nil {
+		if ip := net.ParseIP(c.IPv4.Address); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("invalid IPv4 address: %q", c.IPv4.Address)
+		}
+
+		mask := net.ParseIP(c.IPv4.SubnetMask)
+		if mask == nil || mask.To4() == nil {
+			return fmt.Errorf("invalid IPv4 subnet mask: %q", c.IPv4.SubnetMask)
+		}
+
+		if ones, bits := net.IPMask(mask.To4()).Size(); ones == 0 && bits == 0 {
+			return fmt.Errorf("invalid IPv4 subnet mask: %q", c.IPv4.SubnetMask)
+		}
+
+		if c.IPv4.Gateway != "" {
+			if ip := net.ParseIP(c.IPv4.Gateway); ip == nil || ip.To4() == nil {
+				return fmt.Errorf("invalid IPv4 gateway: %q", c.IPv4.Gateway)
+			}
+		}
+	}
+
+	if c.DHCPv6 && len(c.IPv6) > 0 {
+		return errors.New("DHCPv6 and static IPv6 addresses are exclusive")
+	}
+
+	for _, addr := range c.IPv6 {
+		if ip := net.ParseIP(addr.Address); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("invalid IPv6 address: %q", addr.Address)
+		}
+
+		if addr.PrefixLength < 1 || addr.PrefixLength > 128 {
+			return fmt.Errorf("invalid IPv6 prefix length: %d", addr.PrefixLength)
+		}
+	}
+
+	if c.IPv6Gateway != "" {
+		if ip := net.ParseIP(c.IPv6Gateway); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("invalid IPv6 gateway: %q", c.IPv6Gateway)
+		}
+	}
+
+	for _, server := range c.DNSServers {
+		if net.ParseIP(server) == nil {
+			return fmt.Errorf("invalid DNS server address: %q", server)
+		}
+	}
+
+	if c.VLAN != nil && c.VLAN.Enabled && (c.VLAN.ID < 1 || c.VLAN.ID > 4094) {
+		return fmt.Errorf("invalid VLAN ID: %d", c.VLAN.ID)
+	}
+
+	return nil
+}
+
+// getBMCNetwork returns the BMC network configuration from the first successful provider.
+func getBMCNetwork(ctx context.Context, timeout time.Duration, generic []bmcNetworkConfiguratorProvider) (config *BMCNetworkConfig, metadata Metadata, err error) {
+	calls := make([]providerCall[*BMCNetworkConfig], 0, len(generic))
+	for _, elem := range generic {
+		if elem.BMCNetworkConfigurator == nil {
+			continue
+		}
+		calls = append(calls, providerCall[*BMCNetworkConfig]{name: elem.name, call: elem.GetBMCNetwork})
+	}
+
+	return dispatchRead(ctx, timeout, "GetBMCNetwork", calls, "failed to get BMC network configuration")
+}
+
+// GetBMCNetworkFromInterfaces identifies implementations of the BMCNetworkConfigurator interface and returns the BMC network configuration from the first successful provider.
+func GetBMCNetworkFromInterfaces(ctx context.Context, timeout time.Duration, generic []interface{}) (config *BMCNetworkConfig, metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	implementations, err := bmcNetworkConfigurators(generic)
+	if len(implementations) == 0 {
+		return nil, metadata, multierror.Append(err, errors.New("no BMCNetworkConfigurator implementations found"))
+	}
+
+	return getBMCNetwork(ctx, timeout, implementations)
+}
+
+// setBMCNetwork sets the BMC network configuration through the first successful provider.
+func setBMCNetwork(ctx context.Context, timeout time.Duration, config BMCNetworkConfig, generic []bmcNetworkConfiguratorProvider) (metadata Metadata, err error) {
+	calls := make([]providerCall[struct{}], 0, len(generic))
+	for _, elem := range generic {
+		if elem.BMCNetworkConfigurator == nil {
+			continue
+		}
+		call := withoutResult(func(ctx context.Context) error {
+			return elem.SetBMCNetwork(ctx, config)
+		})
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: call})
+	}
+
+	_, metadata, err = dispatch(ctx, timeout, "SetBMCNetwork", calls, "failed to set BMC network configuration")
+	return metadata, err
+}
+
+// SetBMCNetworkFromInterfaces identifies implementations of the BMCNetworkConfigurator interface and sets the BMC network configuration through the first successful provider.
+func SetBMCNetworkFromInterfaces(ctx context.Context, timeout time.Duration, config BMCNetworkConfig, generic []interface{}) (metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	if err := config.Validate(); err != nil {
+		return metadata, errors.Wrap(err, "invalid BMC network configuration")
+	}
+
+	implementations, err := bmcNetworkConfigurators(generic)
+	if len(implementations) == 0 {
+		return metadata, multierror.Append(err, errors.New("no BMCNetworkConfigurator implementations found"))
+	}
+
+	return setBMCNetwork(ctx, timeout, config, implementations)
+}
+
+// bmcNetworkConfigurators returns the BMCNetworkConfigurator implementations in generic.
+func bmcNetworkConfigurators(generic []interface{}) (implementations []bmcNetworkConfiguratorProvider, err error) {
+	for _, elem := range generic {
+		if elem == nil {
+			continue
+		}
+		temp := bmcNetworkConfiguratorProvider{name: getProviderName(elem)}
+		switch p := elem.(type) {
+		case BMCNetworkConfigurator:
+			temp.BMCNetworkConfigurator = p
+			implementations = append(implementations, temp)
+		default:
+			e := fmt.Sprintf("not a BMCNetworkConfigurator implementation: %T", p)
+			err = multierror.Append(err, errors.New(e))
+		}
+	}
+
+	return implementations, err
+}
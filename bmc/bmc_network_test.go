@@ -0,0 +1,121 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBMCNetwork struct {
+	config *BMCNetworkConfig
+	set    BMCNetworkConfig
+	err    error
+}
+
+func (m *mockBMCNetwork) GetBMCNetwork(ctx context.Context) (*BMCNetworkConfig, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		return m.config, m.err
+	}
+}
+
+func (m *mockBMCNetwork) SetBMCNetwork(ctx context.Context, config BMCNetworkConfig) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if m.err != nil {
+			return m.err
+		}
+		m.set = config
+		return nil
+	}
+}
+
+func (m *mockBMCNetwork) Name() string {
+	return "mock"
+}
+
+func TestGetBMCNetworkFromInterfaces(t *testing.T) {
+	config := &BMCNetworkConfig{InterfaceID: "1", DHCPv4: true}
+
+	got, metadata, err := GetBMCNetworkFromInterfaces(context.Background(), 1*time.Second, []interface{}{&mockBMCNetwork{config: config}})
+	assert.NoError(t, err)
+	assert.Equal(t, config, got)
+	assert.Equal(t, "mock", metadata.SuccessfulProvider)
+
+	_, metadata, err = GetBMCNetworkFromInterfaces(context.Background(), 1*time.Second, []interface{}{&mockBMCNetwork{err: errors.New("no interfaces")}})
+	assert.ErrorContains(t, err, "failed to get BMC network configuration")
+	assert.Equal(t, map[string]string{"mock": "no interfaces"}, metadata.FailedProviderDetail)
+
+	_, _, err = GetBMCNetworkFromInterfaces(context.Background(), 1*time.Second, []interface{}{"foo"})
+	assert.ErrorContains(t, err, "no BMCNetworkConfigurator implementations found")
+}
+
+func TestSetBMCNetworkFromInterfaces(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  BMCNetworkConfig
+		generic []interface{}
+		errMsg  string
+	}{
+		{
+			name: "static ipv4",
+			config: BMCNetworkConfig{
+				IPv4:       &BMCIPv4Address{Address: "10.0.0.10", SubnetMask: "255.255.255.0", Gateway: "10.0.0.1"},
+				DNSServers: []string{"10.0.0.2"},
+				VLAN:       &BMCVLAN{Enabled: true, ID: 100},
+			},
+			generic: []interface{}{&mockBMCNetwork{}},
+		},
+		{
+			name:    "dhcp and static ipv4",
+			config:  BMCNetworkConfig{DHCPv4: true, IPv4: &BMCIPv4Address{Address: "10.0.0.10", SubnetMask: "255.255.255.0"}},
+			generic: []interface{}{&mockBMCNetwork{}},
+			errMsg:  "DHCPv4 and a static IPv4 address are exclusive",
+		},
+		{
+			name:    "invalid subnet mask",
+			config:  BMCNetworkConfig{IPv4: &BMCIPv4Address{Address: "10.0.0.10", SubnetMask: "255.0.255.0"}},
+			generic: []interface{}{&mockBMCNetwork{}},
+			errMsg:  "invalid IPv4 subnet mask",
+		},
+		{
+			name:    "invalid ipv6 prefix",
+			config:  BMCNetworkConfig{IPv6: []BMCIPv6Address{{Address: "fd00::10", PrefixLength: 0}}},
+			generic: []interface{}{&mockBMCNetwork{}},
+			errMsg:  "invalid IPv6 prefix length",
+		},
+		{
+			name:    "invalid vlan",
+			config:  BMCNetworkConfig{VLAN: &BMCVLAN{Enabled: true, ID: 4095}},
+			generic: []interface{}{&mockBMCNetwork{}},
+			errMsg:  "invalid VLAN ID",
+		},
+		{
+			name:    "no implementations",
+			config:  BMCNetworkConfig{DHCPv4: true},
+			generic: []interface{}{"foo"},
+			errMsg:  "no BMCNetworkConfigurator implementations found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata, err := SetBMCNetworkFromInterfaces(context.Background(), 1*time.Second, tc.config, tc.generic)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "mock", metadata.SuccessfulProvider)
+			assert.Equal(t, tc.config, tc.generic[0].(*mockBMCNetwork).set)
+		})
+	}
+}
@@ -44,12 +44,6 @@ type bootDeviceProviders struct {
 	bootDeviceSetter BootDeviceSetter
 }
 
-// bootOverrideProvider is an internal struct to correlate an implementation/provider and its name
-type bootOverrideProvider struct {
-	name               string
-	bootOverrideGetter BootDeviceOverrideGetter
-}
-
 // BootDeviceOverride describes a one-time or persistent boot device override.
 type BootDeviceOverride struct {
 	IsPersistent bool
@@ -62,36 +56,19 @@ type BootDeviceOverride struct {
 // setPersistent persists the next boot device.
 // efiBoot sets up the device to boot off UEFI instead of legacy.
 func setBootDevice(ctx context.Context, timeout time.Duration, bootDevice string, setPersistent, efiBoot bool, b []bootDeviceProviders) (ok bool, metadata Metadata, err error) {
-	metadataLocal := newMetadata()
-
+	calls := make([]providerCall[bool], 0, len(b))
 	for _, elem := range b {
 		if elem.bootDeviceSetter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return false, metadata, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			ctx, cancel := context.WithTimeout(ctx, timeout)
-			ok, setErr := elem.bootDeviceSetter.BootDeviceSet(ctx, bootDevice, setPersistent, efiBoot)
-			cancel()
-			if setErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(setErr, "provider: %v", elem.name))
-				metadataLocal.FailedProviderDetail[elem.name] = setErr.Error()
-				continue
-			}
-			if !ok {
-				err = multierror.Append(err, fmt.Errorf("provider: %v, failed to set boot device", elem.name))
-				continue
-			}
-			metadataLocal.SuccessfulProvider = elem.name
-			return ok, metadataLocal, nil
+		call := func(ctx context.Context) (bool, error) {
+			ok, err := elem.bootDeviceSetter.BootDeviceSet(ctx, bootDevice, setPersistent, efiBoot)
+			return ok, notOK(ok, err, "failed to set boot device")
 		}
+		calls = append(calls, providerCall[bool]{name: elem.name, call: call})
 	}
-	return ok, metadataLocal, multierror.Append(err, errors.New("failed to set boot device"))
+
+	return dispatch(ctx, timeout, "SetBootDevice", calls, "failed to set boot device")
 }
 
 // SetBootDeviceFromInterfaces identifies implementations of the BootDeviceSetter interface and passes the found implementations to the setBootDevice() wrapper
@@ -117,34 +94,6 @@ func SetBootDeviceFromInterfaces(ctx context.Context, timeout time.Duration, boo
 	return setBootDevice(ctx, timeout, bootDevice, setPersistent, efiBoot, bdSetters)
 }
 
-// getBootDeviceOverride gets the boot device override settings for the given provider,
-// and updates the given metadata with provider attempts and errors.
-func getBootDeviceOverride(
-	ctx context.Context,
-	timeout time.Duration,
-	provider *bootOverrideProvider,
-	metadata *Metadata,
-) (override BootDeviceOverride, ok bool, err error) {
-	select {
-	case <-ctx.Done():
-		err = multierror.Append(err, ctx.Err())
-		return override, ok, err
-	default:
-		metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, provider.name)
-		ctx, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
-
-		override, err = provider.bootOverrideGetter.BootDeviceOverrideGet(ctx)
-		if err != nil {
-			metadata.FailedProviderDetail[provider.name] = err.Error()
-			return override, ok, nil
-		}
-
-		metadata.SuccessfulProvider = provider.name
-		return override, true, nil
-	}
-}
-
 // GetBootDeviceOverrideFromInterface will get boot device override settings from the first successful
 // call to a BootDeviceOverrideGetter in the array of providers.
 func GetBootDeviceOverrideFromInterface(
@@ -152,30 +101,28 @@ func GetBootDeviceOverrideFromInterface(
 	timeout time.Duration,
 	providers []interface{},
 ) (override BootDeviceOverride, metadata Metadata, err error) {
-	metadata = newMetadata()
-
+	calls := make([]providerCall[BootDeviceOverride], 0, len(providers))
 	for _, elem := range providers {
 		if elem == nil {
 			continue
 		}
 		switch p := elem.(type) {
 		case BootDeviceOverrideGetter:
-			provider := &bootOverrideProvider{name: getProviderName(elem), bootOverrideGetter: p}
-			override, ok, getErr := getBootDeviceOverride(ctx, timeout, provider, &metadata)
-			if getErr != nil || ok {
-				return override, metadata, getErr
-			}
+			calls = append(calls, providerCall[BootDeviceOverride]{name: getProviderName(elem), call: p.BootDeviceOverrideGet})
 		default:
 			e := fmt.Errorf("not a BootDeviceOverrideGetter implementation: %T", p)
 			err = multierror.Append(err, e)
 		}
 	}
 
-	if len(metadata.ProvidersAttempted) == 0 {
-		err = multierror.Append(err, errors.New("no BootDeviceOverrideGetter implementations found"))
-	} else {
-		err = multierror.Append(err, errors.New("failed to get boot device override settings"))
+	if len(calls) == 0 {
+		return override, newMetadata(), multierror.Append(err, errors.New("no BootDeviceOverrideGetter implementations found"))
+	}
+
+	if enabled, strict := verifiedReads(ctx); enabled {
+		equal := func(a, b BootDeviceOverride) bool { return a == b }
+		return verifiedRead(ctx, timeout, "GetBootDeviceOverride", calls, equal, strict, "failed to get boot device override settings")
 	}
 
-	return override, metadata, err
+	return dispatchRead(ctx, timeout, "GetBootDeviceOverride", calls, "failed to get boot device override settings")
 }
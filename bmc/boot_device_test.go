@@ -241,6 +241,7 @@ func TestBootDeviceOverrideGet(t *testing.T) {
 				assert.Nil(t, err)
 			}
 			assert.Equal(t, testCase.expectedOverride, override)
+			metadata = withoutAttempts(metadata)
 			assert.Equal(t, testCase.expectedMetadata, &metadata)
 		})
 	}
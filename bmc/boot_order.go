@@ -0,0 +1,181 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// BootOption describes an entry in the persistent boot order.
+type BootOption struct {
+	// Reference identifies the boot option in the boot order, for UEFI systems this is the
+	// boot option variable name, e.g. Boot0001.
+	Reference string
+	// DisplayName is the user readable description of the boot option.
+	DisplayName string
+	// UefiDevicePath is the UEFI device path of the boot option, empty when not reported.
+	UefiDevicePath string
+	// Enabled is false when the boot option is skipped by the firmware.
+	Enabled bool
+}
+
+// BootOrderGetter retrieves the persistent boot order of a machine.
+type BootOrderGetter interface {
+	GetBootOrder(ctx context.Context) (order []BootOption, err error)
+}
+
+// BootOrderSetter sets the persistent boot order of a machine.
+type BootOrderSetter interface {
+	// SetBootOrder moves the boot options identified by the given references to the front of the
+	// boot order, in the given order, boot options not listed keep their relative order after them.
+	SetBootOrder(ctx context.Context, order []string) (err error)
+}
+
+type bootOrderGetterProvider struct {
+	name string
+	BootOrderGetter
+}
+
+type bootOrderSetterProvider struct {
+	name string
+	BootOrderSetter
+}
+
+// getBootOrder returns the boot order from the first successful provider.
+func getBootOrder(ctx context.Context, timeout time.Duration, generic []bootOrderGetterProvider) (order []BootOption, metadata Metadata, err error) {
+	calls := make([]providerCall[[]BootOption], 0, len(generic))
+	for _, elem := range generic {
+		if elem.BootOrderGetter == nil {
+			continue
+		}
+		calls = append(calls, providerCall[[]BootOption]{name: elem.name, call: elem.GetBootOrder})
+	}
+
+	return dispatchRead(ctx, timeout, "GetBootOrder", calls, "failed to get boot order")
+}
+
+// GetBootOrderFromInterfaces identifies implementations of the BootOrderGetter interface and returns the boot order from the first successful provider.
+func GetBootOrderFromInterfaces(ctx context.Context, timeout time.Duration, generic []interface{}) (order []BootOption, metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	implementations := make([]bootOrderGetterProvider, 0)
+	for _, elem := range generic {
+		if elem == nil {
+			continue
+		}
+		temp := bootOrderGetterProvider{name: getProviderName(elem)}
+		switch p := elem.(type) {
+		case BootOrderGetter:
+			temp.BootOrderGetter = p
+			implementations = append(implementations, temp)
+		default:
+			e := fmt.Sprintf("not a BootOrderGetter implementation: %T", p)
+			err = multierror.Append(err, errors.New(e))
+		}
+	}
+	if len(implementations) == 0 {
+		return order, metadata, multierror.Append(err, errors.New("no BootOrderGetter implementations found"))
+	}
+
+	return getBootOrder(ctx, timeout, implementations)
+}
+
+// setBootOrder sets the boot order through the first successful provider.
+func setBootOrder(ctx context.Context, timeout time.Duration, order []string, generic []bootOrderSetterProvider) (metadata Metadata, err error) {
+	calls := make([]providerCall[struct{}], 0, len(generic))
+	for _, elem := range generic {
+		if elem.BootOrderSetter == nil {
+			continue
+		}
+		call := withoutResult(func(ctx context.Context) error {
+			return elem.SetBootOrder(ctx, order)
+		})
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: call})
+	}
+
+	_, metadata, err = dispatch(ctx, timeout, "SetBootOrder", calls, "failed to set boot order")
+	return metadata, err
+}
+
+// SetBootOrderFromInterfaces identifies implementations of the BootOrderSetter interface and sets the boot order through the first successful provider.
+func SetBootOrderFromInterfaces(ctx context.Context, timeout time.Duration, order []string, generic []interface{}) (metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	if err := validateBootOrder(order); err != nil {
+		return metadata, err
+	}
+
+	implementations := make([]bootOrderSetterProvider, 0)
+	for _, elem := range generic {
+		if elem == nil {
+			continue
+		}
+		temp := bootOrderSetterProvider{name: getProviderName(elem)}
+		switch p := elem.(type) {
+		case BootOrderSetter:
+			temp.BootOrderSetter = p
+			implementations = append(implementations, temp)
+		default:
+			e := fmt.Sprintf("not a BootOrderSetter implementation: %T", p)
+			err = multierror.Append(err, errors.New(e))
+		}
+	}
+	if len(implementations) == 0 {
+		return metadata, multierror.Append(err, errors.New("no BootOrderSetter implementations found"))
+	}
+
+	return setBootOrder(ctx, timeout, order, implementations)
+}
+
+// validateBootOrder returns an error if the boot order is empty or lists a boot option more than once.
+func validateBootOrder(order []string) error {
+	if len(order) == 0 {
+		return errors.New("invalid boot order: no boot options given")
+	}
+
+	seen := make(map[string]bool, len(order))
+	for _, reference := range order {
+		if reference == "" {
+			return errors.New("invalid boot order: empty boot option reference")
+		}
+
+		if seen[reference] {
+			return fmt.Errorf("invalid boot order: boot option %q listed more than once", reference)
+		}
+
+		seen[reference] = true
+	}
+
+	return nil
+}
+
+// CompleteBootOrder returns the boot order with the requested references moved to the front, the
+// references in current that were not requested follow in their existing order. An error is returned
+// when a requested reference is not part of the current boot order.
+func CompleteBootOrder(current, requested []string) ([]string, error) {
+	known := make(map[string]bool, len(current))
+	for _, reference := range current {
+		known[reference] = true
+	}
+
+	listed := make(map[string]bool, len(requested))
+	for _, reference := range requested {
+		if !known[reference] {
+			return nil, fmt.Errorf("unknown boot option: %q", reference)
+		}
+
+		listed[reference] = true
+	}
+
+	order := append(make([]string, 0, len(current)), requested...)
+	for _, reference := range current {
+		if !listed[reference] {
+			order = append(order, reference)
+		}
+	}
+
+	return order, nil
+}
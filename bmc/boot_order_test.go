@@ -0,0 +1,156 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBootOrder struct {
+	order []BootOption
+	set   []string
+	err   error
+}
+
+func (m *mockBootOrder) GetBootOrder(ctx context.Context) ([]BootOption, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		return m.order, m.err
+	}
+}
+
+func (m *mockBootOrder) SetBootOrder(ctx context.Context, order []string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if m.err != nil {
+			return m.err
+		}
+		m.set = order
+		return nil
+	}
+}
+
+func (m *mockBootOrder) Name() string {
+	return "mock"
+}
+
+func TestGetBootOrderFromInterfaces(t *testing.T) {
+	order := []BootOption{
+		{Reference: "Boot0001", DisplayName: "PXE IPv4", Enabled: true},
+		{Reference: "Boot0000", DisplayName: "NVMe", Enabled: true},
+	}
+
+	testCases := []struct {
+		name             string
+		generic          []interface{}
+		expected         []BootOption
+		errMsg           string
+		expectedMetadata Metadata
+	}{
+		{
+			name:     "success",
+			generic:  []interface{}{&mockBootOrder{order: order}},
+			expected: order,
+			expectedMetadata: Metadata{
+				SuccessfulProvider:   "mock",
+				ProvidersAttempted:   []string{"mock"},
+				FailedProviderDetail: make(map[string]string),
+			},
+		},
+		{
+			name:    "provider error",
+			generic: []interface{}{&mockBootOrder{err: errors.New("no boot options")}},
+			errMsg:  "failed to get boot order",
+			expectedMetadata: Metadata{
+				ProvidersAttempted:   []string{"mock"},
+				FailedProviderDetail: map[string]string{"mock": "no boot options"},
+			},
+		},
+		{
+			name:    "no implementations",
+			generic: []interface{}{"foo"},
+			errMsg:  "no BootOrderGetter implementations found",
+			expectedMetadata: Metadata{
+				FailedProviderDetail: make(map[string]string),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, metadata, err := GetBootOrderFromInterfaces(context.Background(), 1*time.Second, tc.generic)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, got)
+			}
+
+			assert.Equal(t, tc.expectedMetadata, withoutAttempts(metadata))
+		})
+	}
+}
+
+func TestSetBootOrderFromInterfaces(t *testing.T) {
+	testCases := []struct {
+		name    string
+		order   []string
+		generic []interface{}
+		errMsg  string
+	}{
+		{
+			name:    "success",
+			order:   []string{"Boot0001", "Boot0000"},
+			generic: []interface{}{&mockBootOrder{}},
+		},
+		{
+			name:    "empty order",
+			generic: []interface{}{&mockBootOrder{}},
+			errMsg:  "no boot options given",
+		},
+		{
+			name:    "duplicate reference",
+			order:   []string{"Boot0001", "Boot0001"},
+			generic: []interface{}{&mockBootOrder{}},
+			errMsg:  `boot option "Boot0001" listed more than once`,
+		},
+		{
+			name:    "no implementations",
+			order:   []string{"Boot0001"},
+			generic: []interface{}{"foo"},
+			errMsg:  "no BootOrderSetter implementations found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata, err := SetBootOrderFromInterfaces(context.Background(), 1*time.Second, tc.order, tc.generic)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "mock", metadata.SuccessfulProvider)
+			assert.Equal(t, tc.order, tc.generic[0].(*mockBootOrder).set)
+		})
+	}
+}
+
+func TestCompleteBootOrder(t *testing.T) {
+	current := []string{"Boot0000", "Boot0001", "Boot0002", "Boot0003"}
+
+	order, err := CompleteBootOrder(current, []string{"Boot0002", "Boot0000"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Boot0002", "Boot0000", "Boot0001", "Boot0003"}, order)
+
+	_, err = CompleteBootOrder(current, []string{"Boot0009"})
+	assert.ErrorContains(t, err, `unknown boot option: "Boot0009"`)
+}
@@ -0,0 +1,124 @@
+package bmc
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/jacobweinstock/registrar"
+	"github.com/pkg/errors"
+)
+
+// CapabilityProber is implemented by providers whose features depend on the BMC, like its license,
+// model or firmware version, and that can probe the BMC for the features it supports.
+type CapabilityProber interface {
+	// ProbeCapabilities probes the BMC for the features the provider supports on it.
+	ProbeCapabilities(ctx context.Context) (*ProbedCapabilities, error)
+}
+
+// ProbedCapabilities is the result of a provider probing the BMC.
+type ProbedCapabilities struct {
+	// Features maps the probed features to their support on the BMC, features
+	// the provider advertises that are not in the map are taken as supported.
+	Features map[registrar.Feature]bool
+	// FirmwareVersion is the BMC firmware version, empty when it could not be read.
+	FirmwareVersion string
+}
+
+// FeatureSupport is the support of a feature on the BMC.
+type FeatureSupport struct {
+	// Supported is true when at least one provider supports the feature on the BMC.
+	Supported bool
+	// Providers are the providers supporting the feature on the BMC.
+	Providers []string
+	// Unsupported are the providers advertising the feature, that found it unsupported on the BMC.
+	Unsupported []string
+}
+
+// Capabilities is the support matrix of the features on a BMC.
+type Capabilities struct {
+	// Features maps each feature advertised by a provider to its support on the BMC.
+	Features map[registrar.Feature]FeatureSupport
+	// FirmwareVersions maps the provider name to the BMC firmware version it read.
+	FirmwareVersions map[string]string
+}
+
+// Supported returns true when the feature is supported on the BMC.
+func (c Capabilities) Supported(feature registrar.Feature) bool {
+	return c.Features[feature].Supported
+}
+
+// ProbeCapabilitiesFromDrivers returns the support matrix of the features advertised by the drivers.
+//
+// The drivers implementing the CapabilityProber interface probe the BMC for the features they support,
+// the features of the other drivers, and of drivers whose probe failed, are taken as advertised.
+// The probe errors are returned along with the support matrix.
+func ProbeCapabilitiesFromDrivers(ctx context.Context, timeout time.Duration, drivers registrar.Drivers) (capabilities Capabilities, metadata Metadata, err error) {
+	capabilities = Capabilities{
+		Features:         make(map[registrar.Feature]FeatureSupport),
+		FirmwareVersions: make(map[string]string),
+	}
+
+	if len(drivers) == 0 {
+		return capabilities, newMetadata(), errors.New("no providers found")
+	}
+
+	probed := make(map[string]*ProbedCapabilities)
+
+	calls := make([]providerCall[struct{}], 0, len(drivers))
+	for _, driver := range drivers {
+		prober, ok := driver.DriverInterface.(CapabilityProber)
+		if !ok {
+			continue
+		}
+
+		name := driver.Name
+		calls = append(calls, providerCall[struct{}]{name: name, call: func(ctx context.Context) (struct{}, error) {
+			result, err := prober.ProbeCapabilities(ctx)
+			if err != nil {
+				return struct{}{}, err
+			}
+
+			probed[name] = result
+
+			return struct{}{}, nil
+		}})
+	}
+
+	metadata = newMetadata()
+	if len(calls) > 0 {
+		_, metadata, err = dispatchAll(ctx, timeout, "ProbeCapabilities", calls)
+		if err != nil {
+			err = multierror.Append(err, errors.New("failed to probe capabilities"))
+		}
+	}
+
+	for _, driver := range drivers {
+		result := probed[driver.Name]
+		if result != nil && result.FirmwareVersion != "" {
+			capabilities.FirmwareVersions[driver.Name] = result.FirmwareVersion
+		}
+
+		for _, feature := range driver.Features {
+			support := capabilities.Features[feature]
+
+			supported := true
+			if result != nil {
+				if probedSupport, ok := result.Features[feature]; ok {
+					supported = probedSupport
+				}
+			}
+
+			if supported {
+				support.Supported = true
+				support.Providers = append(support.Providers, driver.Name)
+			} else {
+				support.Unsupported = append(support.Unsupported, driver.Name)
+			}
+
+			capabilities.Features[feature] = support
+		}
+	}
+
+	return capabilities, metadata, err
+}
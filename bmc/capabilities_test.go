@@ -0,0 +1,63 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jacobweinstock/registrar"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type capabilityProber struct {
+	probed *ProbedCapabilities
+	err    error
+}
+
+func (p *capabilityProber) ProbeCapabilities(_ context.Context) (*ProbedCapabilities, error) {
+	return p.probed, p.err
+}
+
+func TestProbeCapabilitiesFromDrivers(t *testing.T) {
+	drivers := registrar.Drivers{
+		{
+			Name:     "redfish",
+			Features: registrar.Features{"powerstate", "virtualmedia", "bmcreset"},
+			DriverInterface: &capabilityProber{probed: &ProbedCapabilities{
+				Features:        map[registrar.Feature]bool{"virtualmedia": false, "bmcreset": true, "firmwareinstall": true},
+				FirmwareVersion: "1.45",
+			}},
+		},
+		{
+			Name:            "ipmitool",
+			Features:        registrar.Features{"powerstate", "bmcreset"},
+			DriverInterface: &struct{}{},
+		},
+		{
+			Name:            "failing",
+			Features:        registrar.Features{"screenshot"},
+			DriverInterface: &capabilityProber{err: errors.New("session expired")},
+		},
+	}
+
+	capabilities, metadata, err := ProbeCapabilitiesFromDrivers(context.Background(), 1*time.Second, drivers)
+	assert.ErrorContains(t, err, "provider: failing: session expired")
+	assert.ErrorContains(t, err, "failed to probe capabilities")
+	assert.Equal(t, []string{"redfish", "failing"}, metadata.ProvidersAttempted)
+
+	assert.Equal(t, map[registrar.Feature]FeatureSupport{
+		"powerstate":   {Supported: true, Providers: []string{"redfish", "ipmitool"}},
+		"virtualmedia": {Unsupported: []string{"redfish"}},
+		"bmcreset":     {Supported: true, Providers: []string{"redfish", "ipmitool"}},
+		"screenshot":   {Supported: true, Providers: []string{"failing"}},
+	}, capabilities.Features)
+	assert.Equal(t, map[string]string{"redfish": "1.45"}, capabilities.FirmwareVersions)
+
+	assert.True(t, capabilities.Supported("powerstate"))
+	assert.False(t, capabilities.Supported("virtualmedia"))
+	assert.False(t, capabilities.Supported("firmwareinstall"))
+
+	_, _, err = ProbeCapabilitiesFromDrivers(context.Background(), 1*time.Second, nil)
+	assert.EqualError(t, err, "no providers found")
+}
@@ -0,0 +1,207 @@
+package bmc
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// CertificateManager manages the BMC TLS certificates.
+type CertificateManager interface {
+	// GenerateCSR has the BMC generate a key pair and returns the PEM encoded certificate signing
+	// request, the private key does not leave the BMC.
+	GenerateCSR(ctx context.Context, request CSRRequest) (csr string, err error)
+	// ReplaceCertificate installs the PEM encoded certificate in place of the certificate identified
+	// by certificateID, or in place of the BMC HTTPS certificate when certificateID is empty.
+	//
+	// The certificate is expected to be signed for a CSR generated by the BMC.
+	ReplaceCertificate(ctx context.Context, certificateID, certificate string) (err error)
+	ListCertificates(ctx context.Context) (certificates []Certificate, err error)
+}
+
+type certificateManagerProvider struct {
+	name string
+	CertificateManager
+}
+
+// CSRRequest describes the certificate signing request the BMC is to generate.
+type CSRRequest struct {
+	// CertificateCollection identifies where the signed certificate is to be installed, for Redfish
+	// BMCs the certificate collection URI, when empty the BMC HTTPS certificate collection is used.
+	CertificateCollection string
+	CommonName            string
+	AlternativeNames      []string
+	Organization          string
+	OrganizationalUnit    string
+	City                  string
+	State                 string
+	// Country is the two-letter ISO 3166 country code.
+	Country string
+	Email   string
+	// KeyPairAlgorithm is the TCG algorithm name of the key pair, e.g. TPM_ALG_RSA or TPM_ALG_ECDSA,
+	// when empty the BMC default is used.
+	KeyPairAlgorithm string
+	KeyBitLength     int
+	// KeyCurveID is the TCG curve name used for ECDSA key pairs, e.g. TPM_ECC_NIST_P384.
+	KeyCurveID string
+}
+
+// Certificate describes a certificate installed on the BMC.
+type Certificate struct {
+	// ID identifies the certificate, for Redfish BMCs the certificate resource URI.
+	ID string
+	// Type is the certificate format, e.g. PEM.
+	Type           string
+	Subject        string
+	Issuer         string
+	SerialNumber   string
+	Fingerprint    string
+	ValidNotBefore time.Time
+	ValidNotAfter  time.Time
+	// Usage lists what the certificate is used for, e.g. Web or User.
+	Usage    []string
+	KeyUsage []string
+	// PEM is the PEM encoded certificate, when reported by the BMC.
+	PEM string
+}
+
+// Validate returns an error when the request cannot be submitted.
+func (r *CSRRequest) Validate() error {
+	if r.CommonName == "" {
+		return errors.New("common name is required")
+	}
+
+	if r.Country != "" && len(r.Country) != 2 {
+		return fmt.Errorf("invalid country code: %q", r.Country)
+	}
+
+	if r.KeyBitLength < 0 {
+		return fmt.Errorf("invalid key bit length: %d", r.KeyBitLength)
+	}
+
+	return nil
+}
+
+// validateCertificatePEM returns an error when the certificate is not a PEM encoded certificate.
+func validateCertificatePEM(certificate string) error {
+	block, _ := pem.Decode([]byte(certificate))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return errors.New("certificate is not PEM encoded")
+	}
+
+	return nil
+}
+
+// generateCSR generates a certificate signing request through the first successful provider.
+func generateCSR(ctx context.Context, timeout time.Duration, request CSRRequest, generic []certificateManagerProvider) (csr string, metadata Metadata, err error) {
+	calls := make([]providerCall[string], 0, len(generic))
+	for _, elem := range generic {
+		if elem.CertificateManager == nil {
+			continue
+		}
+		call := func(ctx context.Context) (string, error) {
+			return elem.GenerateCSR(ctx, request)
+		}
+		calls = append(calls, providerCall[string]{name: elem.name, call: call})
+	}
+
+	return dispatch(ctx, timeout, "GenerateCSR", calls, "failed to generate certificate signing request")
+}
+
+// GenerateCSRFromInterfaces identifies implementations of the CertificateManager interface and generates a certificate signing request through the first successful provider.
+func GenerateCSRFromInterfaces(ctx context.Context, timeout time.Duration, request CSRRequest, generic []interface{}) (csr string, metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	if err := request.Validate(); err != nil {
+		return "", metadata, errors.Wrap(err, "invalid certificate signing request")
+	}
+
+	implementations, err := certificateManagers(generic)
+	if len(implementations) == 0 {
+		return "", metadata, multierror.Append(err, errors.New("no CertificateManager implementations found"))
+	}
+
+	return generateCSR(ctx, timeout, request, implementations)
+}
+
+// replaceCertificate replaces a BMC certificate through the first successful provider.
+func replaceCertificate(ctx context.Context, timeout time.Duration, certificateID, certificate string, generic []certificateManagerProvider) (metadata Metadata, err error) {
+	calls := make([]providerCall[struct{}], 0, len(generic))
+	for _, elem := range generic {
+		if elem.CertificateManager == nil {
+			continue
+		}
+		call := withoutResult(func(ctx context.Context) error {
+			return elem.ReplaceCertificate(ctx, certificateID, certificate)
+		})
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: call})
+	}
+
+	_, metadata, err = dispatch(ctx, timeout, "ReplaceCertificate", calls, "failed to replace certificate")
+	return metadata, err
+}
+
+// ReplaceCertificateFromInterfaces identifies implementations of the CertificateManager interface and replaces a BMC certificate through the first successful provider.
+func ReplaceCertificateFromInterfaces(ctx context.Context, timeout time.Duration, certificateID, certificate string, generic []interface{}) (metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	if err := validateCertificatePEM(certificate); err != nil {
+		return metadata, err
+	}
+
+	implementations, err := certificateManagers(generic)
+	if len(implementations) == 0 {
+		return metadata, multierror.Append(err, errors.New("no CertificateManager implementations found"))
+	}
+
+	return replaceCertificate(ctx, timeout, certificateID, certificate, implementations)
+}
+
+// listCertificates returns the BMC certificates from the first successful provider.
+func listCertificates(ctx context.Context, timeout time.Duration, generic []certificateManagerProvider) (certificates []Certificate, metadata Metadata, err error) {
+	calls := make([]providerCall[[]Certificate], 0, len(generic))
+	for _, elem := range generic {
+		if elem.CertificateManager == nil {
+			continue
+		}
+		calls = append(calls, providerCall[[]Certificate]{name: elem.name, call: elem.ListCertificates})
+	}
+
+	return dispatchRead(ctx, timeout, "ListCertificates", calls, "failed to list certificates")
+}
+
+// ListCertificatesFromInterfaces identifies implementations of the CertificateManager interface and returns the BMC certificates from the first successful provider.
+func ListCertificatesFromInterfaces(ctx context.Context, timeout time.Duration, generic []interface{}) (certificates []Certificate, metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	implementations, err := certificateManagers(generic)
+	if len(implementations) == 0 {
+		return nil, metadata, multierror.Append(err, errors.New("no CertificateManager implementations found"))
+	}
+
+	return listCertificates(ctx, timeout, implementations)
+}
+
+// certificateManagers returns the CertificateManager implementations in generic.
+func certificateManagers(generic []interface{}) (implementations []certificateManagerProvider, err error) {
+	for _, elem := range generic {
+		if elem == nil {
+			continue
+		}
+		temp := certificateManagerProvider{name: getProviderName(elem)}
+		switch p := elem.(type) {
+		case CertificateManager:
+			temp.CertificateManager = p
+			implementations = append(implementations, temp)
+		default:
+			e := fmt.Sprintf("not a CertificateManager implementation: %T", p)
+			err = multierror.Append(err, errors.New(e))
+		}
+	}
+
+	return implementations, err
+}
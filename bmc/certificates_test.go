@@ -0,0 +1,130 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const testCertificatePEM = `-----BEGIN CERTIFICATE-----
+MIIBszCCAVmgAwIBAgIUJ1uYVpaxlt0NAw6E1B6MnnNbQyQwCgYIKoZIzj0EAwIw
+-----END CERTIFICATE-----
+`
+
+type mockCertificateManager struct {
+	certificates []Certificate
+	replacedID   string
+	err          error
+}
+
+func (m *mockCertificateManager) GenerateCSR(ctx context.Context, request CSRRequest) (string, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+
+	return "csr for " + request.CommonName, nil
+}
+
+func (m *mockCertificateManager) ReplaceCertificate(ctx context.Context, certificateID, certificate string) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.replacedID = certificateID
+
+	return nil
+}
+
+func (m *mockCertificateManager) ListCertificates(ctx context.Context) ([]Certificate, error) {
+	return m.certificates, m.err
+}
+
+func (m *mockCertificateManager) Name() string {
+	return "mock"
+}
+
+func TestGenerateCSRFromInterfaces(t *testing.T) {
+	testCases := []struct {
+		name     string
+		request  CSRRequest
+		generic  []interface{}
+		expected string
+		errMsg   string
+	}{
+		{
+			name:     "success",
+			request:  CSRRequest{CommonName: "bmc.example.com", Country: "NL"},
+			generic:  []interface{}{&mockCertificateManager{}},
+			expected: "csr for bmc.example.com",
+		},
+		{
+			name:    "no common name",
+			request: CSRRequest{Organization: "example"},
+			generic: []interface{}{&mockCertificateManager{}},
+			errMsg:  "common name is required",
+		},
+		{
+			name:    "invalid country",
+			request: CSRRequest{CommonName: "bmc.example.com", Country: "NLD"},
+			generic: []interface{}{&mockCertificateManager{}},
+			errMsg:  "invalid country code",
+		},
+		{
+			name:    "provider error",
+			request: CSRRequest{CommonName: "bmc.example.com"},
+			generic: []interface{}{&mockCertificateManager{err: errors.New("CertificateService is not supported")}},
+			errMsg:  "failed to generate certificate signing request",
+		},
+		{
+			name:    "no implementations",
+			request: CSRRequest{CommonName: "bmc.example.com"},
+			generic: []interface{}{"foo"},
+			errMsg:  "no CertificateManager implementations found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			csr, metadata, err := GenerateCSRFromInterfaces(context.Background(), 1*time.Second, tc.request, tc.generic)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, csr)
+			assert.Equal(t, "mock", metadata.SuccessfulProvider)
+		})
+	}
+}
+
+func TestReplaceCertificateFromInterfaces(t *testing.T) {
+	mock := &mockCertificateManager{}
+
+	metadata, err := ReplaceCertificateFromInterfaces(context.Background(), 1*time.Second, "/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates/1", testCertificatePEM, []interface{}{mock})
+	assert.NoError(t, err)
+	assert.Equal(t, "mock", metadata.SuccessfulProvider)
+	assert.Equal(t, "/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates/1", mock.replacedID)
+
+	_, err = ReplaceCertificateFromInterfaces(context.Background(), 1*time.Second, "", "not a certificate", []interface{}{mock})
+	assert.ErrorContains(t, err, "certificate is not PEM encoded")
+
+	_, err = ReplaceCertificateFromInterfaces(context.Background(), 1*time.Second, "", testCertificatePEM, []interface{}{"foo"})
+	assert.ErrorContains(t, err, "no CertificateManager implementations found")
+}
+
+func TestListCertificatesFromInterfaces(t *testing.T) {
+	certificates := []Certificate{{ID: "1", Subject: "bmc.example.com"}}
+
+	got, metadata, err := ListCertificatesFromInterfaces(context.Background(), 1*time.Second, []interface{}{&mockCertificateManager{certificates: certificates}})
+	assert.NoError(t, err)
+	assert.Equal(t, certificates, got)
+	assert.Equal(t, "mock", metadata.SuccessfulProvider)
+
+	_, metadata, err = ListCertificatesFromInterfaces(context.Background(), 1*time.Second, []interface{}{&mockCertificateManager{err: errors.New("boom")}})
+	assert.ErrorContains(t, err, "failed to list certificates")
+	assert.Equal(t, map[string]string{"mock": "boom"}, metadata.FailedProviderDetail)
+}
@@ -0,0 +1,112 @@
+package bmc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+type concurrentReadsKey struct{}
+
+// WithConcurrentReads returns a context which has the read operations called with it run against
+// all providers concurrently, the result of the first provider to succeed is returned and the
+// providers still running are cancelled.
+//
+// Operations that change the BMC state ignore the setting and keep trying the providers one at a time.
+func WithConcurrentReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, concurrentReadsKey{}, true)
+}
+
+// concurrentReads returns true when the read operations are to run against the providers concurrently.
+func concurrentReads(ctx context.Context) bool {
+	enabled, _ := ctx.Value(concurrentReadsKey{}).(bool)
+	return enabled
+}
+
+// firstSuccess attempts the read operation on the providers concurrently and returns the result of the
+// first provider to succeed, when none succeeds the provider errors are returned along with the failure message.
+//
+// The providers run side by side, so each one is given the whole remaining deadline of ctx rather than
+// its share of it, the timeout only bounds the providers when ctx has no deadline.
+//
+// Providers that have not returned when a provider succeeds are cancelled and recorded in
+// Metadata.ProvidersCancelled, firstSuccess returns once their calls have returned.
+func firstSuccess[T any](ctx context.Context, timeout time.Duration, operation string, calls []providerCall[T], failure string) (result T, metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	select {
+	case <-ctx.Done():
+		return result, metadata, multierror.Append(err, ctx.Err())
+	default:
+	}
+
+	if _, ok := ctx.Deadline(); ok {
+		timeout = noTimeout
+	}
+
+	var wg sync.WaitGroup
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	type outcome struct {
+		index  int
+		result T
+		record ProviderAttempt
+		err    error
+	}
+
+	// buffered so the cancelled providers can return without a receiver
+	outcomes := make(chan outcome, len(calls))
+	pending := make([]bool, len(calls))
+	start := time.Now()
+
+	for i, pc := range calls {
+		metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, pc.name)
+		pending[i] = true
+
+		wg.Add(1)
+		go func(i int, pc providerCall[T]) {
+			defer wg.Done()
+
+			result, record, err := attempt(ctx, timeout, operation, pc)
+			outcomes <- outcome{index: i, result: result, record: record, err: err}
+		}(i, pc)
+	}
+
+	for range calls {
+		o := <-outcomes
+		pending[o.index] = false
+		metadata.recordAttempt(o.record)
+
+		name := calls[o.index].name
+		if o.err != nil {
+			err = multierror.Append(err, calls[o.index].providerError(o.err))
+			continue
+		}
+
+		metadata.SuccessfulProvider = name
+		for i, running := range pending {
+			if !running {
+				continue
+			}
+
+			metadata.ProvidersCancelled = append(metadata.ProvidersCancelled, calls[i].name)
+			metadata.Attempts = append(metadata.Attempts, ProviderAttempt{
+				Provider: calls[i].name,
+				Outcome:  AttemptCancelled,
+				Duration: time.Since(start),
+			})
+		}
+
+		return o.result, metadata, nil
+	}
+
+	return result, metadata, multierror.Append(err, errors.New(failure))
+}
@@ -0,0 +1,103 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type powerStateReader struct {
+	name      string
+	state     string
+	err       error
+	hang      bool
+	cancelled chan struct{}
+}
+
+func (p *powerStateReader) PowerStateGet(ctx context.Context) (string, error) {
+	if p.hang {
+		<-ctx.Done()
+		close(p.cancelled)
+
+		return "", ctx.Err()
+	}
+
+	return p.state, p.err
+}
+
+func (p *powerStateReader) Name() string {
+	return p.name
+}
+
+func TestGetPowerStateConcurrentReads(t *testing.T) {
+	hung := &powerStateReader{name: "hung", hang: true, cancelled: make(chan struct{})}
+	failing := &powerStateReader{name: "failing", err: errors.New("session expired")}
+	working := &powerStateReader{name: "working", state: "on"}
+
+	ctx := WithConcurrentReads(context.Background())
+
+	// the hung provider would eat the whole per provider timeout when the providers are tried one at a time
+	state, metadata, err := GetPowerStateFromInterfaces(ctx, 1*time.Minute, []interface{}{hung, working})
+	assert.NoError(t, err)
+	assert.Equal(t, "on", state)
+	assert.Equal(t, "working", metadata.SuccessfulProvider)
+	assert.Equal(t, []string{"hung", "working"}, metadata.ProvidersAttempted)
+	assert.Equal(t, []string{"hung"}, metadata.ProvidersCancelled)
+
+	// the cancelled provider call has returned along with the read
+	select {
+	case <-hung.cancelled:
+	default:
+		t.Fatal("hung provider call outlived the read")
+	}
+
+	_, metadata, err = GetPowerStateFromInterfaces(ctx, 1*time.Minute, []interface{}{failing})
+	assert.ErrorContains(t, err, "provider: failing: session expired")
+	assert.ErrorContains(t, err, "failed to get power state")
+	assert.Equal(t, map[string]string{"failing": "session expired"}, metadata.FailedProviderDetail)
+	assert.Empty(t, metadata.SuccessfulProvider)
+	assert.Empty(t, metadata.ProvidersCancelled)
+}
+
+func TestFirstSuccessTimeout(t *testing.T) {
+	calls := []providerCall[string]{
+		{name: "slow", call: func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}},
+	}
+
+	_, metadata, err := firstSuccess(context.Background(), 10*time.Millisecond, "Read", calls, "failed to read")
+	assert.ErrorContains(t, err, "context deadline exceeded")
+	assert.ErrorContains(t, err, "failed to read")
+	assert.Equal(t, context.DeadlineExceeded.Error(), metadata.FailedProviderDetail["slow"])
+	assert.Empty(t, metadata.ProvidersCancelled)
+}
+
+func TestFirstSuccessRemainingDeadline(t *testing.T) {
+	calls := []providerCall[string]{
+		{name: "slow", call: func(ctx context.Context) (string, error) {
+			select {
+			case <-time.After(50 * time.Millisecond):
+				return "on", nil
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}},
+		{name: "failing", call: func(context.Context) (string, error) {
+			return "", errors.New("session expired")
+		}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// a slow but healthy provider is not bound by its share of the deadline
+	state, metadata, err := firstSuccess(ctx, 10*time.Millisecond, "Read", calls, "failed to read")
+	assert.NoError(t, err)
+	assert.Equal(t, "on", state)
+	assert.Equal(t, "slow", metadata.SuccessfulProvider)
+}
@@ -50,6 +50,7 @@ func OpenConnectionFromInterfaces(ctx context.Context, timeout time.Duration, pr
 	type result struct {
 		ProviderName string
 		Opener       Opener
+		Record       ProviderAttempt
 		Err          error
 	}
 
@@ -73,10 +74,13 @@ func OpenConnectionFromInterfaces(ctx context.Context, timeout time.Duration, pr
 			wg.Add(1)
 			go func(provider Opener, providerName string) {
 				defer wg.Done()
-				res := result{ProviderName: providerName, Opener: provider}
+				pc := providerCall[struct{}]{name: providerName, call: withoutResult(provider.Open)}
 
-				if err := provider.Open(ctx); err != nil {
-					res.Err = errors.WithMessagef(err, "provider: %v", providerName)
+				// the providers are opened side by side, the timeout is applied to ctx for all of them
+				_, record, err := attempt(ctx, noTimeout, "Open", pc)
+				res := result{ProviderName: providerName, Opener: provider, Record: record}
+				if err != nil {
+					res.Err = pc.providerError(err)
 				}
 
 				results <- res
@@ -93,9 +97,10 @@ func OpenConnectionFromInterfaces(ctx context.Context, timeout time.Duration, pr
 
 	// Gather and handle results from the opener goroutines.
 	for res := range results {
+		metadata.recordAttempt(res.Record)
+
 		if res.Err != nil {
 			err = multierror.Append(err, res.Err)
-			metadata.FailedProviderDetail[res.ProviderName] = res.Err.Error()
 			continue
 		}
 
@@ -112,26 +117,20 @@ func OpenConnectionFromInterfaces(ctx context.Context, timeout time.Duration, pr
 
 // closeConnection closes a connection to a BMC, trying all interface implementations passed in
 func closeConnection(ctx context.Context, c []connectionProviders) (metadata Metadata, err error) {
-	metadata = newMetadata()
-	var connClosed bool
-
+	calls := make([]providerCall[struct{}], 0, len(c))
 	for _, elem := range c {
 		if elem.closer == nil {
 			continue
 		}
-		metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, elem.name)
-		closeErr := elem.closer.Close(ctx)
-		if closeErr != nil {
-			err = multierror.Append(err, errors.WithMessagef(closeErr, "provider: %v", elem.name))
-			metadata.FailedProviderDetail[elem.name] = closeErr.Error()
-			continue
-		}
-		connClosed = true
-		metadata.SuccessfulCloseConns = append(metadata.SuccessfulCloseConns, elem.name)
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: withoutResult(elem.closer.Close)})
 	}
-	if connClosed {
+
+	closed, metadata, err := dispatchAll(ctx, providerTimeout(ctx), "Close", calls)
+	metadata.SuccessfulCloseConns = closed
+	if len(closed) > 0 {
 		return metadata, nil
 	}
+
 	return metadata, multierror.Append(err, errors.New("failed to close connection"))
 }
 
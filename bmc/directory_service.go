@@ -0,0 +1,173 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// DirectoryServiceType is the type of external account provider the BMC authenticates users with.
+type DirectoryServiceType string
+
+const (
+	DirectoryServiceLDAP            DirectoryServiceType = "LDAP"
+	DirectoryServiceActiveDirectory DirectoryServiceType = "ActiveDirectory"
+)
+
+// DirectoryServiceConfigurator retrieves and sets the BMC LDAP and Active Directory configuration.
+type DirectoryServiceConfigurator interface {
+	GetDirectoryService(ctx context.Context, serviceType DirectoryServiceType) (config *DirectoryServiceConfig, err error)
+	SetDirectoryService(ctx context.Context, config DirectoryServiceConfig) (err error)
+}
+
+type directoryServiceConfiguratorProvider struct {
+	name string
+	DirectoryServiceConfigurator
+}
+
+// DirectoryServiceConfig describes the configuration of an LDAP or Active Directory service.
+//
+// When setting the configuration, empty strings and nil slices are not changed, an empty non nil
+// RoleMappings clears the role mappings.
+type DirectoryServiceConfig struct {
+	Type    DirectoryServiceType
+	Enabled bool
+	// ServiceAddresses are the directory server addresses, e.g. ldaps://ldap.example.com:636.
+	ServiceAddresses []string
+	// BaseDistinguishedNames are the base DNs user and group searches start at.
+	BaseDistinguishedNames []string
+	// UsernameAttribute and GroupsAttribute are the LDAP attributes holding the username and group memberships of a user entry.
+	UsernameAttribute string
+	GroupsAttribute   string
+	BindUsername      string
+	// BindPassword is not reported by BMCs, it is only used when setting the configuration.
+	BindPassword string
+	RoleMappings []DirectoryRoleMapping
+}
+
+// DirectoryRoleMapping maps a directory group to a BMC role.
+type DirectoryRoleMapping struct {
+	// RemoteGroup is the directory group, e.g. a group DN for LDAP or a group name for Active Directory.
+	RemoteGroup string
+	// LocalRole is the BMC role assigned to the group members, e.g. Administrator, Operator or ReadOnly.
+	LocalRole string
+}
+
+// Validate returns an error when the configuration cannot be applied.
+func (c *DirectoryServiceConfig) Validate() error {
+	if err := validateDirectoryServiceType(c.Type); err != nil {
+		return err
+	}
+
+	for _, address := range c.ServiceAddresses {
+		if address == "" {
+			return errors.New("empty directory service address")
+		}
+	}
+
+	for _, mapping := range c.RoleMappings {
+		if mapping.RemoteGroup == "" || mapping.LocalRole == "" {
+			return fmt.Errorf("role mapping requires a remote group and a local role: %+v", mapping)
+		}
+	}
+
+	return nil
+}
+
+// validateDirectoryServiceType returns an error for unknown directory service types.
+func validateDirectoryServiceType(serviceType DirectoryServiceType) error {
+	switch serviceType {
+	case DirectoryServiceLDAP, DirectoryServiceActiveDirectory:
+		return nil
+	default:
+		return fmt.Errorf("unknown directory service type: %q", serviceType)
+	}
+}
+
+// getDirectoryService returns the directory service configuration from the first successful provider.
+func getDirectoryService(ctx context.Context, timeout time.Duration, serviceType DirectoryServiceType, generic []directoryServiceConfiguratorProvider) (config *DirectoryServiceConfig, metadata Metadata, err error) {
+	calls := make([]providerCall[*DirectoryServiceConfig], 0, len(generic))
+	for _, elem := range generic {
+		if elem.DirectoryServiceConfigurator == nil {
+			continue
+		}
+		call := func(ctx context.Context) (*DirectoryServiceConfig, error) {
+			return elem.GetDirectoryService(ctx, serviceType)
+		}
+		calls = append(calls, providerCall[*DirectoryServiceConfig]{name: elem.name, call: call})
+	}
+
+	return dispatchRead(ctx, timeout, "GetDirectoryService", calls, "failed to get directory service configuration")
+}
+
+// GetDirectoryServiceFromInterfaces identifies implementations of the DirectoryServiceConfigurator interface and returns the directory service configuration from the first successful provider.
+func GetDirectoryServiceFromInterfaces(ctx context.Context, timeout time.Duration, serviceType DirectoryServiceType, generic []interface{}) (config *DirectoryServiceConfig, metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	if err := validateDirectoryServiceType(serviceType); err != nil {
+		return nil, metadata, err
+	}
+
+	implementations, err := directoryServiceConfigurators(generic)
+	if len(implementations) == 0 {
+		return nil, metadata, multierror.Append(err, errors.New("no DirectoryServiceConfigurator implementations found"))
+	}
+
+	return getDirectoryService(ctx, timeout, serviceType, implementations)
+}
+
+// setDirectoryService sets the directory service configuration through the first successful provider.
+func setDirectoryService(ctx context.Context, timeout time.Duration, config DirectoryServiceConfig, generic []directoryServiceConfiguratorProvider) (metadata Metadata, err error) {
+	calls := make([]providerCall[struct{}], 0, len(generic))
+	for _, elem := range generic {
+		if elem.DirectoryServiceConfigurator == nil {
+			continue
+		}
+		call := withoutResult(func(ctx context.Context) error {
+			return elem.SetDirectoryService(ctx, config)
+		})
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: call})
+	}
+
+	_, metadata, err = dispatch(ctx, timeout, "SetDirectoryService", calls, "failed to set directory service configuration")
+	return metadata, err
+}
+
+// SetDirectoryServiceFromInterfaces identifies implementations of the DirectoryServiceConfigurator interface and sets the directory service configuration through the first successful provider.
+func SetDirectoryServiceFromInterfaces(ctx context.Context, timeout time.Duration, config DirectoryServiceConfig, generic []interface{}) (metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	if err := config.Validate(); err != nil {
+		return metadata, errors.Wrap(err, "invalid directory service configuration")
+	}
+
+	implementations, err := directoryServiceConfigurators(generic)
+	if len(implementations) == 0 {
+		return metadata, multierror.Append(err, errors.New("no DirectoryServiceConfigurator implementations found"))
+	}
+
+	return setDirectoryService(ctx, timeout, config, implementations)
+}
+
+// directoryServiceConfigurators returns the DirectoryServiceConfigurator implementations in generic.
+func directoryServiceConfigurators(generic []interface{}) (implementations []directoryServiceConfiguratorProvider, err error) {
+	for _, elem := range generic {
+		if elem == nil {
+			continue
+		}
+		temp := directoryServiceConfiguratorProvider{name: getProviderName(elem)}
+		switch p := elem.(type) {
+		case DirectoryServiceConfigurator:
+			temp.DirectoryServiceConfigurator = p
+			implementations = append(implementations, temp)
+		default:
+			e := fmt.Sprintf("not a DirectoryServiceConfigurator implementation: %T", p)
+			err = multierror.Append(err, errors.New(e))
+		}
+	}
+
+	return implementations, err
+}
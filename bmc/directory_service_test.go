@@ -0,0 +1,103 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockDirectoryService struct {
+	config *DirectoryServiceConfig
+	set    DirectoryServiceConfig
+	err    error
+}
+
+func (m *mockDirectoryService) GetDirectoryService(ctx context.Context, serviceType DirectoryServiceType) (*DirectoryServiceConfig, error) {
+	return m.config, m.err
+}
+
+func (m *mockDirectoryService) SetDirectoryService(ctx context.Context, config DirectoryServiceConfig) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.set = config
+
+	return nil
+}
+
+func (m *mockDirectoryService) Name() string {
+	return "mock"
+}
+
+func TestGetDirectoryServiceFromInterfaces(t *testing.T) {
+	config := &DirectoryServiceConfig{Type: DirectoryServiceLDAP, Enabled: true, ServiceAddresses: []string{"ldaps://ldap.example.com"}}
+
+	got, metadata, err := GetDirectoryServiceFromInterfaces(context.Background(), 1*time.Second, DirectoryServiceLDAP, []interface{}{&mockDirectoryService{config: config}})
+	assert.NoError(t, err)
+	assert.Equal(t, config, got)
+	assert.Equal(t, "mock", metadata.SuccessfulProvider)
+
+	_, _, err = GetDirectoryServiceFromInterfaces(context.Background(), 1*time.Second, "Kerberos", []interface{}{&mockDirectoryService{config: config}})
+	assert.ErrorContains(t, err, "unknown directory service type")
+
+	_, metadata, err = GetDirectoryServiceFromInterfaces(context.Background(), 1*time.Second, DirectoryServiceActiveDirectory, []interface{}{&mockDirectoryService{err: errors.New("not supported")}})
+	assert.ErrorContains(t, err, "failed to get directory service configuration")
+	assert.Equal(t, map[string]string{"mock": "not supported"}, metadata.FailedProviderDetail)
+}
+
+func TestSetDirectoryServiceFromInterfaces(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  DirectoryServiceConfig
+		generic []interface{}
+		errMsg  string
+	}{
+		{
+			name: "ldap",
+			config: DirectoryServiceConfig{
+				Type:                   DirectoryServiceLDAP,
+				Enabled:                true,
+				ServiceAddresses:       []string{"ldaps://ldap.example.com:636"},
+				BaseDistinguishedNames: []string{"dc=example,dc=com"},
+				RoleMappings:           []DirectoryRoleMapping{{RemoteGroup: "cn=bmc-admins,ou=groups,dc=example,dc=com", LocalRole: "Administrator"}},
+			},
+			generic: []interface{}{&mockDirectoryService{}},
+		},
+		{
+			name:    "unknown type",
+			config:  DirectoryServiceConfig{Type: "TACACS"},
+			generic: []interface{}{&mockDirectoryService{}},
+			errMsg:  "unknown directory service type",
+		},
+		{
+			name:    "incomplete role mapping",
+			config:  DirectoryServiceConfig{Type: DirectoryServiceActiveDirectory, RoleMappings: []DirectoryRoleMapping{{RemoteGroup: "bmc-admins"}}},
+			generic: []interface{}{&mockDirectoryService{}},
+			errMsg:  "role mapping requires a remote group and a local role",
+		},
+		{
+			name:    "no implementations",
+			config:  DirectoryServiceConfig{Type: DirectoryServiceLDAP},
+			generic: []interface{}{"foo"},
+			errMsg:  "no DirectoryServiceConfigurator implementations found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata, err := SetDirectoryServiceFromInterfaces(context.Background(), 1*time.Second, tc.config, tc.generic)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "mock", metadata.SuccessfulProvider)
+			assert.Equal(t, tc.config, tc.generic[0].(*mockDirectoryService).set)
+		})
+	}
+}
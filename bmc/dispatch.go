@@ -0,0 +1,219 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	pkgName = "github.com/bmc-toolbox/bmclib/v2/bmc"
+
+	// noTimeout is passed by operations without a per provider timeout, like uploads that commonly
+	// outlast it, the providers are then bound only by the deadline of the context.
+	noTimeout time.Duration = -1
+)
+
+type providerTimeoutKey struct{}
+
+// WithProviderTimeout returns a context which has the operations without a timeout argument bound each
+// provider attempt by timeout, like the operations that take a per provider timeout.
+func WithProviderTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, providerTimeoutKey{}, timeout)
+}
+
+// providerTimeout returns the per provider timeout set on ctx, or noTimeout when none is set.
+func providerTimeout(ctx context.Context) time.Duration {
+	if timeout, ok := ctx.Value(providerTimeoutKey{}).(time.Duration); ok {
+		return timeout
+	}
+
+	return noTimeout
+}
+
+// AttemptOutcome is the outcome of an operation attempted on a provider.
+type AttemptOutcome string
+
+const (
+	// AttemptSucceeded is the outcome of an attempt that returned without error.
+	AttemptSucceeded AttemptOutcome = "succeeded"
+	// AttemptFailed is the outcome of an attempt that returned an error.
+	AttemptFailed AttemptOutcome = "failed"
+	// AttemptCancelled is the outcome of an attempt that was cancelled when another provider succeeded first.
+	AttemptCancelled AttemptOutcome = "cancelled"
+)
+
+// ProviderAttempt records an operation attempted on a provider.
+type ProviderAttempt struct {
+	// Provider is the name of the provider the operation was attempted on.
+	Provider string
+	// Outcome is the outcome of the attempt.
+	Outcome AttemptOutcome
+	// Duration is the time the provider took to return, or to be cancelled.
+	Duration time.Duration
+	// Error is the error returned by the provider, it is empty when the attempt succeeded.
+	Error string
+}
+
+// providerCall is an operation bound to a provider.
+type providerCall[T any] struct {
+	name string
+	call func(ctx context.Context) (T, error)
+	// unnamed has the errors of the call returned without the provider name, as the user
+	// operations have always returned them.
+	unnamed bool
+}
+
+// providerError returns the error of the call as returned to the caller of the operation.
+func (pc providerCall[T]) providerError(err error) error {
+	if pc.unnamed {
+		return err
+	}
+
+	var failure *notOKError
+	if errors.As(err, &failure) {
+		return errors.New(fmt.Sprintf("provider: %v, %v", pc.name, failure.message))
+	}
+
+	return errors.WithMessagef(err, "provider: %v", pc.name)
+}
+
+// withoutResult adapts an operation that only returns an error for use in a providerCall.
+func withoutResult(call func(ctx context.Context) error) func(ctx context.Context) (struct{}, error) {
+	return func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, call(ctx)
+	}
+}
+
+// notOKError is the error of an operation that reported a failure with a false ok and no error.
+type notOKError struct {
+	message string
+}
+
+func (e *notOKError) Error() string {
+	return e.message
+}
+
+// notOK returns the error for operations that report a failure with a false ok and no error.
+func notOK(ok bool, err error, failure string) error {
+	if err == nil && !ok {
+		return &notOKError{message: failure}
+	}
+
+	return err
+}
+
+// attempt runs the operation on the provider in a child span of the span in ctx.
+//
+// The timeout is applied to the context passed to the provider unless it is noTimeout.
+func attempt[T any](ctx context.Context, timeout time.Duration, operation string, pc providerCall[T]) (T, ProviderAttempt, error) {
+	tracer := trace.SpanFromContext(ctx).TracerProvider().Tracer(pkgName)
+
+	ctx, span := tracer.Start(ctx, operation, trace.WithAttributes(attribute.String("provider", pc.name)))
+	defer span.End()
+
+	if timeout != noTimeout {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	result, err := pc.call(ctx)
+	record := ProviderAttempt{Provider: pc.name, Outcome: AttemptSucceeded, Duration: time.Since(start)}
+
+	if err != nil {
+		record.Outcome = AttemptFailed
+		record.Error = err.Error()
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.SetAttributes(
+		attribute.String("outcome", string(record.Outcome)),
+		attribute.Int64("duration-ms", record.Duration.Milliseconds()),
+	)
+
+	return result, record, err
+}
+
+// recordAttempt adds the provider attempt to the metadata.
+func (m *Metadata) recordAttempt(record ProviderAttempt) {
+	m.Attempts = append(m.Attempts, record)
+
+	if record.Outcome == AttemptFailed {
+		m.FailedProviderDetail[record.Provider] = record.Error
+	}
+}
+
+// dispatch attempts the operation on the providers one at a time, and returns the result of the
+// first provider to succeed. When no provider succeeds the provider errors are returned along with
+// the failure message.
+func dispatch[T any](ctx context.Context, timeout time.Duration, operation string, calls []providerCall[T], failure string) (result T, metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	for _, pc := range calls {
+		select {
+		case <-ctx.Done():
+			return result, metadata, multierror.Append(err, ctx.Err())
+		default:
+		}
+
+		metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, pc.name)
+
+		value, record, callErr := attempt(ctx, timeout, operation, pc)
+		metadata.recordAttempt(record)
+
+		if callErr != nil {
+			err = multierror.Append(err, pc.providerError(callErr))
+			continue
+		}
+
+		metadata.SuccessfulProvider = pc.name
+
+		return value, metadata, nil
+	}
+
+	return result, metadata, multierror.Append(err, errors.New(failure))
+}
+
+// dispatchRead attempts the read operation on the providers, concurrently when ctx has concurrent
+// reads enabled and otherwise one at a time.
+func dispatchRead[T any](ctx context.Context, timeout time.Duration, operation string, calls []providerCall[T], failure string) (result T, metadata Metadata, err error) {
+	if concurrentReads(ctx) {
+		return firstSuccess(ctx, timeout, operation, calls, failure)
+	}
+
+	return dispatch(ctx, timeout, operation, calls, failure)
+}
+
+// dispatchAll attempts the operation on every provider, for operations where each provider holds
+// its own state, like a session. The attempts that succeeded are returned along with the provider errors.
+//
+// Every provider is attempted even once ctx is done, so that each one gets to release its own state.
+func dispatchAll(ctx context.Context, timeout time.Duration, operation string, calls []providerCall[struct{}]) (succeeded []string, metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	for _, pc := range calls {
+		metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, pc.name)
+
+		_, record, callErr := attempt(ctx, timeout, operation, pc)
+		metadata.recordAttempt(record)
+
+		if callErr != nil {
+			err = multierror.Append(err, pc.providerError(callErr))
+			continue
+		}
+
+		succeeded = append(succeeded, pc.name)
+	}
+
+	return succeeded, metadata, err
+}
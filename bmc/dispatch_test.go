@@ -0,0 +1,143 @@
+package bmc
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// withoutAttempts returns the metadata without the provider attempts, their durations vary between runs.
+func withoutAttempts(metadata Metadata) Metadata {
+	metadata.Attempts = nil
+	return metadata
+}
+
+// spanRecorder is a trace.TracerProvider recording the spans started with it.
+type spanRecorder struct {
+	embedded.TracerProvider
+
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+type spanRecorderTracer struct {
+	embedded.Tracer
+	recorder *spanRecorder
+}
+
+type recordedSpan struct {
+	noop.Span
+	recorder   *spanRecorder
+	name       string
+	parent     trace.Span
+	attributes []attribute.KeyValue
+}
+
+func (r *spanRecorder) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return spanRecorderTracer{recorder: r}
+}
+
+func (t spanRecorderTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	r := t.recorder
+	config := trace.NewSpanStartConfig(opts...)
+	span := &recordedSpan{
+		recorder:   r,
+		name:       name,
+		parent:     trace.SpanFromContext(ctx),
+		attributes: config.Attributes(),
+	}
+
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+func (s *recordedSpan) TracerProvider() trace.TracerProvider {
+	return s.recorder
+}
+
+func (s *recordedSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.attributes = append(s.attributes, kv...)
+}
+
+func TestDispatch(t *testing.T) {
+	recorder := &spanRecorder{}
+
+	ctx, span := recorder.Tracer("test").Start(context.Background(), "GetPowerState")
+
+	calls := []providerCall[string]{
+		{name: "slow", call: func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}},
+		{name: "failing", call: func(context.Context) (string, error) {
+			return "", errors.New("session expired")
+		}},
+		{name: "working", call: func(context.Context) (string, error) {
+			return "on", nil
+		}},
+	}
+
+	state, metadata, err := dispatch(ctx, 10*time.Millisecond, "PowerStateGet", calls, "failed to get power state")
+
+	require.NoError(t, err)
+	assert.Equal(t, "on", state)
+	assert.Equal(t, "working", metadata.SuccessfulProvider)
+	assert.Equal(t, []string{"slow", "failing", "working"}, metadata.ProvidersAttempted)
+	assert.Equal(t, map[string]string{"slow": context.DeadlineExceeded.Error(), "failing": "session expired"}, metadata.FailedProviderDetail)
+
+	require.Len(t, metadata.Attempts, 3)
+	assert.Equal(t, AttemptFailed, metadata.Attempts[0].Outcome)
+	assert.GreaterOrEqual(t, metadata.Attempts[0].Duration, 10*time.Millisecond)
+	assert.Equal(t, "session expired", metadata.Attempts[1].Error)
+	assert.Equal(t, ProviderAttempt{Provider: "working", Outcome: AttemptSucceeded, Duration: metadata.Attempts[2].Duration}, metadata.Attempts[2])
+
+	// one child span per provider attempt
+	require.Len(t, recorder.spans, 4)
+	for i, name := range []string{"slow", "failing", "working"} {
+		child := recorder.spans[i+1]
+		assert.Equal(t, "PowerStateGet", child.name)
+		assert.Equal(t, span, child.parent)
+		assert.Contains(t, child.attributes, attribute.String("provider", name))
+	}
+	assert.Contains(t, recorder.spans[2].attributes, attribute.String("outcome", string(AttemptFailed)))
+
+	_, metadata, err = dispatch(ctx, noTimeout, "PowerStateGet", calls[1:2], "failed to get power state")
+	assert.EqualError(t, err, "2 errors occurred:\n\t* provider: failing: session expired\n\t* failed to get power state\n\n")
+	assert.Empty(t, metadata.SuccessfulProvider)
+}
+
+func TestProviderTimeout(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, noTimeout, providerTimeout(ctx))
+
+	ctx = WithProviderTimeout(ctx, 10*time.Millisecond)
+	assert.Equal(t, 10*time.Millisecond, providerTimeout(ctx))
+
+	calls := []providerCall[string]{
+		{name: "slow", call: func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}},
+		{name: "working", call: func(context.Context) (string, error) {
+			return "on", nil
+		}},
+	}
+
+	// the operations without a timeout argument take the per provider timeout from ctx
+	state, metadata, err := dispatch(ctx, providerTimeout(ctx), "PowerStateGet", calls, "failed to get power state")
+	require.NoError(t, err)
+	assert.Equal(t, "on", state)
+	assert.Equal(t, context.DeadlineExceeded.Error(), metadata.FailedProviderDetail["slow"])
+}
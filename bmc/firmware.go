@@ -36,31 +36,19 @@ type firmwareInstallerProvider struct {
 
 // firmwareInstall uploads and initiates firmware update for the component
 func firmwareInstall(ctx context.Context, component, operationApplyTime string, forceInstall bool, reader io.Reader, generic []firmwareInstallerProvider) (taskID string, metadata Metadata, err error) {
-	metadata = newMetadata()
-
+	calls := make([]providerCall[string], 0, len(generic))
 	for _, elem := range generic {
 		if elem.FirmwareInstaller == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return taskID, metadata, err
-		default:
-			metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, elem.name)
-			taskID, vErr := elem.FirmwareInstall(ctx, component, operationApplyTime, forceInstall, reader)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				metadata.FailedProviderDetail[elem.name] = err.Error()
-				continue
-			}
-			metadata.SuccessfulProvider = elem.name
-			return taskID, metadata, nil
+		call := func(ctx context.Context) (string, error) {
+			return elem.FirmwareInstall(ctx, component, operationApplyTime, forceInstall, reader)
 		}
+		calls = append(calls, providerCall[string]{name: elem.name, call: call})
 	}
 
-	return taskID, metadata, multierror.Append(err, errors.New("failure in FirmwareInstall"))
+	// uploading a firmware image commonly outlasts the per provider timeout, the provider is bound by ctx only
+	return dispatch(ctx, noTimeout, "FirmwareInstall", calls, "failure in FirmwareInstall")
 }
 
 // FirmwareInstallFromInterfaces identifies implementations of the FirmwareInstaller interface and passes the found implementations to the firmwareInstall() wrapper
@@ -119,31 +107,18 @@ type firmwareInstallVerifierProvider struct {
 
 // firmwareInstallStatus returns the status of the firmware install process
 func firmwareInstallStatus(ctx context.Context, installVersion, component, taskID string, generic []firmwareInstallVerifierProvider) (status string, metadata Metadata, err error) {
-	metadata = newMetadata()
-
+	calls := make([]providerCall[string], 0, len(generic))
 	for _, elem := range generic {
 		if elem.FirmwareInstallVerifier == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return status, metadata, err
-		default:
-			metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, elem.name)
-			status, vErr := elem.FirmwareInstallStatus(ctx, installVersion, component, taskID)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				metadata.FailedProviderDetail[elem.name] = err.Error()
-				continue
-			}
-			metadata.SuccessfulProvider = elem.name
-			return status, metadata, nil
+		call := func(ctx context.Context) (string, error) {
+			return elem.FirmwareInstallStatus(ctx, installVersion, component, taskID)
 		}
+		calls = append(calls, providerCall[string]{name: elem.name, call: call})
 	}
 
-	return status, metadata, multierror.Append(err, errors.New("failure in FirmwareInstallStatus"))
+	return dispatch(ctx, providerTimeout(ctx), "FirmwareInstallStatus", calls, "failure in FirmwareInstallStatus")
 }
 
 // FirmwareInstallStatusFromInterfaces identifies implementations of the FirmwareInstallVerifier interface and passes the found implementations to the firmwareInstallStatus() wrapper.
@@ -197,31 +172,19 @@ type firmwareInstallProvider struct {
 
 // firmwareInstall uploads and initiates firmware update for the component
 func firmwareInstallUploadAndInitiate(ctx context.Context, component string, file *os.File, generic []firmwareInstallProvider) (taskID string, metadata Metadata, err error) {
-	metadata = newMetadata()
-
+	calls := make([]providerCall[string], 0, len(generic))
 	for _, elem := range generic {
 		if elem.FirmwareInstallProvider == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return taskID, metadata, err
-		default:
-			metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, elem.name)
-			taskID, vErr := elem.FirmwareInstallUploadAndInitiate(ctx, component, file)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				metadata.FailedProviderDetail[elem.name] = err.Error()
-				continue
-			}
-			metadata.SuccessfulProvider = elem.name
-			return taskID, metadata, nil
+		call := func(ctx context.Context) (string, error) {
+			return elem.FirmwareInstallUploadAndInitiate(ctx, component, file)
 		}
+		calls = append(calls, providerCall[string]{name: elem.name, call: call})
 	}
 
-	return taskID, metadata, multierror.Append(err, errors.New("failure in FirmwareInstallUploadAndInitiate"))
+	// uploading a firmware image commonly outlasts the per provider timeout, the provider is bound by ctx only
+	return dispatch(ctx, noTimeout, "FirmwareInstallUploadAndInitiate", calls, "failure in FirmwareInstallUploadAndInitiate")
 }
 
 // FirmwareInstallUploadAndInitiateFromInterfaces identifies implementations of the FirmwareInstallProvider interface and passes the found implementations to the firmwareInstallUploadAndInitiate() wrapper
@@ -277,32 +240,18 @@ type firmwareInstallerWithOptionsProvider struct {
 
 // firmwareInstallUploaded uploads and initiates firmware update for the component
 func firmwareInstallUploaded(ctx context.Context, component, uploadTaskID string, generic []firmwareInstallerWithOptionsProvider) (installTaskID string, metadata Metadata, err error) {
-	metadata = newMetadata()
-
+	calls := make([]providerCall[string], 0, len(generic))
 	for _, elem := range generic {
 		if elem.FirmwareInstallerUploaded == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return installTaskID, metadata, err
-		default:
-			metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, elem.name)
-			var vErr error
-			installTaskID, vErr = elem.FirmwareInstallUploaded(ctx, component, uploadTaskID)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				metadata.FailedProviderDetail[elem.name] = err.Error()
-				continue
-			}
-			metadata.SuccessfulProvider = elem.name
-			return installTaskID, metadata, nil
+		call := func(ctx context.Context) (string, error) {
+			return elem.FirmwareInstallUploaded(ctx, component, uploadTaskID)
 		}
+		calls = append(calls, providerCall[string]{name: elem.name, call: call})
 	}
 
-	return installTaskID, metadata, multierror.Append(err, errors.New("failure in FirmwareInstallUploaded"))
+	return dispatch(ctx, providerTimeout(ctx), "FirmwareInstallUploaded", calls, "failure in FirmwareInstallUploaded")
 }
 
 // FirmwareInstallerUploadedFromInterfaces identifies implementations of the FirmwareInstallUploaded interface and passes the found implementations to the firmwareInstallUploaded() wrapper
@@ -381,31 +330,18 @@ func FirmwareInstallStepsFromInterfaces(ctx context.Context, component string, g
 }
 
 func firmwareInstallSteps(ctx context.Context, component string, generic []firmwareInstallStepsGetterProvider) (steps []constants.FirmwareInstallStep, metadata Metadata, err error) {
-	metadata = newMetadata()
-
+	calls := make([]providerCall[[]constants.FirmwareInstallStep], 0, len(generic))
 	for _, elem := range generic {
 		if elem.FirmwareInstallStepsGetter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return steps, metadata, err
-		default:
-			metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, elem.name)
-			steps, vErr := elem.FirmwareInstallSteps(ctx, component)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				metadata.FailedProviderDetail[elem.name] = err.Error()
-				continue
-			}
-			metadata.SuccessfulProvider = elem.name
-			return steps, metadata, nil
+		call := func(ctx context.Context) ([]constants.FirmwareInstallStep, error) {
+			return elem.FirmwareInstallSteps(ctx, component)
 		}
+		calls = append(calls, providerCall[[]constants.FirmwareInstallStep]{name: elem.name, call: call})
 	}
 
-	return steps, metadata, multierror.Append(err, errors.New("failure in FirmwareInstallSteps"))
+	return dispatch(ctx, providerTimeout(ctx), "FirmwareInstallSteps", calls, "failure in FirmwareInstallSteps")
 }
 
 // FirmwareUploader provides uploading a firmware image for a component to the BMC.
@@ -452,31 +388,19 @@ func FirmwareUploadFromInterfaces(ctx context.Context, component string, file *o
 }
 
 func firmwareUpload(ctx context.Context, component string, file *os.File, generic []firmwareUploaderProvider) (taskID string, metadata Metadata, err error) {
-	metadata = newMetadata()
-
+	calls := make([]providerCall[string], 0, len(generic))
 	for _, elem := range generic {
 		if elem.FirmwareUploader == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return taskID, metadata, err
-		default:
-			metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, elem.name)
-			taskID, vErr := elem.FirmwareUpload(ctx, component, file)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				metadata.FailedProviderDetail[elem.name] = err.Error()
-				continue
-			}
-			metadata.SuccessfulProvider = elem.name
-			return taskID, metadata, nil
+		call := func(ctx context.Context) (string, error) {
+			return elem.FirmwareUpload(ctx, component, file)
 		}
+		calls = append(calls, providerCall[string]{name: elem.name, call: call})
 	}
 
-	return taskID, metadata, multierror.Append(err, errors.New("failure in FirmwareUpload"))
+	// uploading a firmware image commonly outlasts the per provider timeout, the provider is bound by ctx only
+	return dispatch(ctx, noTimeout, "FirmwareUpload", calls, "failure in FirmwareUpload")
 }
 
 // FirmwareTaskVerifier defines an interface to check the status for firmware related tasks queued on the BMC.
@@ -504,35 +428,28 @@ type firmwareTaskVerifierProvider struct {
 	FirmwareTaskVerifier
 }
 
-// firmwareTaskStatus returns the status of the firmware upload process.
+// firmwareTaskResult holds the values returned by FirmwareTaskStatus.
+type firmwareTaskResult struct {
+	state  constants.TaskState
+	status string
+}
 
+// firmwareTaskStatus returns the status of the firmware upload process.
 func firmwareTaskStatus(ctx context.Context, kind constants.FirmwareInstallStep, component, taskID, installVersion string, generic []firmwareTaskVerifierProvider) (state constants.TaskState, status string, metadata Metadata, err error) {
-	metadata = newMetadata()
-
+	calls := make([]providerCall[firmwareTaskResult], 0, len(generic))
 	for _, elem := range generic {
 		if elem.FirmwareTaskVerifier == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return state, status, metadata, err
-		default:
-			metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, elem.name)
-			state, status, vErr := elem.FirmwareTaskStatus(ctx, kind, component, taskID, installVersion)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				metadata.FailedProviderDetail[elem.name] = err.Error()
-				continue
-			}
-
-			metadata.SuccessfulProvider = elem.name
-			return state, status, metadata, nil
+		call := func(ctx context.Context) (firmwareTaskResult, error) {
+			state, status, err := elem.FirmwareTaskStatus(ctx, kind, component, taskID, installVersion)
+			return firmwareTaskResult{state: state, status: status}, err
 		}
+		calls = append(calls, providerCall[firmwareTaskResult]{name: elem.name, call: call})
 	}
 
-	return state, status, metadata, multierror.Append(err, errors.New("failure in FirmwareTaskStatus"))
+	result, metadata, err := dispatch(ctx, providerTimeout(ctx), "FirmwareTaskStatus", calls, "failure in FirmwareTaskStatus")
+	return result.state, result.status, metadata, err
 }
 
 // FirmwareTaskStatusFromInterfaces identifies implementations of the FirmwareTaskVerifier interface and passes the found implementations to the firmwareTaskStatus() wrapper.
@@ -24,32 +24,20 @@ type floppyImageUploaderProvider struct {
 
 // mountFloppyImage is a wrapper method to invoke methods for the FloppyImageMounter interface
 func mountFloppyImage(ctx context.Context, image io.Reader, p []floppyImageUploaderProvider) (metadata Metadata, err error) {
-	var metadataLocal Metadata
-
+	calls := make([]providerCall[struct{}], 0, len(p))
 	for _, elem := range p {
 		if elem.impl == nil {
 			continue
 		}
-
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return metadata, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			uploadErr := elem.impl.MountFloppyImage(ctx, image)
-			if uploadErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(uploadErr, "provider: %v", elem.name))
-				continue
-			}
-
-			metadataLocal.SuccessfulProvider = elem.name
-			return metadataLocal, nil
-		}
+		call := withoutResult(func(ctx context.Context) error {
+			return elem.impl.MountFloppyImage(ctx, image)
+		})
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: call})
 	}
 
-	return metadataLocal, multierror.Append(err, errors.New("failed to mount floppy image"))
+	// the floppy image is uploaded to the BMC, which may outlast the per provider timeout
+	_, metadata, err = dispatch(ctx, noTimeout, "MountFloppyImage", calls, "failed to mount floppy image")
+	return metadata, err
 }
 
 // MountFloppyImageFromInterfaces identifies implementations of the FloppyImageMounter interface and passes the found implementations to the mountFloppyImage() wrapper
@@ -96,32 +84,16 @@ type floppyImageUnmounterProvider struct {
 
 // unmountFloppyImage is a wrapper method to invoke methods for the FloppyImageUnmounter interface
 func unmountFloppyImage(ctx context.Context, p []floppyImageUnmounterProvider) (metadata Metadata, err error) {
-	var metadataLocal Metadata
-
+	calls := make([]providerCall[struct{}], 0, len(p))
 	for _, elem := range p {
 		if elem.impl == nil {
 			continue
 		}
-
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return metadata, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			uploadErr := elem.impl.UnmountFloppyImage(ctx)
-			if uploadErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(uploadErr, "provider: %v", elem.name))
-				continue
-			}
-
-			metadataLocal.SuccessfulProvider = elem.name
-			return metadataLocal, nil
-		}
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: withoutResult(elem.impl.UnmountFloppyImage)})
 	}
 
-	return metadataLocal, multierror.Append(err, errors.New("failed to unmount floppy image"))
+	_, metadata, err = dispatch(ctx, providerTimeout(ctx), "UnmountFloppyImage", calls, "failed to unmount floppy image")
+	return metadata, err
 }
 
 // UnmountFloppyImageFromInterfaces identifies implementations of the FloppyImageUnmounter interface and passes the found implementations to the unmountFloppyImage() wrapper
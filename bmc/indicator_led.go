@@ -0,0 +1,119 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// IndicatorLEDSetter turns the chassis identify (locator) LED on or off.
+type IndicatorLEDSetter interface {
+	// SetIdentifyLED turns the identify LED on or off, a zero duration keeps the LED on
+	// until it is turned off, a non-zero duration turns the LED off once elapsed. Providers
+	// without an identify interval, such as Redfish, ignore the duration and keep the LED on.
+	SetIdentifyLED(ctx context.Context, on bool, duration time.Duration) (err error)
+}
+
+type indicatorLEDSetterProvider struct {
+	name string
+	IndicatorLEDSetter
+}
+
+// IndicatorLEDGetter retrieves the state of the chassis identify (locator) LED.
+type IndicatorLEDGetter interface {
+	GetIdentifyLED(ctx context.Context) (on bool, err error)
+}
+
+type indicatorLEDGetterProvider struct {
+	name string
+	IndicatorLEDGetter
+}
+
+// setIdentifyLED sets the identify LED through the first successful provider.
+func setIdentifyLED(ctx context.Context, timeout time.Duration, on bool, duration time.Duration, generic []indicatorLEDSetterProvider) (metadata Metadata, err error) {
+	calls := make([]providerCall[struct{}], 0, len(generic))
+	for _, elem := range generic {
+		if elem.IndicatorLEDSetter == nil {
+			continue
+		}
+		call := withoutResult(func(ctx context.Context) error {
+			return elem.SetIdentifyLED(ctx, on, duration)
+		})
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: call})
+	}
+
+	_, metadata, err = dispatch(ctx, timeout, "SetIdentifyLED", calls, "failed to set identify LED")
+	return metadata, err
+}
+
+// SetIdentifyLEDFromInterfaces identifies implementations of the IndicatorLEDSetter interface and sets the identify LED through the first successful provider.
+func SetIdentifyLEDFromInterfaces(ctx context.Context, timeout time.Duration, on bool, duration time.Duration, generic []interface{}) (metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	if duration < 0 {
+		return metadata, fmt.Errorf("invalid identify LED duration: %v", duration)
+	}
+
+	implementations := make([]indicatorLEDSetterProvider, 0)
+	for _, elem := range generic {
+		if elem == nil {
+			continue
+		}
+		temp := indicatorLEDSetterProvider{name: getProviderName(elem)}
+		switch p := elem.(type) {
+		case IndicatorLEDSetter:
+			temp.IndicatorLEDSetter = p
+			implementations = append(implementations, temp)
+		default:
+			e := fmt.Sprintf("not an IndicatorLEDSetter implementation: %T", p)
+			err = multierror.Append(err, errors.New(e))
+		}
+	}
+	if len(implementations) == 0 {
+		return metadata, multierror.Append(err, errors.New("no IndicatorLEDSetter implementations found"))
+	}
+
+	return setIdentifyLED(ctx, timeout, on, duration, implementations)
+}
+
+// getIdentifyLED returns the identify LED state from the first successful provider.
+func getIdentifyLED(ctx context.Context, timeout time.Duration, generic []indicatorLEDGetterProvider) (on bool, metadata Metadata, err error) {
+	calls := make([]providerCall[bool], 0, len(generic))
+	for _, elem := range generic {
+		if elem.IndicatorLEDGetter == nil {
+			continue
+		}
+		calls = append(calls, providerCall[bool]{name: elem.name, call: elem.GetIdentifyLED})
+	}
+
+	return dispatchRead(ctx, timeout, "GetIdentifyLED", calls, "failed to get identify LED state")
+}
+
+// GetIdentifyLEDFromInterfaces identifies implementations of the IndicatorLEDGetter interface and returns the identify LED state from the first successful provider.
+func GetIdentifyLEDFromInterfaces(ctx context.Context, timeout time.Duration, generic []interface{}) (on bool, metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	implementations := make([]indicatorLEDGetterProvider, 0)
+	for _, elem := range generic {
+		if elem == nil {
+			continue
+		}
+		temp := indicatorLEDGetterProvider{name: getProviderName(elem)}
+		switch p := elem.(type) {
+		case IndicatorLEDGetter:
+			temp.IndicatorLEDGetter = p
+			implementations = append(implementations, temp)
+		default:
+			e := fmt.Sprintf("not an IndicatorLEDGetter implementation: %T", p)
+			err = multierror.Append(err, errors.New(e))
+		}
+	}
+	if len(implementations) == 0 {
+		return false, metadata, multierror.Append(err, errors.New("no IndicatorLEDGetter implementations found"))
+	}
+
+	return getIdentifyLED(ctx, timeout, implementations)
+}
@@ -0,0 +1,120 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockIndicatorLED struct {
+	on       bool
+	duration time.Duration
+	err      error
+}
+
+func (m *mockIndicatorLED) SetIdentifyLED(ctx context.Context, on bool, duration time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if m.err != nil {
+			return m.err
+		}
+		m.on, m.duration = on, duration
+		return nil
+	}
+}
+
+func (m *mockIndicatorLED) GetIdentifyLED(ctx context.Context) (bool, error) {
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	default:
+		return m.on, m.err
+	}
+}
+
+func (m *mockIndicatorLED) Name() string {
+	return "mock"
+}
+
+func TestSetIdentifyLEDFromInterfaces(t *testing.T) {
+	testCases := []struct {
+		name             string
+		generic          []interface{}
+		duration         time.Duration
+		errMsg           string
+		expectedMetadata Metadata
+	}{
+		{
+			name:     "success",
+			generic:  []interface{}{&mockIndicatorLED{}},
+			duration: 5 * time.Minute,
+			expectedMetadata: Metadata{
+				SuccessfulProvider:   "mock",
+				ProvidersAttempted:   []string{"mock"},
+				FailedProviderDetail: make(map[string]string),
+			},
+		},
+		{
+			name:     "success with a failing provider first",
+			generic:  []interface{}{&mockIndicatorLED{err: errors.New("timed identify not supported")}, &mockIndicatorLED{}},
+			duration: 5 * time.Minute,
+			expectedMetadata: Metadata{
+				SuccessfulProvider:   "mock",
+				ProvidersAttempted:   []string{"mock", "mock"},
+				FailedProviderDetail: map[string]string{"mock": "timed identify not supported"},
+			},
+		},
+		{
+			name:     "negative duration",
+			generic:  []interface{}{&mockIndicatorLED{}},
+			duration: -time.Second,
+			errMsg:   "invalid identify LED duration",
+			expectedMetadata: Metadata{
+				FailedProviderDetail: make(map[string]string),
+			},
+		},
+		{
+			name:    "no implementations",
+			generic: []interface{}{"foo"},
+			errMsg:  "no IndicatorLEDSetter implementations found",
+			expectedMetadata: Metadata{
+				FailedProviderDetail: make(map[string]string),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata, err := SetIdentifyLEDFromInterfaces(context.Background(), 1*time.Second, true, tc.duration, tc.generic)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+			} else {
+				assert.NoError(t, err)
+				last := tc.generic[len(tc.generic)-1].(*mockIndicatorLED)
+				assert.True(t, last.on)
+				assert.Equal(t, tc.duration, last.duration)
+			}
+
+			assert.Equal(t, tc.expectedMetadata, withoutAttempts(metadata))
+		})
+	}
+}
+
+func TestGetIdentifyLEDFromInterfaces(t *testing.T) {
+	on, metadata, err := GetIdentifyLEDFromInterfaces(context.Background(), 1*time.Second, []interface{}{&mockIndicatorLED{on: true}})
+	assert.NoError(t, err)
+	assert.True(t, on)
+	assert.Equal(t, "mock", metadata.SuccessfulProvider)
+
+	_, metadata, err = GetIdentifyLEDFromInterfaces(context.Background(), 1*time.Second, []interface{}{&mockIndicatorLED{err: errors.New("unsupported")}})
+	assert.ErrorContains(t, err, "failed to get identify LED state")
+	assert.Equal(t, map[string]string{"mock": "unsupported"}, metadata.FailedProviderDetail)
+
+	_, _, err = GetIdentifyLEDFromInterfaces(context.Background(), 1*time.Second, []interface{}{})
+	assert.ErrorContains(t, err, "no IndicatorLEDGetter implementations found")
+}
@@ -24,31 +24,17 @@ type inventoryGetterProvider struct {
 
 // inventory returns hardware and firmware inventory
 func inventory(ctx context.Context, generic []inventoryGetterProvider) (device *common.Device, metadata Metadata, err error) {
-	var metadataLocal Metadata
-
+	calls := make([]providerCall[*common.Device], 0, len(generic))
 	for _, elem := range generic {
 		if elem.InventoryGetter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return device, metadata, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			device, vErr := elem.Inventory(ctx)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				err = multierror.Append(err, vErr)
-				continue
-			}
-			metadataLocal.SuccessfulProvider = elem.name
-			return device, metadataLocal, nil
-		}
+		calls = append(calls, providerCall[*common.Device]{name: elem.name, call: elem.Inventory})
 	}
 
-	return device, metadataLocal, multierror.Append(err, errors.New("failure to get device inventory"))
+	// collecting the inventory walks every component resource of the BMC and commonly outlasts the per
+	// provider timeout, the provider is bound by ctx only
+	return dispatchRead(ctx, noTimeout, "Inventory", calls, "failure to get device inventory")
 }
 
 // GetInventoryFromInterfaces identifies implementations of the InventoryGetter interface and passes the found implementations to the inventory() wrapper method
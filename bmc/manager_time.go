@@ -0,0 +1,153 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// ManagerTimeConfigurator retrieves and sets the BMC clock and its NTP configuration.
+type ManagerTimeConfigurator interface {
+	GetBMCTime(ctx context.Context) (bmcTime *BMCTime, err error)
+	// SetBMCTime sets the BMC clock, BMCs commonly reject setting the clock while NTP is enabled.
+	SetBMCTime(ctx context.Context, t time.Time) (err error)
+	// SetNTPServers sets the NTP servers the BMC synchronizes its clock with, NTP is enabled when
+	// servers are given and disabled otherwise.
+	SetNTPServers(ctx context.Context, servers []string) (err error)
+}
+
+type managerTimeConfiguratorProvider struct {
+	name string
+	ManagerTimeConfigurator
+}
+
+// BMCTime describes the BMC clock.
+type BMCTime struct {
+	// Time is the BMC clock, in the BMC local UTC offset when reported.
+	Time time.Time
+	// RetrievedAt is the local clock at which the BMC clock was read.
+	RetrievedAt time.Time
+	NTPEnabled  bool
+	NTPServers  []string
+}
+
+// Skew returns the offset of the BMC clock to the local clock, a positive skew means the BMC clock is ahead.
+func (t *BMCTime) Skew() time.Duration {
+	return t.Time.Sub(t.RetrievedAt)
+}
+
+// getBMCTime returns the BMC clock from the first successful provider.
+func getBMCTime(ctx context.Context, timeout time.Duration, generic []managerTimeConfiguratorProvider) (bmcTime *BMCTime, metadata Metadata, err error) {
+	calls := make([]providerCall[*BMCTime], 0, len(generic))
+	for _, elem := range generic {
+		if elem.ManagerTimeConfigurator == nil {
+			continue
+		}
+		calls = append(calls, providerCall[*BMCTime]{name: elem.name, call: elem.GetBMCTime})
+	}
+
+	return dispatchRead(ctx, timeout, "GetBMCTime", calls, "failed to get BMC time")
+}
+
+// GetBMCTimeFromInterfaces identifies implementations of the ManagerTimeConfigurator interface and returns the BMC clock from the first successful provider.
+func GetBMCTimeFromInterfaces(ctx context.Context, timeout time.Duration, generic []interface{}) (bmcTime *BMCTime, metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	implementations, err := managerTimeConfigurators(generic)
+	if len(implementations) == 0 {
+		return nil, metadata, multierror.Append(err, errors.New("no ManagerTimeConfigurator implementations found"))
+	}
+
+	return getBMCTime(ctx, timeout, implementations)
+}
+
+// setBMCTime sets the BMC clock through the first successful provider.
+func setBMCTime(ctx context.Context, timeout time.Duration, t time.Time, generic []managerTimeConfiguratorProvider) (metadata Metadata, err error) {
+	calls := make([]providerCall[struct{}], 0, len(generic))
+	for _, elem := range generic {
+		if elem.ManagerTimeConfigurator == nil {
+			continue
+		}
+		call := withoutResult(func(ctx context.Context) error {
+			return elem.SetBMCTime(ctx, t)
+		})
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: call})
+	}
+
+	_, metadata, err = dispatch(ctx, timeout, "SetBMCTime", calls, "failed to set BMC time")
+	return metadata, err
+}
+
+// SetBMCTimeFromInterfaces identifies implementations of the ManagerTimeConfigurator interface and sets the BMC clock through the first successful provider.
+func SetBMCTimeFromInterfaces(ctx context.Context, timeout time.Duration, t time.Time, generic []interface{}) (metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	if t.IsZero() {
+		return metadata, errors.New("no time given")
+	}
+
+	implementations, err := managerTimeConfigurators(generic)
+	if len(implementations) == 0 {
+		return metadata, multierror.Append(err, errors.New("no ManagerTimeConfigurator implementations found"))
+	}
+
+	return setBMCTime(ctx, timeout, t, implementations)
+}
+
+// setNTPServers sets the BMC NTP servers through the first successful provider.
+func setNTPServers(ctx context.Context, timeout time.Duration, servers []string, generic []managerTimeConfiguratorProvider) (metadata Metadata, err error) {
+	calls := make([]providerCall[struct{}], 0, len(generic))
+	for _, elem := range generic {
+		if elem.ManagerTimeConfigurator == nil {
+			continue
+		}
+		call := withoutResult(func(ctx context.Context) error {
+			return elem.SetNTPServers(ctx, servers)
+		})
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: call})
+	}
+
+	_, metadata, err = dispatch(ctx, timeout, "SetNTPServers", calls, "failed to set NTP servers")
+	return metadata, err
+}
+
+// SetNTPServersFromInterfaces identifies implementations of the ManagerTimeConfigurator interface and sets the BMC NTP servers through the first successful provider.
+func SetNTPServersFromInterfaces(ctx context.Context, timeout time.Duration, servers []string, generic []interface{}) (metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	for _, server := range servers {
+		if server == "" {
+			return metadata, errors.New("empty NTP server")
+		}
+	}
+
+	implementations, err := managerTimeConfigurators(generic)
+	if len(implementations) == 0 {
+		return metadata, multierror.Append(err, errors.New("no ManagerTimeConfigurator implementations found"))
+	}
+
+	return setNTPServers(ctx, timeout, servers, implementations)
+}
+
+// managerTimeConfigurators returns the ManagerTimeConfigurator implementations in generic.
+func managerTimeConfigurators(generic []interface{}) (implementations []managerTimeConfiguratorProvider, err error) {
+	for _, elem := range generic {
+		if elem == nil {
+			continue
+		}
+		temp := managerTimeConfiguratorProvider{name: getProviderName(elem)}
+		switch p := elem.(type) {
+		case ManagerTimeConfigurator:
+			temp.ManagerTimeConfigurator = p
+			implementations = append(implementations, temp)
+		default:
+			e := fmt.Sprintf("not a ManagerTimeConfigurator implementation: %T", p)
+			err = multierror.Append(err, errors.New(e))
+		}
+	}
+
+	return implementations, err
+}
@@ -0,0 +1,115 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockManagerTime struct {
+	bmcTime    *BMCTime
+	setTime    time.Time
+	ntpServers []string
+	err        error
+}
+
+func (m *mockManagerTime) GetBMCTime(ctx context.Context) (*BMCTime, error) {
+	return m.bmcTime, m.err
+}
+
+func (m *mockManagerTime) SetBMCTime(ctx context.Context, t time.Time) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.setTime = t
+
+	return nil
+}
+
+func (m *mockManagerTime) SetNTPServers(ctx context.Context, servers []string) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.ntpServers = servers
+
+	return nil
+}
+
+func (m *mockManagerTime) Name() string {
+	return "mock"
+}
+
+func TestBMCTimeSkew(t *testing.T) {
+	retrievedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	bmcTime := &BMCTime{Time: time.Date(2024, 5, 1, 14, 5, 0, 0, time.FixedZone("CEST", 2*60*60)), RetrievedAt: retrievedAt}
+	assert.Equal(t, 5*time.Minute, bmcTime.Skew())
+
+	bmcTime = &BMCTime{Time: retrievedAt.Add(-90 * time.Second), RetrievedAt: retrievedAt}
+	assert.Equal(t, -90*time.Second, bmcTime.Skew())
+}
+
+func TestGetBMCTimeFromInterfaces(t *testing.T) {
+	bmcTime := &BMCTime{Time: time.Now(), NTPEnabled: true, NTPServers: []string{"pool.ntp.org"}}
+
+	got, metadata, err := GetBMCTimeFromInterfaces(context.Background(), 1*time.Second, []interface{}{&mockManagerTime{bmcTime: bmcTime}})
+	assert.NoError(t, err)
+	assert.Equal(t, bmcTime, got)
+	assert.Equal(t, "mock", metadata.SuccessfulProvider)
+
+	_, metadata, err = GetBMCTimeFromInterfaces(context.Background(), 1*time.Second, []interface{}{&mockManagerTime{err: errors.New("no manager")}})
+	assert.ErrorContains(t, err, "failed to get BMC time")
+	assert.Equal(t, map[string]string{"mock": "no manager"}, metadata.FailedProviderDetail)
+
+	_, _, err = GetBMCTimeFromInterfaces(context.Background(), 1*time.Second, []interface{}{"foo"})
+	assert.ErrorContains(t, err, "no ManagerTimeConfigurator implementations found")
+}
+
+func TestSetBMCTimeFromInterfaces(t *testing.T) {
+	mock := &mockManagerTime{}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	metadata, err := SetBMCTimeFromInterfaces(context.Background(), 1*time.Second, now, []interface{}{mock})
+	assert.NoError(t, err)
+	assert.Equal(t, "mock", metadata.SuccessfulProvider)
+	assert.Equal(t, now, mock.setTime)
+
+	_, err = SetBMCTimeFromInterfaces(context.Background(), 1*time.Second, time.Time{}, []interface{}{mock})
+	assert.ErrorContains(t, err, "no time given")
+
+	_, err = SetBMCTimeFromInterfaces(context.Background(), 1*time.Second, now, []interface{}{&mockManagerTime{err: errors.New("NTP is enabled")}})
+	assert.ErrorContains(t, err, "failed to set BMC time")
+}
+
+func TestSetNTPServersFromInterfaces(t *testing.T) {
+	testCases := []struct {
+		name    string
+		servers []string
+		generic []interface{}
+		errMsg  string
+	}{
+		{name: "servers", servers: []string{"0.pool.ntp.org", "10.0.0.2"}, generic: []interface{}{&mockManagerTime{}}},
+		{name: "disable", servers: nil, generic: []interface{}{&mockManagerTime{}}},
+		{name: "empty server", servers: []string{""}, generic: []interface{}{&mockManagerTime{}}, errMsg: "empty NTP server"},
+		{name: "no implementations", servers: []string{"0.pool.ntp.org"}, generic: []interface{}{"foo"}, errMsg: "no ManagerTimeConfigurator implementations found"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata, err := SetNTPServersFromInterfaces(context.Background(), 1*time.Second, tc.servers, tc.generic)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "mock", metadata.SuccessfulProvider)
+			assert.Equal(t, tc.servers, tc.generic[0].(*mockManagerTime).ntpServers)
+		})
+	}
+}
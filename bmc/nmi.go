@@ -14,24 +14,6 @@ type NMISender interface {
 	SendNMI(ctx context.Context) error
 }
 
-func sendNMI(ctx context.Context, timeout time.Duration, sender NMISender, metadata *Metadata) error {
-	senderName := getProviderName(sender)
-	ctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
-
-	metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, senderName)
-
-	err := sender.SendNMI(ctx)
-	if err != nil {
-		metadata.FailedProviderDetail[senderName] = err.Error()
-		return err
-	}
-
-	metadata.SuccessfulProvider = senderName
-
-	return nil
-}
-
 // SendNMIFromInterface will look for providers that implement NMISender
 // and attempt to call SendNMI until a provider is successful,
 // or all providers have been exhausted.
@@ -40,8 +22,7 @@ func SendNMIFromInterface(
 	timeout time.Duration,
 	providers []interface{},
 ) (metadata Metadata, err error) {
-	metadata = newMetadata()
-
+	calls := make([]providerCall[struct{}], 0, len(providers))
 	for _, provider := range providers {
 		sender, ok := provider.(NMISender)
 		if !ok {
@@ -49,19 +30,13 @@ func SendNMIFromInterface(
 			continue
 		}
 
-		sendNMIErr := sendNMI(ctx, timeout, sender, &metadata)
-		if sendNMIErr != nil {
-			err = multierror.Append(err, sendNMIErr)
-			continue
-		}
-		return metadata, nil
+		calls = append(calls, providerCall[struct{}]{name: getProviderName(sender), call: withoutResult(sender.SendNMI)})
 	}
 
-	if len(metadata.ProvidersAttempted) == 0 {
-		err = multierror.Append(err, errors.New("no NMISender implementations found"))
-	} else {
-		err = multierror.Append(err, errors.New("failed to send NMI"))
+	if len(calls) == 0 {
+		return newMetadata(), multierror.Append(err, errors.New("no NMISender implementations found"))
 	}
 
+	_, metadata, err = dispatch(ctx, timeout, "SendNMI", calls, "failed to send NMI")
 	return metadata, err
 }
@@ -24,33 +24,28 @@ type postCodeGetterProvider struct {
 	PostCodeGetter
 }
 
+// postCodeResult holds the values returned by PostCode.
+type postCodeResult struct {
+	status string
+	code   int
+}
+
 // postCode returns the device BIOS/UEFI POST code
 func postCode(ctx context.Context, generic []postCodeGetterProvider) (status string, code int, metadata Metadata, err error) {
-	var metadataLocal Metadata
-
+	calls := make([]providerCall[postCodeResult], 0, len(generic))
 	for _, elem := range generic {
 		if elem.PostCodeGetter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return status, code, metadata, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			status, code, vErr := elem.PostCode(ctx)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				err = multierror.Append(err, vErr)
-				continue
-			}
-			metadataLocal.SuccessfulProvider = elem.name
-			return status, code, metadataLocal, nil
+		call := func(ctx context.Context) (postCodeResult, error) {
+			status, code, err := elem.PostCode(ctx)
+			return postCodeResult{status: status, code: code}, err
 		}
+		calls = append(calls, providerCall[postCodeResult]{name: elem.name, call: call})
 	}
 
-	return status, code, metadataLocal, multierror.Append(err, errors.New("failure to get device POST code"))
+	result, metadata, err := dispatchRead(ctx, providerTimeout(ctx), "PostCode", calls, "failure to get device POST code")
+	return result.status, result.code, metadata, err
 }
 
 // GetPostCodeInterfaces identifies implementations of the PostCodeGetter interface and passes the found implementations to the postCode() wrapper method.
@@ -3,6 +3,7 @@ package bmc
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -39,38 +40,19 @@ type powerProviders struct {
 
 // setPowerState sets the power state for a BMC, trying all interface implementations passed in
 func setPowerState(ctx context.Context, timeout time.Duration, state string, p []powerProviders) (ok bool, m Metadata, err error) {
-	metadataLocal := Metadata{
-		FailedProviderDetail: make(map[string]string),
-	}
-
+	calls := make([]providerCall[bool], 0, len(p))
 	for _, elem := range p {
 		if elem.powerSetter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return false, metadataLocal, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			ctx, cancel := context.WithTimeout(ctx, timeout)
-			ok, setErr := elem.powerSetter.PowerSet(ctx, state)
-			cancel()
-			if setErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(setErr, "provider: %v", elem.name))
-				metadataLocal.FailedProviderDetail[elem.name] = setErr.Error()
-				continue
-			}
-			if !ok {
-				err = multierror.Append(err, fmt.Errorf("provider: %v, failed to set power state", elem.name))
-				continue
-			}
-			metadataLocal.SuccessfulProvider = elem.name
-			return ok, metadataLocal, nil
+		call := func(ctx context.Context) (bool, error) {
+			ok, err := elem.powerSetter.PowerSet(ctx, state)
+			return ok, notOK(ok, err, "failed to set power state")
 		}
+		calls = append(calls, providerCall[bool]{name: elem.name, call: call})
 	}
-	return ok, metadataLocal, multierror.Append(err, errors.New("failed to set power state"))
+
+	return dispatch(ctx, timeout, "SetPowerState", calls, "failed to set power state")
 }
 
 // SetPowerStateFromInterfaces identifies implementations of the PostStateSetter interface and passes the found implementations to the setPowerState() wrapper.
@@ -100,33 +82,29 @@ func SetPowerStateFromInterfaces(ctx context.Context, timeout time.Duration, sta
 
 // getPowerState gets the power state for a BMC, trying all interface implementations passed in
 func getPowerState(ctx context.Context, timeout time.Duration, p []powerProviders) (state string, m Metadata, err error) {
-	metadataLocal := Metadata{
-		FailedProviderDetail: make(map[string]string),
-	}
+	calls := make([]providerCall[string], 0, len(p))
 	for _, elem := range p {
 		if elem.powerStateGetter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
+		calls = append(calls, providerCall[string]{name: elem.name, call: elem.powerStateGetter.PowerStateGet})
+	}
 
-			return state, metadataLocal, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			ctx, cancel := context.WithTimeout(ctx, timeout)
-			state, stateErr := elem.powerStateGetter.PowerStateGet(ctx)
-			cancel()
-			if stateErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(stateErr, "provider: %v", elem.name))
-				metadataLocal.FailedProviderDetail[elem.name] = stateErr.Error()
-				continue
-			}
-			metadataLocal.SuccessfulProvider = elem.name
-			return state, metadataLocal, nil
-		}
+	if enabled, strict := verifiedReads(ctx); enabled {
+		return verifiedRead(ctx, timeout, "GetPowerState", calls, equalPowerState, strict, "failed to get power state")
 	}
-	return state, metadataLocal, multierror.Append(err, errors.New("failed to get power state"))
+
+	return dispatchRead(ctx, timeout, "GetPowerState", calls, "failed to get power state")
+}
+
+// equalPowerState returns true when the power states are the same, the providers report the
+// power state in different forms, like "On" over redfish and "Chassis Power is on" over ipmi.
+func equalPowerState(a, b string) bool {
+	normalize := func(state string) string {
+		return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(state)), "chassis power is ")
+	}
+
+	return normalize(a) == normalize(b)
 }
 
 // GetPowerStateFromInterfaces identifies implementations of the PostStateGetter interface and passes the found implementations to the getPowerState() wrapper.
@@ -0,0 +1,174 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// PowerMeter retrieves the power consumption of a host.
+type PowerMeter interface {
+	PowerConsumption(ctx context.Context) (consumption *PowerConsumption, err error)
+}
+
+type powerMeterProvider struct {
+	name string
+	PowerMeter
+}
+
+// PowerLimiter applies a power limit (power cap) to a host.
+type PowerLimiter interface {
+	SetPowerLimit(ctx context.Context, limit PowerLimit) (err error)
+}
+
+type powerLimiterProvider struct {
+	name string
+	PowerLimiter
+}
+
+// PowerConsumption is the power draw of a host as reported by its BMC.
+type PowerConsumption struct {
+	// CurrentWatts is the instantaneous power draw.
+	CurrentWatts *float64
+	// AverageWatts is the average power draw over Interval.
+	AverageWatts *float64
+	// MinWatts is the minimum power draw over Interval.
+	MinWatts *float64
+	// MaxWatts is the maximum power draw over Interval.
+	MaxWatts *float64
+	// Interval is the period AverageWatts, MinWatts and MaxWatts are sampled over, zero when not reported.
+	Interval time.Duration
+	// Limit is the power limit configured on the BMC, nil when no limit is set.
+	Limit *PowerLimit
+}
+
+// PowerLimitExceptionAction is the action the BMC takes when a power limit cannot be maintained.
+type PowerLimitExceptionAction string
+
+// PowerLimitExceptionAction values enumerate the supported power limit exception actions,
+// the empty value leaves the action to the BMC default.
+const (
+	PowerLimitExceptionActionDefault      PowerLimitExceptionAction = ""
+	PowerLimitExceptionActionNoAction     PowerLimitExceptionAction = "no_action"
+	PowerLimitExceptionActionHardPowerOff PowerLimitExceptionAction = "hard_power_off"
+	PowerLimitExceptionActionLogEvent     PowerLimitExceptionAction = "log_event"
+)
+
+// PowerLimit is a host power limit.
+type PowerLimit struct {
+	// Watts is the power limit, required when Enabled is set.
+	Watts float64
+	// Enabled activates the power limit, when unset the limit is removed.
+	Enabled bool
+	// CorrectionTime is the time the BMC is allowed to bring the power draw under Watts,
+	// zero leaves the BMC default in place.
+	CorrectionTime time.Duration
+	// ExceptionAction is the action taken when the limit cannot be maintained within CorrectionTime.
+	ExceptionAction PowerLimitExceptionAction
+}
+
+func (l PowerLimit) validate() error {
+	if l.Enabled && l.Watts <= 0 {
+		return fmt.Errorf("invalid power limit: %v watts", l.Watts)
+	}
+
+	switch l.ExceptionAction {
+	case PowerLimitExceptionActionDefault,
+		PowerLimitExceptionActionNoAction,
+		PowerLimitExceptionActionHardPowerOff,
+		PowerLimitExceptionActionLogEvent:
+	default:
+		return fmt.Errorf("invalid power limit exception action: %q", l.ExceptionAction)
+	}
+
+	return nil
+}
+
+// powerConsumption returns the power consumption from the first successful provider.
+func powerConsumption(ctx context.Context, timeout time.Duration, generic []powerMeterProvider) (consumption *PowerConsumption, metadata Metadata, err error) {
+	calls := make([]providerCall[*PowerConsumption], 0, len(generic))
+	for _, elem := range generic {
+		if elem.PowerMeter == nil {
+			continue
+		}
+		calls = append(calls, providerCall[*PowerConsumption]{name: elem.name, call: elem.PowerConsumption})
+	}
+
+	return dispatchRead(ctx, timeout, "PowerConsumption", calls, "failed to get power consumption")
+}
+
+// PowerConsumptionFromInterfaces identifies implementations of the PowerMeter interface and returns the power consumption from the first successful provider.
+func PowerConsumptionFromInterfaces(ctx context.Context, timeout time.Duration, generic []interface{}) (consumption *PowerConsumption, metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	implementations := make([]powerMeterProvider, 0)
+	for _, elem := range generic {
+		if elem == nil {
+			continue
+		}
+		temp := powerMeterProvider{name: getProviderName(elem)}
+		switch p := elem.(type) {
+		case PowerMeter:
+			temp.PowerMeter = p
+			implementations = append(implementations, temp)
+		default:
+			e := fmt.Sprintf("not a PowerMeter implementation: %T", p)
+			err = multierror.Append(err, errors.New(e))
+		}
+	}
+	if len(implementations) == 0 {
+		return nil, metadata, multierror.Append(err, errors.New("no PowerMeter implementations found"))
+	}
+
+	return powerConsumption(ctx, timeout, implementations)
+}
+
+// setPowerLimit applies the power limit through the first successful provider.
+func setPowerLimit(ctx context.Context, timeout time.Duration, limit PowerLimit, generic []powerLimiterProvider) (metadata Metadata, err error) {
+	calls := make([]providerCall[struct{}], 0, len(generic))
+	for _, elem := range generic {
+		if elem.PowerLimiter == nil {
+			continue
+		}
+		call := withoutResult(func(ctx context.Context) error {
+			return elem.SetPowerLimit(ctx, limit)
+		})
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: call})
+	}
+
+	_, metadata, err = dispatch(ctx, timeout, "SetPowerLimit", calls, "failed to set power limit")
+	return metadata, err
+}
+
+// SetPowerLimitFromInterfaces identifies implementations of the PowerLimiter interface and applies the power limit through the first successful provider.
+func SetPowerLimitFromInterfaces(ctx context.Context, timeout time.Duration, limit PowerLimit, generic []interface{}) (metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	if err := limit.validate(); err != nil {
+		return metadata, err
+	}
+
+	implementations := make([]powerLimiterProvider, 0)
+	for _, elem := range generic {
+		if elem == nil {
+			continue
+		}
+		temp := powerLimiterProvider{name: getProviderName(elem)}
+		switch p := elem.(type) {
+		case PowerLimiter:
+			temp.PowerLimiter = p
+			implementations = append(implementations, temp)
+		default:
+			e := fmt.Sprintf("not a PowerLimiter implementation: %T", p)
+			err = multierror.Append(err, errors.New(e))
+		}
+	}
+	if len(implementations) == 0 {
+		return metadata, multierror.Append(err, errors.New("no PowerLimiter implementations found"))
+	}
+
+	return setPowerLimit(ctx, timeout, limit, implementations)
+}
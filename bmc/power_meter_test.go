@@ -0,0 +1,175 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockPowerMeter struct {
+	consumption *PowerConsumption
+	err         error
+}
+
+func (m *mockPowerMeter) PowerConsumption(ctx context.Context) (*PowerConsumption, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		return m.consumption, m.err
+	}
+}
+
+func (m *mockPowerMeter) Name() string {
+	return "mock"
+}
+
+type mockPowerLimiter struct {
+	limit PowerLimit
+	err   error
+}
+
+func (m *mockPowerLimiter) SetPowerLimit(ctx context.Context, limit PowerLimit) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		m.limit = limit
+		return m.err
+	}
+}
+
+func (m *mockPowerLimiter) Name() string {
+	return "mock"
+}
+
+func TestPowerConsumptionFromInterfaces(t *testing.T) {
+	watts := 212.0
+	consumption := &PowerConsumption{CurrentWatts: &watts}
+
+	testCases := []struct {
+		name             string
+		generic          []interface{}
+		expected         *PowerConsumption
+		errMsg           string
+		expectedMetadata Metadata
+	}{
+		{
+			name:     "success",
+			generic:  []interface{}{&mockPowerMeter{consumption: consumption}},
+			expected: consumption,
+			expectedMetadata: Metadata{
+				SuccessfulProvider:   "mock",
+				ProvidersAttempted:   []string{"mock"},
+				FailedProviderDetail: make(map[string]string),
+			},
+		},
+		{
+			name:    "provider failure",
+			generic: []interface{}{&mockPowerMeter{err: errors.New("dcmi not supported")}},
+			errMsg:  "failed to get power consumption",
+			expectedMetadata: Metadata{
+				ProvidersAttempted:   []string{"mock"},
+				FailedProviderDetail: map[string]string{"mock": "dcmi not supported"},
+			},
+		},
+		{
+			name:    "no implementations",
+			generic: []interface{}{"foo"},
+			errMsg:  "no PowerMeter implementations found",
+			expectedMetadata: Metadata{
+				FailedProviderDetail: make(map[string]string),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, metadata, err := PowerConsumptionFromInterfaces(context.Background(), 1*time.Second, tc.generic)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expected, got)
+			assert.Equal(t, tc.expectedMetadata, withoutAttempts(metadata))
+		})
+	}
+}
+
+func TestSetPowerLimitFromInterfaces(t *testing.T) {
+	testCases := []struct {
+		name             string
+		limiter          *mockPowerLimiter
+		limit            PowerLimit
+		errMsg           string
+		expectedMetadata Metadata
+	}{
+		{
+			name:    "success",
+			limiter: &mockPowerLimiter{},
+			limit:   PowerLimit{Watts: 450, Enabled: true, ExceptionAction: PowerLimitExceptionActionLogEvent},
+			expectedMetadata: Metadata{
+				SuccessfulProvider:   "mock",
+				ProvidersAttempted:   []string{"mock"},
+				FailedProviderDetail: make(map[string]string),
+			},
+		},
+		{
+			name:    "disable",
+			limiter: &mockPowerLimiter{},
+			limit:   PowerLimit{},
+			expectedMetadata: Metadata{
+				SuccessfulProvider:   "mock",
+				ProvidersAttempted:   []string{"mock"},
+				FailedProviderDetail: make(map[string]string),
+			},
+		},
+		{
+			name:    "enabled without watts",
+			limiter: &mockPowerLimiter{},
+			limit:   PowerLimit{Enabled: true},
+			errMsg:  "invalid power limit: 0 watts",
+			expectedMetadata: Metadata{
+				FailedProviderDetail: make(map[string]string),
+			},
+		},
+		{
+			name:    "invalid exception action",
+			limiter: &mockPowerLimiter{},
+			limit:   PowerLimit{Watts: 450, Enabled: true, ExceptionAction: "shutdown"},
+			errMsg:  "invalid power limit exception action",
+			expectedMetadata: Metadata{
+				FailedProviderDetail: make(map[string]string),
+			},
+		},
+		{
+			name:    "provider failure",
+			limiter: &mockPowerLimiter{err: errors.New("limit out of range")},
+			limit:   PowerLimit{Watts: 10, Enabled: true},
+			errMsg:  "failed to set power limit",
+			expectedMetadata: Metadata{
+				ProvidersAttempted:   []string{"mock"},
+				FailedProviderDetail: map[string]string{"mock": "limit out of range"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata, err := SetPowerLimitFromInterfaces(context.Background(), 1*time.Second, tc.limit, []interface{}{tc.limiter})
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.limit, tc.limiter.limit)
+			}
+
+			assert.Equal(t, tc.expectedMetadata, withoutAttempts(metadata))
+		})
+	}
+}
@@ -24,35 +24,19 @@ type bmcProviders struct {
 
 // resetBMC tries all implementations for a success BMC reset
 func resetBMC(ctx context.Context, timeout time.Duration, resetType string, b []bmcProviders) (ok bool, metadata Metadata, err error) {
-	var metadataLocal Metadata
-
+	calls := make([]providerCall[bool], 0, len(b))
 	for _, elem := range b {
 		if elem.bmcResetter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return false, metadata, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			ctx, cancel := context.WithTimeout(ctx, timeout)
-			ok, setErr := elem.bmcResetter.BmcReset(ctx, resetType)
-			cancel()
-			if setErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(setErr, "provider: %v", elem.name))
-				continue
-			}
-			if !ok {
-				err = multierror.Append(err, fmt.Errorf("provider: %v, failed to reset BMC", elem.name))
-				continue
-			}
-			metadataLocal.SuccessfulProvider = elem.name
-			return ok, metadataLocal, nil
+		call := func(ctx context.Context) (bool, error) {
+			ok, err := elem.bmcResetter.BmcReset(ctx, resetType)
+			return ok, notOK(ok, err, "failed to reset BMC")
 		}
+		calls = append(calls, providerCall[bool]{name: elem.name, call: call})
 	}
-	return ok, metadataLocal, multierror.Append(err, errors.New("failed to reset BMC"))
+
+	return dispatch(ctx, timeout, "ResetBMC", calls, "failed to reset BMC")
 }
 
 // ResetBMCFromInterfaces identifies implementations of the BMCResetter interface and passes them to the resetBMC() wrapper method.
@@ -20,32 +20,28 @@ type screenshotGetterProvider struct {
 	ScreenshotGetter
 }
 
+// screenshotResult holds the values returned by Screenshot.
+type screenshotResult struct {
+	image    []byte
+	fileType string
+}
+
 // screenshot returns an image capture of the video output.
 func screenshot(ctx context.Context, generic []screenshotGetterProvider) (image []byte, fileType string, metadata Metadata, err error) {
-	var metadataLocal Metadata
-
+	calls := make([]providerCall[screenshotResult], 0, len(generic))
 	for _, elem := range generic {
 		if elem.ScreenshotGetter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return image, fileType, metadata, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			image, fileType, vErr := elem.Screenshot(ctx)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				continue
-			}
-			metadataLocal.SuccessfulProvider = elem.name
-			return image, fileType, metadataLocal, nil
+		call := func(ctx context.Context) (screenshotResult, error) {
+			image, fileType, err := elem.Screenshot(ctx)
+			return screenshotResult{image: image, fileType: fileType}, err
 		}
+		calls = append(calls, providerCall[screenshotResult]{name: elem.name, call: call})
 	}
 
-	return image, fileType, metadataLocal, multierror.Append(err, errors.New("failed to capture screenshot"))
+	result, metadata, err := dispatchRead(ctx, providerTimeout(ctx), "Screenshot", calls, "failed to capture screenshot")
+	return result.image, result.fileType, metadata, err
 }
 
 // ScreenshotFromInterfaces identifies implementations of the ScreenshotGetter interface and passes the found implementations to the screenshot() wrapper method.
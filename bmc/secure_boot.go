@@ -41,81 +41,52 @@ type secureBootKeysResetterProvider struct {
 }
 
 func secureBootState(ctx context.Context, generic []secureBootStateGetterProvider) (enabled bool, metadata Metadata, err error) {
-	metadata = newMetadata()
-Loop:
+	calls := make([]providerCall[bool], 0, len(generic))
 	for _, elem := range generic {
 		if elem.SecureBootStateGetter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-			break Loop
-		default:
-			metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, elem.name)
-			enabled, vErr := elem.GetSecureBoot(ctx)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				continue
-			}
-			metadata.SuccessfulProvider = elem.name
-			return enabled, metadata, nil
-		}
+		calls = append(calls, providerCall[bool]{name: elem.name, call: elem.GetSecureBoot})
+	}
+
+	if enabled, strict := verifiedReads(ctx); enabled {
+		equal := func(a, b bool) bool { return a == b }
+		return verifiedRead(ctx, providerTimeout(ctx), "GetSecureBoot", calls, equal, strict, "failure to get secure boot state")
 	}
 
-	return enabled, metadata, multierror.Append(err, errors.New("failure to get secure boot state"))
+	return dispatchRead(ctx, providerTimeout(ctx), "GetSecureBoot", calls, "failure to get secure boot state")
 }
 
 func setSecureBoot(ctx context.Context, generic []secureBootSetterProvider, enable bool) (metadata Metadata, err error) {
-	metadata = newMetadata()
-Loop:
+	calls := make([]providerCall[struct{}], 0, len(generic))
 	for _, elem := range generic {
 		if elem.SecureBootSetter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-			break Loop
-		default:
-			metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, elem.name)
-			vErr := elem.SetSecureBoot(ctx, enable)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				continue
-			}
-			metadata.SuccessfulProvider = elem.name
-			return metadata, nil
-		}
+		call := withoutResult(func(ctx context.Context) error {
+			return elem.SetSecureBoot(ctx, enable)
+		})
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: call})
 	}
 
-	return metadata, multierror.Append(err, errors.New("failure to set secure boot state"))
+	_, metadata, err = dispatch(ctx, providerTimeout(ctx), "SetSecureBoot", calls, "failure to set secure boot state")
+	return metadata, err
 }
 
 func resetSecureBootKeys(ctx context.Context, generic []secureBootKeysResetterProvider, resetType string) (metadata Metadata, err error) {
-	metadata = newMetadata()
-Loop:
+	calls := make([]providerCall[struct{}], 0, len(generic))
 	for _, elem := range generic {
 		if elem.SecureBootKeysResetter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-			break Loop
-		default:
-			metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, elem.name)
-			vErr := elem.ResetSecureBootKeys(ctx, resetType)
-			if vErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(vErr, "provider: %v", elem.name))
-				continue
-			}
-			metadata.SuccessfulProvider = elem.name
-			return metadata, nil
-		}
+		call := withoutResult(func(ctx context.Context) error {
+			return elem.ResetSecureBootKeys(ctx, resetType)
+		})
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: call})
 	}
 
-	return metadata, multierror.Append(err, errors.New("failure to reset secure boot keys"))
+	_, metadata, err = dispatch(ctx, providerTimeout(ctx), "ResetSecureBootKeys", calls, "failure to reset secure boot keys")
+	return metadata, err
 }
 
 // GetSecureBootStateFromInterfaces returns whether UEFI Secure Boot is enabled using
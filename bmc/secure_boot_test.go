@@ -3,6 +3,7 @@ package bmc
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -21,6 +22,17 @@ func (m *mockSecureBootStateGetter) Name() string {
 	return "mock"
 }
 
+type hungSecureBootStateGetter struct{}
+
+func (h *hungSecureBootStateGetter) GetSecureBoot(ctx context.Context) (bool, error) {
+	<-ctx.Done()
+	return false, ctx.Err()
+}
+
+func (h *hungSecureBootStateGetter) Name() string {
+	return "hung"
+}
+
 type mockSecureBootSetter struct {
 	err error
 }
@@ -89,6 +101,20 @@ func TestGetSecureBootStateFromInterfaces(t *testing.T) {
 	}
 }
 
+func TestGetSecureBootVerifiedReadProviderTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+
+	ctx = WithProviderTimeout(WithVerifiedReads(ctx, false), 10*time.Millisecond)
+
+	// the hung provider is given up on after the per provider timeout, not the deadline of ctx
+	enabled, metadata, err := GetSecureBootStateFromInterfaces(ctx, []interface{}{&mockSecureBootStateGetter{enabled: true}, &hungSecureBootStateGetter{}})
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+	assert.Equal(t, "mock", metadata.SuccessfulProvider)
+	assert.Equal(t, context.DeadlineExceeded.Error(), metadata.FailedProviderDetail["hung"])
+}
+
 func TestSetSecureBootFromInterfaces(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -3,6 +3,7 @@ package bmc
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -21,36 +22,102 @@ type systemEventLogProviders struct {
 	systemEventLogProvider SystemEventLog
 }
 
+// SystemEventLogEntriesGetter returns the host's System Event Log (SEL) as typed entries.
+type SystemEventLogEntriesGetter interface {
+	GetSystemEventLogEntries(ctx context.Context) (entries []SystemEventLogEntry, err error)
+}
+
+type systemEventLogEntriesProviders struct {
+	name                          string
+	systemEventLogEntriesProvider SystemEventLogEntriesGetter
+}
+
 // SystemEventLogEntries holds System Event Log entries as rows of string columns.
 type SystemEventLogEntries [][]string
 
-func clearSystemEventLog(ctx context.Context, timeout time.Duration, s []systemEventLogProviders) (metadata Metadata, err error) {
-	var metadataLocal Metadata
+// SystemEventLogSeverity is the normalized severity of a System Event Log entry.
+type SystemEventLogSeverity string
+
+// SystemEventLogSeverity values enumerate the normalized SEL entry severities.
+const (
+	SystemEventLogSeverityUnknown  SystemEventLogSeverity = "unknown"
+	SystemEventLogSeverityInfo     SystemEventLogSeverity = "info"
+	SystemEventLogSeverityOK       SystemEventLogSeverity = "ok"
+	SystemEventLogSeverityWarning  SystemEventLogSeverity = "warning"
+	SystemEventLogSeverityCritical SystemEventLogSeverity = "critical"
+)
+
+// SystemEventLogEventDirection indicates whether a SEL entry records an assertion or a deassertion.
+type SystemEventLogEventDirection string
+
+// SystemEventLogEventDirection values enumerate the SEL event directions.
+const (
+	SystemEventLogEventDirectionUnknown    SystemEventLogEventDirection = ""
+	SystemEventLogEventDirectionAsserted   SystemEventLogEventDirection = "asserted"
+	SystemEventLogEventDirectionDeasserted SystemEventLogEventDirection = "deasserted"
+)
+
+// SystemEventLogEntry is a single System Event Log entry in a provider independent format.
+type SystemEventLogEntry struct {
+	// RecordID is the BMC assigned identifier of the entry.
+	RecordID string
+	// Timestamp is the time the entry was logged, the zero value when the BMC did not record one.
+	Timestamp time.Time
+	// Severity is the normalized severity of the entry.
+	Severity SystemEventLogSeverity
+	// SensorType is the type of the sensor that generated the entry, e.g. "Temperature" or "Power Supply".
+	SensorType string
+	// SensorName identifies the sensor that generated the entry.
+	SensorName string
+	// EventDirection indicates if the event was asserted or deasserted.
+	EventDirection SystemEventLogEventDirection
+	// Message is the human readable description of the event.
+	Message string
+	// Raw holds the entry as returned by the BMC, the 16 byte SEL record for IPMI, JSON for Redfish.
+	Raw []byte
+}
+
+// ParseSystemEventLogSeverity normalizes a vendor or protocol specific severity string.
+func ParseSystemEventLogSeverity(severity string) SystemEventLogSeverity {
+	switch strings.ToLower(strings.TrimSpace(severity)) {
+	case "ok":
+		return SystemEventLogSeverityOK
+	case "info", "informational":
+		return SystemEventLogSeverityInfo
+	case "warning", "degraded", "non-fatal", "noncritical", "non-critical":
+		return SystemEventLogSeverityWarning
+	case "critical", "fatal", "nonrecoverable", "non-recoverable":
+		return SystemEventLogSeverityCritical
+	default:
+		return SystemEventLogSeverityUnknown
+	}
+}
 
+// ParseSystemEventLogEventDirection normalizes an assertion/deassertion string such as
+// the IPMI "Asserted"/"Deasserted" or the Redfish LogEntry "Assert"/"Deassert" entry codes.
+func ParseSystemEventLogEventDirection(direction string) SystemEventLogEventDirection {
+	d := strings.ToLower(direction)
+	switch {
+	case strings.Contains(d, "deassert"):
+		return SystemEventLogEventDirectionDeasserted
+	case strings.Contains(d, "assert"):
+		return SystemEventLogEventDirectionAsserted
+	default:
+		return SystemEventLogEventDirectionUnknown
+	}
+}
+
+func clearSystemEventLog(ctx context.Context, timeout time.Duration, s []systemEventLogProviders) (metadata Metadata, err error) {
+	calls := make([]providerCall[struct{}], 0, len(s))
 	for _, elem := range s {
 		if elem.systemEventLogProvider == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return metadata, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			ctx, cancel := context.WithTimeout(ctx, timeout)
-			selErr := elem.systemEventLogProvider.ClearSystemEventLog(ctx)
-			cancel()
-			if selErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(selErr, "provider: %v", elem.name))
-				continue
-			}
-			metadataLocal.SuccessfulProvider = elem.name
-			return metadataLocal, nil
-		}
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: withoutResult(elem.systemEventLogProvider.ClearSystemEventLog)})
 	}
 
-	return metadataLocal, multierror.Append(err, errors.New("failed to reset System Event Log"))
+	_, metadata, err = dispatch(ctx, timeout, "ClearSystemEventLog", calls, "failed to reset System Event Log")
+	return metadata, err
 }
 
 // ClearSystemEventLogFromInterfaces identifies implementations of the SystemEventLog interface and clears the System Event Log using the first successful provider.
@@ -77,34 +144,15 @@ func ClearSystemEventLogFromInterfaces(ctx context.Context, timeout time.Duratio
 }
 
 func getSystemEventLog(ctx context.Context, timeout time.Duration, s []systemEventLogProviders) (sel SystemEventLogEntries, metadata Metadata, err error) {
-	var metadataLocal Metadata
-
+	calls := make([]providerCall[[][]string], 0, len(s))
 	for _, elem := range s {
 		if elem.systemEventLogProvider == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return sel, metadata, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			ctx, cancel := context.WithTimeout(ctx, timeout)
-
-			sel, selErr := elem.systemEventLogProvider.GetSystemEventLog(ctx)
-			cancel()
-			if selErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(selErr, "provider: %v", elem.name))
-				continue
-			}
-
-			metadataLocal.SuccessfulProvider = elem.name
-			return sel, metadataLocal, nil
-		}
+		calls = append(calls, providerCall[[][]string]{name: elem.name, call: elem.systemEventLogProvider.GetSystemEventLog})
 	}
 
-	return nil, metadataLocal, multierror.Append(err, errors.New("failed to get System Event Log"))
+	return dispatchRead(ctx, timeout, "GetSystemEventLog", calls, "failed to get System Event Log")
 }
 
 // GetSystemEventLogFromInterfaces identifies implementations of the SystemEventLog interface and returns the System Event Log entries from the first successful provider.
@@ -131,34 +179,15 @@ func GetSystemEventLogFromInterfaces(ctx context.Context, timeout time.Duration,
 }
 
 func getSystemEventLogRaw(ctx context.Context, timeout time.Duration, s []systemEventLogProviders) (eventlog string, metadata Metadata, err error) {
-	var metadataLocal Metadata
-
+	calls := make([]providerCall[string], 0, len(s))
 	for _, elem := range s {
 		if elem.systemEventLogProvider == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return eventlog, metadata, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			ctx, cancel := context.WithTimeout(ctx, timeout)
-
-			eventlog, selErr := elem.systemEventLogProvider.GetSystemEventLogRaw(ctx)
-			cancel()
-			if selErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(selErr, "provider: %v", elem.name))
-				continue
-			}
-
-			metadataLocal.SuccessfulProvider = elem.name
-			return eventlog, metadataLocal, nil
-		}
+		calls = append(calls, providerCall[string]{name: elem.name, call: elem.systemEventLogProvider.GetSystemEventLogRaw})
 	}
 
-	return eventlog, metadataLocal, multierror.Append(err, errors.New("failed to get System Event Log"))
+	return dispatchRead(ctx, timeout, "GetSystemEventLogRaw", calls, "failed to get System Event Log")
 }
 
 // GetSystemEventLogRawFromInterfaces identifies implementations of the SystemEventLog interface and returns the raw System Event Log from the first successful provider.
@@ -183,3 +212,38 @@ func GetSystemEventLogRawFromInterfaces(ctx context.Context, timeout time.Durati
 	}
 	return getSystemEventLogRaw(ctx, timeout, selServices)
 }
+
+func getSystemEventLogEntries(ctx context.Context, timeout time.Duration, s []systemEventLogEntriesProviders) (entries []SystemEventLogEntry, metadata Metadata, err error) {
+	calls := make([]providerCall[[]SystemEventLogEntry], 0, len(s))
+	for _, elem := range s {
+		if elem.systemEventLogEntriesProvider == nil {
+			continue
+		}
+		calls = append(calls, providerCall[[]SystemEventLogEntry]{name: elem.name, call: elem.systemEventLogEntriesProvider.GetSystemEventLogEntries})
+	}
+
+	return dispatchRead(ctx, timeout, "GetSystemEventLogEntries", calls, "failed to get System Event Log entries")
+}
+
+// GetSystemEventLogEntriesFromInterfaces identifies implementations of the SystemEventLogEntriesGetter interface and returns the typed System Event Log entries from the first successful provider.
+func GetSystemEventLogEntriesFromInterfaces(ctx context.Context, timeout time.Duration, generic []interface{}) (entries []SystemEventLogEntry, metadata Metadata, err error) {
+	selServices := make([]systemEventLogEntriesProviders, 0)
+	for _, elem := range generic {
+		if elem == nil {
+			continue
+		}
+		temp := systemEventLogEntriesProviders{name: getProviderName(elem)}
+		switch p := elem.(type) {
+		case SystemEventLogEntriesGetter:
+			temp.systemEventLogEntriesProvider = p
+			selServices = append(selServices, temp)
+		default:
+			e := fmt.Sprintf("not a SystemEventLogEntriesGetter implementation: %T", p)
+			err = multierror.Append(err, errors.New(e))
+		}
+	}
+	if len(selServices) == 0 {
+		return entries, metadata, multierror.Append(err, errors.New("no SystemEventLogEntriesGetter implementations found"))
+	}
+	return getSystemEventLogEntries(ctx, timeout, selServices)
+}
@@ -26,6 +26,14 @@ func (m *mockSystemEventLogService) GetSystemEventLogRaw(ctx context.Context) (e
 	return "", m.err
 }
 
+func (m *mockSystemEventLogService) GetSystemEventLogEntries(ctx context.Context) (entries []SystemEventLogEntry, err error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	return []SystemEventLogEntry{{RecordID: "1", Severity: SystemEventLogSeverityOK}}, nil
+}
+
 func (m *mockSystemEventLogService) Name() string {
 	return m.name
 }
@@ -133,3 +141,71 @@ func TestGetSystemEventLogRawFromInterfaces(t *testing.T) {
 	_, _, err = GetSystemEventLogRawFromInterfaces(ctx, timeout, []interface{}{mockService})
 	assert.Nil(t, err)
 }
+
+func TestGetSystemEventLogEntries(t *testing.T) {
+	ctx := context.Background()
+	timeout := 1 * time.Second
+
+	// Test with a mock SystemEventLogService that returns nil
+	mockService := &mockSystemEventLogService{name: "mock1", err: nil}
+	entries, metadata, err := getSystemEventLogEntries(ctx, timeout, []systemEventLogEntriesProviders{{name: mockService.name, systemEventLogEntriesProvider: mockService}})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, mockService.name, metadata.SuccessfulProvider)
+
+	// Test with a mock SystemEventLogService that returns an error
+	mockService = &mockSystemEventLogService{name: "mock2", err: errors.New("mock error")}
+	_, metadata, err = getSystemEventLogEntries(ctx, timeout, []systemEventLogEntriesProviders{{name: mockService.name, systemEventLogEntriesProvider: mockService}})
+	assert.NotNil(t, err)
+	assert.Equal(t, "mock error", metadata.FailedProviderDetail[mockService.name])
+}
+
+func TestGetSystemEventLogEntriesFromInterfaces(t *testing.T) {
+	ctx := context.Background()
+	timeout := 1 * time.Second
+
+	// Test with an empty slice
+	_, _, err := GetSystemEventLogEntriesFromInterfaces(ctx, timeout, []interface{}{})
+	assert.NotNil(t, err)
+
+	// Test with a slice containing a non-SystemEventLogEntriesGetter object
+	_, _, err = GetSystemEventLogEntriesFromInterfaces(ctx, timeout, []interface{}{"not a SystemEventLogEntriesGetter"})
+	assert.NotNil(t, err)
+
+	// Test with a slice containing a mock SystemEventLogService that returns nil
+	mockService := &mockSystemEventLogService{name: "mock1"}
+	entries, metadata, err := GetSystemEventLogEntriesFromInterfaces(ctx, timeout, []interface{}{mockService})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, mockService.name, metadata.SuccessfulProvider)
+}
+
+func TestParseSystemEventLogSeverity(t *testing.T) {
+	tests := map[string]SystemEventLogSeverity{
+		"OK":        SystemEventLogSeverityOK,
+		"Info":      SystemEventLogSeverityInfo,
+		"Warning":   SystemEventLogSeverityWarning,
+		"Non-fatal": SystemEventLogSeverityWarning,
+		"Critical":  SystemEventLogSeverityCritical,
+		"":          SystemEventLogSeverityUnknown,
+		"bogus":     SystemEventLogSeverityUnknown,
+	}
+
+	for in, want := range tests {
+		assert.Equal(t, want, ParseSystemEventLogSeverity(in), in)
+	}
+}
+
+func TestParseSystemEventLogEventDirection(t *testing.T) {
+	tests := map[string]SystemEventLogEventDirection{
+		"Asserted":         SystemEventLogEventDirectionAsserted,
+		"Deasserted":       SystemEventLogEventDirectionDeasserted,
+		"Assert":           SystemEventLogEventDirectionAsserted,
+		"State Deasserted": SystemEventLogEventDirectionDeasserted,
+		"Informational":    SystemEventLogEventDirectionUnknown,
+	}
+
+	for in, want := range tests {
+		assert.Equal(t, want, ParseSystemEventLogEventDirection(in), in)
+	}
+}
@@ -0,0 +1,135 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// SensorsGetter retrieves the current sensor readings of a host.
+type SensorsGetter interface {
+	Sensors(ctx context.Context) (sensors []SensorReading, err error)
+}
+
+type sensorsGetterProvider struct {
+	name string
+	SensorsGetter
+}
+
+// SensorType is the normalized kind of a sensor.
+type SensorType string
+
+// SensorType values enumerate the normalized sensor kinds.
+const (
+	SensorTypeTemperature SensorType = "temperature"
+	SensorTypeFan         SensorType = "fan"
+	SensorTypeVoltage     SensorType = "voltage"
+	SensorTypeCurrent     SensorType = "current"
+	SensorTypePower       SensorType = "power"
+	SensorTypePowerSupply SensorType = "power_supply"
+	SensorTypeOther       SensorType = "other"
+)
+
+// SensorHealth is the normalized health of a sensor.
+type SensorHealth string
+
+// SensorHealth values enumerate the normalized sensor health states.
+const (
+	SensorHealthUnknown  SensorHealth = "unknown"
+	SensorHealthOK       SensorHealth = "ok"
+	SensorHealthWarning  SensorHealth = "warning"
+	SensorHealthCritical SensorHealth = "critical"
+)
+
+// SensorThresholds holds the thresholds of a sensor, nil fields are not reported by the BMC.
+//
+// The NonCritical, Critical and Fatal levels map to the IPMI non-critical,
+// critical and non-recoverable thresholds and to the Redfish caution,
+// critical and fatal thresholds.
+type SensorThresholds struct {
+	LowerNonCritical *float64
+	LowerCritical    *float64
+	LowerFatal       *float64
+	UpperNonCritical *float64
+	UpperCritical    *float64
+	UpperFatal       *float64
+}
+
+// SensorReading is a single sensor reading in a provider independent format.
+type SensorReading struct {
+	// ID is the BMC assigned identifier of the sensor.
+	ID string
+	// Name is the human readable name of the sensor.
+	Name string
+	// Type is the normalized kind of the sensor.
+	Type SensorType
+	// Reading is the current value of the sensor, nil when the BMC has no reading available.
+	Reading *float64
+	// Units is the unit of Reading and Thresholds, e.g. "Cel", "RPM", "V" or "W".
+	Units string
+	// Thresholds holds the thresholds of the sensor.
+	Thresholds SensorThresholds
+	// Health is the normalized health of the sensor.
+	Health SensorHealth
+	// State is the BMC reported state of the sensor, e.g. "Enabled" or "Absent".
+	State string
+	// PhysicalContext is the area or device the sensor measures, e.g. "CPU" or "SystemBoard".
+	PhysicalContext string
+}
+
+// ParseSensorHealth normalizes a vendor or protocol specific sensor health string.
+func ParseSensorHealth(s string) SensorHealth {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "ok", "normal", "good":
+		return SensorHealthOK
+	case "warning", "caution", "non-critical", "noncritical", "lnc", "unc":
+		return SensorHealthWarning
+	case "critical", "fatal", "non-recoverable", "nonrecoverable", "lcr", "ucr", "lnr", "unr":
+		return SensorHealthCritical
+	default:
+		return SensorHealthUnknown
+	}
+}
+
+// sensors returns the sensor readings from the first successful provider.
+func sensors(ctx context.Context, timeout time.Duration, generic []sensorsGetterProvider) (readings []SensorReading, metadata Metadata, err error) {
+	calls := make([]providerCall[[]SensorReading], 0, len(generic))
+	for _, elem := range generic {
+		if elem.SensorsGetter == nil {
+			continue
+		}
+		calls = append(calls, providerCall[[]SensorReading]{name: elem.name, call: elem.Sensors})
+	}
+
+	return dispatchRead(ctx, timeout, "GetSensors", calls, "failed to get sensor readings")
+}
+
+// GetSensorsFromInterfaces identifies implementations of the SensorsGetter interface and returns the sensor readings from the first successful provider.
+func GetSensorsFromInterfaces(ctx context.Context, timeout time.Duration, generic []interface{}) (readings []SensorReading, metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	implementations := make([]sensorsGetterProvider, 0)
+	for _, elem := range generic {
+		if elem == nil {
+			continue
+		}
+		temp := sensorsGetterProvider{name: getProviderName(elem)}
+		switch p := elem.(type) {
+		case SensorsGetter:
+			temp.SensorsGetter = p
+			implementations = append(implementations, temp)
+		default:
+			e := fmt.Sprintf("not a SensorsGetter implementation: %T", p)
+			err = multierror.Append(err, errors.New(e))
+		}
+	}
+	if len(implementations) == 0 {
+		return readings, metadata, multierror.Append(err, errors.New("no SensorsGetter implementations found"))
+	}
+
+	return sensors(ctx, timeout, implementations)
+}
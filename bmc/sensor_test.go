@@ -0,0 +1,122 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSensorsGetter struct {
+	readings []SensorReading
+	err      error
+}
+
+func (m *mockSensorsGetter) Sensors(ctx context.Context) ([]SensorReading, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		return m.readings, m.err
+	}
+}
+
+func (m *mockSensorsGetter) Name() string {
+	return "mock"
+}
+
+func TestGetSensorsFromInterfaces(t *testing.T) {
+	reading := 42.0
+	readings := []SensorReading{
+		{ID: "CPU1_Temp", Name: "CPU1 Temp", Type: SensorTypeTemperature, Reading: &reading, Units: "Cel", Health: SensorHealthOK},
+	}
+
+	testCases := []struct {
+		name             string
+		generic          []interface{}
+		expected         []SensorReading
+		errMsg           string
+		expectedMetadata Metadata
+	}{
+		{
+			name:     "success",
+			generic:  []interface{}{&mockSensorsGetter{readings: readings}},
+			expected: readings,
+			expectedMetadata: Metadata{
+				SuccessfulProvider:   "mock",
+				ProvidersAttempted:   []string{"mock"},
+				FailedProviderDetail: make(map[string]string),
+			},
+		},
+		{
+			name: "success with a failing provider first",
+			generic: []interface{}{
+				&mockSensorsGetter{err: errors.New("sensors unavailable")},
+				&mockSensorsGetter{readings: readings},
+			},
+			expected: readings,
+			expectedMetadata: Metadata{
+				SuccessfulProvider:   "mock",
+				ProvidersAttempted:   []string{"mock", "mock"},
+				FailedProviderDetail: map[string]string{"mock": "sensors unavailable"},
+			},
+		},
+		{
+			name:    "provider failure",
+			generic: []interface{}{&mockSensorsGetter{err: errors.New("sensors unavailable")}},
+			errMsg:  "failed to get sensor readings",
+			expectedMetadata: Metadata{
+				ProvidersAttempted:   []string{"mock"},
+				FailedProviderDetail: map[string]string{"mock": "sensors unavailable"},
+			},
+		},
+		{
+			name:    "not a SensorsGetter",
+			generic: []interface{}{"foo"},
+			errMsg:  "not a SensorsGetter implementation: string",
+			expectedMetadata: Metadata{
+				FailedProviderDetail: make(map[string]string),
+			},
+		},
+		{
+			name:    "no implementations",
+			generic: []interface{}{},
+			errMsg:  "no SensorsGetter implementations found",
+			expectedMetadata: Metadata{
+				FailedProviderDetail: make(map[string]string),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, metadata, err := GetSensorsFromInterfaces(context.Background(), 1*time.Second, tc.generic)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expected, got)
+			assert.Equal(t, tc.expectedMetadata, withoutAttempts(metadata))
+		})
+	}
+}
+
+func TestParseSensorHealth(t *testing.T) {
+	testCases := map[string]SensorHealth{
+		"OK":       SensorHealthOK,
+		"Warning":  SensorHealthWarning,
+		"unc":      SensorHealthWarning,
+		"Critical": SensorHealthCritical,
+		"lnr":      SensorHealthCritical,
+		"":         SensorHealthUnknown,
+		"N/A":      SensorHealthUnknown,
+	}
+
+	for in, expected := range testCases {
+		assert.Equal(t, expected, ParseSensorHealth(in), in)
+	}
+}
@@ -0,0 +1,72 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// SessionKeeper is implemented by providers that hold a BMC session which times out when left idle.
+type SessionKeeper interface {
+	// KeepSessionAlive refreshes the session idle timer, a session that has already expired is re-established.
+	KeepSessionAlive(ctx context.Context) (err error)
+}
+
+type sessionKeeperProvider struct {
+	name string
+	SessionKeeper
+}
+
+// keepSessionsAlive refreshes the sessions of all providers, unlike most operations every provider
+// holds its own session so the call is not stopped at the first successful provider.
+func keepSessionsAlive(ctx context.Context, timeout time.Duration, generic []sessionKeeperProvider) (metadata Metadata, err error) {
+	calls := make([]providerCall[struct{}], 0, len(generic))
+	for _, elem := range generic {
+		if elem.SessionKeeper == nil {
+			continue
+		}
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: withoutResult(elem.KeepSessionAlive)})
+	}
+
+	_, metadata, err = dispatchAll(ctx, timeout, "KeepSessionAlive", calls)
+	if err != nil {
+		return metadata, multierror.Append(err, errors.New("failed to keep sessions alive"))
+	}
+
+	return metadata, nil
+}
+
+// KeepSessionAliveFromInterfaces identifies implementations of the SessionKeeper interface and refreshes the session of each of them.
+func KeepSessionAliveFromInterfaces(ctx context.Context, timeout time.Duration, generic []interface{}) (metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	implementations, err := sessionKeepers(generic)
+	if len(implementations) == 0 {
+		return metadata, multierror.Append(err, errors.New("no SessionKeeper implementations found"))
+	}
+
+	return keepSessionsAlive(ctx, timeout, implementations)
+}
+
+// sessionKeepers returns the SessionKeeper implementations in generic.
+func sessionKeepers(generic []interface{}) (implementations []sessionKeeperProvider, err error) {
+	for _, elem := range generic {
+		if elem == nil {
+			continue
+		}
+		temp := sessionKeeperProvider{name: getProviderName(elem)}
+		switch p := elem.(type) {
+		case SessionKeeper:
+			temp.SessionKeeper = p
+			implementations = append(implementations, temp)
+		default:
+			e := fmt.Sprintf("not a SessionKeeper implementation: %T", p)
+			err = multierror.Append(err, errors.New(e))
+		}
+	}
+
+	return implementations, err
+}
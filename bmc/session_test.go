@@ -0,0 +1,47 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSessionKeeper struct {
+	name  string
+	calls int
+	err   error
+}
+
+func (m *mockSessionKeeper) KeepSessionAlive(ctx context.Context) error {
+	m.calls++
+
+	return m.err
+}
+
+func (m *mockSessionKeeper) Name() string {
+	return m.name
+}
+
+func TestKeepSessionAliveFromInterfaces(t *testing.T) {
+	first := &mockSessionKeeper{name: "first"}
+	second := &mockSessionKeeper{name: "second"}
+
+	metadata, err := KeepSessionAliveFromInterfaces(context.Background(), 1*time.Second, []interface{}{first, "no session", second})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, metadata.ProvidersAttempted)
+	assert.Equal(t, 1, first.calls)
+	assert.Equal(t, 1, second.calls)
+
+	expired := &mockSessionKeeper{name: "expired", err: errors.New("login failed")}
+
+	metadata, err = KeepSessionAliveFromInterfaces(context.Background(), 1*time.Second, []interface{}{expired, second})
+	assert.ErrorContains(t, err, "failed to keep sessions alive")
+	assert.Equal(t, map[string]string{"expired": "login failed"}, metadata.FailedProviderDetail)
+	assert.Equal(t, 2, second.calls)
+
+	_, err = KeepSessionAliveFromInterfaces(context.Background(), 1*time.Second, []interface{}{"foo"})
+	assert.ErrorContains(t, err, "no SessionKeeper implementations found")
+}
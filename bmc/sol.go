@@ -22,31 +22,16 @@ type deactivatorProvider struct {
 
 // deactivateSOL tries all implementations for a successful SOL deactivation
 func deactivateSOL(ctx context.Context, timeout time.Duration, b []deactivatorProvider) (metadata Metadata, err error) {
-	var metadataLocal Metadata
-
+	calls := make([]providerCall[struct{}], 0, len(b))
 	for _, elem := range b {
 		if elem.solDeactivator == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return metadata, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			ctx, cancel := context.WithTimeout(ctx, timeout)
-			newErr := elem.solDeactivator.DeactivateSOL(ctx)
-			cancel()
-			if newErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(newErr, "provider: %v", elem.name))
-				continue
-			}
-			metadataLocal.SuccessfulProvider = elem.name
-			return metadataLocal, nil
-		}
+		calls = append(calls, providerCall[struct{}]{name: elem.name, call: withoutResult(elem.solDeactivator.DeactivateSOL)})
 	}
-	return metadataLocal, multierror.Append(err, errors.New("failed to deactivate SOL session"))
+
+	_, metadata, err = dispatch(ctx, timeout, "DeactivateSOL", calls, "failed to deactivate SOL session")
+	return metadata, err
 }
 
 // DeactivateSOLFromInterfaces identifies implementations of the SOLDeactivator interface and passes them to the deactivateSOL() wrapper method.
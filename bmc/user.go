@@ -40,35 +40,19 @@ type userProviders struct {
 
 // createUser creates a user using the passed in implementation
 func createUser(ctx context.Context, timeout time.Duration, user, pass, role string, u []userProviders) (ok bool, metadata Metadata, err error) {
-	var metadataLocal Metadata
-
+	calls := make([]providerCall[bool], 0, len(u))
 	for _, elem := range u {
 		if elem.userCreator == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return false, metadata, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			ctx, cancel := context.WithTimeout(ctx, timeout)
-			ok, createErr := elem.userCreator.UserCreate(ctx, user, pass, role)
-			cancel()
-			if createErr != nil {
-				err = multierror.Append(err, createErr)
-				continue
-			}
-			if !ok {
-				err = multierror.Append(err, errors.New("failed to create user"))
-				continue
-			}
-			metadataLocal.SuccessfulProvider = elem.name
-			return ok, metadataLocal, nil
+		call := func(ctx context.Context) (bool, error) {
+			ok, err := elem.userCreator.UserCreate(ctx, user, pass, role)
+			return ok, notOK(ok, err, "failed to create user")
 		}
+		calls = append(calls, providerCall[bool]{name: elem.name, call: call, unnamed: true})
 	}
-	return ok, metadataLocal, multierror.Append(err, errors.New("failed to create user"))
+
+	return dispatch(ctx, timeout, "CreateUser", calls, "failed to create user")
 }
 
 // CreateUserFromInterfaces identifies implementations of the UserCreator interface and passes them to the createUser() wrapper method.
@@ -96,35 +80,19 @@ func CreateUserFromInterfaces(ctx context.Context, timeout time.Duration, user,
 
 // updateUser updates a user's settings
 func updateUser(ctx context.Context, timeout time.Duration, user, pass, role string, u []userProviders) (ok bool, metadata Metadata, err error) {
-	var metadataLocal Metadata
-
+	calls := make([]providerCall[bool], 0, len(u))
 	for _, elem := range u {
 		if elem.userUpdater == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return false, metadata, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			ctx, cancel := context.WithTimeout(ctx, timeout)
-			ok, UpdateErr := elem.userUpdater.UserUpdate(ctx, user, pass, role)
-			cancel()
-			if UpdateErr != nil {
-				err = multierror.Append(err, UpdateErr)
-				continue
-			}
-			if !ok {
-				err = multierror.Append(err, errors.New("failed to update user"))
-				continue
-			}
-			metadataLocal.SuccessfulProvider = elem.name
-			return ok, metadataLocal, nil
+		call := func(ctx context.Context) (bool, error) {
+			ok, err := elem.userUpdater.UserUpdate(ctx, user, pass, role)
+			return ok, notOK(ok, err, "failed to update user")
 		}
+		calls = append(calls, providerCall[bool]{name: elem.name, call: call, unnamed: true})
 	}
-	return ok, metadataLocal, multierror.Append(err, errors.New("failed to update user"))
+
+	return dispatch(ctx, timeout, "UpdateUser", calls, "failed to update user")
 }
 
 // UpdateUserFromInterfaces identifies implementations of the UserUpdater interface and passes them to the updateUser() wrapper method.
@@ -152,35 +120,19 @@ func UpdateUserFromInterfaces(ctx context.Context, timeout time.Duration, user,
 
 // deleteUser deletes a user from a BMC
 func deleteUser(ctx context.Context, timeout time.Duration, user string, u []userProviders) (ok bool, metadata Metadata, err error) {
-	var metadataLocal Metadata
-
+	calls := make([]providerCall[bool], 0, len(u))
 	for _, elem := range u {
 		if elem.userDeleter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return false, metadata, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			ctx, cancel := context.WithTimeout(ctx, timeout)
-			ok, deleteErr := elem.userDeleter.UserDelete(ctx, user)
-			cancel()
-			if deleteErr != nil {
-				err = multierror.Append(err, deleteErr)
-				continue
-			}
-			if !ok {
-				err = multierror.Append(err, errors.New("failed to delete user"))
-				continue
-			}
-			metadataLocal.SuccessfulProvider = elem.name
-			return ok, metadataLocal, nil
+		call := func(ctx context.Context) (bool, error) {
+			ok, err := elem.userDeleter.UserDelete(ctx, user)
+			return ok, notOK(ok, err, "failed to delete user")
 		}
+		calls = append(calls, providerCall[bool]{name: elem.name, call: call, unnamed: true})
 	}
-	return ok, metadataLocal, multierror.Append(err, errors.New("failed to delete user"))
+
+	return dispatch(ctx, timeout, "DeleteUser", calls, "failed to delete user")
 }
 
 // DeleteUserFromInterfaces identifies implementations of the UserDeleter interface and passes them to the deleteUser() wrapper method.
@@ -208,31 +160,15 @@ func DeleteUserFromInterfaces(ctx context.Context, timeout time.Duration, user s
 
 // readUsers returns all users from a BMC
 func readUsers(ctx context.Context, timeout time.Duration, u []userProviders) (users []map[string]string, metadata Metadata, err error) {
-	var metadataLocal Metadata
-
+	calls := make([]providerCall[[]map[string]string], 0, len(u))
 	for _, elem := range u {
 		if elem.userReader == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return users, metadata, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			ctx, cancel := context.WithTimeout(ctx, timeout)
-			users, readErr := elem.userReader.UserRead(ctx)
-			cancel()
-			if readErr != nil {
-				err = multierror.Append(err, readErr)
-				continue
-			}
-			metadataLocal.SuccessfulProvider = elem.name
-			return users, metadataLocal, nil
-		}
+		calls = append(calls, providerCall[[]map[string]string]{name: elem.name, call: elem.userReader.UserRead, unnamed: true})
 	}
-	return users, metadataLocal, multierror.Append(err, errors.New("failed to read users"))
+
+	return dispatchRead(ctx, timeout, "ReadUsers", calls, "failed to read users")
 }
 
 // ReadUsersFromInterfaces identifies implementations of the UserReader interface and passes them to the readUsers() wrapper method.
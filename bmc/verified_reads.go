@@ -0,0 +1,120 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+type verifiedReadsKey struct{}
+
+// WithVerifiedReads returns a context which has the read operations that support verification query the
+// value from all providers and compare the results. Providers that disagree are recorded in the Metadata,
+// with strict set the disagreement is also returned as an error wrapping errors.ErrInconsistentResults.
+//
+// The read operations supporting verification are the power state, boot device override and secure boot state.
+func WithVerifiedReads(ctx context.Context, strict bool) context.Context {
+	return context.WithValue(ctx, verifiedReadsKey{}, strict)
+}
+
+// verifiedReads returns if the read operations are to be verified, and if a disagreement is an error.
+func verifiedReads(ctx context.Context) (enabled, strict bool) {
+	strict, enabled = ctx.Value(verifiedReadsKey{}).(bool)
+	return enabled, strict
+}
+
+// verifiedRead attempts the read operation on all providers concurrently and compares the results of
+// those that succeed with equal.
+//
+// The result of the first provider in order to succeed is returned, and the result of each provider is
+// recorded in Metadata.ProviderResults. When the results do not agree Metadata.Inconsistent is set, and
+// with strict set an error is returned along with the result.
+func verifiedRead[T any](ctx context.Context, timeout time.Duration, operation string, calls []providerCall[T], equal func(a, b T) bool, strict bool, failure string) (result T, metadata Metadata, err error) {
+	metadata = newMetadata()
+
+	select {
+	case <-ctx.Done():
+		return result, metadata, multierror.Append(err, ctx.Err())
+	default:
+	}
+
+	type outcome struct {
+		result T
+		record ProviderAttempt
+		err    error
+	}
+
+	outcomes := make([]outcome, len(calls))
+
+	var wg sync.WaitGroup
+	for i, pc := range calls {
+		metadata.ProvidersAttempted = append(metadata.ProvidersAttempted, pc.name)
+
+		wg.Add(1)
+		go func(i int, pc providerCall[T]) {
+			defer wg.Done()
+
+			result, record, err := attempt(ctx, timeout, operation, pc)
+			outcomes[i] = outcome{result: result, record: record, err: err}
+		}(i, pc)
+	}
+
+	wg.Wait()
+
+	for i, o := range outcomes {
+		metadata.recordAttempt(o.record)
+
+		name := calls[i].name
+		if o.err != nil {
+			err = multierror.Append(err, calls[i].providerError(o.err))
+			continue
+		}
+
+		if metadata.ProviderResults == nil {
+			metadata.ProviderResults = make(map[string]string)
+		}
+
+		metadata.ProviderResults[name] = fmt.Sprintf("%v", o.result)
+
+		if metadata.SuccessfulProvider == "" {
+			metadata.SuccessfulProvider = name
+			result = o.result
+
+			continue
+		}
+
+		if !equal(result, o.result) {
+			metadata.Inconsistent = true
+		}
+	}
+
+	if metadata.SuccessfulProvider == "" {
+		return result, metadata, multierror.Append(err, errors.New(failure))
+	}
+
+	if metadata.Inconsistent && strict {
+		return result, metadata, errors.Wrap(bmclibErrs.ErrInconsistentResults, formatProviderResults(metadata.ProviderResults))
+	}
+
+	return result, metadata, nil
+}
+
+// formatProviderResults returns the provider results as a sorted list of provider: result pairs.
+func formatProviderResults(results map[string]string) string {
+	pairs := make([]string, 0, len(results))
+	for name, result := range results {
+		pairs = append(pairs, name+": "+result)
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,94 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+func TestGetPowerStateVerifiedReads(t *testing.T) {
+	ipmi := &powerStateReader{name: "ipmi", state: "Chassis Power is on"}
+	redfish := &powerStateReader{name: "redfish", state: "On"}
+	hung := &powerStateReader{name: "hung", state: "Off"}
+	failing := &powerStateReader{name: "failing", err: errors.New("session expired")}
+
+	testCases := []struct {
+		name             string
+		strict           bool
+		providers        []interface{}
+		wantState        string
+		wantInconsistent bool
+		wantErr          error
+	}{
+		{
+			name:      "providers agree",
+			providers: []interface{}{ipmi, redfish, failing},
+			wantState: "Chassis Power is on",
+		},
+		{
+			name:             "providers disagree",
+			providers:        []interface{}{redfish, hung},
+			wantState:        "On",
+			wantInconsistent: true,
+		},
+		{
+			name:             "providers disagree strict",
+			strict:           true,
+			providers:        []interface{}{redfish, hung},
+			wantState:        "On",
+			wantInconsistent: true,
+			wantErr:          bmclibErrs.ErrInconsistentResults,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := WithVerifiedReads(context.Background(), tc.strict)
+
+			state, metadata, err := GetPowerStateFromInterfaces(ctx, 1*time.Second, tc.providers)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.wantState, state)
+			assert.Equal(t, tc.wantInconsistent, metadata.Inconsistent)
+		})
+	}
+}
+
+func TestVerifiedReadResults(t *testing.T) {
+	ctx := WithVerifiedReads(context.Background(), true)
+	override := BootDeviceOverride{Device: BootDeviceTypePXE}
+
+	readers := []interface{}{
+		&bootOverrideReader{name: "first", override: override},
+		&bootOverrideReader{name: "second", override: BootDeviceOverride{Device: BootDeviceTypeDisk}},
+	}
+
+	got, metadata, err := GetBootDeviceOverrideFromInterface(ctx, 1*time.Second, readers)
+	assert.ErrorIs(t, err, bmclibErrs.ErrInconsistentResults)
+	assert.ErrorContains(t, err, "first: {false false pxe}, second: {false false disk}")
+	assert.Equal(t, override, got)
+	assert.Equal(t, "first", metadata.SuccessfulProvider)
+	assert.Len(t, metadata.ProviderResults, 2)
+}
+
+type bootOverrideReader struct {
+	name     string
+	override BootDeviceOverride
+}
+
+func (b *bootOverrideReader) BootDeviceOverrideGet(_ context.Context) (BootDeviceOverride, error) {
+	return b.override, nil
+}
+
+func (b *bootOverrideReader) Name() string {
+	return b.name
+}
@@ -21,33 +21,19 @@ type virtualMediaProviders struct {
 
 // setVirtualMedia sets the virtual media.
 func setVirtualMedia(ctx context.Context, kind, mediaURL string, b []virtualMediaProviders) (ok bool, metadata Metadata, err error) {
-	var metadataLocal Metadata
-
+	calls := make([]providerCall[bool], 0, len(b))
 	for _, elem := range b {
 		if elem.virtualMediaSetter == nil {
 			continue
 		}
-		select {
-		case <-ctx.Done():
-			err = multierror.Append(err, ctx.Err())
-
-			return false, metadata, err
-		default:
-			metadataLocal.ProvidersAttempted = append(metadataLocal.ProvidersAttempted, elem.name)
-			ok, setErr := elem.virtualMediaSetter.SetVirtualMedia(ctx, kind, mediaURL)
-			if setErr != nil {
-				err = multierror.Append(err, errors.WithMessagef(setErr, "provider: %v", elem.name))
-				continue
-			}
-			if !ok {
-				err = multierror.Append(err, fmt.Errorf("provider: %v, failed to set virtual media", elem.name))
-				continue
-			}
-			metadataLocal.SuccessfulProvider = elem.name
-			return ok, metadataLocal, nil
+		call := func(ctx context.Context) (bool, error) {
+			ok, err := elem.virtualMediaSetter.SetVirtualMedia(ctx, kind, mediaURL)
+			return ok, notOK(ok, err, "failed to set virtual media")
 		}
+		calls = append(calls, providerCall[bool]{name: elem.name, call: call})
 	}
-	return ok, metadataLocal, multierror.Append(err, errors.New("failed to set virtual media"))
+
+	return dispatch(ctx, providerTimeout(ctx), "SetVirtualMedia", calls, "failed to set virtual media")
 }
 
 // SetVirtualMediaFromInterfaces identifies implementations of the virtualMediaSetter interface and passes the found implementations to the setVirtualMedia() wrapper
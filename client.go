@@ -24,16 +24,26 @@ import (
 	"github.com/bmc-toolbox/bmclib/v2/constants"
 	"github.com/bmc-toolbox/bmclib/v2/internal/httpclient"
 	"github.com/bmc-toolbox/bmclib/v2/providers/asrockrack"
+	"github.com/bmc-toolbox/bmclib/v2/providers/cisco"
 	"github.com/bmc-toolbox/bmclib/v2/providers/dell"
+	"github.com/bmc-toolbox/bmclib/v2/providers/fujitsu"
 	"github.com/bmc-toolbox/bmclib/v2/providers/homeassistant"
+	"github.com/bmc-toolbox/bmclib/v2/providers/hpe"
 	"github.com/bmc-toolbox/bmclib/v2/providers/intelamt"
 	"github.com/bmc-toolbox/bmclib/v2/providers/ipmi"
 	"github.com/bmc-toolbox/bmclib/v2/providers/ipmitool"
 	"github.com/bmc-toolbox/bmclib/v2/providers/lenovo"
+	"github.com/bmc-toolbox/bmclib/v2/providers/libvirt"
+	"github.com/bmc-toolbox/bmclib/v2/providers/megarac"
+	"github.com/bmc-toolbox/bmclib/v2/providers/nvidia"
 	"github.com/bmc-toolbox/bmclib/v2/providers/openbmc"
 	"github.com/bmc-toolbox/bmclib/v2/providers/redfish"
+	"github.com/bmc-toolbox/bmclib/v2/providers/redfishpdu"
 	"github.com/bmc-toolbox/bmclib/v2/providers/rpc"
+	"github.com/bmc-toolbox/bmclib/v2/providers/snmppdu"
+	"github.com/bmc-toolbox/bmclib/v2/providers/sshclp"
 	"github.com/bmc-toolbox/bmclib/v2/providers/supermicro"
+	"github.com/bmc-toolbox/bmclib/v2/providers/xfusion"
 )
 
 const (
@@ -57,6 +67,11 @@ type Client struct {
 	oneTimeRegistryEnabled bool
 	providerConfig         providerConfig
 	traceprovider          oteltrace.TracerProvider
+	keepaliveInterval      time.Duration
+	keepaliveStop          func()
+	concurrentReads        bool
+	verifiedReads          bool
+	verifiedReadsStrict    bool
 }
 
 // Auth details for connecting to a BMC
@@ -71,14 +86,24 @@ type providerConfig struct {
 	ipmi          ipmi.Config
 	ipmitool      ipmitool.Config
 	asrock        asrockrack.Config
+	megarac       megarac.Config
 	gofish        redfish.Config
 	intelamt      intelamt.Config
 	dell          dell.Config
 	lenovo        lenovo.Config
+	hpe           hpe.Config
+	fujitsu       fujitsu.Config
+	cisco         cisco.Config
+	xfusion       xfusion.Config
+	nvidia        nvidia.Config
 	supermicro    supermicro.Config
 	rpc           rpc.Provider
 	openbmc       openbmc.Config
 	homeassistant homeassistant.Config
+	redfishpdu    redfishpdu.Config
+	snmppdu       snmppdu.Config
+	libvirt       libvirt.Config
+	sshclp        sshclp.Config
 }
 
 // NewClient returns a new Client struct
@@ -100,6 +125,9 @@ func NewClient(host, user, pass string, opts ...Option) *Client {
 			asrock: asrockrack.Config{
 				Port: "443",
 			},
+			megarac: megarac.Config{
+				Port: "443",
+			},
 			gofish: redfish.Config{
 				Port:                  "443",
 				VersionsNotCompatible: []string{},
@@ -116,6 +144,26 @@ func NewClient(host, user, pass string, opts ...Option) *Client {
 				Port:                  "443",
 				VersionsNotCompatible: []string{},
 			},
+			hpe: hpe.Config{
+				Port:                  "443",
+				VersionsNotCompatible: []string{},
+			},
+			fujitsu: fujitsu.Config{
+				Port:                  "443",
+				VersionsNotCompatible: []string{},
+			},
+			cisco: cisco.Config{
+				Port:                  "443",
+				VersionsNotCompatible: []string{},
+			},
+			xfusion: xfusion.Config{
+				Port:                  "443",
+				VersionsNotCompatible: []string{},
+			},
+			nvidia: nvidia.Config{
+				Port:                  "443",
+				VersionsNotCompatible: []string{},
+			},
 			supermicro: supermicro.Config{
 				Port: "443",
 			},
@@ -124,6 +172,10 @@ func NewClient(host, user, pass string, opts ...Option) *Client {
 				Port: "443",
 			},
 			homeassistant: homeassistant.Config{},
+			redfishpdu:    redfishpdu.Config{},
+			snmppdu:       snmppdu.Config{},
+			libvirt:       libvirt.Config{},
+			sshclp:        sshclp.Config{},
 		},
 	}
 
@@ -178,6 +230,59 @@ func (c *Client) registerHomeAssistantProvider() error {
 	return nil
 }
 
+func (c *Client) registerRedfishPDUProvider() error {
+	driverPDU := redfishpdu.New(c.Auth.Host, c.Auth.User, c.Auth.Pass)
+	c.providerConfig.redfishpdu.Logger = c.Logger
+	httpClient := *c.httpClient
+	httpClient.Transport = c.httpClient.Transport.(*http.Transport).Clone()
+	c.providerConfig.redfishpdu.HTTPClient = &httpClient
+
+	if err := mergo.Merge(driverPDU, c.providerConfig.redfishpdu, mergo.WithOverride); err != nil {
+		return fmt.Errorf("failed to merge user specified redfishpdu config with the config defaults, redfishpdu provider not available: %w", err)
+	}
+	c.Registry.Register(redfishpdu.ProviderName, redfishpdu.ProviderProtocol, redfishpdu.Features, nil, driverPDU)
+
+	return nil
+}
+
+func (c *Client) registerLibvirtProvider() error {
+	driverLibvirt := libvirt.New(c.providerConfig.libvirt.Address, c.providerConfig.libvirt.Domain)
+	c.providerConfig.libvirt.Logger = c.Logger
+
+	if err := mergo.Merge(driverLibvirt, c.providerConfig.libvirt, mergo.WithOverride); err != nil {
+		return fmt.Errorf("failed to merge user specified libvirt config with the config defaults, libvirt provider not available: %w", err)
+	}
+	c.Registry.Register(libvirt.ProviderName, libvirt.ProviderProtocol, libvirt.Features, nil, driverLibvirt)
+
+	return nil
+}
+
+// register the SNMP PDU provider, the PDU host and outlets are the ones of the provider config.
+func (c *Client) registerSNMPPDUProvider() error {
+	driverPDU := snmppdu.New(c.providerConfig.snmppdu.Host, c.providerConfig.snmppdu.Community, c.providerConfig.snmppdu.Profile)
+	c.providerConfig.snmppdu.Logger = c.Logger
+
+	if err := mergo.Merge(driverPDU, c.providerConfig.snmppdu, mergo.WithOverride); err != nil {
+		return fmt.Errorf("failed to merge user specified snmppdu config with the config defaults, snmppdu provider not available: %w", err)
+	}
+	c.Registry.Register(snmppdu.ProviderName, snmppdu.ProviderProtocol, snmppdu.Features, nil, driverPDU)
+
+	return nil
+}
+
+// register the SSH CLP provider, the command line of the BMC is the profile of the provider config.
+func (c *Client) registerSSHCLPProvider() error {
+	driverSSH := sshclp.New(c.Auth.Host, c.Auth.User, c.Auth.Pass, c.providerConfig.sshclp.Profile)
+	c.providerConfig.sshclp.Logger = c.Logger
+
+	if err := mergo.Merge(driverSSH, c.providerConfig.sshclp, mergo.WithOverride); err != nil {
+		return fmt.Errorf("failed to merge user specified sshclp config with the config defaults, sshclp provider not available: %w", err)
+	}
+	c.Registry.Register(sshclp.ProviderName, sshclp.ProviderProtocol, sshclp.Features, nil, driverSSH)
+
+	return nil
+}
+
 func (c *Client) registerRPCProvider() error {
 	driverRPC := rpc.New(c.providerConfig.rpc.ConsumerURL, c.Auth.Host, c.providerConfig.rpc.Opts.HMAC.Secrets)
 	c.providerConfig.rpc.Logger = c.Logger
@@ -237,6 +342,14 @@ func (c *Client) registerASRRProvider() {
 	c.Registry.Register(asrockrack.ProviderName, asrockrack.ProviderProtocol, asrockrack.Features, nil, driverAsrockrack)
 }
 
+// register MegaRAC SP-X vendorapi provider
+func (c *Client) registerMegaRACProvider() {
+	megaracHTTPClient := *c.httpClient
+	megaracHTTPClient.Transport = c.httpClient.Transport.(*http.Transport).Clone()
+	driverMegaRAC := megarac.New(c.Auth.Host+":"+c.providerConfig.megarac.Port, c.Auth.User, c.Auth.Pass, c.Logger, megarac.WithHTTPClient(&megaracHTTPClient))
+	c.Registry.Register(megarac.ProviderName, megarac.ProviderProtocol, megarac.Features, nil, driverMegaRAC)
+}
+
 // register gofish provider
 func (c *Client) registerGofishProvider() {
 	gfHTTPClient := *c.httpClient
@@ -256,10 +369,14 @@ func (c *Client) registerGofishProvider() {
 
 // register Intel AMT provider
 func (c *Client) registerIntelAMTProvider() {
+	iamtHTTPClient := *c.httpClient
+	iamtHTTPClient.Transport = c.httpClient.Transport.(*http.Transport).Clone()
 	iamtOpts := []intelamt.Option{
 		intelamt.WithLogger(c.Logger),
+		intelamt.WithHTTPClient(&iamtHTTPClient),
 		intelamt.WithHostScheme(c.providerConfig.intelamt.HostScheme),
 		intelamt.WithPort(c.providerConfig.intelamt.Port),
+		intelamt.WithRedirectionPort(c.providerConfig.intelamt.RedirectionPort),
 	}
 	driverAMT := intelamt.New(c.Auth.Host, c.Auth.User, c.Auth.Pass, iamtOpts...)
 	c.Registry.Register(intelamt.ProviderName, intelamt.ProviderProtocol, intelamt.Features, nil, driverAMT)
@@ -294,6 +411,76 @@ func (c *Client) registerLenovoProvider() {
 	c.Registry.Register(lenovo.ProviderName, lenovo.ProviderProtocol, lenovo.Features, nil, driverLenovo)
 }
 
+// register HPE iLO gofish provider
+func (c *Client) registerHPEProvider() {
+	hpeHTTPClient := *c.httpClient
+	hpeHTTPClient.Transport = c.httpClient.Transport.(*http.Transport).Clone()
+	hpeOpts := []hpe.Option{
+		hpe.WithHTTPClient(&hpeHTTPClient),
+		hpe.WithVersionsNotCompatible(c.providerConfig.hpe.VersionsNotCompatible),
+		hpe.WithUseBasicAuth(c.providerConfig.hpe.UseBasicAuth),
+		hpe.WithPort(c.providerConfig.hpe.Port),
+	}
+	driverHPE := hpe.New(c.Auth.Host, c.Auth.User, c.Auth.Pass, c.Logger, hpeOpts...)
+	c.Registry.Register(hpe.ProviderName, hpe.ProviderProtocol, hpe.Features, nil, driverHPE)
+}
+
+// register Fujitsu iRMC gofish provider
+func (c *Client) registerFujitsuProvider() {
+	fujitsuHTTPClient := *c.httpClient
+	fujitsuHTTPClient.Transport = c.httpClient.Transport.(*http.Transport).Clone()
+	fujitsuOpts := []fujitsu.Option{
+		fujitsu.WithHTTPClient(&fujitsuHTTPClient),
+		fujitsu.WithVersionsNotCompatible(c.providerConfig.fujitsu.VersionsNotCompatible),
+		fujitsu.WithUseBasicAuth(c.providerConfig.fujitsu.UseBasicAuth),
+		fujitsu.WithPort(c.providerConfig.fujitsu.Port),
+	}
+	driverFujitsu := fujitsu.New(c.Auth.Host, c.Auth.User, c.Auth.Pass, c.Logger, fujitsuOpts...)
+	c.Registry.Register(fujitsu.ProviderName, fujitsu.ProviderProtocol, fujitsu.Features, nil, driverFujitsu)
+}
+
+// register Cisco CIMC gofish provider
+func (c *Client) registerCiscoProvider() {
+	ciscoHTTPClient := *c.httpClient
+	ciscoHTTPClient.Transport = c.httpClient.Transport.(*http.Transport).Clone()
+	ciscoOpts := []cisco.Option{
+		cisco.WithHTTPClient(&ciscoHTTPClient),
+		cisco.WithVersionsNotCompatible(c.providerConfig.cisco.VersionsNotCompatible),
+		cisco.WithUseBasicAuth(c.providerConfig.cisco.UseBasicAuth),
+		cisco.WithPort(c.providerConfig.cisco.Port),
+	}
+	driverCisco := cisco.New(c.Auth.Host, c.Auth.User, c.Auth.Pass, c.Logger, ciscoOpts...)
+	c.Registry.Register(cisco.ProviderName, cisco.ProviderProtocol, cisco.Features, nil, driverCisco)
+}
+
+// register xFusion iBMC gofish provider
+func (c *Client) registerXFusionProvider() {
+	xfusionHTTPClient := *c.httpClient
+	xfusionHTTPClient.Transport = c.httpClient.Transport.(*http.Transport).Clone()
+	xfusionOpts := []xfusion.Option{
+		xfusion.WithHTTPClient(&xfusionHTTPClient),
+		xfusion.WithVersionsNotCompatible(c.providerConfig.xfusion.VersionsNotCompatible),
+		xfusion.WithUseBasicAuth(c.providerConfig.xfusion.UseBasicAuth),
+		xfusion.WithPort(c.providerConfig.xfusion.Port),
+	}
+	driverXFusion := xfusion.New(c.Auth.Host, c.Auth.User, c.Auth.Pass, c.Logger, xfusionOpts...)
+	c.Registry.Register(xfusion.ProviderName, xfusion.ProviderProtocol, xfusion.Features, nil, driverXFusion)
+}
+
+// register NVIDIA HGX gofish provider
+func (c *Client) registerNVIDIAProvider() {
+	nvidiaHTTPClient := *c.httpClient
+	nvidiaHTTPClient.Transport = c.httpClient.Transport.(*http.Transport).Clone()
+	nvidiaOpts := []nvidia.Option{
+		nvidia.WithHTTPClient(&nvidiaHTTPClient),
+		nvidia.WithVersionsNotCompatible(c.providerConfig.nvidia.VersionsNotCompatible),
+		nvidia.WithUseBasicAuth(c.providerConfig.nvidia.UseBasicAuth),
+		nvidia.WithPort(c.providerConfig.nvidia.Port),
+	}
+	driverNVIDIA := nvidia.New(c.Auth.Host, c.Auth.User, c.Auth.Pass, c.Logger, nvidiaOpts...)
+	c.Registry.Register(nvidia.ProviderName, nvidia.ProviderProtocol, nvidia.Features, nil, driverNVIDIA)
+}
+
 // register supermicro vendorapi provider
 func (c *Client) registerSupermicroProvider() {
 	smcHTTPClient := *c.httpClient
@@ -337,6 +524,28 @@ func (c *Client) registerProviders() {
 		c.Logger.Info("failed to register homeassistant provider, falling back to registering all other providers", "error", err.Error())
 	}
 
+	// register the redfishpdu provider, if the PDU outlets of the machine were provided
+	if len(c.providerConfig.redfishpdu.Outlets) > 0 {
+		// when the redfishpdu provider is to be used, we won't register any other providers.
+		err := c.registerRedfishPDUProvider()
+		if err == nil {
+			c.Logger.Info("note: with the redfishpdu provider registered, no other providers will be registered and available")
+			return
+		}
+		c.Logger.Info("failed to register redfishpdu provider, falling back to registering all other providers", "error", err.Error())
+	}
+
+	// register the libvirt provider, if the domain of the machine was provided
+	if c.providerConfig.libvirt.Domain != "" {
+		// when the libvirt provider is to be used, we won't register any other providers.
+		err := c.registerLibvirtProvider()
+		if err == nil {
+			c.Logger.Info("note: with the libvirt provider registered, no other providers will be registered and available")
+			return
+		}
+		c.Logger.Info("failed to register libvirt provider, falling back to registering all other providers", "error", err.Error())
+	}
+
 	// register the rpc provider
 	// without the consumer URL there is no way to send RPC requests.
 	if c.providerConfig.rpc.ConsumerURL != "" {
@@ -358,12 +567,32 @@ func (c *Client) registerProviders() {
 	}
 
 	c.registerASRRProvider()
+	c.registerMegaRACProvider()
 	c.registerGofishProvider()
 	c.registerIntelAMTProvider()
 	c.registerDellProvider()
 	c.registerLenovoProvider()
+	c.registerHPEProvider()
+	c.registerFujitsuProvider()
+	c.registerCiscoProvider()
+	c.registerXFusionProvider()
+	c.registerNVIDIAProvider()
 	c.registerSupermicroProvider()
 	c.registerOpenBMCProvider()
+
+	// register the sshclp provider only with a profile, the command line of a BMC can't be detected.
+	if c.providerConfig.sshclp.Profile.Name != "" {
+		if err := c.registerSSHCLPProvider(); err != nil {
+			c.Logger.Info("failed to register sshclp provider", "error", err.Error())
+		}
+	}
+
+	// register the snmppdu provider last, as a power fallback for a machine with an unresponsive BMC.
+	if c.providerConfig.snmppdu.Host != "" && len(c.providerConfig.snmppdu.Outlets) > 0 {
+		if err := c.registerSNMPPDUProvider(); err != nil {
+			c.Logger.Info("failed to register snmppdu provider", "error", err.Error())
+		}
+	}
 }
 
 // GetMetadata returns the metadata that is populated after each BMC function/method call
@@ -447,14 +676,87 @@ func (c *Client) Open(ctx context.Context) error {
 	}
 	c.Registry.Drivers = reg
 
+	// the keepalive refreshes the sessions this Open established, a previous one is replaced
+	c.stopKeepalive()
+	c.startKeepalive(ifs)
+
 	return nil
 }
 
+// providerContext returns the context for an operation without a timeout argument, it carries the per
+// provider timeout of the Client.
+func (c *Client) providerContext(ctx context.Context) context.Context {
+	return bmc.WithProviderTimeout(ctx, c.perProviderTimeout(ctx))
+}
+
+// readContext returns the context for a read operation, it carries the per provider timeout and the
+// concurrent and verified read settings of the Client.
+func (c *Client) readContext(ctx context.Context) context.Context {
+	ctx = c.providerContext(ctx)
+
+	if c.concurrentReads {
+		ctx = bmc.WithConcurrentReads(ctx)
+	}
+
+	if c.verifiedReads {
+		ctx = bmc.WithVerifiedReads(ctx, c.verifiedReadsStrict)
+	}
+
+	return ctx
+}
+
+// startKeepalive starts the goroutine refreshing the sessions of the opened drivers when a keepalive
+// interval is set.
+//
+// The keepalive runs alongside the Client methods and does not record metadata, failures are logged.
+func (c *Client) startKeepalive(drivers []interface{}) {
+	if c.keepaliveInterval <= 0 || c.keepaliveStop != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	c.keepaliveStop = func() {
+		cancel()
+		<-done
+	}
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(c.keepaliveInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := bmc.KeepSessionAliveFromInterfaces(ctx, c.perProviderTimeout(ctx), drivers); err != nil {
+					c.Logger.V(1).Info("session keepalive failed", "host", c.Auth.Host, "error", err.Error())
+				}
+			}
+		}
+	}()
+}
+
+// stopKeepalive stops the session keepalive goroutine and waits for it to return.
+func (c *Client) stopKeepalive() {
+	if c.keepaliveStop == nil {
+		return
+	}
+
+	c.keepaliveStop()
+	c.keepaliveStop = nil
+}
+
 // Close pass through to library function
 func (c *Client) Close(ctx context.Context) (err error) {
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "Close")
 	defer span.End()
 
+	c.stopKeepalive()
+
 	// Generally, we always want the close function to run.
 	// We don't want a context timeout or cancellation to prevent this.
 	// But because the current model is to pass just a single context to all
@@ -465,7 +767,7 @@ func (c *Client) Close(ctx context.Context) (err error) {
 		ctx, done = context.WithTimeout(context.Background(), defaultConnectTimeout)
 		defer done()
 	}
-	metadata, err := bmc.CloseConnectionFromInterfaces(ctx, c.registry().GetDriverInterfaces())
+	metadata, err := bmc.CloseConnectionFromInterfaces(c.providerContext(ctx), c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -487,7 +789,7 @@ func (c *Client) GetPowerState(ctx context.Context) (state string, err error) {
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "GetPowerState")
 	defer span.End()
 
-	state, metadata, err := bmc.GetPowerStateFromInterfaces(ctx, c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
+	state, metadata, err := bmc.GetPowerStateFromInterfaces(c.readContext(ctx), c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -547,7 +849,7 @@ func (c *Client) ReadUsers(ctx context.Context) (users []map[string]string, err
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "ReadUsers")
 	defer span.End()
 
-	users, metadata, err := bmc.ReadUsersFromInterfaces(ctx, c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
+	users, metadata, err := bmc.ReadUsersFromInterfaces(c.readContext(ctx), c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -559,7 +861,7 @@ func (c *Client) GetBootDeviceOverride(ctx context.Context) (override bmc.BootDe
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "GetBootDeviceOverride")
 	defer span.End()
 
-	override, metadata, err := bmc.GetBootDeviceOverrideFromInterface(ctx, c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
+	override, metadata, err := bmc.GetBootDeviceOverrideFromInterface(c.readContext(ctx), c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 
 	return override, err
@@ -585,7 +887,7 @@ func (c *Client) SetVirtualMedia(ctx context.Context, kind, mediaURL string) (ok
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "SetVirtualMedia")
 	defer span.End()
 
-	ok, metadata, err := bmc.SetVirtualMediaFromInterfaces(ctx, kind, mediaURL, c.registry().GetDriverInterfaces())
+	ok, metadata, err := bmc.SetVirtualMediaFromInterfaces(c.providerContext(ctx), kind, mediaURL, c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -618,7 +920,7 @@ func (c *Client) Inventory(ctx context.Context) (device *common.Device, err erro
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "Inventory")
 	defer span.End()
 
-	device, metadata, err := bmc.GetInventoryFromInterfaces(ctx, c.registry().GetDriverInterfaces())
+	device, metadata, err := bmc.GetInventoryFromInterfaces(c.readContext(ctx), c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	return device, err
 }
@@ -628,7 +930,7 @@ func (c *Client) GetBiosConfiguration(ctx context.Context) (biosConfig map[strin
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "GetBiosConfiguration")
 	defer span.End()
 
-	biosConfig, metadata, err := bmc.GetBiosConfigurationInterfaces(ctx, c.registry().GetDriverInterfaces())
+	biosConfig, metadata, err := bmc.GetBiosConfigurationInterfaces(c.readContext(ctx), c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -640,7 +942,7 @@ func (c *Client) SetBiosConfiguration(ctx context.Context, biosConfig map[string
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "SetBiosConfiguration")
 	defer span.End()
 
-	metadata, err := bmc.SetBiosConfigurationInterfaces(ctx, c.registry().GetDriverInterfaces(), biosConfig)
+	metadata, err := bmc.SetBiosConfigurationInterfaces(c.providerContext(ctx), c.registry().GetDriverInterfaces(), biosConfig)
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -652,7 +954,7 @@ func (c *Client) SetBiosConfigurationFromFile(ctx context.Context, cfg string) (
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "SetBiosConfigurationFromFile")
 	defer span.End()
 
-	metadata, err := bmc.SetBiosConfigurationFromFileInterfaces(ctx, c.registry().GetDriverInterfaces(), cfg)
+	metadata, err := bmc.SetBiosConfigurationFromFileInterfaces(c.providerContext(ctx), c.registry().GetDriverInterfaces(), cfg)
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -664,7 +966,7 @@ func (c *Client) ResetBiosConfiguration(ctx context.Context) (err error) {
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "ResetBiosConfiguration")
 	defer span.End()
 
-	metadata, err := bmc.ResetBiosConfigurationInterfaces(ctx, c.registry().GetDriverInterfaces())
+	metadata, err := bmc.ResetBiosConfigurationInterfaces(c.providerContext(ctx), c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -676,7 +978,7 @@ func (c *Client) GetSecureBoot(ctx context.Context) (enabled bool, err error) {
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "GetSecureBoot")
 	defer span.End()
 
-	enabled, metadata, err := bmc.GetSecureBootStateFromInterfaces(ctx, c.registry().GetDriverInterfaces())
+	enabled, metadata, err := bmc.GetSecureBootStateFromInterfaces(c.readContext(ctx), c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -688,7 +990,7 @@ func (c *Client) SetSecureBoot(ctx context.Context, enable bool) (err error) {
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "SetSecureBoot")
 	defer span.End()
 
-	metadata, err := bmc.SetSecureBootFromInterfaces(ctx, c.registry().GetDriverInterfaces(), enable)
+	metadata, err := bmc.SetSecureBootFromInterfaces(c.providerContext(ctx), c.registry().GetDriverInterfaces(), enable)
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -701,7 +1003,7 @@ func (c *Client) ResetSecureBootKeys(ctx context.Context, resetType string) (err
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "ResetSecureBootKeys")
 	defer span.End()
 
-	metadata, err := bmc.ResetSecureBootKeysFromInterfaces(ctx, c.registry().GetDriverInterfaces(), resetType)
+	metadata, err := bmc.ResetSecureBootKeysFromInterfaces(c.providerContext(ctx), c.registry().GetDriverInterfaces(), resetType)
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -727,7 +1029,7 @@ func (c *Client) FirmwareInstallStatus(ctx context.Context, installVersion, comp
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "FirmwareInstallStatus")
 	defer span.End()
 
-	status, metadata, err := bmc.FirmwareInstallStatusFromInterfaces(ctx, installVersion, component, taskID, c.registry().GetDriverInterfaces())
+	status, metadata, err := bmc.FirmwareInstallStatusFromInterfaces(c.providerContext(ctx), installVersion, component, taskID, c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -739,7 +1041,7 @@ func (c *Client) PostCode(ctx context.Context) (status string, code int, err err
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "PostCode")
 	defer span.End()
 
-	status, code, metadata, err := bmc.GetPostCodeInterfaces(ctx, c.registry().GetDriverInterfaces())
+	status, code, metadata, err := bmc.GetPostCodeInterfaces(c.readContext(ctx), c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -751,7 +1053,7 @@ func (c *Client) Screenshot(ctx context.Context) (image []byte, fileType string,
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "Screenshot")
 	defer span.End()
 
-	image, fileType, metadata, err := bmc.ScreenshotFromInterfaces(ctx, c.registry().GetDriverInterfaces())
+	image, fileType, metadata, err := bmc.ScreenshotFromInterfaces(c.readContext(ctx), c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -787,7 +1089,7 @@ func (c *Client) UnmountFloppyImage(ctx context.Context) (err error) {
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "UnmountFloppyImage")
 	defer span.End()
 
-	metadata, err := bmc.UnmountFloppyImageFromInterfaces(ctx, c.registry().GetDriverInterfaces())
+	metadata, err := bmc.UnmountFloppyImageFromInterfaces(c.providerContext(ctx), c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -799,7 +1101,7 @@ func (c *Client) FirmwareInstallSteps(ctx context.Context, component string) (ac
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "FirmwareInstallSteps")
 	defer span.End()
 
-	status, metadata, err := bmc.FirmwareInstallStepsFromInterfaces(ctx, component, c.registry().GetDriverInterfaces())
+	status, metadata, err := bmc.FirmwareInstallStepsFromInterfaces(c.providerContext(ctx), component, c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -823,7 +1125,7 @@ func (c *Client) FirmwareTaskStatus(ctx context.Context, kind constants.Firmware
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "FirmwareTaskStatus")
 	defer span.End()
 
-	state, status, metadata, err := bmc.FirmwareTaskStatusFromInterfaces(ctx, kind, component, taskID, installVersion, c.registry().GetDriverInterfaces())
+	state, status, metadata, err := bmc.FirmwareTaskStatusFromInterfaces(c.providerContext(ctx), kind, component, taskID, installVersion, c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -835,7 +1137,7 @@ func (c *Client) FirmwareInstallUploaded(ctx context.Context, component, uploadV
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "FirmwareInstallUploaded")
 	defer span.End()
 
-	installTaskID, metadata, err := bmc.FirmwareInstallerUploadedFromInterfaces(ctx, component, uploadVerifyTaskID, c.registry().GetDriverInterfaces())
+	installTaskID, metadata, err := bmc.FirmwareInstallerUploadedFromInterfaces(c.providerContext(ctx), component, uploadVerifyTaskID, c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	metadata.RegisterSpanAttributes(c.Auth.Host, span)
 
@@ -859,7 +1161,7 @@ func (c *Client) GetSystemEventLog(ctx context.Context) (entries bmc.SystemEvent
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "GetSystemEventLog")
 	defer span.End()
 
-	entries, metadata, err := bmc.GetSystemEventLogFromInterfaces(ctx, c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
+	entries, metadata, err := bmc.GetSystemEventLogFromInterfaces(c.readContext(ctx), c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	return entries, err
 }
@@ -869,11 +1171,248 @@ func (c *Client) GetSystemEventLogRaw(ctx context.Context) (eventlog string, err
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "GetSystemEventLogRaw")
 	defer span.End()
 
-	eventlog, metadata, err := bmc.GetSystemEventLogRawFromInterfaces(ctx, c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
+	eventlog, metadata, err := bmc.GetSystemEventLogRawFromInterfaces(c.readContext(ctx), c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
 	c.setMetadata(metadata)
 	return eventlog, err
 }
 
+// GetSystemEventLogEntries queries for the SEL and returns typed entries in a provider independent format.
+func (c *Client) GetSystemEventLogEntries(ctx context.Context) (entries []bmc.SystemEventLogEntry, err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "GetSystemEventLogEntries")
+	defer span.End()
+
+	entries, metadata, err := bmc.GetSystemEventLogEntriesFromInterfaces(c.readContext(ctx), c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return entries, err
+}
+
+// Sensors returns the host sensor readings, e.g. temperatures, fans, voltages and power supplies.
+func (c *Client) Sensors(ctx context.Context) (sensors []bmc.SensorReading, err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "Sensors")
+	defer span.End()
+
+	sensors, metadata, err := bmc.GetSensorsFromInterfaces(c.readContext(ctx), c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return sensors, err
+}
+
+// PowerConsumption returns the host power consumption and the power limit configured on the BMC.
+func (c *Client) PowerConsumption(ctx context.Context) (consumption *bmc.PowerConsumption, err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "PowerConsumption")
+	defer span.End()
+
+	consumption, metadata, err := bmc.PowerConsumptionFromInterfaces(c.readContext(ctx), c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return consumption, err
+}
+
+// SetPowerLimit sets the host power limit, a limit that is not enabled removes the power limit.
+func (c *Client) SetPowerLimit(ctx context.Context, limit bmc.PowerLimit) (err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "SetPowerLimit")
+	defer span.End()
+
+	metadata, err := bmc.SetPowerLimitFromInterfaces(ctx, c.perProviderTimeout(ctx), limit, c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return err
+}
+
+// SetIdentifyLED turns the chassis identify LED on or off, a zero duration keeps the LED on until it is turned off.
+// Redfish providers have no identify interval, they ignore the duration and the LED stays on until it is turned off.
+func (c *Client) SetIdentifyLED(ctx context.Context, on bool, duration time.Duration) (err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "SetIdentifyLED")
+	defer span.End()
+
+	metadata, err := bmc.SetIdentifyLEDFromInterfaces(ctx, c.perProviderTimeout(ctx), on, duration, c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return err
+}
+
+// GetIdentifyLED returns true when the chassis identify LED is on.
+func (c *Client) GetIdentifyLED(ctx context.Context) (on bool, err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "GetIdentifyLED")
+	defer span.End()
+
+	on, metadata, err := bmc.GetIdentifyLEDFromInterfaces(c.readContext(ctx), c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return on, err
+}
+
+// GetBootOrder returns the persistent boot order of the host.
+func (c *Client) GetBootOrder(ctx context.Context) (order []bmc.BootOption, err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "GetBootOrder")
+	defer span.End()
+
+	order, metadata, err := bmc.GetBootOrderFromInterfaces(c.readContext(ctx), c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return order, err
+}
+
+// SetBootOrder moves the boot options identified by the given references to the front of the persistent boot order,
+// boot options not listed keep their relative order after them.
+func (c *Client) SetBootOrder(ctx context.Context, order []string) (err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "SetBootOrder")
+	defer span.End()
+
+	metadata, err := bmc.SetBootOrderFromInterfaces(ctx, c.perProviderTimeout(ctx), order, c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return err
+}
+
+// GetBMCNetwork returns the BMC management network configuration.
+func (c *Client) GetBMCNetwork(ctx context.Context) (config *bmc.BMCNetworkConfig, err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "GetBMCNetwork")
+	defer span.End()
+
+	config, metadata, err := bmc.GetBMCNetworkFromInterfaces(c.readContext(ctx), c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return config, err
+}
+
+// SetBMCNetwork applies the configuration to the BMC management interface, the BMC may become
+// unreachable at its current address once the configuration is applied.
+func (c *Client) SetBMCNetwork(ctx context.Context, config bmc.BMCNetworkConfig) (err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "SetBMCNetwork")
+	defer span.End()
+
+	metadata, err := bmc.SetBMCNetworkFromInterfaces(ctx, c.perProviderTimeout(ctx), config, c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return err
+}
+
+// GenerateCSR has the BMC generate a key pair and returns the PEM encoded certificate signing request,
+// the signed certificate is installed with ReplaceCertificate.
+func (c *Client) GenerateCSR(ctx context.Context, request bmc.CSRRequest) (csr string, err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "GenerateCSR")
+	defer span.End()
+
+	csr, metadata, err := bmc.GenerateCSRFromInterfaces(ctx, c.perProviderTimeout(ctx), request, c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return csr, err
+}
+
+// ReplaceCertificate installs the PEM encoded certificate in place of the BMC certificate identified by
+// certificateID, or in place of the BMC HTTPS certificate when certificateID is empty.
+func (c *Client) ReplaceCertificate(ctx context.Context, certificateID, certificate string) (err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "ReplaceCertificate")
+	defer span.End()
+
+	metadata, err := bmc.ReplaceCertificateFromInterfaces(ctx, c.perProviderTimeout(ctx), certificateID, certificate, c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return err
+}
+
+// ListCertificates returns the certificates installed on the BMC.
+func (c *Client) ListCertificates(ctx context.Context) (certificates []bmc.Certificate, err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "ListCertificates")
+	defer span.End()
+
+	certificates, metadata, err := bmc.ListCertificatesFromInterfaces(c.readContext(ctx), c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return certificates, err
+}
+
+// GetBMCTime returns the BMC clock and its NTP configuration, BMCTime.Skew returns the offset of the
+// BMC clock to the local clock.
+func (c *Client) GetBMCTime(ctx context.Context) (bmcTime *bmc.BMCTime, err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "GetBMCTime")
+	defer span.End()
+
+	bmcTime, metadata, err := bmc.GetBMCTimeFromInterfaces(c.readContext(ctx), c.perProviderTimeout(ctx), c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return bmcTime, err
+}
+
+// SetBMCTime sets the BMC clock.
+func (c *Client) SetBMCTime(ctx context.Context, t time.Time) (err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "SetBMCTime")
+	defer span.End()
+
+	metadata, err := bmc.SetBMCTimeFromInterfaces(ctx, c.perProviderTimeout(ctx), t, c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return err
+}
+
+// SetNTPServers sets the NTP servers the BMC synchronizes its clock with, NTP is disabled when no servers are given.
+func (c *Client) SetNTPServers(ctx context.Context, servers []string) (err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "SetNTPServers")
+	defer span.End()
+
+	metadata, err := bmc.SetNTPServersFromInterfaces(ctx, c.perProviderTimeout(ctx), servers, c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return err
+}
+
+// GetDirectoryService returns the BMC LDAP or Active Directory configuration, the bind password is not reported.
+func (c *Client) GetDirectoryService(ctx context.Context, serviceType bmc.DirectoryServiceType) (config *bmc.DirectoryServiceConfig, err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "GetDirectoryService")
+	defer span.End()
+
+	config, metadata, err := bmc.GetDirectoryServiceFromInterfaces(c.readContext(ctx), c.perProviderTimeout(ctx), serviceType, c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return config, err
+}
+
+// SetDirectoryService sets the BMC LDAP or Active Directory configuration, empty fields of the configuration are left unchanged.
+func (c *Client) SetDirectoryService(ctx context.Context, config bmc.DirectoryServiceConfig) (err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "SetDirectoryService")
+	defer span.End()
+
+	metadata, err := bmc.SetDirectoryServiceFromInterfaces(ctx, c.perProviderTimeout(ctx), config, c.registry().GetDriverInterfaces())
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return err
+}
+
+// Capabilities returns the support matrix of the features on this BMC, it is to be called after Open.
+//
+// The features advertised by the opened providers are probed on the BMC where the provider supports it,
+// like a redfish BMC without the license for virtual media. The probe errors are returned along with the matrix.
+func (c *Client) Capabilities(ctx context.Context) (capabilities bmc.Capabilities, err error) {
+	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "Capabilities")
+	defer span.End()
+
+	capabilities, metadata, err := bmc.ProbeCapabilitiesFromDrivers(ctx, c.perProviderTimeout(ctx), c.registry().Drivers)
+	c.setMetadata(metadata)
+	metadata.RegisterSpanAttributes(c.Auth.Host, span)
+
+	return capabilities, err
+}
+
 // SendNMI tells the BMC to issue an NMI to the device
 func (c *Client) SendNMI(ctx context.Context) error {
 	ctx, span := c.traceprovider.Tracer(pkgName).Start(ctx, "SendNMI")
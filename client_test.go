@@ -2,6 +2,8 @@ package bmclib
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -236,3 +238,71 @@ func TestOpenFiltered(t *testing.T) {
 		t.Errorf("diff: %s", diff)
 	}
 }
+
+type testSessionProvider struct {
+	testProvider
+	keepalives atomic.Int32
+}
+
+func (t *testSessionProvider) KeepSessionAlive(_ context.Context) error {
+	t.keepalives.Add(1)
+	return nil
+}
+
+func TestWithSessionKeepalive(t *testing.T) {
+	provider := &testSessionProvider{}
+	registry := registrar.NewRegistry()
+	registry.Register("tester", "tester", nil, nil, provider)
+
+	cl := NewClient("", "", "", WithRegistry(registry), WithSessionKeepalive(10*time.Millisecond))
+	if err := cl.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for provider.keepalives.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("session keepalive did not run")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := cl.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// the keepalive is stopped by Close
+	calls := provider.keepalives.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, calls, provider.keepalives.Load())
+}
+
+func TestWithSessionKeepaliveOpenedDrivers(t *testing.T) {
+	opened := &testSessionProvider{testProvider: testProvider{PName: "opened"}}
+	filtered := &testSessionProvider{testProvider: testProvider{PName: "filtered"}}
+	failed := &testSessionProvider{testProvider: testProvider{PName: "failed", Err: errors.New("open failed")}}
+
+	registry := registrar.NewRegistry()
+	registry.Register("opened", "tester", nil, nil, opened)
+	registry.Register("filtered", "other", nil, nil, filtered)
+	registry.Register("failed", "tester", nil, nil, failed)
+
+	cl := NewClient("", "", "", WithRegistry(registry), WithSessionKeepalive(10*time.Millisecond))
+	if err := cl.Using("tester").Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for opened.keepalives.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("session keepalive did not run")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cl.stopKeepalive()
+
+	// only the sessions established by Open are refreshed
+	assert.Equal(t, int32(0), filtered.keepalives.Load())
+	assert.Equal(t, int32(0), failed.keepalives.Load())
+}
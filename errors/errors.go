@@ -127,6 +127,9 @@ var (
 
 	// ErrBMCUpdating is returned when the BMC is going through an update and will not serve other queries.
 	ErrBMCUpdating = errors.New("a BMC firmware update is in progress")
+
+	// ErrInconsistentResults is returned when the providers queried for a value do not agree on it.
+	ErrInconsistentResults = errors.New("providers returned inconsistent results")
 )
 
 // ErrUnsupportedHardware is returned when an operation is attempted on unsupported hardware.
@@ -21,7 +21,7 @@ func main() {
 	host := flag.String("host", "", "BMC hostname to connect to")
 	withSecureTLS := flag.Bool("secure-tls", false, "Enable secure TLS")
 	certPoolFile := flag.String("cert-pool", "", "Path to an file containing x509 CAs. An empty string uses the system CAs. Only takes effect when --secure-tls=true")
-	action := flag.String("action", "get", "Action to perform on the System Event Log (clear|get)")
+	action := flag.String("action", "get", "Action to perform on the System Event Log (clear|get|get-raw|get-entries)")
 	flag.Parse()
 
 	l := logrus.New()
@@ -78,6 +78,13 @@ func main() {
 		}
 		l.Info("System Event Log", "eventlog", eventlog)
 		return
+	case "get-entries":
+		entries, err := cl.GetSystemEventLogEntries(ctx)
+		if err != nil {
+			l.WithError(err).Fatal(err, "failed to get System Event Log entries")
+		}
+		l.Info("System Event Log entries", "entries", entries)
+		return
 	case "clear":
 		err = cl.ClearSystemEventLog(ctx)
 		if err != nil {
@@ -4,13 +4,28 @@ package goipmi
 import (
 	"context"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
 	"github.com/bougou/go-ipmi"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+const (
+	// dcmiDefaultCorrectionTime is the DCMI power limit correction time used when neither the request nor the BMC provide one.
+	dcmiDefaultCorrectionTime = time.Second
+	// dcmiDefaultSamplingPeriod is the DCMI power limit statistics sampling period in seconds used when the BMC does not provide one.
+	dcmiDefaultSamplingPeriod = 1
+	// sessionIdleCheck is how long the session may sit idle before it is checked ahead of a command,
+	// BMCs close IPMI sessions after an inactivity timeout of typically 60 seconds.
+	sessionIdleCheck = 30 * time.Second
 )
 
 // Ipmi holds the data for an ipmi connection
@@ -22,6 +37,11 @@ type Ipmi struct {
 	client      *ipmi.Client
 	cipherSuite int
 	log         logr.Logger
+	// mu guards client and lastUsed, client is replaced when an expired session is re-established.
+	mu       sync.Mutex
+	lastUsed time.Time
+	// checkSession replaces the session check with the BMC in tests.
+	checkSession func(ctx context.Context) (renewed bool, err error)
 }
 
 // Option for setting optional Ipmi values
@@ -46,10 +66,6 @@ func WithLogger(log logr.Logger) Option {
 
 // New returns a new ipmi instance
 func New(username, password, host string, port int, opts ...Option) (c *Ipmi, err error) {
-	cl, err := ipmi.NewClient(host, port, username, password)
-	if err != nil {
-		return nil, err
-	}
 	c = &Ipmi{
 		Username:    username,
 		Password:    password,
@@ -57,13 +73,15 @@ func New(username, password, host string, port int, opts ...Option) (c *Ipmi, er
 		Port:        port,
 		log:         logr.Discard(),
 		cipherSuite: 3,
-		client:      cl,
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
-	c.client.WithInterface(ipmi.InterfaceLanplus)
-	c.client.WithCipherSuiteID(toCipherSuiteID(c.cipherSuite))
+
+	c.client, err = c.newClient()
+	if err != nil {
+		return nil, err
+	}
 
 	return c, nil
 }
@@ -72,10 +90,6 @@ func New(username, password, host string, port int, opts ...Option) (c *Ipmi, er
 // to run isolated operations (such as compatibility checks) without touching the
 // session state of the original connection.
 func (i *Ipmi) Clone() (*Ipmi, error) {
-	cl, err := ipmi.NewClient(i.Host, i.Port, i.Username, i.Password)
-	if err != nil {
-		return nil, err
-	}
 	c := &Ipmi{
 		Username:    i.Username,
 		Password:    i.Password,
@@ -83,14 +97,31 @@ func (i *Ipmi) Clone() (*Ipmi, error) {
 		Port:        i.Port,
 		log:         i.log,
 		cipherSuite: i.cipherSuite,
-		client:      cl,
 	}
-	c.client.WithInterface(ipmi.InterfaceLanplus)
-	c.client.WithCipherSuiteID(toCipherSuiteID(c.cipherSuite))
+
+	cl, err := c.newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	c.client = cl
 
 	return c, nil
 }
 
+// newClient returns an unconnected LAN+ client for the BMC.
+func (i *Ipmi) newClient() (*ipmi.Client, error) {
+	cl, err := ipmi.NewClient(i.Host, i.Port, i.Username, i.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	cl.WithInterface(ipmi.InterfaceLanplus)
+	cl.WithCipherSuiteID(toCipherSuiteID(i.cipherSuite))
+
+	return cl, nil
+}
+
 // parseSystemEventLogRaw parses the raw output of the system event log. Helper
 // function for GetSystemEventLog to make testing the parser easier.
 func parseSystemEventLog(raw string) (entries [][]string) {
@@ -122,17 +153,145 @@ func toCipherSuiteID(c int) ipmi.CipherSuiteID {
 
 // Open establishes an IPMI LAN+ session to the BMC.
 func (i *Ipmi) Open(ctx context.Context) error {
-	return i.client.Connect(ctx)
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if err := i.client.Connect(ctx); err != nil {
+		return err
+	}
+
+	i.lastUsed = time.Now()
+
+	return nil
 }
 
 // Close tears down the active IPMI session.
 func (i *Ipmi) Close(ctx context.Context) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.lastUsed = time.Time{}
+
 	return i.client.Close(ctx)
 }
 
+// KeepSessionAlive refreshes the IPMI session, a session the BMC no longer knows is re-established.
+func (i *Ipmi) KeepSessionAlive(ctx context.Context) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if _, err := i.refreshSession(ctx); err != nil {
+		return errors.Wrap(err, "error re-establishing the IPMI session")
+	}
+
+	i.lastUsed = time.Now()
+
+	return nil
+}
+
+// session returns the client to send commands with. A session that sat idle long enough to have
+// been closed by the BMC is checked first and re-established when it has expired.
+func (i *Ipmi) session(ctx context.Context) *ipmi.Client {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if !i.lastUsed.IsZero() && time.Since(i.lastUsed) > sessionIdleCheck {
+		if _, err := i.refreshSession(ctx); err != nil {
+			// the command is sent with the current session and reports the failure
+			i.log.V(1).Info("IPMI session renewal failed", "error", err.Error())
+		}
+	}
+
+	i.lastUsed = time.Now()
+
+	return i.client
+}
+
+// refreshSession checks the session with the BMC and replaces the client with a newly
+// connected one when the session is no longer valid, renewed reports the replacement.
+// The caller must hold i.mu.
+func (i *Ipmi) refreshSession(ctx context.Context) (renewed bool, err error) {
+	if i.checkSession != nil {
+		return i.checkSession(ctx)
+	}
+
+	if _, err := i.client.GetCurrentSessionInfo(ctx); err == nil {
+		return false, nil
+	}
+
+	cl, err := i.newClient()
+	if err != nil {
+		return false, err
+	}
+
+	if err := cl.Connect(ctx); err != nil {
+		return false, err
+	}
+
+	// the expired session is gone on the BMC, this releases the local connection
+	_ = i.client.Close(ctx)
+	i.client = cl
+
+	return true, nil
+}
+
+// renewSession re-establishes an open session the BMC no longer knows and reports
+// whether the session was renewed.
+func (i *Ipmi) renewSession(ctx context.Context) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	// a session that was never opened, or was closed, is not re-established
+	if i.lastUsed.IsZero() {
+		return false
+	}
+
+	renewed, err := i.refreshSession(ctx)
+	if err != nil {
+		i.log.V(1).Info("IPMI session renewal failed", "error", err.Error())
+		return false
+	}
+
+	if renewed {
+		i.lastUsed = time.Now()
+	}
+
+	return renewed
+}
+
+// sendCommand sends the command on the session. The BMC does not run commands of a
+// session it closed, a failed command is sent once more when the session turns out
+// to have expired and was re-established.
+func sendCommand[T any](ctx context.Context, i *Ipmi, command func(*ipmi.Client) (T, error)) (T, error) {
+	response, err := command(i.session(ctx))
+	if err == nil || ctx.Err() != nil || !i.renewSession(ctx) {
+		return response, err
+	}
+
+	return command(i.session(ctx))
+}
+
+// execCommand sends a command without a response, see sendCommand.
+func (i *Ipmi) execCommand(ctx context.Context, command func(*ipmi.Client) error) error {
+	_, err := sendCommand(ctx, i, func(c *ipmi.Client) (struct{}, error) {
+		return struct{}{}, command(c)
+	})
+
+	return err
+}
+
+// chassisControl sends a Chassis Control command, see sendCommand.
+func (i *Ipmi) chassisControl(ctx context.Context, control ipmi.ChassisControl) error {
+	_, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.ChassisControlResponse, error) {
+		return c.ChassisControl(ctx, control)
+	})
+
+	return err
+}
+
 // PowerCycle reboots the machine via bmc
 func (i *Ipmi) PowerCycle(ctx context.Context) (status bool, err error) {
-	_, err = i.client.ChassisControl(ctx, ipmi.ChassisControlPowerCycle)
+	err = i.chassisControl(ctx, ipmi.ChassisControlPowerCycle)
 	if err != nil {
 		return false, fmt.Errorf("chassis control failed: %v", err)
 	}
@@ -145,17 +304,19 @@ func (i *Ipmi) PowerCycle(ctx context.Context) (status bool, err error) {
 //	Perform an immediate (non-graceful) shutdown, followed by a restart.
 func (i *Ipmi) ForceRestart(ctx context.Context) (status bool, err error) {
 	// Get current power state
-	chassisStatus, err := i.client.GetChassisStatus(ctx)
+	chassisStatus, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.GetChassisStatusResponse, error) {
+		return c.GetChassisStatus(ctx)
+	})
 	if err != nil {
 		return false, fmt.Errorf("failed to get chassis status: %v", err)
 	}
 
 	if chassisStatus.PowerIsOn {
 		// System is on, do a power cycle
-		_, err = i.client.ChassisControl(ctx, ipmi.ChassisControlPowerCycle)
+		err = i.chassisControl(ctx, ipmi.ChassisControlPowerCycle)
 	} else {
 		// System is off, just power on
-		_, err = i.client.ChassisControl(ctx, ipmi.ChassisControlPowerUp)
+		err = i.chassisControl(ctx, ipmi.ChassisControlPowerUp)
 	}
 
 	if err != nil {
@@ -166,7 +327,7 @@ func (i *Ipmi) ForceRestart(ctx context.Context) (status bool, err error) {
 
 // PowerReset reboots the machine via bmc
 func (i *Ipmi) PowerReset(ctx context.Context) (status bool, err error) {
-	_, err = i.client.ChassisControl(ctx, ipmi.ChassisControlHardReset)
+	err = i.chassisControl(ctx, ipmi.ChassisControlHardReset)
 	if err != nil {
 		return false, fmt.Errorf("chassis control failed: %v", err)
 	}
@@ -175,7 +336,9 @@ func (i *Ipmi) PowerReset(ctx context.Context) (status bool, err error) {
 
 // PowerCycleBmc reboots the bmc we are connected to
 func (i *Ipmi) PowerCycleBmc(ctx context.Context) (status bool, err error) {
-	err = i.client.ColdReset(ctx)
+	err = i.execCommand(ctx, func(c *ipmi.Client) error {
+		return c.ColdReset(ctx)
+	})
 	if err != nil {
 		return false, fmt.Errorf("MC cold reset failed: %v", err)
 	}
@@ -186,9 +349,13 @@ func (i *Ipmi) PowerCycleBmc(ctx context.Context) (status bool, err error) {
 func (i *Ipmi) PowerResetBmc(ctx context.Context, resetType string) (ok bool, err error) {
 	switch strings.ToLower(resetType) {
 	case "cold":
-		err = i.client.ColdReset(ctx)
+		err = i.execCommand(ctx, func(c *ipmi.Client) error {
+			return c.ColdReset(ctx)
+		})
 	case "warm":
-		err = i.client.WarmReset(ctx)
+		err = i.execCommand(ctx, func(c *ipmi.Client) error {
+			return c.WarmReset(ctx)
+		})
 	default:
 		return false, fmt.Errorf("unsupported reset type: %s", resetType)
 	}
@@ -209,7 +376,7 @@ func (i *Ipmi) PowerOn(ctx context.Context) (status bool, err error) {
 		return true, nil
 	}
 
-	_, err = i.client.ChassisControl(ctx, ipmi.ChassisControlPowerUp)
+	err = i.chassisControl(ctx, ipmi.ChassisControlPowerUp)
 	if err != nil {
 		return false, fmt.Errorf("chassis control failed: %v", err)
 	}
@@ -218,7 +385,7 @@ func (i *Ipmi) PowerOn(ctx context.Context) (status bool, err error) {
 
 // PowerOnForce power on the machine via bmc even when the machine is already on (Thanks HP!)
 func (i *Ipmi) PowerOnForce(ctx context.Context) (status bool, err error) {
-	_, err = i.client.ChassisControl(ctx, ipmi.ChassisControlPowerUp)
+	err = i.chassisControl(ctx, ipmi.ChassisControlPowerUp)
 	if err != nil {
 		return false, fmt.Errorf("chassis control failed: %v", err)
 	}
@@ -231,7 +398,7 @@ func (i *Ipmi) PowerOff(ctx context.Context) (status bool, err error) {
 		return true, nil
 	}
 
-	_, err = i.client.ChassisControl(ctx, ipmi.ChassisControlPowerDown)
+	err = i.chassisControl(ctx, ipmi.ChassisControlPowerDown)
 	if err != nil {
 		return false, fmt.Errorf("chassis control failed: %v", err)
 	}
@@ -248,7 +415,7 @@ func (i *Ipmi) PowerSoft(ctx context.Context) (status bool, err error) {
 		return true, nil
 	}
 
-	_, err = i.client.ChassisControl(ctx, ipmi.ChassisControlSoftShutdown)
+	err = i.chassisControl(ctx, ipmi.ChassisControlSoftShutdown)
 	if err != nil {
 		return false, fmt.Errorf("chassis control failed: %v", err)
 	}
@@ -257,7 +424,9 @@ func (i *Ipmi) PowerSoft(ctx context.Context) (status bool, err error) {
 
 // PxeOnceEfi makes the machine to boot via pxe once using EFI
 func (i *Ipmi) PxeOnceEfi(ctx context.Context) (status bool, err error) {
-	err = i.client.SetBootDevice(ctx, ipmi.BootDeviceSelectorForcePXE, ipmi.BIOSBootTypeEFI, false)
+	err = i.execCommand(ctx, func(c *ipmi.Client) error {
+		return c.SetBootDevice(ctx, ipmi.BootDeviceSelectorForcePXE, ipmi.BIOSBootTypeEFI, false)
+	})
 	if err != nil {
 		return false, fmt.Errorf("set boot device failed: %v", err)
 	}
@@ -291,7 +460,9 @@ func (i *Ipmi) BootDeviceSet(ctx context.Context, bootDevice string, setPersiste
 		biosBootType = ipmi.BIOSBootTypeEFI
 	}
 
-	err = i.client.SetBootDevice(ctx, device, biosBootType, setPersistent)
+	err = i.execCommand(ctx, func(c *ipmi.Client) error {
+		return c.SetBootDevice(ctx, device, biosBootType, setPersistent)
+	})
 	if err != nil {
 		return false, fmt.Errorf("set boot device failed: %v", err)
 	}
@@ -300,7 +471,9 @@ func (i *Ipmi) BootDeviceSet(ctx context.Context, bootDevice string, setPersiste
 
 // PxeOnceMbr makes the machine to boot via pxe once using MBR
 func (i *Ipmi) PxeOnceMbr(ctx context.Context) (status bool, err error) {
-	err = i.client.SetBootDevice(ctx, ipmi.BootDeviceSelectorForcePXE, ipmi.BIOSBootTypeLegacy, false)
+	err = i.execCommand(ctx, func(c *ipmi.Client) error {
+		return c.SetBootDevice(ctx, ipmi.BootDeviceSelectorForcePXE, ipmi.BIOSBootTypeLegacy, false)
+	})
 	if err != nil {
 		return false, fmt.Errorf("set boot device failed: %v", err)
 	}
@@ -314,7 +487,9 @@ func (i *Ipmi) PxeOnce(ctx context.Context) (status bool, err error) {
 
 // IsOn tells if a machine is currently powered on
 func (i *Ipmi) IsOn(ctx context.Context) (status bool, err error) {
-	chassisStatus, err := i.client.GetChassisStatus(ctx)
+	chassisStatus, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.GetChassisStatusResponse, error) {
+		return c.GetChassisStatus(ctx)
+	})
 	if err != nil {
 		return false, fmt.Errorf("failed to get chassis status: %v", err)
 	}
@@ -323,7 +498,9 @@ func (i *Ipmi) IsOn(ctx context.Context) (status bool, err error) {
 
 // PowerState returns the current power state of the machine
 func (i *Ipmi) PowerState(ctx context.Context) (state string, err error) {
-	chassisStatus, err := i.client.GetChassisStatus(ctx)
+	chassisStatus, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.GetChassisStatusResponse, error) {
+		return c.GetChassisStatus(ctx)
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to get chassis status: %v", err)
 	}
@@ -339,14 +516,18 @@ func (i *Ipmi) ReadUsers(ctx context.Context) (users []map[string]string, err er
 	// Try to get user information for user IDs 1-16 (typical range)
 	// Since GetUsers might not be available, we'll iterate through user IDs
 	for userID := uint8(1); userID <= 16; userID++ {
-		userAccess, err := i.client.GetUserAccess(ctx, 1, userID)
+		userAccess, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.GetUserAccessResponse, error) {
+			return c.GetUserAccess(ctx, 1, userID)
+		})
 		if err != nil {
 			// Skip users that don't exist or can't be accessed
 			continue
 		}
 
 		// Get username for this user ID
-		userNameResp, err := i.client.GetUsername(ctx, userID)
+		userNameResp, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.GetUsernameResponse, error) {
+			return c.GetUsername(ctx, userID)
+		})
 		if err != nil {
 			// Skip users that can't be queried
 			continue
@@ -373,7 +554,9 @@ func (i *Ipmi) ReadUsers(ctx context.Context) (users []map[string]string, err er
 // ClearSystemEventLog clears the system event log
 func (i *Ipmi) ClearSystemEventLog(ctx context.Context) (err error) {
 	// Use 0x4321 as the clear operation code (standard IPMI clear operation)
-	_, err = i.client.ClearSEL(ctx, 0x4321)
+	_, err = sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.ClearSELResponse, error) {
+		return c.ClearSEL(ctx, 0x4321)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to clear SEL: %v", err)
 	}
@@ -391,10 +574,478 @@ func (i *Ipmi) GetSystemEventLog(ctx context.Context) (entries [][]string, err e
 	return entries, nil
 }
 
+// GetSystemEventLogEntries returns the system event log as typed entries
+func (i *Ipmi) GetSystemEventLogEntries(ctx context.Context) (entries []bmc.SystemEventLogEntry, err error) {
+	selEntries, err := sendCommand(ctx, i, func(c *ipmi.Client) ([]*ipmi.SEL, error) {
+		return c.GetSELEntries(ctx, 0)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SEL entries: %v", err)
+	}
+
+	for _, entry := range selEntries {
+		entries = append(entries, toSystemEventLogEntry(entry))
+	}
+
+	return entries, nil
+}
+
+// toSystemEventLogEntry converts a SEL record into a bmc.SystemEventLogEntry.
+func toSystemEventLogEntry(sel *ipmi.SEL) bmc.SystemEventLogEntry {
+	entry := bmc.SystemEventLogEntry{
+		RecordID: fmt.Sprintf("%x", sel.RecordID),
+		Severity: bmc.SystemEventLogSeverityUnknown,
+		Raw:      sel.Pack(),
+	}
+
+	switch {
+	case sel.Standard != nil:
+		entry.Timestamp = sel.Standard.Timestamp
+		entry.Severity = bmc.ParseSystemEventLogSeverity(string(sel.Standard.EventSeverity()))
+		entry.SensorType = sel.Standard.SensorType.String()
+		entry.SensorName = fmt.Sprintf("Sensor %d", sel.Standard.SensorNumber)
+		entry.EventDirection = bmc.SystemEventLogEventDirectionAsserted
+		if sel.Standard.EventDir == ipmi.EventDirDeassertion {
+			entry.EventDirection = bmc.SystemEventLogEventDirectionDeasserted
+		}
+		entry.Message = sel.Standard.EventString()
+	case sel.OEMTimestamped != nil:
+		entry.Timestamp = sel.OEMTimestamped.Timestamp
+		entry.Message = fmt.Sprintf("OEM record type 0x%02x, manufacturer 0x%06x", uint8(sel.RecordType), sel.OEMTimestamped.ManufacturerID)
+	default:
+		entry.Message = fmt.Sprintf("OEM record type 0x%02x", uint8(sel.RecordType))
+	}
+
+	return entry
+}
+
+// Sensors returns the sensor readings from the Sensor Data Repository (SDR)
+func (i *Ipmi) Sensors(ctx context.Context) (readings []bmc.SensorReading, err error) {
+	sensors, err := sendCommand(ctx, i, func(c *ipmi.Client) ([]*ipmi.Sensor, error) {
+		return c.GetSensors(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sensors: %v", err)
+	}
+
+	for _, sensor := range sensors {
+		readings = append(readings, toSensorReading(sensor))
+	}
+
+	return readings, nil
+}
+
+// toSensorReading converts an SDR sensor into a bmc.SensorReading.
+func toSensorReading(sensor *ipmi.Sensor) bmc.SensorReading {
+	reading := bmc.SensorReading{
+		ID:              fmt.Sprintf("%d", sensor.Number),
+		Name:            sensor.Name,
+		Type:            toSensorType(sensor.SensorType),
+		Health:          bmc.SensorHealthUnknown,
+		State:           "Absent",
+		PhysicalContext: sensor.EntityID.String(),
+	}
+
+	if !sensor.IsThreshold() {
+		if sensor.IsReadingValid() {
+			reading.State = "Enabled"
+		}
+
+		return reading
+	}
+
+	reading.Units = sensor.SensorUnit.String()
+	reading.Thresholds = bmc.SensorThresholds{
+		LowerNonCritical: thresholdValue(sensor, ipmi.SensorThresholdType_LNC, sensor.Threshold.LNC),
+		LowerCritical:    thresholdValue(sensor, ipmi.SensorThresholdType_LCR, sensor.Threshold.LCR),
+		LowerFatal:       thresholdValue(sensor, ipmi.SensorThresholdType_LNR, sensor.Threshold.LNR),
+		UpperNonCritical: thresholdValue(sensor, ipmi.SensorThresholdType_UNC, sensor.Threshold.UNC),
+		UpperCritical:    thresholdValue(sensor, ipmi.SensorThresholdType_UCR, sensor.Threshold.UCR),
+		UpperFatal:       thresholdValue(sensor, ipmi.SensorThresholdType_UNR, sensor.Threshold.UNR),
+	}
+
+	if sensor.IsReadingValid() {
+		value := sensor.Value
+		reading.Reading = &value
+		reading.State = "Enabled"
+		reading.Health = bmc.ParseSensorHealth(string(sensor.Threshold.ThresholdStatus))
+	}
+
+	return reading
+}
+
+func toSensorType(sensorType ipmi.SensorType) bmc.SensorType {
+	switch sensorType {
+	case ipmi.SensorTypeTemperature:
+		return bmc.SensorTypeTemperature
+	case ipmi.SensorTypeFan:
+		return bmc.SensorTypeFan
+	case ipmi.SensorTypeVoltage:
+		return bmc.SensorTypeVoltage
+	case ipmi.SensorTypeCurrent:
+		return bmc.SensorTypeCurrent
+	case ipmi.SensorTypePowerSupply:
+		return bmc.SensorTypePowerSupply
+	case ipmi.SensorTypePowerUnit:
+		return bmc.SensorTypePower
+	default:
+		return bmc.SensorTypeOther
+	}
+}
+
+func thresholdValue(sensor *ipmi.Sensor, thresholdType ipmi.SensorThresholdType, value float64) *float64 {
+	if !sensor.IsThresholdReadable(thresholdType) {
+		return nil
+	}
+
+	return &value
+}
+
+// PowerConsumption returns the DCMI power reading and the active DCMI power limit
+func (i *Ipmi) PowerConsumption(ctx context.Context) (consumption *bmc.PowerConsumption, err error) {
+	reading, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.GetDCMIPowerReadingResponse, error) {
+		return c.GetDCMIPowerReading(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DCMI power reading: %v", err)
+	}
+
+	if !reading.PowerMeasurementActive {
+		return nil, errors.New("DCMI power measurement is not active")
+	}
+
+	consumption = toPowerConsumption(reading)
+
+	// the power limit is optional in DCMI, the BMC also returns an error when no limit is active
+	limit, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.GetDCMIPowerLimitResponse, error) {
+		return c.GetDCMIPowerLimit(ctx)
+	})
+	if err != nil {
+		i.log.V(2).Info("DCMI power limit not available", "error", err.Error())
+		return consumption, nil
+	}
+
+	consumption.Limit = toPowerLimit(limit)
+
+	return consumption, nil
+}
+
+// toPowerConsumption converts a DCMI power reading into a bmc.PowerConsumption.
+func toPowerConsumption(reading *ipmi.GetDCMIPowerReadingResponse) *bmc.PowerConsumption {
+	current := float64(reading.CurrentPower)
+	average := float64(reading.AveragePower)
+	minimum := float64(reading.MinimumPower)
+	maximum := float64(reading.MaximumPower)
+
+	return &bmc.PowerConsumption{
+		CurrentWatts: &current,
+		AverageWatts: &average,
+		MinWatts:     &minimum,
+		MaxWatts:     &maximum,
+		Interval:     time.Duration(reading.ReportingPeriod) * time.Millisecond,
+	}
+}
+
+// toPowerLimit converts an active DCMI power limit into a bmc.PowerLimit.
+func toPowerLimit(limit *ipmi.GetDCMIPowerLimitResponse) *bmc.PowerLimit {
+	powerLimit := &bmc.PowerLimit{
+		Watts:          float64(limit.PowerLimitRequested),
+		Enabled:        true,
+		CorrectionTime: time.Duration(limit.CorrectionTimeLimitMilliSec) * time.Millisecond,
+	}
+
+	switch limit.ExceptionAction {
+	case ipmi.DCMIExceptionAction_NoAction:
+		powerLimit.ExceptionAction = bmc.PowerLimitExceptionActionNoAction
+	case ipmi.DCMIExceptionAction_PowerOffAndLogSEL:
+		powerLimit.ExceptionAction = bmc.PowerLimitExceptionActionHardPowerOff
+	case ipmi.DCMIExceptionAction_LogSEL:
+		powerLimit.ExceptionAction = bmc.PowerLimitExceptionActionLogEvent
+	}
+
+	return powerLimit
+}
+
+// SetPowerLimit sets and activates the DCMI power limit, or deactivates it when the limit is not enabled
+func (i *Ipmi) SetPowerLimit(ctx context.Context, limit bmc.PowerLimit) (err error) {
+	if !limit.Enabled {
+		if _, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.ActivateDCMIPowerLimitResponse, error) {
+			return c.ActivateDCMIPowerLimit(ctx, false)
+		}); err != nil {
+			return fmt.Errorf("failed to deactivate DCMI power limit: %v", err)
+		}
+
+		return nil
+	}
+
+	request := &ipmi.SetDCMIPowerLimitRequest{
+		ExceptionAction:             ipmi.DCMIExceptionAction_NoAction,
+		CorrectionTimeLimitMilliSec: uint32(dcmiDefaultCorrectionTime.Milliseconds()),
+		StatisticsSamplingPeriodSec: dcmiDefaultSamplingPeriod,
+	}
+
+	// keep the settings not part of the request as currently configured on the BMC
+	if current, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.GetDCMIPowerLimitResponse, error) {
+		return c.GetDCMIPowerLimit(ctx)
+	}); err == nil {
+		request.ExceptionAction = current.ExceptionAction
+		if current.CorrectionTimeLimitMilliSec > 0 {
+			request.CorrectionTimeLimitMilliSec = current.CorrectionTimeLimitMilliSec
+		}
+		if current.StatisticsSamplingPeriodSec > 0 {
+			request.StatisticsSamplingPeriodSec = current.StatisticsSamplingPeriodSec
+		}
+	}
+
+	request.PowerLimitRequested = uint16(limit.Watts)
+
+	if limit.CorrectionTime > 0 {
+		request.CorrectionTimeLimitMilliSec = uint32(limit.CorrectionTime.Milliseconds())
+	}
+
+	switch limit.ExceptionAction {
+	case bmc.PowerLimitExceptionActionNoAction:
+		request.ExceptionAction = ipmi.DCMIExceptionAction_NoAction
+	case bmc.PowerLimitExceptionActionHardPowerOff:
+		request.ExceptionAction = ipmi.DCMIExceptionAction_PowerOffAndLogSEL
+	case bmc.PowerLimitExceptionActionLogEvent:
+		request.ExceptionAction = ipmi.DCMIExceptionAction_LogSEL
+	}
+
+	if _, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.SetDCMIPowerLimitResponse, error) {
+		return c.SetDCMIPowerLimit(ctx, request)
+	}); err != nil {
+		return fmt.Errorf("failed to set DCMI power limit: %v", err)
+	}
+
+	if _, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.ActivateDCMIPowerLimitResponse, error) {
+		return c.ActivateDCMIPowerLimit(ctx, true)
+	}); err != nil {
+		return fmt.Errorf("failed to activate DCMI power limit: %v", err)
+	}
+
+	return nil
+}
+
+// maxIdentifyInterval is the longest identify interval the Chassis Identify command accepts
+const maxIdentifyInterval = 255 * time.Second
+
+// SetIdentifyLED turns the chassis identify LED on or off, a zero duration turns it on until turned off
+func (i *Ipmi) SetIdentifyLED(ctx context.Context, on bool, duration time.Duration) (err error) {
+	interval, force, err := identifyInterval(on, duration)
+	if err != nil {
+		return err
+	}
+
+	if _, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.ChassisIdentifyResponse, error) {
+		return c.ChassisIdentify(ctx, interval, force)
+	}); err != nil {
+		return fmt.Errorf("failed to set chassis identify: %v", err)
+	}
+
+	return nil
+}
+
+// identifyInterval returns the Chassis Identify interval and force values for the requested state
+func identifyInterval(on bool, duration time.Duration) (interval uint8, force bool, err error) {
+	switch {
+	case !on:
+		return 0, false, nil
+	case duration == 0:
+		return 0, true, nil
+	case duration > maxIdentifyInterval:
+		return 0, false, fmt.Errorf("identify LED duration %v exceeds the maximum of %v", duration, maxIdentifyInterval)
+	case duration < time.Second:
+		return 1, false, nil
+	}
+
+	return uint8(duration / time.Second), false, nil
+}
+
+// GetIdentifyLED returns true when the chassis identify LED is on
+func (i *Ipmi) GetIdentifyLED(ctx context.Context) (on bool, err error) {
+	chassisStatus, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.GetChassisStatusResponse, error) {
+		return c.GetChassisStatus(ctx)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get chassis status: %v", err)
+	}
+
+	if !chassisStatus.ChassisIdentifySupported {
+		return false, errors.New("chassis identify state is not reported by the BMC")
+	}
+
+	return chassisStatus.ChassisIdentifyState != ipmi.ChassisIdentifyStateOff, nil
+}
+
+// defaultLanChannel is the IPMI channel of the BMC management interface used when none is given.
+const defaultLanChannel = 1
+
+// GetBMCNetwork returns the IPv4 and VLAN configuration of the BMC LAN channel.
+func (i *Ipmi) GetBMCNetwork(ctx context.Context) (config *bmc.BMCNetworkConfig, err error) {
+	source := &ipmi.LanConfigParam_IPSource{}
+	address := &ipmi.LanConfigParam_IP{}
+	mask := &ipmi.LanConfigParam_SubnetMask{}
+	gateway := &ipmi.LanConfigParam_DefaultGatewayIP{}
+	mac := &ipmi.LanConfigParam_MAC{}
+	vlan := &ipmi.LanConfigParam_VLANID{}
+
+	for _, param := range []ipmi.LanConfigParameter{source, address, mask, gateway, mac, vlan} {
+		if err := i.execCommand(ctx, func(c *ipmi.Client) error {
+			return c.GetLanConfigParamFor(ctx, defaultLanChannel, param)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to get LAN configuration: %v", err)
+		}
+	}
+
+	config = &bmc.BMCNetworkConfig{
+		InterfaceID: strconv.Itoa(defaultLanChannel),
+		MACAddress:  mac.MAC.String(),
+		DHCPv4:      source.Source == ipmi.IPAddressSourceDHCP,
+		VLAN:        &bmc.BMCVLAN{Enabled: vlan.Enabled, ID: int(vlan.ID)},
+	}
+
+	if address.IP != nil && !address.IP.IsUnspecified() {
+		config.IPv4 = &bmc.BMCIPv4Address{Address: address.IP.String(), SubnetMask: mask.SubnetMask.String()}
+		if gateway.IP != nil && !gateway.IP.IsUnspecified() {
+			config.IPv4.Gateway = gateway.IP.String()
+		}
+	}
+
+	return config, nil
+}
+
+// SetBMCNetwork applies the IPv4 and VLAN configuration to the BMC LAN channel, the InterfaceID is
+// the LAN channel number.
+func (i *Ipmi) SetBMCNetwork(ctx context.Context, config bmc.BMCNetworkConfig) (err error) {
+	channel, params, err := lanConfigParams(config)
+	if err != nil {
+		return err
+	}
+
+	for _, param := range params {
+		if err := i.execCommand(ctx, func(c *ipmi.Client) error {
+			return c.SetLanConfigParamFor(ctx, channel, param)
+		}); err != nil {
+			return fmt.Errorf("failed to set LAN configuration: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// lanConfigParams returns the LAN channel and the LAN configuration parameters to set for the configuration.
+func lanConfigParams(config bmc.BMCNetworkConfig) (channel uint8, params []ipmi.LanConfigParameter, err error) {
+	if config.DHCPv6 || len(config.IPv6) > 0 || config.IPv6Gateway != "" || config.DNSServers != nil || config.Hostname != "" {
+		return 0, nil, errors.New("IPv6, DNS server and hostname settings are not supported over IPMI")
+	}
+
+	channel = defaultLanChannel
+	if config.InterfaceID != "" {
+		n, err := strconv.ParseUint(config.InterfaceID, 10, 8)
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid LAN channel: %q", config.InterfaceID)
+		}
+
+		channel = uint8(n)
+	}
+
+	// The subnet mask, gateway and VLAN are set before the address source and address, the session runs
+	// over the LAN channel and the parameters following a change of address would be sent to the old address.
+	if !config.DHCPv4 && config.IPv4 != nil {
+		params = append(params, &ipmi.LanConfigParam_SubnetMask{SubnetMask: net.ParseIP(config.IPv4.SubnetMask).To4()})
+
+		if config.IPv4.Gateway != "" {
+			params = append(params, &ipmi.LanConfigParam_DefaultGatewayIP{IP: net.ParseIP(config.IPv4.Gateway).To4()})
+		}
+	}
+
+	if config.VLAN != nil {
+		params = append(params, &ipmi.LanConfigParam_VLANID{Enabled: config.VLAN.Enabled, ID: uint16(config.VLAN.ID)})
+	}
+
+	switch {
+	case config.DHCPv4:
+		params = append(params, &ipmi.LanConfigParam_IPSource{Source: ipmi.IPAddressSourceDHCP})
+	case config.IPv4 != nil:
+		params = append(params,
+			&ipmi.LanConfigParam_IPSource{Source: ipmi.IPAddressSourceStatic},
+			&ipmi.LanConfigParam_IP{IP: net.ParseIP(config.IPv4.Address).To4()},
+		)
+	}
+
+	if len(params) == 0 {
+		return 0, nil, errors.New("no BMC network settings given")
+	}
+
+	return channel, params, nil
+}
+
+// selTimeUnspecifiedOffset is the SEL Time UTC offset the BMC reports when no offset is configured.
+const selTimeUnspecifiedOffset = 0x07ff
+
+// GetBMCTime returns the SEL clock, NTP is not reported over IPMI.
+func (i *Ipmi) GetBMCTime(ctx context.Context) (bmcTime *bmc.BMCTime, err error) {
+	selTime, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.GetSELTimeResponse, error) {
+		return c.GetSELTime(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SEL time: %v", err)
+	}
+
+	retrievedAt := time.Now()
+
+	return &bmc.BMCTime{Time: fromSELTime(selTime.Time, i.selTimeUTCOffset(ctx)), RetrievedAt: retrievedAt}, nil
+}
+
+// SetBMCTime sets the SEL clock, the SEL Time UTC offset is left unchanged.
+func (i *Ipmi) SetBMCTime(ctx context.Context, t time.Time) (err error) {
+	if _, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.SetSELTimeResponse, error) {
+		return c.SetSELTime(ctx, toSELTime(t, i.selTimeUTCOffset(ctx)))
+	}); err != nil {
+		return fmt.Errorf("failed to set SEL time: %v", err)
+	}
+
+	return nil
+}
+
+// SetNTPServers is not supported, IPMI does not define the BMC NTP configuration.
+func (i *Ipmi) SetNTPServers(ctx context.Context, servers []string) (err error) {
+	return errors.New("NTP configuration is not supported over IPMI")
+}
+
+// selTimeUTCOffset returns the SEL Time UTC offset in minutes, BMCs that do not implement the
+// Get SEL Time UTC Offset command keep the SEL clock in UTC.
+func (i *Ipmi) selTimeUTCOffset(ctx context.Context) int16 {
+	offset, err := sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.GetSELTimeUTCOffsetResponse, error) {
+		return c.GetSELTimeUTCOffset(ctx)
+	})
+	if err != nil || offset.MinutesOffset == selTimeUnspecifiedOffset {
+		return 0
+	}
+
+	return offset.MinutesOffset
+}
+
+// fromSELTime returns the time for a SEL timestamp, the SEL timestamp counts the seconds of the
+// BMC local time since the epoch, offset is the BMC local time offset to UTC in minutes.
+func fromSELTime(selTime time.Time, offset int16) time.Time {
+	seconds := int(offset) * 60
+
+	return time.Unix(selTime.Unix()-int64(seconds), 0).In(time.FixedZone("", seconds))
+}
+
+// toSELTime returns the SEL timestamp for the time, offset is the BMC local time offset to UTC in minutes.
+func toSELTime(t time.Time, offset int16) time.Time {
+	return time.Unix(t.Unix()+int64(offset)*60, 0)
+}
+
 // GetSystemEventLogRaw returns the raw SEL output
 func (i *Ipmi) GetSystemEventLogRaw(ctx context.Context) (eventlog string, err error) {
 	// Get all SEL entries starting from record ID 0
-	selEntries, err := i.client.GetSELEntries(ctx, 0)
+	selEntries, err := sendCommand(ctx, i, func(c *ipmi.Client) ([]*ipmi.SEL, error) {
+		return c.GetSELEntries(ctx, 0)
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to get SEL entries: %v", err)
 	}
@@ -428,9 +1079,11 @@ func (i *Ipmi) GetSystemEventLogRaw(ctx context.Context) (eventlog string, err e
 
 // DeactivateSOL deactivates any active SOL payload, treating an already-deactivated payload as success.
 func (i *Ipmi) DeactivateSOL(ctx context.Context) (err error) {
-	_, err = i.client.DeactivatePayload(ctx, &ipmi.DeactivatePayloadRequest{
-		PayloadType:     ipmi.PayloadTypeSOL,
-		PayloadInstance: 0,
+	_, err = sendCommand(ctx, i, func(c *ipmi.Client) (*ipmi.DeactivatePayloadResponse, error) {
+		return c.DeactivatePayload(ctx, &ipmi.DeactivatePayloadRequest{
+			PayloadType:     ipmi.PayloadTypeSOL,
+			PayloadInstance: 0,
+		})
 	})
 	if err != nil {
 		// 0x80 means SOL was already deactivated; treat as success.
@@ -445,7 +1098,7 @@ func (i *Ipmi) DeactivateSOL(ctx context.Context) (err error) {
 
 // SendPowerDiag tells the BMC to issue an NMI to the device
 func (i *Ipmi) SendPowerDiag(ctx context.Context) error {
-	_, err := i.client.ChassisControl(ctx, ipmi.ChassisControlDiagnosticInterrupt)
+	err := i.chassisControl(ctx, ipmi.ChassisControlDiagnosticInterrupt)
 	if err != nil {
 		return errors.Wrap(err, "failed sending power diag")
 	}
@@ -1,10 +1,18 @@
 package goipmi
 
 import (
+	"context"
+	"errors"
+	"net"
 	"testing"
+	"time"
 
+	"github.com/bougou/go-ipmi"
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
 )
 
 func TestClone(t *testing.T) {
@@ -26,3 +34,288 @@ func TestClone(t *testing.T) {
 	assert.Equal(t, orig.Port, clone.Port)
 	assert.Equal(t, orig.cipherSuite, clone.cipherSuite)
 }
+
+func TestSendCommandRetry(t *testing.T) {
+	errExpired := errors.New("session expired")
+
+	tests := map[string]struct {
+		renewed   bool
+		checkErr  error
+		wantCalls int
+		wantErr   error
+	}{
+		"retried once on a renewed session": {renewed: true, wantCalls: 2},
+		"not retried on a valid session":    {renewed: false, wantCalls: 1, wantErr: errExpired},
+		"not retried when renewal fails":    {checkErr: errors.New("connect failed"), wantCalls: 1, wantErr: errExpired},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var checks int
+
+			i := &Ipmi{log: logr.Discard(), lastUsed: time.Now()}
+			i.checkSession = func(context.Context) (bool, error) {
+				checks++
+				return tt.renewed, tt.checkErr
+			}
+
+			var calls int
+
+			got, err := sendCommand(context.Background(), i, func(*ipmi.Client) (int, error) {
+				calls++
+				if calls == 1 {
+					return 0, errExpired
+				}
+
+				return 42, nil
+			})
+
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Equal(t, 1, checks)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantErr == nil {
+				assert.Equal(t, 42, got)
+			}
+		})
+	}
+
+	// a closed session is not re-established to retry a command
+	i := &Ipmi{log: logr.Discard()}
+	i.checkSession = func(context.Context) (bool, error) { return true, nil }
+
+	err := i.execCommand(context.Background(), func(*ipmi.Client) error { return errExpired })
+	assert.ErrorIs(t, err, errExpired)
+}
+
+func TestToSystemEventLogEntry(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+
+	standard := &ipmi.SEL{
+		RecordID:   0x1a,
+		RecordType: 0x02,
+		Standard: &ipmi.SELStandard{
+			Timestamp:        ts,
+			SensorType:       ipmi.SensorTypePowerSupply,
+			SensorNumber:     0x51,
+			EventDir:         ipmi.EventDirDeassertion,
+			EventReadingType: ipmi.EventReadingTypeSensorSpecific,
+			EventData:        ipmi.EventData{EventData1: 0x01},
+		},
+	}
+
+	entry := toSystemEventLogEntry(standard)
+	assert.Equal(t, "1a", entry.RecordID)
+	assert.True(t, ts.Equal(entry.Timestamp))
+	assert.Equal(t, "Power Supply", entry.SensorType)
+	assert.Equal(t, "Sensor 81", entry.SensorName)
+	assert.Equal(t, bmc.SystemEventLogEventDirectionDeasserted, entry.EventDirection)
+	assert.NotEmpty(t, entry.Message)
+	assert.Len(t, entry.Raw, 16)
+
+	oem := &ipmi.SEL{
+		RecordID:       0x1b,
+		RecordType:     0xc0,
+		OEMTimestamped: &ipmi.SELOEMTimestamped{Timestamp: ts, ManufacturerID: 0x002a7c},
+	}
+
+	entry = toSystemEventLogEntry(oem)
+	assert.Equal(t, "1b", entry.RecordID)
+	assert.Equal(t, bmc.SystemEventLogSeverityUnknown, entry.Severity)
+	assert.True(t, ts.Equal(entry.Timestamp))
+	assert.Contains(t, entry.Message, "0x002a7c")
+}
+
+func TestToSensorReading(t *testing.T) {
+	threshold := &ipmi.Sensor{
+		Number:           0x30,
+		Name:             "CPU Temp",
+		SensorType:       ipmi.SensorTypeTemperature,
+		EventReadingType: ipmi.EventReadingTypeThreshold,
+		SensorUnit: ipmi.SensorUnit{
+			AnalogDataFormat: ipmi.SensorAnalogUnitFormat_Unsigned,
+			BaseUnit:         ipmi.SensorUnitType_DegreesC,
+		},
+		EntityID: 0x03,
+	}
+	threshold.Threshold.Mask.UCR.Readable = true
+	threshold.Threshold.UCR = 95
+	threshold.Threshold.UNC = 90
+
+	reading := toSensorReading(threshold)
+	assert.Equal(t, "48", reading.ID)
+	assert.Equal(t, "CPU Temp", reading.Name)
+	assert.Equal(t, bmc.SensorTypeTemperature, reading.Type)
+	assert.Equal(t, "processor", reading.PhysicalContext)
+	assert.Equal(t, "degrees C", reading.Units)
+	require.NotNil(t, reading.Thresholds.UpperCritical)
+	assert.Equal(t, 95.0, *reading.Thresholds.UpperCritical)
+	assert.Nil(t, reading.Thresholds.UpperNonCritical, "thresholds not flagged readable are left unset")
+	// the reading was never fetched from the BMC
+	assert.Nil(t, reading.Reading)
+	assert.Equal(t, "Absent", reading.State)
+	assert.Equal(t, bmc.SensorHealthUnknown, reading.Health)
+
+	discrete := &ipmi.Sensor{
+		Number:           0x51,
+		Name:             "PS1 Status",
+		SensorType:       ipmi.SensorTypePowerSupply,
+		EventReadingType: ipmi.EventReadingTypeSensorSpecific,
+	}
+
+	reading = toSensorReading(discrete)
+	assert.Equal(t, bmc.SensorTypePowerSupply, reading.Type)
+	assert.Empty(t, reading.Units)
+	assert.Nil(t, reading.Reading)
+}
+
+func TestToPowerConsumption(t *testing.T) {
+	consumption := toPowerConsumption(&ipmi.GetDCMIPowerReadingResponse{
+		CurrentPower:           220,
+		MinimumPower:           68,
+		MaximumPower:           522,
+		AveragePower:           213,
+		ReportingPeriod:        5000,
+		PowerMeasurementActive: true,
+	})
+
+	require.NotNil(t, consumption.CurrentWatts)
+	assert.Equal(t, 220.0, *consumption.CurrentWatts)
+	assert.Equal(t, 213.0, *consumption.AverageWatts)
+	assert.Equal(t, 68.0, *consumption.MinWatts)
+	assert.Equal(t, 522.0, *consumption.MaxWatts)
+	assert.Equal(t, 5*time.Second, consumption.Interval)
+	assert.Nil(t, consumption.Limit)
+
+	limit := toPowerLimit(&ipmi.GetDCMIPowerLimitResponse{
+		ExceptionAction:             ipmi.DCMIExceptionAction_PowerOffAndLogSEL,
+		PowerLimitRequested:         500,
+		CorrectionTimeLimitMilliSec: 1000,
+		StatisticsSamplingPeriodSec: 5,
+	})
+
+	assert.Equal(t, &bmc.PowerLimit{
+		Watts:           500,
+		Enabled:         true,
+		CorrectionTime:  time.Second,
+		ExceptionAction: bmc.PowerLimitExceptionActionHardPowerOff,
+	}, limit)
+}
+
+func TestIdentifyInterval(t *testing.T) {
+	testCases := []struct {
+		name     string
+		on       bool
+		duration time.Duration
+		interval uint8
+		force    bool
+		wantErr  bool
+	}{
+		{name: "off", on: false, duration: time.Minute},
+		{name: "on indefinitely", on: true, force: true},
+		{name: "on for a minute", on: true, duration: time.Minute, interval: 60},
+		{name: "sub second rounds up", on: true, duration: 100 * time.Millisecond, interval: 1},
+		{name: "on for the maximum", on: true, duration: 255 * time.Second, interval: 255},
+		{name: "exceeds maximum", on: true, duration: 5 * time.Minute, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			interval, force, err := identifyInterval(tc.on, tc.duration)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.interval, interval)
+			assert.Equal(t, tc.force, force)
+		})
+	}
+}
+
+func TestLanConfigParams(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  bmc.BMCNetworkConfig
+		channel uint8
+		params  []ipmi.LanConfigParameter
+		wantErr bool
+	}{
+		{
+			name:    "dhcp",
+			config:  bmc.BMCNetworkConfig{DHCPv4: true},
+			channel: 1,
+			params:  []ipmi.LanConfigParameter{&ipmi.LanConfigParam_IPSource{Source: ipmi.IPAddressSourceDHCP}},
+		},
+		{
+			name: "static with vlan on channel 8",
+			config: bmc.BMCNetworkConfig{
+				InterfaceID: "8",
+				IPv4:        &bmc.BMCIPv4Address{Address: "10.0.0.10", SubnetMask: "255.255.255.0", Gateway: "10.0.0.1"},
+				VLAN:        &bmc.BMCVLAN{Enabled: true, ID: 100},
+			},
+			channel: 8,
+			// the address is set last, the session is lost once the BMC moves to it
+			params: []ipmi.LanConfigParameter{
+				&ipmi.LanConfigParam_SubnetMask{SubnetMask: net.IPv4(255, 255, 255, 0).To4()},
+				&ipmi.LanConfigParam_DefaultGatewayIP{IP: net.IPv4(10, 0, 0, 1).To4()},
+				&ipmi.LanConfigParam_VLANID{Enabled: true, ID: 100},
+				&ipmi.LanConfigParam_IPSource{Source: ipmi.IPAddressSourceStatic},
+				&ipmi.LanConfigParam_IP{IP: net.IPv4(10, 0, 0, 10).To4()},
+			},
+		},
+		{
+			name:    "dhcp with vlan disabled",
+			config:  bmc.BMCNetworkConfig{DHCPv4: true, VLAN: &bmc.BMCVLAN{}},
+			channel: 1,
+			params: []ipmi.LanConfigParameter{
+				&ipmi.LanConfigParam_VLANID{},
+				&ipmi.LanConfigParam_IPSource{Source: ipmi.IPAddressSourceDHCP},
+			},
+		},
+		{name: "dns servers", config: bmc.BMCNetworkConfig{DHCPv4: true, DNSServers: []string{"10.0.0.2"}}, wantErr: true},
+		{name: "ipv6", config: bmc.BMCNetworkConfig{DHCPv6: true}, wantErr: true},
+		{name: "invalid channel", config: bmc.BMCNetworkConfig{InterfaceID: "eth0", DHCPv4: true}, wantErr: true},
+		{name: "no settings", config: bmc.BMCNetworkConfig{}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			channel, params, err := lanConfigParams(tc.config)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.channel, channel)
+			assert.Equal(t, tc.params, params)
+		})
+	}
+}
+
+func TestSELTime(t *testing.T) {
+	utc := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		offset  int16
+		selTime time.Time
+	}{
+		{name: "utc", offset: 0, selTime: time.Unix(utc.Unix(), 0)},
+		{name: "ahead of utc", offset: 120, selTime: time.Unix(utc.Add(2*time.Hour).Unix(), 0)},
+		{name: "behind utc", offset: -300, selTime: time.Unix(utc.Add(-5*time.Hour).Unix(), 0)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.selTime, toSELTime(utc, tc.offset))
+
+			got := fromSELTime(tc.selTime, tc.offset)
+			assert.True(t, got.Equal(utc))
+			_, offset := got.Zone()
+			assert.Equal(t, int(tc.offset)*60, offset)
+		})
+	}
+}
@@ -8,12 +8,19 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
 )
 
+// selTimestampLayout is the date and time layout of the ipmitool sel list output.
+const selTimestampLayout = "01/02/2006 15:04:05"
+
 // Ipmi holds the date for an ipmi connection
 type Ipmi struct {
 	Username    string
@@ -427,6 +434,57 @@ func parseSystemEventLog(raw string) (entries [][]string) {
 	return entries
 }
 
+// GetSystemEventLogEntries returns the system event log as typed entries
+func (i *Ipmi) GetSystemEventLogEntries(ctx context.Context) (entries []bmc.SystemEventLogEntry, err error) {
+	output, err := i.GetSystemEventLogRaw(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting system event log")
+	}
+
+	return parseSystemEventLogEntries(output), nil
+}
+
+// parseSystemEventLogEntries parses the raw output of the system event log into typed entries.
+//
+// ipmitool sel list lines are of the form
+// ID | Date | Time | Sensor Type #Sensor Number | Event | Direction
+func parseSystemEventLogEntries(raw string) (entries []bmc.SystemEventLogEntry) {
+	scanner := bufio.NewScanner(strings.NewReader(raw))
+	for scanner.Scan() {
+		line := strings.Split(scanner.Text(), "|")
+		if len(line) < 6 {
+			continue
+		}
+		for i := range line {
+			line[i] = strings.TrimSpace(line[i])
+		}
+		if line[0] == "ID" {
+			continue
+		}
+
+		// entries logged before the BMC clock was set (Pre-Init) are left with a zero timestamp
+		timestamp, _ := time.ParseInLocation(selTimestampLayout, line[1]+" "+line[2], time.UTC)
+
+		sensorType := line[3]
+		if idx := strings.Index(sensorType, " #0x"); idx > 0 {
+			sensorType = sensorType[:idx]
+		}
+
+		entries = append(entries, bmc.SystemEventLogEntry{
+			RecordID:       line[0],
+			Timestamp:      timestamp,
+			Severity:       bmc.SystemEventLogSeverityUnknown,
+			SensorType:     sensorType,
+			SensorName:     line[3],
+			EventDirection: bmc.ParseSystemEventLogEventDirection(line[5]),
+			Message:        line[4],
+			Raw:            []byte(scanner.Text()),
+		})
+	}
+
+	return entries
+}
+
 // GetSystemEventLogRaw returns the raw SEL output
 func (i *Ipmi) GetSystemEventLogRaw(ctx context.Context) (eventlog string, err error) {
 	output, err := i.run(ctx, []string{"sel", "list"})
@@ -437,6 +495,349 @@ func (i *Ipmi) GetSystemEventLogRaw(ctx context.Context) (eventlog string, err e
 	return output, nil
 }
 
+// PowerConsumption returns the DCMI power reading and the active DCMI power limit
+func (i *Ipmi) PowerConsumption(ctx context.Context) (consumption *bmc.PowerConsumption, err error) {
+	output, err := i.run(ctx, []string{"dcmi", "power", "reading"})
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting DCMI power reading")
+	}
+
+	consumption, err = parseDCMIPowerReading(output)
+	if err != nil {
+		return nil, err
+	}
+
+	// the power limit is optional in DCMI, ipmitool also fails when no limit was ever set
+	output, err = i.run(ctx, []string{"dcmi", "power", "get_limit"})
+	if err != nil {
+		i.log.V(2).Info("DCMI power limit not available", "error", err.Error())
+		return consumption, nil
+	}
+
+	consumption.Limit = parseDCMIPowerLimit(output)
+
+	return consumption, nil
+}
+
+// dcmiFields returns the "key: value" pairs of the ipmitool dcmi command output.
+func dcmiFields(raw string) map[string]string {
+	fields := map[string]string{}
+
+	scanner := bufio.NewScanner(strings.NewReader(raw))
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+
+		fields[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return fields
+}
+
+// dcmiNumber returns the leading number of an ipmitool dcmi value, e.g. 220 for "220 Watts".
+func dcmiNumber(value string) (float64, error) {
+	number, _, _ := strings.Cut(value, " ")
+
+	return strconv.ParseFloat(number, 64)
+}
+
+// parseDCMIPowerReading parses the output of ipmitool dcmi power reading.
+func parseDCMIPowerReading(raw string) (*bmc.PowerConsumption, error) {
+	fields := dcmiFields(raw)
+
+	if state := fields["Power reading state is"]; state != "" && state != "activated" {
+		return nil, errors.New("DCMI power measurement is not active")
+	}
+
+	consumption := &bmc.PowerConsumption{}
+	for key, target := range map[string]**float64{
+		"Instantaneous power reading":              &consumption.CurrentWatts,
+		"Minimum during sampling period":           &consumption.MinWatts,
+		"Maximum during sampling period":           &consumption.MaxWatts,
+		"Average power reading over sample period": &consumption.AverageWatts,
+	} {
+		value, ok := fields[key]
+		if !ok {
+			continue
+		}
+
+		watts, err := dcmiNumber(value)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing DCMI power reading: "+key)
+		}
+
+		*target = &watts
+	}
+
+	if consumption.CurrentWatts == nil {
+		return nil, errors.New("no DCMI power reading found")
+	}
+
+	if seconds, err := dcmiNumber(fields["Sampling period"]); err == nil {
+		consumption.Interval = time.Duration(seconds) * time.Second
+	}
+
+	return consumption, nil
+}
+
+// parseDCMIPowerLimit parses the output of ipmitool dcmi power get_limit, it returns nil
+// when no power limit is active.
+func parseDCMIPowerLimit(raw string) *bmc.PowerLimit {
+	fields := dcmiFields(raw)
+
+	if fields["Current Limit State"] != "Power Limit Active" {
+		return nil
+	}
+
+	watts, err := dcmiNumber(fields["Power Limit"])
+	if err != nil {
+		return nil
+	}
+
+	limit := &bmc.PowerLimit{Watts: watts, Enabled: true}
+
+	if ms, err := dcmiNumber(fields["Correction time"]); err == nil {
+		limit.CorrectionTime = time.Duration(ms) * time.Millisecond
+	}
+
+	switch fields["Exception actions"] {
+	case "No Action":
+		limit.ExceptionAction = bmc.PowerLimitExceptionActionNoAction
+	case "Hard Power Off & Log Event to SEL":
+		limit.ExceptionAction = bmc.PowerLimitExceptionActionHardPowerOff
+	case "Log Event to SEL":
+		limit.ExceptionAction = bmc.PowerLimitExceptionActionLogEvent
+	}
+
+	return limit
+}
+
+// SetPowerLimit sets and activates the DCMI power limit, or deactivates it when the limit is not enabled
+func (i *Ipmi) SetPowerLimit(ctx context.Context, limit bmc.PowerLimit) (err error) {
+	if !limit.Enabled {
+		if _, err := i.run(ctx, []string{"dcmi", "power", "deactivate"}); err != nil {
+			return errors.Wrap(err, "error deactivating DCMI power limit")
+		}
+
+		return nil
+	}
+
+	commands := [][]string{
+		{"dcmi", "power", "set_limit", "limit", strconv.Itoa(int(limit.Watts))},
+	}
+
+	if limit.CorrectionTime > 0 {
+		commands = append(commands, []string{"dcmi", "power", "set_limit", "correction", strconv.FormatInt(limit.CorrectionTime.Milliseconds(), 10)})
+	}
+
+	if action := dcmiExceptionAction(limit.ExceptionAction); action != "" {
+		commands = append(commands, []string{"dcmi", "power", "set_limit", "action", action})
+	}
+
+	commands = append(commands, []string{"dcmi", "power", "activate"})
+
+	for _, command := range commands {
+		if _, err := i.run(ctx, command); err != nil {
+			return errors.Wrap(err, "error setting DCMI power limit")
+		}
+	}
+
+	return nil
+}
+
+// dcmiExceptionAction returns the ipmitool dcmi power set_limit action for the exception action.
+func dcmiExceptionAction(action bmc.PowerLimitExceptionAction) string {
+	switch action {
+	case bmc.PowerLimitExceptionActionNoAction:
+		return "no_action"
+	case bmc.PowerLimitExceptionActionHardPowerOff:
+		return "power_off"
+	case bmc.PowerLimitExceptionActionLogEvent:
+		return "sel_logging"
+	default:
+		return ""
+	}
+}
+
+// SetIdentifyLED turns the chassis identify LED on or off, a zero duration turns it on until turned off
+func (i *Ipmi) SetIdentifyLED(ctx context.Context, on bool, duration time.Duration) (err error) {
+	args, err := chassisIdentifyArgs(on, duration)
+	if err != nil {
+		return err
+	}
+
+	if _, err := i.run(ctx, args); err != nil {
+		return errors.Wrap(err, "error setting chassis identify")
+	}
+
+	return nil
+}
+
+// chassisIdentifyArgs returns the ipmitool chassis identify arguments for the requested state,
+// the identify interval is limited to 255 seconds.
+func chassisIdentifyArgs(on bool, duration time.Duration) ([]string, error) {
+	switch {
+	case !on:
+		return []string{"chassis", "identify", "0"}, nil
+	case duration == 0:
+		return []string{"chassis", "identify", "force"}, nil
+	case duration > 255*time.Second:
+		return nil, fmt.Errorf("identify LED duration %v exceeds the maximum of 255s", duration)
+	case duration < time.Second:
+		duration = time.Second
+	}
+
+	return []string{"chassis", "identify", strconv.Itoa(int(duration / time.Second))}, nil
+}
+
+// GetIdentifyLED returns true when the chassis identify LED is on
+func (i *Ipmi) GetIdentifyLED(ctx context.Context) (on bool, err error) {
+	// ipmitool chassis status does not report the identify state, read it from the raw Get Chassis Status response
+	out, err := i.run(ctx, []string{"raw", "0x00", "0x01"})
+	if err != nil {
+		return false, errors.Wrap(err, "error getting chassis status")
+	}
+
+	return parseChassisIdentifyState(out)
+}
+
+// parseChassisIdentifyState parses the identify state out of a raw Get Chassis Status response,
+// bit 6 of the miscellaneous chassis state byte indicates the state is reported, bits 5:4 hold the state.
+func parseChassisIdentifyState(raw string) (on bool, err error) {
+	fields := strings.Fields(raw)
+	if len(fields) < 3 {
+		return false, fmt.Errorf("unexpected chassis status response: %q", raw)
+	}
+
+	state, err := strconv.ParseUint(fields[2], 16, 8)
+	if err != nil {
+		return false, errors.Wrap(err, "error parsing chassis status response")
+	}
+
+	if state&0x40 == 0 {
+		return false, errors.New("chassis identify state is not reported by the BMC")
+	}
+
+	return (state&0x30)>>4 != 0, nil
+}
+
+// defaultLanChannel is the IPMI channel of the BMC management interface used when none is given.
+const defaultLanChannel = "1"
+
+// GetBMCNetwork returns the IPv4 and VLAN configuration of the BMC LAN channel.
+func (i *Ipmi) GetBMCNetwork(ctx context.Context) (config *bmc.BMCNetworkConfig, err error) {
+	out, err := i.run(ctx, []string{"lan", "print", defaultLanChannel})
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting LAN configuration")
+	}
+
+	return parseLanPrint(defaultLanChannel, out)
+}
+
+// parseLanPrint parses the output of ipmitool lan print.
+func parseLanPrint(channel, raw string) (*bmc.BMCNetworkConfig, error) {
+	fields := dcmiFields(raw)
+	if _, ok := fields["IP Address Source"]; !ok {
+		return nil, fmt.Errorf("unexpected lan print output: %q", raw)
+	}
+
+	config := &bmc.BMCNetworkConfig{
+		InterfaceID: channel,
+		MACAddress:  fields["MAC Address"],
+		DHCPv4:      strings.HasPrefix(fields["IP Address Source"], "DHCP"),
+	}
+
+	if address := fields["IP Address"]; address != "" && address != "0.0.0.0" {
+		config.IPv4 = &bmc.BMCIPv4Address{Address: address, SubnetMask: fields["Subnet Mask"]}
+		if gateway := fields["Default Gateway IP"]; gateway != "0.0.0.0" {
+			config.IPv4.Gateway = gateway
+		}
+	}
+
+	if vlan, ok := fields["802.1q VLAN ID"]; ok {
+		config.VLAN = &bmc.BMCVLAN{}
+		if id, err := strconv.Atoi(vlan); err == nil {
+			config.VLAN = &bmc.BMCVLAN{Enabled: true, ID: id}
+		}
+	}
+
+	return config, nil
+}
+
+// SetBMCNetwork applies the IPv4 and VLAN configuration to the BMC LAN channel, the InterfaceID is
+// the LAN channel number.
+func (i *Ipmi) SetBMCNetwork(ctx context.Context, config bmc.BMCNetworkConfig) (err error) {
+	commands, err := lanSetArgs(config)
+	if err != nil {
+		return err
+	}
+
+	for _, args := range commands {
+		if _, err := i.run(ctx, args); err != nil {
+			return errors.Wrap(err, "error setting LAN configuration")
+		}
+	}
+
+	return nil
+}
+
+// lanSetArgs returns the ipmitool lan set commands for the configuration.
+func lanSetArgs(config bmc.BMCNetworkConfig) (commands [][]string, err error) {
+	if config.DHCPv6 || len(config.IPv6) > 0 || config.IPv6Gateway != "" || config.DNSServers != nil || config.Hostname != "" {
+		return nil, errors.New("IPv6, DNS server and hostname settings are not supported over IPMI")
+	}
+
+	channel := defaultLanChannel
+	if config.InterfaceID != "" {
+		if _, err := strconv.ParseUint(config.InterfaceID, 10, 8); err != nil {
+			return nil, fmt.Errorf("invalid LAN channel: %q", config.InterfaceID)
+		}
+
+		channel = config.InterfaceID
+	}
+
+	lanSet := func(args ...string) []string {
+		return append([]string{"lan", "set", channel}, args...)
+	}
+
+	// The netmask, gateway and VLAN are set before the address source and address, the session runs over
+	// the LAN channel and the settings following a change of address would be sent to the old address.
+	if !config.DHCPv4 && config.IPv4 != nil {
+		commands = append(commands, lanSet("netmask", config.IPv4.SubnetMask))
+
+		if config.IPv4.Gateway != "" {
+			commands = append(commands, lanSet("defgw", "ipaddr", config.IPv4.Gateway))
+		}
+	}
+
+	if config.VLAN != nil {
+		vlan := "off"
+		if config.VLAN.Enabled {
+			vlan = strconv.Itoa(config.VLAN.ID)
+		}
+
+		commands = append(commands, lanSet("vlan", "id", vlan))
+	}
+
+	switch {
+	case config.DHCPv4:
+		commands = append(commands, lanSet("ipsrc", "dhcp"))
+	case config.IPv4 != nil:
+		commands = append(commands,
+			lanSet("ipsrc", "static"),
+			lanSet("ipaddr", config.IPv4.Address),
+		)
+	}
+
+	if len(commands) == 0 {
+		return nil, errors.New("no BMC network settings given")
+	}
+
+	return commands, nil
+}
+
 // DeactivateSOL deactivates any active SOL session, treating an already-deactivated payload as success.
 func (i *Ipmi) DeactivateSOL(ctx context.Context) (err error) {
 	out, err := i.run(ctx, []string{"sol", "deactivate"})
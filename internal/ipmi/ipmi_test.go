@@ -0,0 +1,223 @@
+package ipmi
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+func TestParseSystemEventLogEntries(t *testing.T) {
+	raw := `   1 | Pre-Init  |0000000000| Event Logging Disabled #0x07 | Log area reset/cleared | Asserted
+  1a | 05/27/2021 | 10:38:37 | Power Supply #0x51 | Failure detected | Deasserted
+ bogus line`
+
+	entries := parseSystemEventLogEntries(raw)
+	assert.Len(t, entries, 2)
+
+	assert.Equal(t, "1", entries[0].RecordID)
+	assert.True(t, entries[0].Timestamp.IsZero())
+	assert.Equal(t, "Event Logging Disabled", entries[0].SensorType)
+	assert.Equal(t, bmc.SystemEventLogEventDirectionAsserted, entries[0].EventDirection)
+
+	assert.Equal(t, "1a", entries[1].RecordID)
+	assert.Equal(t, time.Date(2021, 5, 27, 10, 38, 37, 0, time.UTC), entries[1].Timestamp)
+	assert.Equal(t, "Power Supply", entries[1].SensorType)
+	assert.Equal(t, "Power Supply #0x51", entries[1].SensorName)
+	assert.Equal(t, "Failure detected", entries[1].Message)
+	assert.Equal(t, bmc.SystemEventLogEventDirectionDeasserted, entries[1].EventDirection)
+	assert.Equal(t, bmc.SystemEventLogSeverityUnknown, entries[1].Severity)
+}
+
+func TestParseDCMIPowerReading(t *testing.T) {
+	raw := `
+    Instantaneous power reading:                   220 Watts
+    Minimum during sampling period:                 68 Watts
+    Maximum during sampling period:                522 Watts
+    Average power reading over sample period:      213 Watts
+    IPMI timestamp:                           Thu Jan 12 10:02:11 2023
+    Sampling period:                          00000005 Seconds.
+    Power reading state is:                   activated
+`
+
+	consumption, err := parseDCMIPowerReading(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, 220.0, *consumption.CurrentWatts)
+	assert.Equal(t, 68.0, *consumption.MinWatts)
+	assert.Equal(t, 522.0, *consumption.MaxWatts)
+	assert.Equal(t, 213.0, *consumption.AverageWatts)
+	assert.Equal(t, 5*time.Second, consumption.Interval)
+
+	_, err = parseDCMIPowerReading(strings.Replace(raw, "is:                   activated", "is:                   deactivated", 1))
+	assert.ErrorContains(t, err, "not active")
+
+	_, err = parseDCMIPowerReading("")
+	assert.ErrorContains(t, err, "no DCMI power reading found")
+}
+
+func TestParseDCMIPowerLimit(t *testing.T) {
+	raw := `
+    Current Limit State: Power Limit Active
+    Exception actions:   Hard Power Off & Log Event to SEL
+    Power Limit:         500   Watts
+    Correction time:     1000 milliseconds
+    Sampling period:     5 seconds
+`
+
+	assert.Equal(t, &bmc.PowerLimit{
+		Watts:           500,
+		Enabled:         true,
+		CorrectionTime:  time.Second,
+		ExceptionAction: bmc.PowerLimitExceptionActionHardPowerOff,
+	}, parseDCMIPowerLimit(raw))
+
+	assert.Nil(t, parseDCMIPowerLimit(strings.Replace(raw, "Power Limit Active", "No Active Power Limit", 1)))
+}
+
+func TestChassisIdentifyArgs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		on       bool
+		duration time.Duration
+		expected []string
+		wantErr  bool
+	}{
+		{name: "off", on: false, expected: []string{"chassis", "identify", "0"}},
+		{name: "on indefinitely", on: true, expected: []string{"chassis", "identify", "force"}},
+		{name: "on for a minute", on: true, duration: time.Minute, expected: []string{"chassis", "identify", "60"}},
+		{name: "sub second rounds up", on: true, duration: time.Millisecond, expected: []string{"chassis", "identify", "1"}},
+		{name: "exceeds maximum", on: true, duration: time.Hour, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args, err := chassisIdentifyArgs(tc.on, tc.duration)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, args)
+		})
+	}
+}
+
+func TestParseChassisIdentifyState(t *testing.T) {
+	testCases := []struct {
+		name     string
+		raw      string
+		expected bool
+		wantErr  bool
+	}{
+		{name: "off", raw: " 01 10 40 70\n", expected: false},
+		{name: "temporary on", raw: " 01 10 50 70\n", expected: true},
+		{name: "indefinite on", raw: " 21 10 60\n", expected: true},
+		{name: "not supported", raw: " 01 10 00 70\n", wantErr: true},
+		{name: "short response", raw: " 01 10\n", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			on, err := parseChassisIdentifyState(tc.raw)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, on)
+		})
+	}
+}
+
+func TestParseLanPrint(t *testing.T) {
+	raw := `Set in Progress         : Set Complete
+Auth Type Support       : NONE MD2 MD5 PASSWORD
+IP Address Source       : Static Address
+IP Address              : 10.20.30.40
+Subnet Mask             : 255.255.255.0
+MAC Address             : 00:00:5e:00:53:10
+Default Gateway IP      : 10.20.30.1
+Default Gateway MAC     : 00:00:00:00:00:00
+802.1q VLAN ID          : 120
+802.1q VLAN Priority    : 0
+`
+
+	config, err := parseLanPrint("1", raw)
+	assert.NoError(t, err)
+	assert.Equal(t, &bmc.BMCNetworkConfig{
+		InterfaceID: "1",
+		MACAddress:  "00:00:5e:00:53:10",
+		IPv4:        &bmc.BMCIPv4Address{Address: "10.20.30.40", SubnetMask: "255.255.255.0", Gateway: "10.20.30.1"},
+		VLAN:        &bmc.BMCVLAN{Enabled: true, ID: 120},
+	}, config)
+
+	raw = `IP Address Source       : DHCP Address
+IP Address              : 0.0.0.0
+MAC Address             : 00:00:5e:00:53:11
+802.1q VLAN ID          : Disabled
+`
+
+	config, err = parseLanPrint("1", raw)
+	assert.NoError(t, err)
+	assert.Equal(t, &bmc.BMCNetworkConfig{
+		InterfaceID: "1",
+		MACAddress:  "00:00:5e:00:53:11",
+		DHCPv4:      true,
+		VLAN:        &bmc.BMCVLAN{},
+	}, config)
+
+	_, err = parseLanPrint("1", "Invalid channel 1\n")
+	assert.Error(t, err)
+}
+
+func TestLanSetArgs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   bmc.BMCNetworkConfig
+		expected [][]string
+		wantErr  bool
+	}{
+		{
+			name:     "dhcp with vlan disabled",
+			config:   bmc.BMCNetworkConfig{DHCPv4: true, VLAN: &bmc.BMCVLAN{}},
+			expected: [][]string{{"lan", "set", "1", "vlan", "id", "off"}, {"lan", "set", "1", "ipsrc", "dhcp"}},
+		},
+		{
+			name: "static on channel 8",
+			config: bmc.BMCNetworkConfig{
+				InterfaceID: "8",
+				IPv4:        &bmc.BMCIPv4Address{Address: "10.0.0.10", SubnetMask: "255.255.255.0", Gateway: "10.0.0.1"},
+				VLAN:        &bmc.BMCVLAN{Enabled: true, ID: 100},
+			},
+			// the address is set last, the session is lost once the BMC moves to it
+			expected: [][]string{
+				{"lan", "set", "8", "netmask", "255.255.255.0"},
+				{"lan", "set", "8", "defgw", "ipaddr", "10.0.0.1"},
+				{"lan", "set", "8", "vlan", "id", "100"},
+				{"lan", "set", "8", "ipsrc", "static"},
+				{"lan", "set", "8", "ipaddr", "10.0.0.10"},
+			},
+		},
+		{name: "hostname", config: bmc.BMCNetworkConfig{DHCPv4: true, Hostname: "bmc"}, wantErr: true},
+		{name: "invalid channel", config: bmc.BMCNetworkConfig{InterfaceID: "eth0", DHCPv4: true}, wantErr: true},
+		{name: "no settings", config: bmc.BMCNetworkConfig{}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			commands, err := lanSetArgs(tc.config)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, commands)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Package pdu implements the machine power logic shared by the PDU providers,
+// which control the power of a machine by switching the outlets its power
+// supplies are plugged into.
+package pdu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// restoreTimeout bounds switching the outlets back on after a cancelled power cycle.
+const restoreTimeout = 30 * time.Second
+
+// SwitchFunc switches all the outlets of a machine on or off, in the order
+// the outlets are configured.
+type SwitchFunc func(ctx context.Context, on bool) error
+
+// PowerState returns "on" when any of the outlets of a machine is on, and
+// "off" when all of them are off.
+func PowerState(outletsOn []bool) string {
+	for _, on := range outletsOn {
+		if on {
+			return "on"
+		}
+	}
+
+	return "off"
+}
+
+// PowerSet sets the power state of a machine with the outlet switch.
+//
+// "on" and "off" switch the outlets, "cycle" and "reset" switch them off, wait
+// the cycle delay, and switch them on. A PDU can not shut down the machine
+// gracefully, "soft" is not supported.
+func PowerSet(ctx context.Context, log logr.Logger, switchOutlets SwitchFunc, cycleDelay time.Duration, state string) (ok bool, err error) {
+	switch strings.ToLower(state) {
+	case "on":
+		err = switchOutlets(ctx, true)
+	case "off":
+		err = switchOutlets(ctx, false)
+	case "cycle", "reset":
+		err = cycle(ctx, log, switchOutlets, cycleDelay)
+	default:
+		return false, fmt.Errorf("invalid power state: %s", state)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// cycle switches the outlets off, waits the cycle delay and switches them on.
+//
+// A cycle cancelled during the delay switches the outlets back on under a context detached from
+// the cancelled one, a cancelled power cycle does not leave the machine powered down.
+func cycle(ctx context.Context, log logr.Logger, switchOutlets SwitchFunc, delay time.Duration) error {
+	if err := switchOutlets(ctx, false); err != nil {
+		return err
+	}
+
+	log.V(1).Info("waiting for PDU power cycle delay", "delay", delay.String())
+
+	select {
+	case <-ctx.Done():
+		restoreCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), restoreTimeout)
+		defer cancel()
+
+		if err := switchOutlets(restoreCtx, true); err != nil {
+			return fmt.Errorf("power cycle cancelled, the outlets were left off: %w", errors.Join(ctx.Err(), err))
+		}
+
+		return fmt.Errorf("power cycle cancelled, the outlets were switched back on: %w", ctx.Err())
+	case <-time.After(delay):
+	}
+
+	return switchOutlets(ctx, true)
+}
@@ -0,0 +1,89 @@
+package pdu
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPowerState(t *testing.T) {
+	assert.Equal(t, "on", PowerState([]bool{false, true}))
+	assert.Equal(t, "off", PowerState([]bool{false, false}))
+}
+
+func TestPowerSet(t *testing.T) {
+	tests := map[string]struct {
+		state     string
+		switchErr error
+		want      []bool
+		err       string
+	}{
+		"on":          {state: "on", want: []bool{true}},
+		"off":         {state: "Off", want: []bool{false}},
+		"cycle":       {state: "cycle", want: []bool{false, true}},
+		"reset":       {state: "reset", want: []bool{false, true}},
+		"soft":        {state: "soft", err: "invalid power state: soft"},
+		"switch fail": {state: "cycle", switchErr: errors.New("outlet unreachable"), want: []bool{false}, err: "outlet unreachable"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var switched []bool
+
+			switchOutlets := func(_ context.Context, on bool) error {
+				switched = append(switched, on)
+				return tt.switchErr
+			}
+
+			ok, err := PowerSet(context.Background(), logr.Discard(), switchOutlets, 0, tt.state)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				assert.False(t, ok)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, ok)
+			}
+
+			assert.Equal(t, tt.want, switched)
+		})
+	}
+}
+
+func TestPowerSetCycleCancelled(t *testing.T) {
+	tests := map[string]struct {
+		restoreErr error
+		err        string
+	}{
+		"outlets switched back on": {err: "power cycle cancelled, the outlets were switched back on: context canceled"},
+		"outlets left off":         {restoreErr: errors.New("outlet unreachable"), err: "power cycle cancelled, the outlets were left off: context canceled\noutlet unreachable"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var switched []bool
+
+			switchOutlets := func(ctx context.Context, on bool) error {
+				switched = append(switched, on)
+				if on {
+					// the outlets are switched back on under a live context
+					assert.NoError(t, ctx.Err())
+					return tt.restoreErr
+				}
+
+				return nil
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := PowerSet(ctx, logr.Discard(), switchOutlets, time.Minute, "cycle")
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.EqualError(t, err, tt.err)
+			assert.Equal(t, []bool{false, true}, switched)
+		})
+	}
+}
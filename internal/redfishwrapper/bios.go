@@ -16,7 +16,7 @@ func (c *Client) GetBiosConfiguration(ctx context.Context) (biosConfig map[strin
 	}
 
 	biosConfig = make(map[string]string)
-	if !c.compatibleOdataID(sys.ODataID, knownSystemsOdataIDs) {
+	if !c.compatibleSystemOdataID(sys.ODataID) {
 		return biosConfig, nil
 	}
 
@@ -49,7 +49,7 @@ func (c *Client) SetBiosConfiguration(ctx context.Context, biosConfig map[string
 		settingsAttributes[attr] = value
 	}
 
-	if !c.compatibleOdataID(sys.ODataID, knownSystemsOdataIDs) {
+	if !c.compatibleSystemOdataID(sys.ODataID) {
 		return nil
 	}
 
@@ -69,7 +69,7 @@ func (c *Client) ResetBiosConfiguration(ctx context.Context) (err error) {
 		return err
 	}
 
-	if !c.compatibleOdataID(sys.ODataID, knownSystemsOdataIDs) {
+	if !c.compatibleSystemOdataID(sys.ODataID) {
 		return nil
 	}
 
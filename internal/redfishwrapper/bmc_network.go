@@ -0,0 +1,210 @@
+package redfishwrapper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/stmcginnis/gofish/schemas"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+var (
+	errNoBMCInterface       = errors.New("no BMC management interface found")
+	errNoBMCNetworkSettings = errors.New("no BMC network settings given")
+
+	// unassignedAddresses are the placeholder values BMCs report for unset addresses.
+	unassignedAddresses = map[string]bool{"": true, "0.0.0.0": true, "::": true}
+)
+
+// GetBMCNetwork returns the configuration of the BMC management interface, the IPv4 and IPv6
+// addresses are the addresses currently assigned, including addresses assigned through DHCP.
+func (c *Client) GetBMCNetwork(ctx context.Context) (config *bmc.BMCNetworkConfig, err error) {
+	eth, err := c.bmcInterface(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	config = &bmc.BMCNetworkConfig{
+		InterfaceID: eth.ID,
+		MACAddress:  interfaceMAC(eth),
+		Hostname:    eth.HostName,
+		DHCPv4:      eth.DHCPv4.DHCPEnabled,
+		DHCPv6: eth.DHCPv6.OperatingMode == schemas.StatefulDHCPv6OperatingMode ||
+			eth.DHCPv6.OperatingMode == schemas.EnabledDHCPv6OperatingMode,
+		IPv6Gateway: eth.IPv6DefaultGateway,
+	}
+
+	if unassignedAddresses[config.IPv6Gateway] {
+		config.IPv6Gateway = ""
+	}
+
+	for _, addr := range append(eth.IPv4Addresses, eth.IPv4StaticAddresses...) {
+		if !unassignedAddresses[addr.Address] {
+			config.IPv4 = &bmc.BMCIPv4Address{Address: addr.Address, SubnetMask: addr.SubnetMask, Gateway: addr.Gateway}
+			break
+		}
+	}
+
+	for _, addr := range eth.IPv6Addresses {
+		if unassignedAddresses[addr.Address] || addr.AddressOrigin == schemas.LinkLocalIPv6AddressOrigin {
+			continue
+		}
+
+		config.IPv6 = append(config.IPv6, bmc.BMCIPv6Address{Address: addr.Address, PrefixLength: int(addr.PrefixLength)})
+	}
+
+	for _, server := range eth.NameServers {
+		if !unassignedAddresses[server] {
+			config.DNSServers = append(config.DNSServers, server)
+		}
+	}
+
+	if hasProperty(eth.RawData, "VLAN") {
+		config.VLAN = &bmc.BMCVLAN{Enabled: eth.VLAN.VLANEnable, ID: int(eth.VLAN.VLANID)}
+	}
+
+	return config, nil
+}
+
+// SetBMCNetwork applies the configuration to the BMC management interface, settings left unset in
+// the configuration are not included in the request.
+func (c *Client) SetBMCNetwork(ctx context.Context, config bmc.BMCNetworkConfig) (err error) {
+	eth, err := c.bmcInterface(ctx, config.InterfaceID)
+	if err != nil {
+		return err
+	}
+
+	payload := bmcNetworkPayload(config)
+	if len(payload) == 0 {
+		return errNoBMCNetworkSettings
+	}
+
+	if err := c.patchIfMatch(eth.ODataID, payload); err != nil {
+		return errors.Wrap(err, "error updating the BMC ethernet interface")
+	}
+
+	return nil
+}
+
+// bmcNetworkPayload returns the EthernetInterface PATCH request payload for the configuration.
+func bmcNetworkPayload(config bmc.BMCNetworkConfig) map[string]any {
+	payload := map[string]any{}
+	dhcpv4 := map[string]any{}
+
+	switch {
+	case config.DHCPv4:
+		dhcpv4["DHCPEnabled"] = true
+	case config.IPv4 != nil:
+		dhcpv4["DHCPEnabled"] = false
+
+		address := map[string]any{
+			"Address":    config.IPv4.Address,
+			"SubnetMask": config.IPv4.SubnetMask,
+		}
+		if config.IPv4.Gateway != "" {
+			address["Gateway"] = config.IPv4.Gateway
+		}
+
+		payload["IPv4StaticAddresses"] = []map[string]any{address}
+	}
+
+	switch {
+	case config.DHCPv6:
+		payload["DHCPv6"] = map[string]any{"OperatingMode": schemas.StatefulDHCPv6OperatingMode}
+	case len(config.IPv6) > 0:
+		payload["DHCPv6"] = map[string]any{"OperatingMode": schemas.DisabledDHCPv6OperatingMode}
+
+		addresses := make([]map[string]any, 0, len(config.IPv6))
+		for _, addr := range config.IPv6 {
+			addresses = append(addresses, map[string]any{"Address": addr.Address, "PrefixLength": addr.PrefixLength})
+		}
+
+		payload["IPv6StaticAddresses"] = addresses
+	}
+
+	if config.IPv6Gateway != "" {
+		payload["IPv6StaticDefaultGateways"] = []map[string]any{{"Address": config.IPv6Gateway}}
+	}
+
+	if config.DNSServers != nil {
+		payload["StaticNameServers"] = config.DNSServers
+		if config.DHCPv4 {
+			// static name servers are only used when DHCP does not provide them
+			dhcpv4["UseDNSServers"] = false
+		}
+	}
+
+	if len(dhcpv4) > 0 {
+		payload["DHCPv4"] = dhcpv4
+	}
+
+	if config.Hostname != "" {
+		payload["HostName"] = config.Hostname
+	}
+
+	if config.VLAN != nil {
+		payload["VLAN"] = map[string]any{"VLANEnable": config.VLAN.Enabled, "VLANId": config.VLAN.ID}
+	}
+
+	return payload
+}
+
+// bmcInterface returns the manager ethernet interface with the given ID, or the first interface
+// with a MAC address when the ID is empty.
+func (c *Client) bmcInterface(ctx context.Context, id string) (*schemas.EthernetInterface, error) {
+	manager, err := c.Manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	interfaces, err := manager.EthernetInterfaces()
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying BMC ethernet interfaces")
+	}
+
+	for _, eth := range interfaces {
+		if id != "" && eth.ID == id {
+			return eth, nil
+		}
+
+		if id == "" && interfaceMAC(eth) != "" {
+			return eth, nil
+		}
+	}
+
+	if id != "" {
+		return nil, fmt.Errorf("%w: %s", errNoBMCInterface, id)
+	}
+
+	return nil, errNoBMCInterface
+}
+
+// interfaceMAC returns the MAC address of the interface, falling back to the permanent MAC address
+// when the current one is not reported, or empty when neither is.
+func interfaceMAC(eth *schemas.EthernetInterface) string {
+	mac := eth.MACAddress
+	if mac == "" || mac == "00:00:00:00:00:00" {
+		mac = eth.PermanentMACAddress
+	}
+
+	if mac == "00:00:00:00:00:00" {
+		return ""
+	}
+
+	return mac
+}
+
+// hasProperty returns true when the raw resource includes the property.
+func hasProperty(raw []byte, property string) bool {
+	properties := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &properties); err != nil {
+		return false
+	}
+
+	_, ok := properties[property]
+
+	return ok
+}
@@ -0,0 +1,117 @@
+package redfishwrapper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// newBMCNetworkTestClient returns a client for a mock BMC serving the manager ethernet interfaces.
+func newBMCNetworkTestClient(t *testing.T, patches map[string]map[string]any) *Client {
+	t.Helper()
+
+	return newPatchRecordingClient(t, map[string]string{
+		"/redfish/v1/Managers":                           "managers.json",
+		"/redfish/v1/Managers/1":                         "managers_1.json",
+		"/redfish/v1/Managers/1/EthernetInterfaces":      "bmc_network/ethernet_interfaces.json",
+		"/redfish/v1/Managers/1/EthernetInterfaces/usb0": "bmc_network/ethernet_usb0.json",
+		"/redfish/v1/Managers/1/EthernetInterfaces/1":    "bmc_network/ethernet_1.json",
+	}, patches)
+}
+
+func TestGetBMCNetwork(t *testing.T) {
+	client := newBMCNetworkTestClient(t, map[string]map[string]any{})
+
+	config, err := client.GetBMCNetwork(context.Background())
+	require.NoError(t, err)
+
+	expected := &bmc.BMCNetworkConfig{
+		InterfaceID: "1",
+		MACAddress:  "00:00:5e:00:53:10",
+		Hostname:    "bmc-r12-u07",
+		IPv4:        &bmc.BMCIPv4Address{Address: "10.20.30.40", SubnetMask: "255.255.255.0", Gateway: "10.20.30.1"},
+		IPv6:        []bmc.BMCIPv6Address{{Address: "fd00:20:30::40", PrefixLength: 64}},
+		DNSServers:  []string{"10.20.0.2"},
+		VLAN:        &bmc.BMCVLAN{Enabled: true, ID: 120},
+	}
+	assert.Equal(t, expected, config)
+}
+
+func TestSetBMCNetwork(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   bmc.BMCNetworkConfig
+		expected map[string]any
+		errMsg   string
+	}{
+		{
+			name: "static ipv4",
+			config: bmc.BMCNetworkConfig{
+				IPv4:       &bmc.BMCIPv4Address{Address: "10.40.0.12", SubnetMask: "255.255.252.0", Gateway: "10.40.0.1"},
+				DNSServers: []string{"10.40.0.2", "10.40.0.3"},
+				VLAN:       &bmc.BMCVLAN{Enabled: false},
+			},
+			expected: map[string]any{
+				"DHCPv4": map[string]any{"DHCPEnabled": false},
+				"IPv4StaticAddresses": []any{
+					map[string]any{"Address": "10.40.0.12", "SubnetMask": "255.255.252.0", "Gateway": "10.40.0.1"},
+				},
+				"StaticNameServers": []any{"10.40.0.2", "10.40.0.3"},
+				"VLAN":              map[string]any{"VLANEnable": false, "VLANId": 0.0},
+			},
+		},
+		{
+			name:   "dhcp with static dns and hostname",
+			config: bmc.BMCNetworkConfig{DHCPv4: true, DHCPv6: true, DNSServers: []string{"10.40.0.2"}, Hostname: "bmc-r14-u01"},
+			expected: map[string]any{
+				"DHCPv4":            map[string]any{"DHCPEnabled": true, "UseDNSServers": false},
+				"DHCPv6":            map[string]any{"OperatingMode": "Stateful"},
+				"StaticNameServers": []any{"10.40.0.2"},
+				"HostName":          "bmc-r14-u01",
+			},
+		},
+		{
+			name: "static ipv6",
+			config: bmc.BMCNetworkConfig{
+				IPv6:        []bmc.BMCIPv6Address{{Address: "fd00:40::12", PrefixLength: 64}},
+				IPv6Gateway: "fd00:40::1",
+			},
+			expected: map[string]any{
+				"DHCPv6":                    map[string]any{"OperatingMode": "Disabled"},
+				"IPv6StaticAddresses":       []any{map[string]any{"Address": "fd00:40::12", "PrefixLength": 64.0}},
+				"IPv6StaticDefaultGateways": []any{map[string]any{"Address": "fd00:40::1"}},
+			},
+		},
+		{
+			name:   "unknown interface",
+			config: bmc.BMCNetworkConfig{InterfaceID: "2", DHCPv4: true},
+			errMsg: "no BMC management interface found: 2",
+		},
+		{
+			name:   "no settings",
+			config: bmc.BMCNetworkConfig{},
+			errMsg: errNoBMCNetworkSettings.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			patches := map[string]map[string]any{}
+			client := newBMCNetworkTestClient(t, patches)
+
+			err := client.SetBMCNetwork(context.Background(), tc.config)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+				assert.Empty(t, patches)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, map[string]map[string]any{"/redfish/v1/Managers/1/EthernetInterfaces/1": tc.expected}, patches)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package redfishwrapper
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/stmcginnis/gofish/schemas"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+var errNoBootOrder = errors.New("system does not report a persistent boot order")
+
+// GetBootOrder returns the persistent boot order of the system, each entry is described by the
+// matching resource in the BootOptions collection when the system links one.
+func (c *Client) GetBootOrder(ctx context.Context) (order []bmc.BootOption, err error) {
+	system, err := c.bootOrderSystem()
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := c.BootOptions(system)
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying boot options")
+	}
+
+	order = make([]bmc.BootOption, 0, len(system.Boot.BootOrder))
+	for _, reference := range system.Boot.BootOrder {
+		option, ok := options[reference]
+		if !ok {
+			// boot options are enabled unless stated otherwise
+			order = append(order, bmc.BootOption{Reference: reference, Enabled: true})
+			continue
+		}
+
+		order = append(order, bmc.BootOption{
+			Reference:      reference,
+			DisplayName:    option.DisplayName,
+			UefiDevicePath: option.UefiDevicePath,
+			Enabled:        option.BootOptionEnabled,
+		})
+	}
+
+	return order, nil
+}
+
+// SetBootOrder moves the given boot options to the front of the persistent boot order.
+//
+// The boot order is written to the system resource, when that is rejected and the system
+// advertises a settings resource the boot order is staged there instead.
+func (c *Client) SetBootOrder(ctx context.Context, order []string) (err error) {
+	return c.setBootOrder(order, false)
+}
+
+// SetPendingBootOrder moves the given boot options to the front of the persistent boot order,
+// writing it to the settings resource of the system, for implementations that reject boot order
+// changes on the system resource.
+func (c *Client) SetPendingBootOrder(ctx context.Context, order []string) (err error) {
+	return c.setBootOrder(order, true)
+}
+
+func (c *Client) setBootOrder(order []string, pending bool) error {
+	system, err := c.bootOrderSystem()
+	if err != nil {
+		return err
+	}
+
+	bootOrder, err := bmc.CompleteBootOrder(system.Boot.BootOrder, order)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{"Boot": map[string]any{"BootOrder": bootOrder}}
+	settings := settingsObject(system)
+
+	if !pending || settings == "" {
+		err = c.patchIfMatch(system.ODataID, payload)
+		if err == nil || settings == "" {
+			return err
+		}
+	}
+
+	return c.patchIfMatch(settings, payload)
+}
+
+// BootOptions returns the boot options of the system indexed by their boot option reference,
+// the result is empty when the system does not link a BootOptions collection.
+func (c *Client) BootOptions(system *schemas.ComputerSystem) (map[string]*schemas.BootOption, error) {
+	var raw struct {
+		Boot struct {
+			BootOptions struct {
+				ODataID string `json:"@odata.id"`
+			} `json:"BootOptions"`
+		} `json:"Boot"`
+	}
+
+	options := map[string]*schemas.BootOption{}
+	if err := json.Unmarshal(system.RawData, &raw); err != nil || raw.Boot.BootOptions.ODataID == "" {
+		return options, nil
+	}
+
+	list, err := system.BootOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, option := range list {
+		options[option.BootOptionReference] = option
+	}
+
+	return options, nil
+}
+
+// bootOrderSystem returns the system, or an error when it does not report a boot order.
+func (c *Client) bootOrderSystem() (*schemas.ComputerSystem, error) {
+	if err := c.SessionActive(); err != nil {
+		return nil, errors.Wrap(bmclibErrs.ErrNotAuthenticated, err.Error())
+	}
+
+	system, err := c.System()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(system.Boot.BootOrder) == 0 {
+		return nil, errNoBootOrder
+	}
+
+	return system, nil
+}
+
+// settingsObject returns the @Redfish.Settings settings resource of the system, if any.
+func settingsObject(system *schemas.ComputerSystem) string {
+	var raw struct {
+		Settings struct {
+			SettingsObject struct {
+				ODataID string `json:"@odata.id"`
+			} `json:"SettingsObject"`
+		} `json:"@Redfish.Settings"`
+	}
+
+	if err := json.Unmarshal(system.RawData, &raw); err != nil {
+		return ""
+	}
+
+	if raw.Settings.SettingsObject.ODataID == system.ODataID {
+		return ""
+	}
+
+	return raw.Settings.SettingsObject.ODataID
+}
+
+// patchIfMatch PATCHes the resource, including the If-Match header with the current resource
+// ETag unless the ETag match is disabled.
+func (c *Client) patchIfMatch(odataID string, payload any) error {
+	headers := map[string]string{}
+
+	if !c.disableEtagMatch {
+		resp, err := c.client.Get(odataID)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			headers["If-Match"] = etag
+		}
+	}
+
+	resp, err := c.client.PatchWithHeaders(odataID, payload, headers)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
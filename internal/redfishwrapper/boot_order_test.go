@@ -0,0 +1,96 @@
+package redfishwrapper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// newBootOrderTestClient returns a client for a mock BMC serving the given system fixture.
+func newBootOrderTestClient(t *testing.T, systemFixture string, patches map[string]map[string]any) *Client {
+	t.Helper()
+
+	return newPatchRecordingClient(t, map[string]string{
+		"/redfish/v1/Systems/1":                      systemFixture,
+		"/redfish/v1/Systems/1/Pending":              "boot_order/pending.json",
+		"/redfish/v1/Systems/1/BootOptions":          "boot_order/bootoptions.json",
+		"/redfish/v1/Systems/1/BootOptions/Boot0000": "boot_order/bootoption_0000.json",
+		"/redfish/v1/Systems/1/BootOptions/Boot0001": "boot_order/bootoption_0001.json",
+	}, patches)
+}
+
+func TestGetBootOrder(t *testing.T) {
+	client := newBootOrderTestClient(t, "boot_order/systems_1.json", map[string]map[string]any{})
+
+	order, err := client.GetBootOrder(context.Background())
+	require.NoError(t, err)
+
+	expected := []bmc.BootOption{
+		{
+			Reference:      "Boot0000",
+			DisplayName:    "Samsung SSD 980 PRO",
+			UefiDevicePath: "PciRoot(0x0)/Pci(0x1,0x1)/Pci(0x0,0x0)/NVMe(0x1,00-25-38-B2-11-A4-52-71)",
+			Enabled:        true,
+		},
+		{
+			Reference:      "Boot0001",
+			DisplayName:    "UEFI PXEv4 (MAC:3CECEF0A1B2C)",
+			UefiDevicePath: "PciRoot(0x0)/Pci(0x1C,0x0)/Pci(0x0,0x0)/MAC(3CECEF0A1B2C,0x1)/IPv4(0.0.0.0)",
+			Enabled:        false,
+		},
+		{
+			// listed in the boot order without a boot option resource
+			Reference: "Boot0002",
+			Enabled:   true,
+		},
+	}
+	assert.Equal(t, expected, order)
+}
+
+func TestGetBootOrderNotReported(t *testing.T) {
+	client := newBootOrderTestClient(t, "boot_order/systems_1_no_boot_order.json", map[string]map[string]any{})
+
+	_, err := client.GetBootOrder(context.Background())
+	assert.ErrorIs(t, err, errNoBootOrder)
+}
+
+func TestSetBootOrder(t *testing.T) {
+	expected := map[string]any{
+		"Boot": map[string]any{
+			"BootOrder": []any{"Boot0001", "Boot0000", "Boot0002"},
+		},
+	}
+
+	t.Run("system resource", func(t *testing.T) {
+		patches := map[string]map[string]any{}
+		client := newBootOrderTestClient(t, "boot_order/systems_1.json", patches)
+
+		err := client.SetBootOrder(context.Background(), []string{"Boot0001"})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]map[string]any{"/redfish/v1/Systems/1": expected}, patches)
+	})
+
+	t.Run("settings resource", func(t *testing.T) {
+		patches := map[string]map[string]any{}
+		client := newBootOrderTestClient(t, "boot_order/systems_1.json", patches)
+
+		err := client.SetPendingBootOrder(context.Background(), []string{"Boot0001"})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]map[string]any{"/redfish/v1/Systems/1/Pending": expected}, patches)
+	})
+
+	t.Run("unknown boot option", func(t *testing.T) {
+		patches := map[string]map[string]any{}
+		client := newBootOrderTestClient(t, "boot_order/systems_1.json", patches)
+
+		err := client.SetBootOrder(context.Background(), []string{"Boot0009"})
+		assert.ErrorContains(t, err, "unknown boot option")
+		assert.Empty(t, patches)
+	})
+}
@@ -0,0 +1,117 @@
+package redfishwrapper
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jacobweinstock/registrar"
+	"github.com/pkg/errors"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/providers"
+)
+
+var (
+	// userFeatures require the service root to link an AccountService.
+	userFeatures = []registrar.Feature{
+		providers.FeatureUserCreate,
+		providers.FeatureUserUpdate,
+		providers.FeatureUserDelete,
+		providers.FeatureUserRead,
+	}
+
+	// firmwareFeatures require an enabled UpdateService.
+	firmwareFeatures = []registrar.Feature{
+		providers.FeatureFirmwareInstallSteps,
+		providers.FeatureFirmwareTaskStatus,
+		providers.FeatureFirmwareInstallUploaded,
+	}
+
+	// firmwarePushFeatures require an enabled UpdateService with a push URI to upload the firmware to.
+	firmwarePushFeatures = []registrar.Feature{
+		providers.FeatureFirmwareInstall,
+		providers.FeatureFirmwareUpload,
+		providers.FeatureFirmwareUploadInitiateInstall,
+	}
+)
+
+// ProbeCapabilities probes the service root, UpdateService and Manager for the features supported
+// by the BMC, and reads the BMC firmware version.
+//
+// Virtual media is reported supported when the BMC lists VirtualMedia slots. A BMC that lists the
+// slots but requires a license to insert media is not detected, the license is only enforced by
+// the insert, and the InsertMedia action is not probed as media is also inserted by a PATCH on
+// BMCs without the action.
+func (c *Client) ProbeCapabilities(ctx context.Context) (*bmc.ProbedCapabilities, error) {
+	service, err := c.ServiceRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	probed := &bmc.ProbedCapabilities{Features: make(map[registrar.Feature]bool)}
+
+	accountService, err := service.AccountService()
+	setFeatures(probed.Features, userFeatures, err == nil && accountService != nil)
+
+	updateService, err := service.UpdateService()
+	updateEnabled := err == nil && updateService != nil && serviceEnabled(updateService.RawData)
+	setFeatures(probed.Features, firmwareFeatures, updateEnabled)
+	setFeatures(probed.Features, firmwarePushFeatures, updateEnabled &&
+		(updateService.MultipartHTTPPushURI != "" || updateService.HTTPPushURI != "")) //nolint:staticcheck // HTTPPushURI is deprecated but still required for older Redfish implementations
+
+	manager, err := c.Manager(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying the manager")
+	}
+
+	probed.FirmwareVersion = manager.FirmwareVersion
+
+	resettable, err := managerResettable(manager.RawData)
+	if err != nil {
+		return nil, err
+	}
+
+	probed.Features[providers.FeatureBmcReset] = resettable
+
+	_, err = c.getVirtualMedia(ctx)
+	probed.Features[providers.FeatureVirtualMedia] = err == nil
+
+	return probed, nil
+}
+
+// managerResettable returns true when the manager resource lists the Manager.Reset action.
+func managerResettable(raw []byte) (bool, error) {
+	var manager struct {
+		Actions struct {
+			Reset struct {
+				Target string `json:"target"`
+			} `json:"#Manager.Reset"`
+		}
+	}
+
+	if err := json.Unmarshal(raw, &manager); err != nil {
+		return false, errors.Wrap(err, "error decoding the manager actions")
+	}
+
+	return manager.Actions.Reset.Target != "", nil
+}
+
+// serviceEnabled returns false when the service resource sets ServiceEnabled to false, the property
+// is optional and a service without it is enabled.
+func serviceEnabled(raw []byte) bool {
+	var service struct {
+		ServiceEnabled *bool
+	}
+
+	if err := json.Unmarshal(raw, &service); err != nil {
+		return false
+	}
+
+	return service.ServiceEnabled == nil || *service.ServiceEnabled
+}
+
+func setFeatures(probed map[registrar.Feature]bool, features []registrar.Feature, supported bool) {
+	for _, feature := range features {
+		probed[feature] = supported
+	}
+}
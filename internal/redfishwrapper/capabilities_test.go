@@ -0,0 +1,102 @@
+package redfishwrapper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jacobweinstock/registrar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmc-toolbox/bmclib/v2/providers"
+)
+
+func TestProbeCapabilities(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fixtures map[string]string
+		expected map[registrar.Feature]bool
+	}{
+		{
+			name: "licensed BMC",
+			fixtures: map[string]string{
+				"/redfish/v1/AccountService":                           "directory_service/accountservice.json",
+				"/redfish/v1/UpdateService":                            "updateservice_with_multipart.json",
+				"/redfish/v1/Managers/1/VirtualMedia":                  "dell/virtualmedia_collection.json",
+				"/redfish/v1/Systems/System.Embedded.1/VirtualMedia/1": "dell/virtualmedia_1.json",
+				"/redfish/v1/Systems/System.Embedded.1/VirtualMedia/2": "dell/virtualmedia_2.json",
+			},
+			expected: map[registrar.Feature]bool{
+				providers.FeatureUserCreate:                    true,
+				providers.FeatureUserUpdate:                    true,
+				providers.FeatureUserDelete:                    true,
+				providers.FeatureUserRead:                      true,
+				providers.FeatureFirmwareInstallSteps:          true,
+				providers.FeatureFirmwareTaskStatus:            true,
+				providers.FeatureFirmwareInstallUploaded:       true,
+				providers.FeatureFirmwareInstall:               true,
+				providers.FeatureFirmwareUpload:                true,
+				providers.FeatureFirmwareUploadInitiateInstall: true,
+				providers.FeatureBmcReset:                      true,
+				providers.FeatureVirtualMedia:                  true,
+			},
+		},
+		{
+			name: "no virtual media and update service disabled",
+			fixtures: map[string]string{
+				"/redfish/v1/AccountService": "directory_service/accountservice.json",
+				"/redfish/v1/UpdateService":  "updateservice_disabled.json",
+			},
+			expected: map[registrar.Feature]bool{
+				providers.FeatureUserCreate:                    true,
+				providers.FeatureUserUpdate:                    true,
+				providers.FeatureUserDelete:                    true,
+				providers.FeatureUserRead:                      true,
+				providers.FeatureFirmwareInstallSteps:          false,
+				providers.FeatureFirmwareTaskStatus:            false,
+				providers.FeatureFirmwareInstallUploaded:       false,
+				providers.FeatureFirmwareInstall:               false,
+				providers.FeatureFirmwareUpload:                false,
+				providers.FeatureFirmwareUploadInitiateInstall: false,
+				providers.FeatureBmcReset:                      true,
+				providers.FeatureVirtualMedia:                  false,
+			},
+		},
+		{
+			name: "update service without ServiceEnabled",
+			fixtures: map[string]string{
+				"/redfish/v1/AccountService": "directory_service/accountservice.json",
+				"/redfish/v1/UpdateService":  "updateservice_no_service_enabled.json",
+			},
+			expected: map[registrar.Feature]bool{
+				providers.FeatureUserCreate:                    true,
+				providers.FeatureUserUpdate:                    true,
+				providers.FeatureUserDelete:                    true,
+				providers.FeatureUserRead:                      true,
+				providers.FeatureFirmwareInstallSteps:          true,
+				providers.FeatureFirmwareTaskStatus:            true,
+				providers.FeatureFirmwareInstallUploaded:       true,
+				providers.FeatureFirmwareInstall:               true,
+				providers.FeatureFirmwareUpload:                true,
+				providers.FeatureFirmwareUploadInitiateInstall: true,
+				providers.FeatureBmcReset:                      true,
+				providers.FeatureVirtualMedia:                  false,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.fixtures["/redfish/v1/Managers"] = "managers.json"
+			tc.fixtures["/redfish/v1/Managers/1"] = "managers_1.json"
+
+			client := newPatchRecordingClient(t, tc.fixtures, map[string]map[string]any{})
+
+			probed, err := client.ProbeCapabilities(context.Background())
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, probed.Features)
+			assert.Equal(t, "01.13.04", probed.FirmwareVersion)
+		})
+	}
+}
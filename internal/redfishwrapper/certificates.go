@@ -0,0 +1,251 @@
+package redfishwrapper
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stmcginnis/gofish/schemas"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+var (
+	errNoCertificateService = errors.New("CertificateService is not supported")
+	errNoHTTPSCertificate   = errors.New("no BMC HTTPS certificate found")
+)
+
+// GenerateCSR has the BMC generate a key pair and returns the PEM encoded certificate signing request.
+//
+// The action parameters are posted as is, gofish sends the CertificateCollection link as a plain
+// string which BMCs reject.
+func (c *Client) GenerateCSR(ctx context.Context, request bmc.CSRRequest) (csr string, err error) {
+	service, err := c.certificateService()
+	if err != nil {
+		return "", err
+	}
+
+	collection := request.CertificateCollection
+	if collection == "" {
+		if collection, err = c.httpsCertificateCollection(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	resp, err := c.PostWithHeaders(ctx, service.ODataID+"/Actions/CertificateService.GenerateCSR", csrPayload(collection, request), nil)
+	if err != nil {
+		return "", errors.Wrap(err, "error generating the certificate signing request")
+	}
+	defer resp.Body.Close()
+
+	response := struct {
+		CSRString string `json:"CSRString"`
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", errors.Wrap(err, "error decoding the GenerateCSR response")
+	}
+
+	if response.CSRString == "" {
+		return "", errors.New("no certificate signing request in the GenerateCSR response")
+	}
+
+	return response.CSRString, nil
+}
+
+// csrPayload returns the GenerateCSR action parameters for the request.
+func csrPayload(collection string, request bmc.CSRRequest) map[string]any {
+	payload := map[string]any{
+		"CertificateCollection": map[string]string{"@odata.id": collection},
+		"CommonName":            request.CommonName,
+	}
+
+	optional := map[string]string{
+		"Organization":       request.Organization,
+		"OrganizationalUnit": request.OrganizationalUnit,
+		"City":               request.City,
+		"State":              request.State,
+		"Country":            request.Country,
+		"Email":              request.Email,
+		"KeyPairAlgorithm":   request.KeyPairAlgorithm,
+		"KeyCurveId":         request.KeyCurveID,
+	}
+
+	for property, value := range optional {
+		if value != "" {
+			payload[property] = value
+		}
+	}
+
+	if len(request.AlternativeNames) > 0 {
+		payload["AlternativeNames"] = request.AlternativeNames
+	}
+
+	if request.KeyBitLength > 0 {
+		payload["KeyBitLength"] = request.KeyBitLength
+	}
+
+	return payload
+}
+
+// ReplaceCertificate installs the PEM encoded certificate in place of the certificate with the given
+// resource URI, or in place of the BMC HTTPS certificate when the URI is empty.
+func (c *Client) ReplaceCertificate(ctx context.Context, certificateID, certificate string) (err error) {
+	service, err := c.certificateService()
+	if err != nil {
+		return err
+	}
+
+	if certificateID == "" {
+		if certificateID, err = c.httpsCertificate(ctx, service); err != nil {
+			return err
+		}
+	}
+
+	payload := map[string]any{
+		"CertificateString": certificate,
+		"CertificateType":   schemas.PEMCertificateType,
+		"CertificateUri":    map[string]string{"@odata.id": certificateID},
+	}
+
+	resp, err := c.PostWithHeaders(ctx, service.ODataID+"/Actions/CertificateService.ReplaceCertificate", payload, nil)
+	if err != nil {
+		return errors.Wrap(err, "error replacing the certificate")
+	}
+
+	return resp.Body.Close()
+}
+
+// ListCertificates returns the certificates listed in the CertificateService certificate locations,
+// ordered by their resource URI.
+func (c *Client) ListCertificates(ctx context.Context) (certificates []bmc.Certificate, err error) {
+	service, err := c.certificateService()
+	if err != nil {
+		return nil, err
+	}
+
+	installed, err := c.installedCertificates(service)
+	if err != nil {
+		return nil, err
+	}
+
+	certificates = make([]bmc.Certificate, 0, len(installed))
+	for _, certificate := range installed {
+		certificates = append(certificates, toCertificate(certificate))
+	}
+
+	// the certificates are retrieved concurrently and returned in no particular order
+	slices.SortFunc(certificates, func(a, b bmc.Certificate) int { return strings.Compare(a.ID, b.ID) })
+
+	return certificates, nil
+}
+
+// toCertificate converts a Redfish certificate resource.
+func toCertificate(certificate *schemas.Certificate) bmc.Certificate {
+	converted := bmc.Certificate{
+		ID:           certificate.ODataID,
+		Type:         string(certificate.CertificateType),
+		Subject:      certificateName(certificate.Subject),
+		Issuer:       certificateName(certificate.Issuer),
+		SerialNumber: certificate.SerialNumber,
+		Fingerprint:  certificate.Fingerprint,
+		PEM:          certificate.CertificateString,
+	}
+
+	// the validity dates are left unset when the BMC reports them in an unexpected format
+	converted.ValidNotBefore, _ = time.Parse(time.RFC3339, certificate.ValidNotBefore)
+	converted.ValidNotAfter, _ = time.Parse(time.RFC3339, certificate.ValidNotAfter)
+
+	for _, usage := range certificate.CertificateUsageTypes {
+		converted.Usage = append(converted.Usage, string(usage))
+	}
+
+	for _, usage := range certificate.KeyUsage {
+		converted.KeyUsage = append(converted.KeyUsage, string(usage))
+	}
+
+	return converted
+}
+
+// certificateName returns the common name of the certificate subject or issuer, falling back to the
+// display string.
+func certificateName(identifier schemas.CertificateIdentifier) string {
+	if identifier.CommonName != "" {
+		return identifier.CommonName
+	}
+
+	return identifier.DisplayString
+}
+
+// certificateService returns the CertificateService.
+func (c *Client) certificateService() (*schemas.CertificateService, error) {
+	if err := c.SessionActive(); err != nil {
+		return nil, errors.Wrap(bmclibErrs.ErrNotAuthenticated, err.Error())
+	}
+
+	service, err := c.client.Service.CertificateService()
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying the CertificateService")
+	}
+
+	if service == nil {
+		return nil, errNoCertificateService
+	}
+
+	return service, nil
+}
+
+// installedCertificates returns the certificates listed in the CertificateService certificate locations.
+func (c *Client) installedCertificates(service *schemas.CertificateService) ([]*schemas.Certificate, error) {
+	locations, err := service.CertificateLocations()
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying the certificate locations")
+	}
+
+	if locations == nil {
+		return nil, nil
+	}
+
+	certificates, err := locations.Certificates()
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying the certificates")
+	}
+
+	return certificates, nil
+}
+
+// httpsCertificateCollection returns the URI of the manager HTTPS certificate collection, as defined
+// by the Redfish specification.
+func (c *Client) httpsCertificateCollection(ctx context.Context) (string, error) {
+	manager, err := c.Manager(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return manager.ODataID + "/NetworkProtocol/HTTPS/Certificates", nil
+}
+
+// httpsCertificate returns the URI of the first certificate in the manager HTTPS certificate collection.
+func (c *Client) httpsCertificate(ctx context.Context, service *schemas.CertificateService) (string, error) {
+	collection, err := c.httpsCertificateCollection(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	installed, err := c.installedCertificates(service)
+	if err != nil {
+		return "", err
+	}
+
+	for _, certificate := range installed {
+		if strings.HasPrefix(certificate.ODataID, collection+"/") {
+			return certificate.ODataID, nil
+		}
+	}
+
+	return "", errNoHTTPSCertificate
+}
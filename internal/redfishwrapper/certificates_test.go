@@ -0,0 +1,174 @@
+package redfishwrapper
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+const testCSR = "-----BEGIN CERTIFICATE REQUEST-----\nMIICvDCCAaQCAQAwdzELMAkGA1UEBhMC\n-----END CERTIFICATE REQUEST-----\n"
+
+// newCertificateTestClient returns an opened client for a mock BMC serving the CertificateService,
+// the CertificateService action request bodies are recorded in actions by action name.
+func newCertificateTestClient(t *testing.T, actions map[string]map[string]any) *Client {
+	t.Helper()
+
+	fixtures := map[string]string{
+		"/redfish/v1/":                                                "serviceroot.json",
+		"/redfish/v1/Managers":                                        "managers.json",
+		"/redfish/v1/Managers/1":                                      "managers_1.json",
+		"/redfish/v1/CertificateService":                              "certificates/certificateservice.json",
+		"/redfish/v1/CertificateService/CertificateLocations":         "certificates/certificatelocations.json",
+		"/redfish/v1/AccountService/LDAP/Certificates/1":              "certificates/ldap_1.json",
+		"/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates/1": "certificates/https_1.json",
+	}
+
+	mux := http.NewServeMux()
+	for path, fixture := range fixtures {
+		mux.HandleFunc(path, endpointFunc(t, fixture))
+	}
+
+	for _, action := range []string{"GenerateCSR", "ReplaceCertificate"} {
+		mux.HandleFunc("/redfish/v1/CertificateService/Actions/CertificateService."+action, func(w http.ResponseWriter, r *http.Request) {
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			payload := map[string]any{}
+			require.NoError(t, json.Unmarshal(b, &payload))
+			actions[action] = payload
+
+			if action == "GenerateCSR" {
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"CSRString":             testCSR,
+					"CertificateCollection": map[string]string{"@odata.id": "/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates"},
+				})
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	client := NewClient(u.Hostname(), u.Port(), "", "", WithBasicAuthEnabled(true))
+	require.NoError(t, client.Open(context.Background()))
+	t.Cleanup(func() { _ = client.Close(context.Background()) })
+
+	return client
+}
+
+func TestGenerateCSR(t *testing.T) {
+	testCases := []struct {
+		name     string
+		request  bmc.CSRRequest
+		expected map[string]any
+	}{
+		{
+			name:    "https certificate collection",
+			request: bmc.CSRRequest{CommonName: "bmc-r12-u07.example.com", AlternativeNames: []string{"10.20.30.40"}, Country: "NL", KeyBitLength: 2048},
+			expected: map[string]any{
+				"CertificateCollection": map[string]any{"@odata.id": "/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates"},
+				"CommonName":            "bmc-r12-u07.example.com",
+				"AlternativeNames":      []any{"10.20.30.40"},
+				"Country":               "NL",
+				"KeyBitLength":          2048.0,
+			},
+		},
+		{
+			name:    "given certificate collection",
+			request: bmc.CSRRequest{CertificateCollection: "/redfish/v1/AccountService/LDAP/Certificates", CommonName: "bmc-r12-u07"},
+			expected: map[string]any{
+				"CertificateCollection": map[string]any{"@odata.id": "/redfish/v1/AccountService/LDAP/Certificates"},
+				"CommonName":            "bmc-r12-u07",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actions := map[string]map[string]any{}
+			client := newCertificateTestClient(t, actions)
+
+			csr, err := client.GenerateCSR(context.Background(), tc.request)
+			require.NoError(t, err)
+			assert.Equal(t, testCSR, csr)
+			assert.Equal(t, tc.expected, actions["GenerateCSR"])
+		})
+	}
+}
+
+func TestReplaceCertificate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		certificateID string
+		expectedURI   string
+	}{
+		{
+			name:        "https certificate",
+			expectedURI: "/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates/1",
+		},
+		{
+			name:          "given certificate",
+			certificateID: "/redfish/v1/AccountService/LDAP/Certificates/1",
+			expectedURI:   "/redfish/v1/AccountService/LDAP/Certificates/1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actions := map[string]map[string]any{}
+			client := newCertificateTestClient(t, actions)
+
+			err := client.ReplaceCertificate(context.Background(), tc.certificateID, "certificate")
+			require.NoError(t, err)
+
+			expected := map[string]any{
+				"CertificateString": "certificate",
+				"CertificateType":   "PEM",
+				"CertificateUri":    map[string]any{"@odata.id": tc.expectedURI},
+			}
+			assert.Equal(t, expected, actions["ReplaceCertificate"])
+		})
+	}
+}
+
+func TestListCertificates(t *testing.T) {
+	client := newCertificateTestClient(t, map[string]map[string]any{})
+
+	certificates, err := client.ListCertificates(context.Background())
+	require.NoError(t, err)
+	require.Len(t, certificates, 2)
+
+	assert.Equal(t, bmc.Certificate{
+		ID:             "/redfish/v1/AccountService/LDAP/Certificates/1",
+		Type:           "PEM",
+		Subject:        "ldap.example.com",
+		Issuer:         "Example Root CA",
+		ValidNotBefore: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		ValidNotAfter:  time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Usage:          []string{"User"},
+	}, certificates[0])
+
+	https := certificates[1]
+	assert.Equal(t, "CN=AMI-BMC, O=American Megatrends International LLC", https.Subject)
+	assert.Equal(t, "AMI-BMC", https.Issuer)
+	assert.Equal(t, "6c:7e:18:2c", https.SerialNumber)
+	assert.Equal(t, []string{"Web"}, https.Usage)
+	assert.Equal(t, []string{"DigitalSignature", "KeyEncipherment", "ServerAuthentication"}, https.KeyUsage)
+	assert.Contains(t, https.PEM, "BEGIN CERTIFICATE")
+}
@@ -35,12 +35,15 @@ type Client struct {
 	user                  string
 	pass                  string
 	systemName            string
+	systemsOdataIDPrefix  string
+	ignoredIDPrefix       string
 	basicAuth             bool
 	disableEtagMatch      bool
 	versionsNotCompatible []string // a slice of redfish versions to ignore as incompatible
 	client                *gofish.APIClient
 	httpClient            *http.Client
 	httpClientSetupFuncs  []func(*http.Client)
+	session               *sessionTransport
 	logger                logr.Logger
 }
 
@@ -101,6 +104,31 @@ func WithSystemName(name string) Option {
 	}
 }
 
+// WithSystemsOdataIDPrefix accepts Systems with an Odata ID starting with the
+// prefix in addition to the known Systems Odata IDs, for BMCs that derive the
+// System Odata ID from the serial number of the system.
+func WithSystemsOdataIDPrefix(prefix string) Option {
+	return func(c *Client) {
+		c.systemsOdataIDPrefix = prefix
+	}
+}
+
+// WithIgnoredIDPrefix ignores the Systems and Managers with an Id starting with
+// the prefix when selecting the System and Manager to operate on, for BMCs that
+// aggregate the resources of another management controller into their service,
+// e.g. the "HGX_" resources of an NVIDIA HGX HMC.
+func WithIgnoredIDPrefix(prefix string) Option {
+	return func(c *Client) {
+		c.ignoredIDPrefix = prefix
+	}
+}
+
+// ignored returns true when the resource Id starts with the prefix set by
+// WithIgnoredIDPrefix.
+func (c *Client) ignored(id string) bool {
+	return c.ignoredIDPrefix != "" && strings.HasPrefix(id, c.ignoredIDPrefix)
+}
+
 // NewClient returns a redfishwrapper client
 func NewClient(host, port, user, pass string, opts ...Option) *Client {
 	if !strings.HasPrefix(host, "https://") && !strings.HasPrefix(host, "http://") {
@@ -155,6 +183,17 @@ func (c *Client) Open(ctx context.Context) error {
 	if tm := getTimeout(ctx); tm != 0 {
 		config.HTTPClient.Timeout = tm
 	}
+
+	// session authenticated clients renew expired sessions, the HTTP client is copied
+	// so that a client shared with other providers is left as is.
+	if !c.basicAuth {
+		c.session = newSessionTransport(config.HTTPClient.Transport, endpoint, c.user, c.pass)
+
+		httpClient := *config.HTTPClient
+		httpClient.Transport = c.session
+		config.HTTPClient = &httpClient
+	}
+
 	var err error
 	c.client, err = gofish.Connect(config)
 
@@ -178,6 +217,10 @@ func (c *Client) Close(ctx context.Context) error {
 
 	c.client.Logout()
 
+	if c.session != nil {
+		return c.session.logout(ctx)
+	}
+
 	return nil
 }
 
@@ -219,6 +262,17 @@ func (c *Client) RunRawRequestWithHeaders(method, url string, payloadBuffer io.R
 	return c.client.RunRawRequestWithHeaders(method, url, payloadBuffer, contentType, customHeaders)
 }
 
+// RunRawRequestWithContext issues a raw request bound to ctx, the payload is streamed to the BMC as it
+// is read. The payload length is set with a Content-Length custom header, the payload is sent chunked
+// without one.
+func (c *Client) RunRawRequestWithContext(ctx context.Context, method, url string, payload io.Reader, contentType string, customHeaders map[string]string) (*http.Response, error) {
+	if err := c.SessionActive(); err != nil {
+		return nil, errors.Wrap(bmclibErrs.ErrNotAuthenticated, err.Error())
+	}
+
+	return c.client.WithContext(ctx).RunRawRequestWithHeaders(method, url, pipeReaderFakeSeeker{payload}, contentType, customHeaders)
+}
+
 // Delete issues an HTTP DELETE request to the given URL.
 func (c *Client) Delete(url string) (*http.Response, error) {
 	return c.client.Delete(url)
@@ -91,6 +91,17 @@ func TestWithEtagMatchDisabled(t *testing.T) {
 	}
 }
 
+func TestWithIgnoredIDPrefix(t *testing.T) {
+	client := NewClient("127.0.0.1", "", "ADMIN", "ADMIN")
+	assert.False(t, client.ignored("HGX_Baseboard_0"))
+
+	client = NewClient("127.0.0.1", "", "ADMIN", "ADMIN", WithIgnoredIDPrefix("HGX_"))
+	assert.True(t, client.ignored("HGX_Baseboard_0"))
+	assert.True(t, client.ignored("HGX_BMC_0"))
+	assert.False(t, client.ignored("DGX"))
+	assert.False(t, client.ignored("Self"))
+}
+
 const (
 	fixturesDir = "./fixtures"
 )
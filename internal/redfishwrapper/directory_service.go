@@ -0,0 +1,145 @@
+package redfishwrapper
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/stmcginnis/gofish/schemas"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+var errDirectoryServiceNotSupported = errors.New("account service does not support the directory service")
+
+// GetDirectoryService returns the LDAP or Active Directory configuration of the account service.
+func (c *Client) GetDirectoryService(ctx context.Context, serviceType bmc.DirectoryServiceType) (config *bmc.DirectoryServiceConfig, err error) {
+	return c.DirectoryServiceFromProperty(ctx, string(serviceType), serviceType)
+}
+
+// SetDirectoryService sets the LDAP or Active Directory configuration of the account service.
+func (c *Client) SetDirectoryService(ctx context.Context, config bmc.DirectoryServiceConfig) (err error) {
+	return c.PatchDirectoryService(ctx, string(config.Type), DirectoryServicePayload(config))
+}
+
+// DirectoryServiceFromProperty returns the directory service configuration held in the given account service property,
+// this allows providers to read a directory service from a property other than the one named after its type.
+func (c *Client) DirectoryServiceFromProperty(ctx context.Context, property string, serviceType bmc.DirectoryServiceType) (*bmc.DirectoryServiceConfig, error) {
+	service, err := c.AccountService()
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying the account service")
+	}
+
+	if !hasProperty(service.RawData, property) {
+		return nil, errors.Wrap(errDirectoryServiceNotSupported, property)
+	}
+
+	var provider schemas.ExternalAccountProvider
+
+	switch property {
+	case string(bmc.DirectoryServiceLDAP):
+		provider = service.LDAP
+	case string(bmc.DirectoryServiceActiveDirectory):
+		provider = service.ActiveDirectory
+	default:
+		return nil, errors.Wrap(errDirectoryServiceNotSupported, property)
+	}
+
+	config := &bmc.DirectoryServiceConfig{
+		Type:                   serviceType,
+		Enabled:                provider.ServiceEnabled,
+		BaseDistinguishedNames: provider.LDAPService.SearchSettings.BaseDistinguishedNames,
+		UsernameAttribute:      provider.LDAPService.SearchSettings.UsernameAttribute,
+		GroupsAttribute:        provider.LDAPService.SearchSettings.GroupsAttribute,
+		BindUsername:           provider.Authentication.Username,
+	}
+
+	for _, address := range provider.ServiceAddresses {
+		// BMCs report unset server slots as empty strings
+		if address != "" {
+			config.ServiceAddresses = append(config.ServiceAddresses, address)
+		}
+	}
+
+	for _, mapping := range provider.RemoteRoleMapping {
+		// fixed size role mapping tables report unset slots with empty values
+		if mapping.RemoteGroup == "" || mapping.LocalRole == "" {
+			continue
+		}
+
+		config.RoleMappings = append(config.RoleMappings, bmc.DirectoryRoleMapping{
+			RemoteGroup: mapping.RemoteGroup,
+			LocalRole:   mapping.LocalRole,
+		})
+	}
+
+	return config, nil
+}
+
+// DirectoryServicePayload returns the ExternalAccountProvider PATCH payload for the directory service configuration,
+// empty fields of the configuration are left out of the payload.
+func DirectoryServicePayload(config bmc.DirectoryServiceConfig) map[string]any {
+	payload := map[string]any{"ServiceEnabled": config.Enabled}
+
+	if config.ServiceAddresses != nil {
+		payload["ServiceAddresses"] = config.ServiceAddresses
+	}
+
+	if config.BindUsername != "" || config.BindPassword != "" {
+		authentication := map[string]any{"AuthenticationType": schemas.UsernameAndPasswordAuthenticationTypes}
+		if config.BindUsername != "" {
+			authentication["Username"] = config.BindUsername
+		}
+
+		if config.BindPassword != "" {
+			authentication["Password"] = config.BindPassword
+		}
+
+		payload["Authentication"] = authentication
+	}
+
+	searchSettings := map[string]any{}
+	if config.BaseDistinguishedNames != nil {
+		searchSettings["BaseDistinguishedNames"] = config.BaseDistinguishedNames
+	}
+
+	if config.UsernameAttribute != "" {
+		searchSettings["UsernameAttribute"] = config.UsernameAttribute
+	}
+
+	if config.GroupsAttribute != "" {
+		searchSettings["GroupsAttribute"] = config.GroupsAttribute
+	}
+
+	if len(searchSettings) > 0 {
+		payload["LDAPService"] = map[string]any{"SearchSettings": searchSettings}
+	}
+
+	if config.RoleMappings != nil {
+		mappings := []any{}
+		for _, mapping := range config.RoleMappings {
+			mappings = append(mappings, map[string]any{"RemoteGroup": mapping.RemoteGroup, "LocalRole": mapping.LocalRole})
+		}
+
+		payload["RemoteRoleMapping"] = mappings
+	}
+
+	return payload
+}
+
+// PatchDirectoryService sets the given account service directory service property to the payload.
+func (c *Client) PatchDirectoryService(ctx context.Context, property string, payload map[string]any) error {
+	service, err := c.AccountService()
+	if err != nil {
+		return errors.Wrap(err, "error querying the account service")
+	}
+
+	if !hasProperty(service.RawData, property) {
+		return errors.Wrap(errDirectoryServiceNotSupported, property)
+	}
+
+	if err := c.patchIfMatch(service.ODataID, map[string]any{property: payload}); err != nil {
+		return errors.Wrap(err, "error setting the "+property+" directory service")
+	}
+
+	return nil
+}
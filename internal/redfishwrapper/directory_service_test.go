@@ -0,0 +1,111 @@
+package redfishwrapper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// newDirectoryServiceTestClient returns a client for a mock BMC serving an account service with an LDAP service.
+func newDirectoryServiceTestClient(t *testing.T, patches map[string]map[string]any) *Client {
+	t.Helper()
+
+	return newPatchRecordingClient(t, map[string]string{
+		"/redfish/v1/AccountService": "directory_service/accountservice.json",
+	}, patches)
+}
+
+func TestGetDirectoryService(t *testing.T) {
+	client := newDirectoryServiceTestClient(t, map[string]map[string]any{})
+
+	config, err := client.GetDirectoryService(context.Background(), bmc.DirectoryServiceLDAP)
+	require.NoError(t, err)
+
+	assert.Equal(t, &bmc.DirectoryServiceConfig{
+		Type:                   bmc.DirectoryServiceLDAP,
+		Enabled:                true,
+		ServiceAddresses:       []string{"ldaps://ldap1.example.com:636"},
+		BaseDistinguishedNames: []string{"dc=example,dc=com"},
+		UsernameAttribute:      "uid",
+		GroupsAttribute:        "memberOf",
+		BindUsername:           "cn=bmc,ou=services,dc=example,dc=com",
+		RoleMappings:           []bmc.DirectoryRoleMapping{{RemoteGroup: "cn=bmc-admins,ou=groups,dc=example,dc=com", LocalRole: "Administrator"}},
+	}, config)
+
+	_, err = client.GetDirectoryService(context.Background(), bmc.DirectoryServiceActiveDirectory)
+	assert.ErrorIs(t, err, errDirectoryServiceNotSupported)
+}
+
+func TestSetDirectoryService(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   bmc.DirectoryServiceConfig
+		expected map[string]any
+		err      error
+	}{
+		{
+			name: "full",
+			config: bmc.DirectoryServiceConfig{
+				Type:                   bmc.DirectoryServiceLDAP,
+				Enabled:                true,
+				ServiceAddresses:       []string{"ldaps://ldap2.example.com"},
+				BaseDistinguishedNames: []string{"ou=people,dc=example,dc=com"},
+				GroupsAttribute:        "memberOf",
+				BindUsername:           "cn=bmc,ou=services,dc=example,dc=com",
+				BindPassword:           "secret",
+				RoleMappings:           []bmc.DirectoryRoleMapping{{RemoteGroup: "cn=ops,ou=groups,dc=example,dc=com", LocalRole: "Operator"}},
+			},
+			expected: map[string]any{
+				"LDAP": map[string]any{
+					"ServiceEnabled":   true,
+					"ServiceAddresses": []any{"ldaps://ldap2.example.com"},
+					"Authentication": map[string]any{
+						"AuthenticationType": "UsernameAndPassword",
+						"Username":           "cn=bmc,ou=services,dc=example,dc=com",
+						"Password":           "secret",
+					},
+					"LDAPService": map[string]any{
+						"SearchSettings": map[string]any{
+							"BaseDistinguishedNames": []any{"ou=people,dc=example,dc=com"},
+							"GroupsAttribute":        "memberOf",
+						},
+					},
+					"RemoteRoleMapping": []any{map[string]any{"RemoteGroup": "cn=ops,ou=groups,dc=example,dc=com", "LocalRole": "Operator"}},
+				},
+			},
+		},
+		{
+			name:   "disable and clear role mappings",
+			config: bmc.DirectoryServiceConfig{Type: bmc.DirectoryServiceLDAP, RoleMappings: []bmc.DirectoryRoleMapping{}},
+			expected: map[string]any{
+				"LDAP": map[string]any{"ServiceEnabled": false, "RemoteRoleMapping": []any{}},
+			},
+		},
+		{
+			name:   "not supported",
+			config: bmc.DirectoryServiceConfig{Type: bmc.DirectoryServiceActiveDirectory, Enabled: true},
+			err:    errDirectoryServiceNotSupported,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			patches := map[string]map[string]any{}
+			client := newDirectoryServiceTestClient(t, patches)
+
+			err := client.SetDirectoryService(context.Background(), tc.config)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Empty(t, patches)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, map[string]map[string]any{"/redfish/v1/AccountService": tc.expected}, patches)
+		})
+	}
+}
@@ -302,13 +302,13 @@ func updateParametersFormField(fieldName string, writer *multipart.Writer) (io.W
 	return writer.CreatePart(h)
 }
 
-// pipeReaderFakeSeeker wraps the io.PipeReader and implements the io.Seeker interface
+// pipeReaderFakeSeeker wraps the io.PipeReader, or another streamed payload, and implements the io.Seeker interface
 // to meet the API requirements for the Gofish client https://github.com/stmcginnis/gofish/blob/46b1b33645ed1802727dc4df28f5d3c3da722b15/client.go#L434
 //
 // The Gofish method linked does not currently perform seeks and so a PR will be suggested
 // to change the method signature to accept an io.Reader instead.
 type pipeReaderFakeSeeker struct {
-	*io.PipeReader
+	io.Reader
 }
 
 // Seek impelements the io.Seeker interface only to panic if called
@@ -0,0 +1,80 @@
+package redfishwrapper
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stmcginnis/gofish/schemas"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+var errNoIdentifyLED = errors.New("no chassis with an identify LED found")
+
+// SetIdentifyLED turns the identify LED of the first chassis that exposes one on or off.
+//
+// Redfish has no notion of an identify interval, the duration is ignored and the LED stays on
+// until it is turned off, turning it off once the duration elapsed is left to the caller.
+func (c *Client) SetIdentifyLED(ctx context.Context, on bool, duration time.Duration) (err error) {
+	if on && duration > 0 {
+		c.logger.V(1).Info("identify LED duration is not supported over Redfish, the LED stays on until turned off", "duration", duration.String())
+	}
+
+	ch, err := c.identifyLEDChassis()
+	if err != nil {
+		return err
+	}
+
+	if hasLocationIndicator(ch) {
+		ch.LocationIndicatorActive = on
+	} else {
+		ch.IndicatorLED = schemas.OffIndicatorLED
+		if on {
+			ch.IndicatorLED = schemas.BlinkingIndicatorLED
+		}
+	}
+
+	return ch.Update()
+}
+
+// GetIdentifyLED returns the identify LED state of the first chassis that exposes one.
+func (c *Client) GetIdentifyLED(ctx context.Context) (on bool, err error) {
+	ch, err := c.identifyLEDChassis()
+	if err != nil {
+		return false, err
+	}
+
+	if hasLocationIndicator(ch) {
+		return ch.LocationIndicatorActive, nil
+	}
+
+	return ch.IndicatorLED == schemas.LitIndicatorLED || ch.IndicatorLED == schemas.BlinkingIndicatorLED, nil
+}
+
+// identifyLEDChassis returns the first chassis with the LocationIndicatorActive property
+// or the deprecated IndicatorLED property.
+func (c *Client) identifyLEDChassis() (*schemas.Chassis, error) {
+	if err := c.SessionActive(); err != nil {
+		return nil, errors.Wrap(bmclibErrs.ErrNotAuthenticated, err.Error())
+	}
+
+	chassis, err := c.client.Service.Chassis()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ch := range chassis {
+		if hasLocationIndicator(ch) || ch.IndicatorLED != "" {
+			return ch, nil
+		}
+	}
+
+	return nil, errNoIdentifyLED
+}
+
+// hasLocationIndicator returns true when the chassis implements LocationIndicatorActive,
+// gofish decodes the property into a bool so its presence is looked up in the raw resource.
+func hasLocationIndicator(ch *schemas.Chassis) bool {
+	return hasProperty(ch.RawData, "LocationIndicatorActive")
+}
@@ -0,0 +1,112 @@
+package redfishwrapper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetIdentifyLED(t *testing.T) {
+	testCases := []struct {
+		name     string
+		chassis  string
+		on       bool
+		duration time.Duration
+		expected map[string]any
+		err      error
+	}{
+		{
+			name:     "location indicator on",
+			chassis:  "identify_led/chassis_1.json",
+			on:       true,
+			expected: map[string]any{"LocationIndicatorActive": true},
+		},
+		{
+			name:     "legacy indicator led on",
+			chassis:  "identify_led/chassis_1_indicatorled.json",
+			on:       true,
+			expected: map[string]any{"IndicatorLED": "Blinking"},
+		},
+		{
+			name:     "legacy indicator led off",
+			chassis:  "identify_led/chassis_1_indicatorled.json",
+			on:       false,
+			expected: map[string]any{"IndicatorLED": "Off"},
+		},
+		{
+			name:     "duration left to the caller",
+			chassis:  "identify_led/chassis_1.json",
+			on:       true,
+			duration: time.Minute,
+			expected: map[string]any{"LocationIndicatorActive": true},
+		},
+		{
+			name:     "legacy indicator led duration left to the caller",
+			chassis:  "identify_led/chassis_1_indicatorled.json",
+			on:       true,
+			duration: time.Minute,
+			expected: map[string]any{"IndicatorLED": "Blinking"},
+		},
+		{
+			name:    "no identify led",
+			chassis: "identify_led/chassis_1_no_led.json",
+			on:      true,
+			err:     errNoIdentifyLED,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			patches := map[string]map[string]any{}
+			client := newPatchRecordingClient(t, map[string]string{
+				"/redfish/v1/Chassis":   "identify_led/chassis.json",
+				"/redfish/v1/Chassis/1": tc.chassis,
+			}, patches)
+
+			err := client.SetIdentifyLED(context.Background(), tc.on, tc.duration)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Empty(t, patches)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, patches["/redfish/v1/Chassis/1"])
+		})
+	}
+}
+
+func TestGetIdentifyLED(t *testing.T) {
+	testCases := []struct {
+		name     string
+		chassis  string
+		expected bool
+	}{
+		{
+			name:     "location indicator",
+			chassis:  "identify_led/chassis_1.json",
+			expected: false,
+		},
+		{
+			name:     "legacy indicator led",
+			chassis:  "identify_led/chassis_1_indicatorled.json",
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newPatchRecordingClient(t, map[string]string{
+				"/redfish/v1/Chassis":   "identify_led/chassis.json",
+				"/redfish/v1/Chassis/1": tc.chassis,
+			}, map[string]map[string]any{})
+
+			on, err := client.GetIdentifyLED(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, on)
+		})
+	}
+}
@@ -25,6 +25,8 @@ var (
 		"/redfish/v1/Chassis/Self",
 		// OpenBMC on ASRock
 		"/redfish/v1/Chassis/ASRock_ROMED8HM3",
+		// Fujitsu iRMC
+		"/redfish/v1/Chassis/0",
 	}
 
 	// Supported System Odata IDs
@@ -38,6 +40,8 @@ var (
 		"/redfish/v1/Systems/Self",
 		// OpenBMC on ASRock
 		"/redfish/v1/Systems/system",
+		// Fujitsu iRMC
+		"/redfish/v1/Systems/0",
 	}
 
 	// Supported Manager Odata IDs (BMCs)
@@ -50,6 +54,10 @@ var (
 		"/redfish/v1/Managers/Self",
 		// OpenBMC on ASRock
 		"/redfish/v1/Managers/bmc",
+		// Fujitsu iRMC
+		"/redfish/v1/Managers/iRMC",
+		// Cisco CIMC
+		"/redfish/v1/Managers/CIMC",
 	}
 )
 
@@ -64,6 +72,16 @@ func (c *Client) compatibleOdataID(odataID string, knownOdataIDs []string) bool
 	return false
 }
 
+// compatibleSystemOdataID returns true when the System Odata ID is a known
+// one, or starts with the prefix set by WithSystemsOdataIDPrefix.
+func (c *Client) compatibleSystemOdataID(odataID string) bool {
+	if c.systemsOdataIDPrefix != "" && strings.HasPrefix(odataID, c.systemsOdataIDPrefix) {
+		return true
+	}
+
+	return c.compatibleOdataID(odataID, knownSystemsOdataIDs)
+}
+
 // Inventory collects hardware inventory and firmware information for the device.
 func (c *Client) Inventory(ctx context.Context, failOnError bool) (device *common.Device, err error) {
 	updateService, err := c.UpdateService()
@@ -211,7 +229,7 @@ func (c *Client) systemAttributes(device *common.Device, failOnError bool, softw
 		return err
 	}
 
-	if !c.compatibleOdataID(sys.ODataID, knownSystemsOdataIDs) {
+	if !c.compatibleSystemOdataID(sys.ODataID) {
 		return bmclibErrs.ErrRedfishSystemOdataID
 	}
 
@@ -300,11 +300,8 @@ func (c *Client) collectBMCNIC(manager *schemas.Manager, bmc *common.BMC) {
 
 	var ports []*common.NICPort
 	for _, eth := range interfaces {
-		mac := eth.MACAddress
-		if mac == "" || mac == "00:00:00:00:00:00" {
-			mac = eth.PermanentMACAddress
-		}
-		if mac == "" || mac == "00:00:00:00:00:00" {
+		mac := interfaceMAC(eth)
+		if mac == "" {
 			continue
 		}
 
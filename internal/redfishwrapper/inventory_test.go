@@ -467,3 +467,14 @@ func TestInventoryCollectBMCNIC(t *testing.T) {
 		}
 	}
 }
+
+func TestCompatibleSystemOdataID(t *testing.T) {
+	client := NewClient("127.0.0.1", "443", "", "")
+	assert.True(t, client.compatibleSystemOdataID("/redfish/v1/Systems/1"))
+	assert.False(t, client.compatibleSystemOdataID("/redfish/v1/Systems/WZP21330ABC"))
+
+	client = NewClient("127.0.0.1", "443", "", "", WithSystemsOdataIDPrefix("/redfish/v1/Systems/"))
+	assert.True(t, client.compatibleSystemOdataID("/redfish/v1/Systems/1"))
+	assert.True(t, client.compatibleSystemOdataID("/redfish/v1/Systems/WZP21330ABC"))
+	assert.False(t, client.compatibleSystemOdataID("/redfish/v1/Chassis/1"))
+}
@@ -1,11 +1,17 @@
 package redfishwrapper
 
 import (
+	"context"
+	"encoding/json"
 	"io"
 	"log"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func mustReadFile(t *testing.T, filename string) []byte {
@@ -42,3 +48,53 @@ var endpointFunc = func(t *testing.T, file string) http.HandlerFunc {
 		_, _ = w.Write(mustReadFile(t, file))
 	}
 }
+
+// newPatchRecordingClient returns an opened client for a mock BMC serving the given fixtures,
+// the service root and the system are served from the default fixtures unless given. The PATCH
+// request bodies received for a resource are recorded in patches.
+func newPatchRecordingClient(t *testing.T, fixtures map[string]string, patches map[string]map[string]any) *Client {
+	t.Helper()
+
+	defaults := map[string]string{
+		"/redfish/v1/":          "serviceroot.json",
+		"/redfish/v1/Systems":   "systems.json",
+		"/redfish/v1/Systems/1": "systems_1.json",
+	}
+	for path, fixture := range defaults {
+		if _, ok := fixtures[path]; !ok {
+			fixtures[path] = fixture
+		}
+	}
+
+	mux := http.NewServeMux()
+	for path, fixture := range fixtures {
+		get := endpointFunc(t, fixture)
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPatch {
+				get(w, r)
+				return
+			}
+
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			payload := map[string]any{}
+			require.NoError(t, json.Unmarshal(b, &payload))
+			patches[r.URL.Path] = payload
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	client := NewClient(u.Hostname(), u.Port(), "", "", WithBasicAuthEnabled(true))
+	require.NoError(t, client.Open(context.Background()))
+	t.Cleanup(func() { _ = client.Close(context.Background()) })
+
+	return client
+}
@@ -0,0 +1,97 @@
+package redfishwrapper
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+var (
+	errNoManagerDateTime     = errors.New("manager does not report its DateTime")
+	errNoNetworkProtocol     = errors.New("manager does not link a NetworkProtocol resource")
+	errManagerDateTimeFormat = errors.New("unexpected manager DateTime format")
+)
+
+// GetBMCTime returns the manager clock and its NTP configuration, the NTP configuration is left
+// unset when the manager does not link a NetworkProtocol resource.
+func (c *Client) GetBMCTime(ctx context.Context) (bmcTime *bmc.BMCTime, err error) {
+	manager, err := c.Manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	retrievedAt := time.Now()
+
+	if manager.DateTime == "" {
+		return nil, errNoManagerDateTime
+	}
+
+	t, err := time.Parse(time.RFC3339, manager.DateTime)
+	if err != nil {
+		return nil, errors.Wrapf(errManagerDateTimeFormat, "%s: %s", manager.DateTime, err.Error())
+	}
+
+	bmcTime = &bmc.BMCTime{Time: t, RetrievedAt: retrievedAt}
+
+	protocol, err := manager.NetworkProtocol()
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying the manager NetworkProtocol")
+	}
+
+	if protocol != nil {
+		bmcTime.NTPEnabled = protocol.NTP.ProtocolEnabled
+		for _, server := range protocol.NTP.NTPServers {
+			// BMCs report unset NTP server slots as empty strings
+			if server != "" {
+				bmcTime.NTPServers = append(bmcTime.NTPServers, server)
+			}
+		}
+	}
+
+	return bmcTime, nil
+}
+
+// SetBMCTime sets the manager clock, the manager UTC offset is left unchanged.
+func (c *Client) SetBMCTime(ctx context.Context, t time.Time) (err error) {
+	manager, err := c.Manager(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.patchIfMatch(manager.ODataID, map[string]any{"DateTime": t.Format(time.RFC3339)}); err != nil {
+		return errors.Wrap(err, "error setting the manager DateTime")
+	}
+
+	return nil
+}
+
+// SetNTPServers sets the manager NTP servers, NTP is enabled when servers are given and disabled otherwise.
+func (c *Client) SetNTPServers(ctx context.Context, servers []string) (err error) {
+	manager, err := c.Manager(ctx)
+	if err != nil {
+		return err
+	}
+
+	protocol, err := manager.NetworkProtocol()
+	if err != nil {
+		return errors.Wrap(err, "error querying the manager NetworkProtocol")
+	}
+
+	if protocol == nil {
+		return errNoNetworkProtocol
+	}
+
+	ntp := map[string]any{"ProtocolEnabled": len(servers) > 0}
+	if len(servers) > 0 {
+		ntp["NTPServers"] = servers
+	}
+
+	if err := c.patchIfMatch(protocol.ODataID, map[string]any{"NTP": ntp}); err != nil {
+		return errors.Wrap(err, "error setting the manager NTP servers")
+	}
+
+	return nil
+}
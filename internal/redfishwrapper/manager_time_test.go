@@ -0,0 +1,79 @@
+package redfishwrapper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newManagerTimeTestClient returns a client for a mock BMC serving the given manager fixture and its NetworkProtocol.
+func newManagerTimeTestClient(t *testing.T, managerFixture string, patches map[string]map[string]any) *Client {
+	t.Helper()
+
+	return newPatchRecordingClient(t, map[string]string{
+		"/redfish/v1/Managers":                   "managers.json",
+		"/redfish/v1/Managers/1":                 managerFixture,
+		"/redfish/v1/Managers/1/NetworkProtocol": "manager_time/networkprotocol.json",
+	}, patches)
+}
+
+func TestGetBMCTime(t *testing.T) {
+	client := newManagerTimeTestClient(t, "managers_1.json", map[string]map[string]any{})
+
+	before := time.Now()
+	bmcTime, err := client.GetBMCTime(context.Background())
+	require.NoError(t, err)
+
+	assert.True(t, bmcTime.Time.Equal(time.Date(2023, 11, 6, 14, 16, 52, 0, time.UTC)))
+	assert.False(t, bmcTime.RetrievedAt.Before(before))
+	assert.True(t, bmcTime.NTPEnabled)
+	assert.Equal(t, []string{"10.20.0.5", "0.pool.ntp.org"}, bmcTime.NTPServers)
+
+	client = newManagerTimeTestClient(t, "manager_time/managers_1_no_datetime.json", map[string]map[string]any{})
+
+	_, err = client.GetBMCTime(context.Background())
+	assert.ErrorIs(t, err, errNoManagerDateTime)
+}
+
+func TestSetBMCTime(t *testing.T) {
+	patches := map[string]map[string]any{}
+	client := newManagerTimeTestClient(t, "managers_1.json", patches)
+
+	err := client.SetBMCTime(context.Background(), time.Date(2024, 5, 1, 14, 5, 0, 0, time.FixedZone("CEST", 2*60*60)))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]map[string]any{
+		"/redfish/v1/Managers/1": {"DateTime": "2024-05-01T14:05:00+02:00"},
+	}, patches)
+}
+
+func TestSetNTPServers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		servers  []string
+		expected map[string]any
+	}{
+		{
+			name:     "servers",
+			servers:  []string{"10.20.0.5", "10.20.0.6"},
+			expected: map[string]any{"NTP": map[string]any{"ProtocolEnabled": true, "NTPServers": []any{"10.20.0.5", "10.20.0.6"}}},
+		},
+		{
+			name:     "disable",
+			expected: map[string]any{"NTP": map[string]any{"ProtocolEnabled": false}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			patches := map[string]map[string]any{}
+			client := newManagerTimeTestClient(t, "managers_1.json", patches)
+
+			require.NoError(t, client.SetNTPServers(context.Background(), tc.servers))
+			assert.Equal(t, map[string]map[string]any{"/redfish/v1/Managers/1/NetworkProtocol": tc.expected}, patches)
+		})
+	}
+}
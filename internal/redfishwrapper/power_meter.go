@@ -0,0 +1,256 @@
+package redfishwrapper
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stmcginnis/gofish/schemas"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+var (
+	errNoPowerMetrics = errors.New("no power metrics found")
+	errNoPowerControl = errors.New("no power limit control found")
+)
+
+// PowerConsumption returns the power consumption of the first chassis that reports it.
+//
+// The Power PowerControl resource is preferred, chassis that do not implement it are read
+// through EnvironmentMetrics and last through the PowerSubsystem power supply metrics.
+func (c *Client) PowerConsumption(ctx context.Context) (consumption *bmc.PowerConsumption, err error) {
+	if err := c.SessionActive(); err != nil {
+		return nil, errors.Wrap(bmclibErrs.ErrNotAuthenticated, err.Error())
+	}
+
+	chassis, err := c.client.Service.Chassis()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ch := range chassis {
+		power, err := ch.Power()
+		if err != nil {
+			return nil, errors.Wrap(err, "error querying chassis power: "+ch.ID)
+		}
+
+		if power != nil && len(power.PowerControl) > 0 {
+			return powerConsumptionFromPowerControl(&power.PowerControl[0]), nil
+		}
+
+		metrics, err := ch.EnvironmentMetrics()
+		if err != nil {
+			return nil, errors.Wrap(err, "error querying chassis environment metrics: "+ch.ID)
+		}
+
+		if metrics != nil && metrics.PowerWatts.Reading != nil {
+			return powerConsumptionFromEnvironmentMetrics(metrics), nil
+		}
+
+		subsystem, err := ch.PowerSubsystem()
+		if err != nil {
+			return nil, errors.Wrap(err, "error querying chassis power subsystem: "+ch.ID)
+		}
+
+		if subsystem == nil {
+			continue
+		}
+
+		consumption, err := c.powerConsumptionFromPowerSubsystem(subsystem)
+		if err != nil {
+			return nil, errors.Wrap(err, "error querying chassis power supplies: "+ch.ID)
+		}
+
+		if consumption != nil {
+			return consumption, nil
+		}
+	}
+
+	return nil, errNoPowerMetrics
+}
+
+func powerConsumptionFromPowerControl(pc *schemas.PowerControl) *bmc.PowerConsumption {
+	consumption := &bmc.PowerConsumption{
+		CurrentWatts: float32ToFloat64(pc.PowerConsumedWatts),
+		AverageWatts: float32ToFloat64(pc.PowerMetrics.AverageConsumedWatts),
+		MinWatts:     float32ToFloat64(pc.PowerMetrics.MinConsumedWatts),
+		MaxWatts:     float32ToFloat64(pc.PowerMetrics.MaxConsumedWatts),
+	}
+
+	if pc.PowerMetrics.IntervalInMin != nil {
+		consumption.Interval = time.Duration(*pc.PowerMetrics.IntervalInMin) * time.Minute
+	}
+
+	if pc.PowerLimit.LimitInWatts != nil {
+		consumption.Limit = &bmc.PowerLimit{
+			Watts:           *pc.PowerLimit.LimitInWatts,
+			Enabled:         true,
+			ExceptionAction: powerLimitExceptionAction(pc.PowerLimit.LimitException),
+		}
+
+		if pc.PowerLimit.CorrectionInMs != nil {
+			consumption.Limit.CorrectionTime = time.Duration(*pc.PowerLimit.CorrectionInMs) * time.Millisecond
+		}
+	}
+
+	return consumption
+}
+
+func powerConsumptionFromEnvironmentMetrics(metrics *schemas.EnvironmentMetrics) *bmc.PowerConsumption {
+	consumption := &bmc.PowerConsumption{
+		CurrentWatts: metrics.PowerWatts.Reading,
+	}
+
+	setPoint := metrics.PowerLimitWatts.SetPoint
+	if setPoint != nil && metrics.PowerLimitWatts.ControlMode != schemas.DisabledControlMode {
+		consumption.Limit = &bmc.PowerLimit{
+			Watts:   *setPoint,
+			Enabled: true,
+		}
+	}
+
+	return consumption
+}
+
+// powerConsumptionFromPowerSubsystem returns the sum of the power supply input power,
+// nil when none of the power supplies report it.
+func (c *Client) powerConsumptionFromPowerSubsystem(subsystem *schemas.PowerSubsystem) (*bmc.PowerConsumption, error) {
+	// gofish decodes the PowerSupplies link of the subsystem into the deprecated Power
+	// PowerSupply type, the collection URI is fixed by the Redfish specification.
+	psus, err := schemas.ListReferencedPowerSupplyUnits(c.client, subsystem.ODataID+"/PowerSupplies")
+	if err != nil {
+		return nil, err
+	}
+
+	var total float64
+	var found bool
+
+	for _, psu := range psus {
+		metrics, err := psu.Metrics()
+		if err != nil {
+			return nil, err
+		}
+
+		if metrics == nil || metrics.InputPowerWatts.Reading == nil {
+			continue
+		}
+
+		total += *metrics.InputPowerWatts.Reading
+		found = true
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	return &bmc.PowerConsumption{CurrentWatts: &total}, nil
+}
+
+func powerLimitExceptionAction(e schemas.PowerLimitException) bmc.PowerLimitExceptionAction {
+	switch e {
+	case schemas.NoActionPowerLimitException:
+		return bmc.PowerLimitExceptionActionNoAction
+	case schemas.HardPowerOffPowerLimitException:
+		return bmc.PowerLimitExceptionActionHardPowerOff
+	case schemas.LogEventOnlyPowerLimitException:
+		return bmc.PowerLimitExceptionActionLogEvent
+	default:
+		return bmc.PowerLimitExceptionActionDefault
+	}
+}
+
+func powerLimitException(a bmc.PowerLimitExceptionAction) schemas.PowerLimitException {
+	switch a {
+	case bmc.PowerLimitExceptionActionNoAction:
+		return schemas.NoActionPowerLimitException
+	case bmc.PowerLimitExceptionActionHardPowerOff:
+		return schemas.HardPowerOffPowerLimitException
+	case bmc.PowerLimitExceptionActionLogEvent:
+		return schemas.LogEventOnlyPowerLimitException
+	default:
+		return ""
+	}
+}
+
+// SetPowerLimit applies the power limit to the first chassis that exposes a power limit control.
+//
+// The Power PowerControl PowerLimit is preferred, chassis that do not implement it are
+// capped through the EnvironmentMetrics PowerLimitWatts control.
+func (c *Client) SetPowerLimit(ctx context.Context, limit bmc.PowerLimit) (err error) {
+	if err := c.SessionActive(); err != nil {
+		return errors.Wrap(bmclibErrs.ErrNotAuthenticated, err.Error())
+	}
+
+	chassis, err := c.client.Service.Chassis()
+	if err != nil {
+		return err
+	}
+
+	for _, ch := range chassis {
+		power, err := ch.Power()
+		if err != nil {
+			return errors.Wrap(err, "error querying chassis power: "+ch.ID)
+		}
+
+		if power != nil && len(power.PowerControl) > 0 {
+			return c.patch(power.ODataID, powerControlLimitPayload(limit))
+		}
+
+		metrics, err := ch.EnvironmentMetrics()
+		if err != nil {
+			return errors.Wrap(err, "error querying chassis environment metrics: "+ch.ID)
+		}
+
+		if metrics != nil {
+			return c.patch(metrics.ODataID, environmentMetricsLimitPayload(limit))
+		}
+	}
+
+	return errNoPowerControl
+}
+
+func powerControlLimitPayload(limit bmc.PowerLimit) map[string]any {
+	// a null LimitInWatts removes the power limit
+	powerLimit := map[string]any{"LimitInWatts": nil}
+
+	if limit.Enabled {
+		powerLimit["LimitInWatts"] = limit.Watts
+
+		if exception := powerLimitException(limit.ExceptionAction); exception != "" {
+			powerLimit["LimitException"] = exception
+		}
+
+		if limit.CorrectionTime > 0 {
+			powerLimit["CorrectionInMs"] = limit.CorrectionTime.Milliseconds()
+		}
+	}
+
+	return map[string]any{
+		"PowerControl": []map[string]any{
+			{"PowerLimit": powerLimit},
+		},
+	}
+}
+
+func environmentMetricsLimitPayload(limit bmc.PowerLimit) map[string]any {
+	control := map[string]any{"ControlMode": schemas.DisabledControlMode}
+
+	if limit.Enabled {
+		control["ControlMode"] = schemas.AutomaticControlMode
+		control["SetPoint"] = limit.Watts
+	}
+
+	return map[string]any{"PowerLimitWatts": control}
+}
+
+// patch issues a PATCH request for the given resource and closes the response body.
+func (c *Client) patch(odataID string, payload any) error {
+	resp, err := c.client.PatchWithHeaders(odataID, payload, nil)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
@@ -0,0 +1,131 @@
+package redfishwrapper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// newPowerTestClient returns a client for a mock BMC serving the given chassis fixture.
+func newPowerTestClient(t *testing.T, chassisFixture string, patches map[string]map[string]any) *Client {
+	t.Helper()
+
+	return newPatchRecordingClient(t, map[string]string{
+		"/redfish/v1/Chassis":                      "power/chassis.json",
+		"/redfish/v1/Chassis/1":                    chassisFixture,
+		"/redfish/v1/Chassis/1/Power":              "power/power.json",
+		"/redfish/v1/Chassis/1/EnvironmentMetrics": "power/environmentmetrics.json",
+	}, patches)
+}
+
+func TestPowerConsumption(t *testing.T) {
+	client := newPowerTestClient(t, "power/chassis_1_power.json", map[string]map[string]any{})
+
+	consumption, err := client.PowerConsumption(context.Background())
+	require.NoError(t, err)
+
+	require.NotNil(t, consumption.CurrentWatts)
+	assert.Equal(t, 224.0, *consumption.CurrentWatts)
+	require.NotNil(t, consumption.AverageWatts)
+	assert.Equal(t, 218.0, *consumption.AverageWatts)
+	require.NotNil(t, consumption.MinWatts)
+	assert.Equal(t, 196.0, *consumption.MinWatts)
+	require.NotNil(t, consumption.MaxWatts)
+	assert.Equal(t, 412.0, *consumption.MaxWatts)
+	assert.Equal(t, time.Hour, consumption.Interval)
+
+	expected := &bmc.PowerLimit{
+		Watts:           500,
+		Enabled:         true,
+		CorrectionTime:  6 * time.Second,
+		ExceptionAction: bmc.PowerLimitExceptionActionLogEvent,
+	}
+	assert.Equal(t, expected, consumption.Limit)
+}
+
+func TestPowerConsumptionEnvironmentMetrics(t *testing.T) {
+	client := newPowerTestClient(t, "power/chassis_1_environmentmetrics.json", map[string]map[string]any{})
+
+	consumption, err := client.PowerConsumption(context.Background())
+	require.NoError(t, err)
+
+	require.NotNil(t, consumption.CurrentWatts)
+	assert.Equal(t, 374.0, *consumption.CurrentWatts)
+	assert.Nil(t, consumption.AverageWatts)
+	assert.Nil(t, consumption.Limit, "a disabled PowerLimitWatts control is not an active limit")
+}
+
+func TestSetPowerLimit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		chassis  string
+		limit    bmc.PowerLimit
+		path     string
+		expected map[string]any
+	}{
+		{
+			name:    "power control",
+			chassis: "power/chassis_1_power.json",
+			limit: bmc.PowerLimit{
+				Watts:           450,
+				Enabled:         true,
+				CorrectionTime:  2 * time.Second,
+				ExceptionAction: bmc.PowerLimitExceptionActionHardPowerOff,
+			},
+			path: "/redfish/v1/Chassis/1/Power",
+			expected: map[string]any{
+				"PowerControl": []any{
+					map[string]any{
+						"PowerLimit": map[string]any{
+							"LimitInWatts":   450.0,
+							"LimitException": "HardPowerOff",
+							"CorrectionInMs": 2000.0,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "power control disable",
+			chassis: "power/chassis_1_power.json",
+			limit:   bmc.PowerLimit{},
+			path:    "/redfish/v1/Chassis/1/Power",
+			expected: map[string]any{
+				"PowerControl": []any{
+					map[string]any{
+						"PowerLimit": map[string]any{"LimitInWatts": nil},
+					},
+				},
+			},
+		},
+		{
+			name:    "environment metrics",
+			chassis: "power/chassis_1_environmentmetrics.json",
+			limit:   bmc.PowerLimit{Watts: 900, Enabled: true},
+			path:    "/redfish/v1/Chassis/1/EnvironmentMetrics",
+			expected: map[string]any{
+				"PowerLimitWatts": map[string]any{
+					"ControlMode": "Automatic",
+					"SetPoint":    900.0,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			patches := map[string]map[string]any{}
+			client := newPowerTestClient(t, tc.chassis, patches)
+
+			err := client.SetPowerLimit(context.Background(), tc.limit)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, patches[tc.path])
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package redfishwrapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/stmcginnis/gofish/schemas"
+)
+
+// ODataID is the Redfish reference link shape: {"@odata.id": "/redfish/..."}.
+type ODataID struct {
+	ODataID string `json:"@odata.id"`
+}
+
+// GetJSON GETs a Redfish resource and unmarshals its body into out, for the OEM properties and
+// resources gofish does not model.
+func (c *Client) GetJSON(url string, out any) error {
+	resp, err := c.Get(url)
+	if err != nil {
+		return fmt.Errorf("GET %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("GET %s: %w", url, err)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("GET %s: %w", url, err)
+	}
+
+	return nil
+}
+
+// CollectionMembers GETs a Redfish collection and returns its member links.
+func (c *Client) CollectionMembers(url string) ([]string, error) {
+	var collection struct {
+		Members []ODataID `json:"Members"`
+	}
+
+	if err := c.GetJSON(url, &collection); err != nil {
+		return nil, err
+	}
+
+	members := make([]string, 0, len(collection.Members))
+	for _, member := range collection.Members {
+		members = append(members, member.ODataID)
+	}
+
+	return members, nil
+}
+
+// IsNotFound returns true when the error is a gofish error for an HTTP 404 response.
+//
+// gofish returns the non 2xx responses as a *schemas.Error, a 404 arrives as an error rather than
+// a response.
+func IsNotFound(err error) bool {
+	var re *schemas.Error
+	if errors.As(err, &re) {
+		return re.HTTPReturnedStatusCode == http.StatusNotFound
+	}
+
+	return false
+}
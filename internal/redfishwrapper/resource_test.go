@@ -0,0 +1,39 @@
+package redfishwrapper
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stmcginnis/gofish/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionMembers(t *testing.T) {
+	client := newPatchRecordingClient(t, map[string]string{
+		"/redfish/v1/Managers":   "managers.json",
+		"/redfish/v1/Managers/1": "managers_1.json",
+	}, map[string]map[string]any{})
+
+	members, err := client.CollectionMembers("/redfish/v1/Managers")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/redfish/v1/Managers/1"}, members)
+
+	var manager struct {
+		ID          string  `json:"Id"`
+		EthernetIfs ODataID `json:"EthernetInterfaces"`
+	}
+
+	require.NoError(t, client.GetJSON(members[0], &manager))
+	assert.Equal(t, "1", manager.ID)
+	assert.Equal(t, "/redfish/v1/Managers/1/EthernetInterfaces", manager.EthernetIfs.ODataID)
+
+	_, err = client.CollectionMembers("/missing")
+	assert.True(t, IsNotFound(err))
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert.True(t, IsNotFound(&schemas.Error{HTTPReturnedStatusCode: http.StatusNotFound}))
+	assert.False(t, IsNotFound(&schemas.Error{HTTPReturnedStatusCode: http.StatusUnauthorized}))
+	assert.False(t, IsNotFound(assert.AnError))
+}
@@ -15,7 +15,7 @@ func (c *Client) GetSecureBoot(ctx context.Context) (enabled bool, err error) {
 		return false, err
 	}
 
-	if !c.compatibleOdataID(sys.ODataID, knownSystemsOdataIDs) {
+	if !c.compatibleSystemOdataID(sys.ODataID) {
 		return false, bmclibErrs.ErrRedfishSystemOdataID
 	}
 
@@ -35,7 +35,7 @@ func (c *Client) SetSecureBoot(ctx context.Context, enable bool) (err error) {
 		return err
 	}
 
-	if !c.compatibleOdataID(sys.ODataID, knownSystemsOdataIDs) {
+	if !c.compatibleSystemOdataID(sys.ODataID) {
 		return bmclibErrs.ErrRedfishSystemOdataID
 	}
 
@@ -57,7 +57,7 @@ func (c *Client) ResetSecureBootKeys(ctx context.Context, resetType string) (err
 		return err
 	}
 
-	if !c.compatibleOdataID(sys.ODataID, knownSystemsOdataIDs) {
+	if !c.compatibleSystemOdataID(sys.ODataID) {
 		return bmclibErrs.ErrRedfishSystemOdataID
 	}
 
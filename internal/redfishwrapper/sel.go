@@ -3,10 +3,13 @@ package redfishwrapper
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stmcginnis/gofish/schemas"
 
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
 	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
 )
 
@@ -75,6 +78,77 @@ func (c *Client) GetSystemEventLog(ctx context.Context) (entries [][]string, err
 	return entries, nil
 }
 
+// GetSystemEventLogEntries returns the Manager LogServices entries as typed SystemEventLogEntry values.
+func (c *Client) GetSystemEventLogEntries(ctx context.Context) (entries []bmc.SystemEventLogEntry, err error) {
+	if err := c.SessionActive(); err != nil {
+		return nil, errors.Wrap(bmclibErrs.ErrNotAuthenticated, err.Error())
+	}
+
+	managers, err := c.client.Service.Managers()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range managers {
+		logServices, err := m.LogServices()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, logService := range logServices {
+			lentries, err := logService.Entries()
+			if err != nil {
+				return nil, err
+			}
+
+			for _, entry := range lentries {
+				entries = append(entries, SystemEventLogEntryFromLogEntry(entry))
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// SystemEventLogEntryFromLogEntry converts a redfish LogEntry into a bmc.SystemEventLogEntry.
+func SystemEventLogEntryFromLogEntry(entry *schemas.LogEntry) bmc.SystemEventLogEntry {
+	sensorType := string(entry.SensorType)
+	if sensorType == "" || entry.SensorType == "OEM" {
+		if entry.OemSensorType != "" {
+			sensorType = entry.OemSensorType
+		}
+	}
+
+	var sensorName string
+	if entry.SensorNumber != nil {
+		sensorName = fmt.Sprintf("Sensor %d", *entry.SensorNumber)
+	}
+
+	created := entry.Created
+	if created == "" {
+		created = entry.EventTimestamp
+	}
+
+	// a zero timestamp is returned when the value is missing or not RFC3339
+	timestamp, _ := time.Parse(time.RFC3339, created)
+
+	raw := entry.RawData
+	if len(raw) == 0 {
+		raw, _ = json.Marshal(entry)
+	}
+
+	return bmc.SystemEventLogEntry{
+		RecordID:       entry.ID,
+		Timestamp:      timestamp,
+		Severity:       bmc.ParseSystemEventLogSeverity(string(entry.Severity)),
+		SensorType:     sensorType,
+		SensorName:     sensorName,
+		EventDirection: bmc.ParseSystemEventLogEventDirection(string(entry.EntryCode)),
+		Message:        entry.Message,
+		Raw:            raw,
+	}
+}
+
 // GetSystemEventLogRaw returns the raw SEL
 func (c *Client) GetSystemEventLogRaw(ctx context.Context) (eventlog string, err error) {
 	var allEntries []*schemas.LogEntry
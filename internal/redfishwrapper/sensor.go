@@ -0,0 +1,236 @@
+package redfishwrapper
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/stmcginnis/gofish/schemas"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+// Sensors returns the sensor readings of all chassis.
+//
+// The Chassis Sensors collection is preferred, chassis that do not implement it
+// are read through the deprecated Thermal and Power resources instead.
+func (c *Client) Sensors(ctx context.Context) (readings []bmc.SensorReading, err error) {
+	if err := c.SessionActive(); err != nil {
+		return nil, errors.Wrap(bmclibErrs.ErrNotAuthenticated, err.Error())
+	}
+
+	chassis, err := c.client.Service.Chassis()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ch := range chassis {
+		sensors, err := ch.Sensors()
+		if err != nil {
+			return nil, errors.Wrap(err, "error querying chassis sensors: "+ch.ID)
+		}
+
+		if len(sensors) > 0 {
+			for _, s := range sensors {
+				readings = append(readings, sensorReadingFromSensor(s))
+			}
+
+			continue
+		}
+
+		thermal, err := ch.Thermal()
+		if err != nil {
+			return nil, errors.Wrap(err, "error querying chassis thermal: "+ch.ID)
+		}
+
+		if thermal != nil {
+			readings = append(readings, sensorReadingsFromThermal(thermal)...)
+		}
+
+		power, err := ch.Power()
+		if err != nil {
+			return nil, errors.Wrap(err, "error querying chassis power: "+ch.ID)
+		}
+
+		if power != nil {
+			readings = append(readings, sensorReadingsFromPower(power)...)
+		}
+	}
+
+	if len(readings) == 0 {
+		return nil, errors.New("no sensors found")
+	}
+
+	return readings, nil
+}
+
+func sensorReadingFromSensor(s *schemas.Sensor) bmc.SensorReading {
+	return bmc.SensorReading{
+		ID:      s.ID,
+		Name:    s.Name,
+		Type:    sensorTypeFromReadingType(s.ReadingType),
+		Reading: s.Reading,
+		Units:   s.ReadingUnits,
+		Thresholds: bmc.SensorThresholds{
+			LowerNonCritical: s.Thresholds.LowerCaution.Reading,
+			LowerCritical:    s.Thresholds.LowerCritical.Reading,
+			LowerFatal:       s.Thresholds.LowerFatal.Reading,
+			UpperNonCritical: s.Thresholds.UpperCaution.Reading,
+			UpperCritical:    s.Thresholds.UpperCritical.Reading,
+			UpperFatal:       s.Thresholds.UpperFatal.Reading,
+		},
+		Health:          sensorHealth(s.Status),
+		State:           string(s.Status.State),
+		PhysicalContext: string(s.PhysicalContext),
+	}
+}
+
+func sensorTypeFromReadingType(t schemas.ReadingType) bmc.SensorType {
+	switch t {
+	case schemas.TemperatureReadingType:
+		return bmc.SensorTypeTemperature
+	case schemas.RotationalReadingType:
+		return bmc.SensorTypeFan
+	case schemas.VoltageReadingType:
+		return bmc.SensorTypeVoltage
+	case schemas.CurrentReadingType:
+		return bmc.SensorTypeCurrent
+	case schemas.PowerReadingType:
+		return bmc.SensorTypePower
+	default:
+		return bmc.SensorTypeOther
+	}
+}
+
+func sensorReadingsFromThermal(thermal *schemas.Thermal) (readings []bmc.SensorReading) {
+	for i := range thermal.Temperatures {
+		t := &thermal.Temperatures[i]
+		readings = append(readings, bmc.SensorReading{
+			ID:      firstNonEmpty(t.MemberID, t.ID, t.Name),
+			Name:    t.Name,
+			Type:    bmc.SensorTypeTemperature,
+			Reading: t.ReadingCelsius,
+			Units:   "Cel",
+			Thresholds: bmc.SensorThresholds{
+				LowerNonCritical: t.LowerThresholdNonCritical,
+				LowerCritical:    t.LowerThresholdCritical,
+				LowerFatal:       t.LowerThresholdFatal,
+				UpperNonCritical: t.UpperThresholdNonCritical,
+				UpperCritical:    t.UpperThresholdCritical,
+				UpperFatal:       t.UpperThresholdFatal,
+			},
+			Health:          sensorHealth(t.Status),
+			State:           string(t.Status.State),
+			PhysicalContext: string(t.PhysicalContext),
+		})
+	}
+
+	for i := range thermal.Fans {
+		f := &thermal.Fans[i]
+		readings = append(readings, bmc.SensorReading{
+			ID:      firstNonEmpty(f.MemberID, f.ID, f.Name, f.FanName),
+			Name:    firstNonEmpty(f.Name, f.FanName),
+			Type:    bmc.SensorTypeFan,
+			Reading: intToFloat64(f.Reading),
+			Units:   string(f.ReadingUnits),
+			Thresholds: bmc.SensorThresholds{
+				LowerNonCritical: intToFloat64(f.LowerThresholdNonCritical),
+				LowerCritical:    intToFloat64(f.LowerThresholdCritical),
+				LowerFatal:       intToFloat64(f.LowerThresholdFatal),
+				UpperNonCritical: intToFloat64(f.UpperThresholdNonCritical),
+				UpperCritical:    intToFloat64(f.UpperThresholdCritical),
+				UpperFatal:       intToFloat64(f.UpperThresholdFatal),
+			},
+			Health:          sensorHealth(f.Status),
+			State:           string(f.Status.State),
+			PhysicalContext: string(f.PhysicalContext),
+		})
+	}
+
+	return readings
+}
+
+func sensorReadingsFromPower(power *schemas.Power) (readings []bmc.SensorReading) {
+	for i := range power.Voltages {
+		v := &power.Voltages[i]
+		readings = append(readings, bmc.SensorReading{
+			ID:      firstNonEmpty(v.MemberID, v.ID, v.Name),
+			Name:    v.Name,
+			Type:    bmc.SensorTypeVoltage,
+			Reading: float32ToFloat64(v.ReadingVolts),
+			Units:   "V",
+			Thresholds: bmc.SensorThresholds{
+				LowerNonCritical: float32ToFloat64(v.LowerThresholdNonCritical),
+				LowerCritical:    float32ToFloat64(v.LowerThresholdCritical),
+				LowerFatal:       float32ToFloat64(v.LowerThresholdFatal),
+				UpperNonCritical: float32ToFloat64(v.UpperThresholdNonCritical),
+				UpperCritical:    float32ToFloat64(v.UpperThresholdCritical),
+				UpperFatal:       float32ToFloat64(v.UpperThresholdFatal),
+			},
+			Health:          sensorHealth(v.Status),
+			State:           string(v.Status.State),
+			PhysicalContext: string(v.PhysicalContext),
+		})
+	}
+
+	for i := range power.PowerSupplies {
+		p := &power.PowerSupplies[i]
+
+		// prefer the input power, fall back to what the power supply reports as its output
+		watts := firstNonNil(p.PowerInputWatts, p.PowerOutputWatts, p.LastPowerOutputWatts)
+
+		readings = append(readings, bmc.SensorReading{
+			ID:      firstNonEmpty(p.MemberID, p.ID, p.Name),
+			Name:    p.Name,
+			Type:    bmc.SensorTypePowerSupply,
+			Reading: float32ToFloat64(watts),
+			Units:   "W",
+			Health:  sensorHealth(p.Status),
+			State:   string(p.Status.State),
+		})
+	}
+
+	return readings
+}
+
+func sensorHealth(status schemas.Status) bmc.SensorHealth {
+	return bmc.ParseSensorHealth(string(status.Health))
+}
+
+func intToFloat64(v *int) *float64 {
+	if v == nil {
+		return nil
+	}
+
+	f := float64(*v)
+	return &f
+}
+
+func float32ToFloat64(v *float32) *float64 {
+	if v == nil {
+		return nil
+	}
+
+	f := float64(*v)
+	return &f
+}
+
+func firstNonNil(values ...*float32) *float32 {
+	for _, v := range values {
+		if v != nil {
+			return v
+		}
+	}
+
+	return nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,98 @@
+package redfishwrapper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// TestSensors verifies sensors are read from the Chassis Sensors collection
+// when present, and from the Thermal and Power resources otherwise.
+func TestSensors(t *testing.T) {
+	mux := http.NewServeMux()
+	for path, fixture := range map[string]string{
+		"/redfish/v1/":          "serviceroot.json",
+		"/redfish/v1/Systems":   "systems.json",
+		"/redfish/v1/Systems/1": "systems_1.json",
+
+		"/redfish/v1/Chassis":                    "sensors/chassis.json",
+		"/redfish/v1/Chassis/1":                  "sensors/chassis_1.json",
+		"/redfish/v1/Chassis/1/Sensors":          "sensors/sensors.json",
+		"/redfish/v1/Chassis/1/Sensors/CPU1Temp": "sensors/sensor_cpu1temp.json",
+		"/redfish/v1/Chassis/1/Sensors/FAN1":     "sensors/sensor_fan1.json",
+		"/redfish/v1/Chassis/2":                  "sensors/chassis_2.json",
+		"/redfish/v1/Chassis/2/Thermal":          "sensors/thermal.json",
+		"/redfish/v1/Chassis/2/Power":            "sensors/power.json",
+	} {
+		mux.HandleFunc(path, endpointFunc(t, fixture))
+	}
+
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	client := NewClient(u.Hostname(), u.Port(), "", "", WithBasicAuthEnabled(true))
+	require.NoError(t, client.Open(context.Background()))
+	defer client.Close(context.Background())
+
+	readings, err := client.Sensors(context.Background())
+	require.NoError(t, err)
+	require.Len(t, readings, 6)
+
+	byName := make(map[string]bmc.SensorReading, len(readings))
+	for _, r := range readings {
+		byName[r.Name] = r
+	}
+
+	cpu := byName["CPU1 Temp"]
+	assert.Equal(t, "CPU1Temp", cpu.ID)
+	assert.Equal(t, bmc.SensorTypeTemperature, cpu.Type)
+	require.NotNil(t, cpu.Reading)
+	assert.Equal(t, 45.0, *cpu.Reading)
+	assert.Equal(t, "Cel", cpu.Units)
+	assert.Equal(t, bmc.SensorHealthOK, cpu.Health)
+	assert.Equal(t, "CPU", cpu.PhysicalContext)
+	require.NotNil(t, cpu.Thresholds.UpperCritical)
+	assert.Equal(t, 95.0, *cpu.Thresholds.UpperCritical)
+	assert.Nil(t, cpu.Thresholds.LowerCritical)
+
+	fan := byName["FAN1"]
+	assert.Equal(t, bmc.SensorTypeFan, fan.Type)
+	assert.Equal(t, bmc.SensorHealthCritical, fan.Health)
+	require.NotNil(t, fan.Thresholds.LowerCritical)
+	assert.Equal(t, 700.0, *fan.Thresholds.LowerCritical)
+
+	inlet := byName["Inlet Temp"]
+	assert.Equal(t, bmc.SensorTypeTemperature, inlet.Type)
+	require.NotNil(t, inlet.Reading)
+	assert.Equal(t, 24.0, *inlet.Reading)
+	require.NotNil(t, inlet.Thresholds.UpperNonCritical)
+	assert.Equal(t, 42.0, *inlet.Thresholds.UpperNonCritical)
+
+	sysFan := byName["System Fan 1"]
+	assert.Equal(t, bmc.SensorTypeFan, sysFan.Type)
+	assert.Equal(t, "RPM", sysFan.Units)
+	require.NotNil(t, sysFan.Reading)
+	assert.Equal(t, 5400.0, *sysFan.Reading)
+
+	volts := byName["12V"]
+	assert.Equal(t, bmc.SensorTypeVoltage, volts.Type)
+	assert.Equal(t, "V", volts.Units)
+	require.NotNil(t, volts.Reading)
+	assert.Equal(t, 12.25, *volts.Reading)
+
+	psu := byName["PSU1"]
+	assert.Equal(t, bmc.SensorTypePowerSupply, psu.Type)
+	assert.Equal(t, bmc.SensorHealthWarning, psu.Health)
+	require.NotNil(t, psu.Reading)
+	assert.Equal(t, 212.0, *psu.Reading)
+}
@@ -0,0 +1,235 @@
+package redfishwrapper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+const (
+	// sessionsURI and sessionServiceURI are the fixed session URIs of the Redfish specification.
+	sessionsURI       = "/redfish/v1/SessionService/Sessions"
+	sessionServiceURI = "/redfish/v1/SessionService"
+
+	authTokenHeader = "X-Auth-Token"
+)
+
+var errSessionToken = errors.New("session response does not include an X-Auth-Token")
+
+// ErrNoSession is returned for the session token of a client authenticating with basic auth, which
+// does not open a redfish session.
+var ErrNoSession = errors.New("no redfish session, the client authenticates with basic auth")
+
+// sessionTransport is the http.RoundTripper of session authenticated clients, it re-establishes
+// a session that has expired and retries the rejected request once with the new session.
+//
+// gofish holds the session token in the APIClient and resources fetched through it, the token
+// of the renewed session is substituted for the expired one on all requests going out.
+type sessionTransport struct {
+	base     http.RoundTripper
+	endpoint string
+	user     string
+	pass     string
+
+	mu sync.Mutex
+	// token and location identify the renewed session, they are empty until the session was renewed.
+	token    string
+	location string
+}
+
+// newSessionTransport returns a sessionTransport sending requests through base.
+func newSessionTransport(base http.RoundTripper, endpoint, user, pass string) *sessionTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &sessionTransport{base: base, endpoint: endpoint, user: user, pass: pass}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	sent := req.Header.Get(authTokenHeader)
+	if sent == "" {
+		// session creation and unauthenticated requests
+		return t.base.RoundTrip(req)
+	}
+
+	if token := t.currentToken(); token != "" && token != sent {
+		req = withAuthToken(req, token)
+		sent = token
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// the request can only be retried when its body can be replayed
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+
+	token, rErr := t.renew(req.Context(), sent)
+	if rErr != nil {
+		// hand the original response back to the caller
+		return resp, nil
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	retry := withAuthToken(req, token)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+
+		retry.Body = body
+	}
+
+	return t.base.RoundTrip(retry)
+}
+
+// currentToken returns the renewed session token, or an empty string when the session was not renewed.
+func (t *sessionTransport) currentToken() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.token
+}
+
+// renew creates a new session in place of the session identified by the expired token, when the
+// session was already renewed by a concurrent request the renewed session token is returned.
+func (t *sessionTransport) renew(ctx context.Context, expired string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && t.token != expired {
+		return t.token, nil
+	}
+
+	payload, err := json.Marshal(map[string]string{"UserName": t.user, "Password": t.pass})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint+sessionsURI, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return "", err
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", errors.Wrapf(bmclibErrs.ErrLoginFailed, "session create returned: %d", resp.StatusCode)
+	}
+
+	token := resp.Header.Get(authTokenHeader)
+	if token == "" {
+		return "", errSessionToken
+	}
+
+	t.token = token
+	t.location = resp.Header.Get("Location")
+
+	return token, nil
+}
+
+// logout deletes the renewed session, gofish only knows about the session it created at login.
+func (t *sessionTransport) logout(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.location == "" {
+		return nil
+	}
+
+	location := t.location
+	if location[0] == '/' {
+		location = t.endpoint + location
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, location, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set(authTokenHeader, t.token)
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+
+	t.token, t.location = "", ""
+
+	return resp.Body.Close()
+}
+
+// withAuthToken returns a copy of the request carrying the given session token.
+func withAuthToken(req *http.Request, token string) *http.Request {
+	r := req.Clone(req.Context())
+	r.Header.Set(authTokenHeader, token)
+
+	return r
+}
+
+// KeepSessionAlive refreshes the redfish session idle timer by querying the SessionService, a session that
+// has expired is re-established by the session transport. There is no session to keep alive with basic auth.
+func (c *Client) KeepSessionAlive(ctx context.Context) error {
+	if err := c.SessionActive(); err != nil {
+		return errors.Wrap(bmclibErrs.ErrNotAuthenticated, err.Error())
+	}
+
+	if c.basicAuth {
+		return nil
+	}
+
+	resp, err := c.client.WithContext(ctx).Get(sessionServiceURI)
+	if err != nil {
+		return errors.Wrap(bmclibErrs.ErrNotAuthenticated, err.Error())
+	}
+
+	return resp.Body.Close()
+}
+
+// SessionToken returns the token of the redfish session, for vendor endpoints outside of the Redfish
+// service that take the session token in a cookie or form field instead of the X-Auth-Token header.
+func (c *Client) SessionToken() (string, error) {
+	if c.basicAuth {
+		return "", ErrNoSession
+	}
+
+	if err := c.SessionActive(); err != nil {
+		return "", errors.Wrap(bmclibErrs.ErrNotAuthenticated, err.Error())
+	}
+
+	if c.session != nil {
+		if token := c.session.currentToken(); token != "" {
+			return token, nil
+		}
+	}
+
+	session, err := c.client.GetSession()
+	if err != nil {
+		return "", errors.Wrap(bmclibErrs.ErrNotAuthenticated, err.Error())
+	}
+
+	return session.Token, nil
+}
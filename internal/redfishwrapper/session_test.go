@@ -0,0 +1,159 @@
+package redfishwrapper
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sessionServer is a mock BMC issuing session tokens that can be expired.
+type sessionServer struct {
+	mu          sync.Mutex
+	logins      int
+	rejectLogin bool
+	valid       map[string]bool
+	deleted     []string
+	patches     []string
+}
+
+func (s *sessionServer) expireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.valid = map[string]bool{}
+}
+
+func (s *sessionServer) authenticated(r *http.Request) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.valid[r.Header.Get("X-Auth-Token")]
+}
+
+func newSessionTestClient(t *testing.T, s *sessionServer) *Client {
+	t.Helper()
+
+	s.valid = map[string]bool{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/redfish/v1/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(mustReadFile(t, "serviceroot.json"))
+	})
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if r.Method != http.MethodPost || s.rejectLogin {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		s.logins++
+		token := "token-" + strconv.Itoa(s.logins)
+		s.valid[token] = true
+
+		w.Header().Set("X-Auth-Token", token)
+		w.Header().Set("Location", "/redfish/v1/SessionService/Sessions/"+strconv.Itoa(s.logins))
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions/", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.deleted = append(s.deleted, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/redfish/v1/SessionService", func(w http.ResponseWriter, r *http.Request) {
+		if !s.authenticated(r) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"@odata.id": "/redfish/v1/SessionService", "Id": "SessionService"}`))
+	})
+	mux.HandleFunc("/redfish/v1/Systems/1", func(w http.ResponseWriter, r *http.Request) {
+		if !s.authenticated(r) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.Method == http.MethodPatch {
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			s.mu.Lock()
+			s.patches = append(s.patches, string(b))
+			s.mu.Unlock()
+
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		_, _ = w.Write(mustReadFile(t, "systems_1.json"))
+	})
+
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	client := NewClient(u.Hostname(), u.Port(), "admin", "password")
+	require.NoError(t, client.Open(context.Background()))
+
+	return client
+}
+
+func TestSessionRenewal(t *testing.T) {
+	s := &sessionServer{}
+	client := newSessionTestClient(t, s)
+
+	s.expireSessions()
+
+	// the GET and the PATCH body are retried with the renewed session
+	require.NoError(t, client.patch("/redfish/v1/Systems/1", map[string]any{"AssetTag": "rack-12"}))
+	assert.Equal(t, 2, s.logins)
+	assert.Equal(t, []string{`{"AssetTag":"rack-12"}`}, s.patches)
+
+	// the renewed session is reused by later requests
+	resp, err := client.Get("/redfish/v1/Systems/1")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 2, s.logins)
+
+	require.NoError(t, client.Close(context.Background()))
+	assert.Contains(t, s.deleted, "/redfish/v1/SessionService/Sessions/2")
+}
+
+func TestSessionRenewalLoginFailure(t *testing.T) {
+	s := &sessionServer{}
+	client := newSessionTestClient(t, s)
+
+	s.expireSessions()
+	s.rejectLogin = true
+
+	_, err := client.Get("/redfish/v1/Systems/1")
+	assert.ErrorContains(t, err, "401")
+	assert.Equal(t, 1, s.logins)
+}
+
+func TestKeepSessionAlive(t *testing.T) {
+	s := &sessionServer{}
+	client := newSessionTestClient(t, s)
+
+	require.NoError(t, client.KeepSessionAlive(context.Background()))
+	assert.Equal(t, 1, s.logins)
+
+	s.expireSessions()
+
+	require.NoError(t, client.KeepSessionAlive(context.Background()))
+	assert.Equal(t, 2, s.logins)
+}
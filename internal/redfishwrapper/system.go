@@ -3,6 +3,7 @@ package redfishwrapper
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
 
@@ -55,6 +56,10 @@ func (c *Client) System() (*schemas.ComputerSystem, error) {
 		return nil, err
 	}
 
+	systems = slices.DeleteFunc(systems, func(s *schemas.ComputerSystem) bool {
+		return s != nil && c.ignored(s.ID)
+	})
+
 	// If no system name is set and there is only one system, return it.
 	// This is to handle backwards compatibility where we didn't require passing
 	// a system name to the client.
@@ -79,6 +84,10 @@ func (c *Client) Manager(ctx context.Context) (*schemas.Manager, error) {
 		return nil, err
 	}
 
+	ms = slices.DeleteFunc(ms, func(m *schemas.Manager) bool {
+		return m != nil && c.ignored(m.ID)
+	})
+
 	// If no system name is set and there is only one manager, return it.
 	// This is to handle backwards compatibility where we didn't require passing
 	// a system name to the client.
@@ -12,7 +12,11 @@ import (
 
 	"github.com/bmc-toolbox/bmclib/v2/internal/httpclient"
 	"github.com/bmc-toolbox/bmclib/v2/providers/homeassistant"
+	"github.com/bmc-toolbox/bmclib/v2/providers/libvirt"
+	"github.com/bmc-toolbox/bmclib/v2/providers/redfishpdu"
 	"github.com/bmc-toolbox/bmclib/v2/providers/rpc"
+	"github.com/bmc-toolbox/bmclib/v2/providers/snmppdu"
+	"github.com/bmc-toolbox/bmclib/v2/providers/sshclp"
 )
 
 // Option for setting optional Client values
@@ -56,6 +60,41 @@ func WithPerProviderTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithSessionKeepalive has Open start a goroutine that refreshes the BMC sessions of the opened
+// providers at the given interval, so that the sessions of a long-lived Client do not time out.
+// Close stops the goroutine. Expired sessions are re-established by the providers whether the
+// keepalive is enabled or not.
+func WithSessionKeepalive(interval time.Duration) Option {
+	return func(args *Client) {
+		args.keepaliveInterval = interval
+	}
+}
+
+// WithConcurrentReads has the read operations, like GetPowerState and Inventory, query all providers
+// concurrently and return the result of the first provider to succeed, the remaining providers are cancelled.
+// The providers that failed or were cancelled are recorded in the Client metadata. Each provider is given
+// the whole remaining deadline of the context rather than the per provider timeout.
+//
+// Operations that change the BMC state keep trying the providers one at a time.
+func WithConcurrentReads() Option {
+	return func(args *Client) {
+		args.concurrentReads = true
+	}
+}
+
+// WithVerifiedReads has GetPowerState, GetBootDeviceOverride and GetSecureBoot query the value from all
+// providers and compare the results, to detect a BMC whose interfaces disagree before acting on the value.
+// The value each provider returned and whether they disagree is recorded in the Client metadata, with
+// strict set a disagreement is also returned as an error wrapping errors.ErrInconsistentResults.
+//
+// Verification takes precedence over WithConcurrentReads for these operations.
+func WithVerifiedReads(strict bool) Option {
+	return func(args *Client) {
+		args.verifiedReads = true
+		args.verifiedReadsStrict = strict
+	}
+}
+
 // WithIPMICipherSuite sets the cipher suite for the pure-go ipmi provider.
 func WithIPMICipherSuite(cipherSuite string) Option {
 	return func(args *Client) {
@@ -105,6 +144,13 @@ func WithAsrockrackPort(port string) Option {
 	}
 }
 
+// WithMegaRACPort sets the port used by the MegaRAC SP-X provider.
+func WithMegaRACPort(port string) Option {
+	return func(args *Client) {
+		args.providerConfig.megarac.Port = port
+	}
+}
+
 // WithRedfishHTTPClient sets the HTTP client used by the redfish (gofish) provider.
 func WithRedfishHTTPClient(httpClient *http.Client) Option {
 	return func(args *Client) {
@@ -164,6 +210,14 @@ func WithIntelAMTPort(port uint32) Option {
 	}
 }
 
+// WithIntelAMTRedirectionPort sets the port of the redirection service serving
+// the virtual media of the Intel AMT provider.
+func WithIntelAMTRedirectionPort(port uint32) Option {
+	return func(args *Client) {
+		args.providerConfig.intelamt.RedirectionPort = port
+	}
+}
+
 // WithDellRedfishVersionsNotCompatible sets the list of incompatible redfish versions.
 //
 // With this option set, The bmclib.Registry.FilterForCompatible(ctx) method will not proceed on
@@ -207,6 +261,136 @@ func WithLenovoVersionsNotCompatible(versions []string) Option {
 	}
 }
 
+// WithHPEPort sets the port for the HPE iLO (redfish) provider.
+func WithHPEPort(port string) Option {
+	return func(args *Client) {
+		args.providerConfig.hpe.Port = port
+	}
+}
+
+// WithHPEUseBasicAuth sets HTTP Basic auth (instead of session login) for the
+// HPE iLO provider. Firmware uploads to the iLO repository require a session.
+func WithHPEUseBasicAuth(useBasicAuth bool) Option {
+	return func(args *Client) {
+		args.providerConfig.hpe.UseBasicAuth = useBasicAuth
+	}
+}
+
+// WithHPEVersionsNotCompatible sets the list of incompatible redfish versions
+// for the HPE iLO provider.
+//
+// With this option set, the bmclib.Registry.FilterForCompatible(ctx) method will
+// not proceed on devices with the given redfish version(s).
+func WithHPEVersionsNotCompatible(versions []string) Option {
+	return func(args *Client) {
+		args.providerConfig.hpe.VersionsNotCompatible = append(args.providerConfig.hpe.VersionsNotCompatible, versions...)
+	}
+}
+
+// WithFujitsuPort sets the port for the Fujitsu iRMC (redfish) provider.
+func WithFujitsuPort(port string) Option {
+	return func(args *Client) {
+		args.providerConfig.fujitsu.Port = port
+	}
+}
+
+// WithFujitsuUseBasicAuth sets HTTP Basic auth (instead of session login) for the
+// Fujitsu iRMC provider.
+func WithFujitsuUseBasicAuth(useBasicAuth bool) Option {
+	return func(args *Client) {
+		args.providerConfig.fujitsu.UseBasicAuth = useBasicAuth
+	}
+}
+
+// WithFujitsuVersionsNotCompatible sets the list of incompatible redfish versions
+// for the Fujitsu iRMC provider.
+//
+// With this option set, the bmclib.Registry.FilterForCompatible(ctx) method will
+// not proceed on devices with the given redfish version(s).
+func WithFujitsuVersionsNotCompatible(versions []string) Option {
+	return func(args *Client) {
+		args.providerConfig.fujitsu.VersionsNotCompatible = append(args.providerConfig.fujitsu.VersionsNotCompatible, versions...)
+	}
+}
+
+// WithCiscoPort sets the port for the Cisco CIMC (redfish) provider.
+func WithCiscoPort(port string) Option {
+	return func(args *Client) {
+		args.providerConfig.cisco.Port = port
+	}
+}
+
+// WithCiscoUseBasicAuth sets HTTP Basic auth (instead of session login) for the
+// Cisco CIMC provider.
+func WithCiscoUseBasicAuth(useBasicAuth bool) Option {
+	return func(args *Client) {
+		args.providerConfig.cisco.UseBasicAuth = useBasicAuth
+	}
+}
+
+// WithCiscoVersionsNotCompatible sets the list of incompatible redfish versions
+// for the Cisco CIMC provider.
+//
+// With this option set, the bmclib.Registry.FilterForCompatible(ctx) method will
+// not proceed on devices with the given redfish version(s).
+func WithCiscoVersionsNotCompatible(versions []string) Option {
+	return func(args *Client) {
+		args.providerConfig.cisco.VersionsNotCompatible = append(args.providerConfig.cisco.VersionsNotCompatible, versions...)
+	}
+}
+
+// WithXFusionPort sets the port for the xFusion iBMC (redfish) provider.
+func WithXFusionPort(port string) Option {
+	return func(args *Client) {
+		args.providerConfig.xfusion.Port = port
+	}
+}
+
+// WithXFusionUseBasicAuth sets HTTP Basic auth (instead of session login) for
+// the xFusion iBMC provider.
+func WithXFusionUseBasicAuth(useBasicAuth bool) Option {
+	return func(args *Client) {
+		args.providerConfig.xfusion.UseBasicAuth = useBasicAuth
+	}
+}
+
+// WithXFusionVersionsNotCompatible sets the list of incompatible redfish
+// versions for the xFusion iBMC provider.
+//
+// With this option set, the bmclib.Registry.FilterForCompatible(ctx) method will
+// not proceed on devices with the given redfish version(s).
+func WithXFusionVersionsNotCompatible(versions []string) Option {
+	return func(args *Client) {
+		args.providerConfig.xfusion.VersionsNotCompatible = append(args.providerConfig.xfusion.VersionsNotCompatible, versions...)
+	}
+}
+
+// WithNVIDIAPort sets the port for the NVIDIA HGX (redfish) provider.
+func WithNVIDIAPort(port string) Option {
+	return func(args *Client) {
+		args.providerConfig.nvidia.Port = port
+	}
+}
+
+// WithNVIDIAUseBasicAuth sets HTTP Basic auth (instead of session login) for
+// the NVIDIA HGX provider.
+func WithNVIDIAUseBasicAuth(useBasicAuth bool) Option {
+	return func(args *Client) {
+		args.providerConfig.nvidia.UseBasicAuth = useBasicAuth
+	}
+}
+
+// WithNVIDIAVersionsNotCompatible sets the list of incompatible redfish
+// versions for the NVIDIA HGX provider.
+//
+// With this option set, the bmclib.Registry.FilterForCompatible(ctx) method will
+// not proceed on devices with the given redfish version(s).
+func WithNVIDIAVersionsNotCompatible(versions []string) Option {
+	return func(args *Client) {
+		args.providerConfig.nvidia.VersionsNotCompatible = append(args.providerConfig.nvidia.VersionsNotCompatible, versions...)
+	}
+}
+
 // WithRPCOpt configures the rpc provider.
 func WithRPCOpt(opt rpc.Provider) Option { //nolint:gocritic // functional options take their config by value by convention
 	return func(args *Client) {
@@ -221,6 +405,40 @@ func WithHomeAssistantOpt(opt homeassistant.Config) Option { //nolint:gocritic /
 	}
 }
 
+// WithRedfishPDUOpt configures the Redfish PDU provider, the client host and
+// credentials are the ones of the PDU.
+func WithRedfishPDUOpt(opt redfishpdu.Config) Option { //nolint:gocritic // functional options take their config by value by convention
+	return func(args *Client) {
+		args.providerConfig.redfishpdu = opt
+	}
+}
+
+// WithLibvirtOpt configures the libvirt provider, the machine is the libvirt
+// domain of opt.Domain and the client host and credentials are not used.
+func WithLibvirtOpt(opt libvirt.Config) Option { //nolint:gocritic // functional options take their config by value by convention
+	return func(args *Client) {
+		args.providerConfig.libvirt = opt
+	}
+}
+
+// WithSNMPPDUOpt configures the SNMP PDU provider. The provider is registered
+// after all the other providers, as a power fallback for a machine with an
+// unresponsive BMC, when the PDU host and the outlets of the machine are set.
+func WithSNMPPDUOpt(opt snmppdu.Config) Option { //nolint:gocritic // functional options take their config by value by convention
+	return func(args *Client) {
+		args.providerConfig.snmppdu = opt
+	}
+}
+
+// WithSSHCLPOpt configures the SSH CLP provider, it is registered when
+// opt.Profile names the command line of the BMC, e.g. sshclp.ProfileRacadm.
+// The client host and credentials are used unless opt sets them.
+func WithSSHCLPOpt(opt sshclp.Config) Option { //nolint:gocritic // functional options take their config by value by convention
+	return func(args *Client) {
+		args.providerConfig.sshclp = opt
+	}
+}
+
 // WithTracerProvider specifies a tracer provider to use for creating a tracer.
 // If none is specified a noop tracerprovider is used.
 func WithTracerProvider(provider oteltrace.TracerProvider) Option {
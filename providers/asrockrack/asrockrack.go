@@ -1,21 +1,19 @@
 // Package asrockrack implements a bmclib provider for ASRock Rack BMCs.
+//
+// ASRock Rack BMCs run the AMI MegaRAC SP-X web API with ASRock Rack OEM
+// endpoints, the provider is the megarac provider configured with
+// megarac.ProfileASRockRack.
 package asrockrack
 
 import (
-	"context"
 	"crypto/x509"
-	"fmt"
 	"net/http"
-	"strings"
 
-	"github.com/bmc-toolbox/common"
 	"github.com/go-logr/logr"
 	"github.com/jacobweinstock/registrar"
-	"github.com/pkg/errors"
 
-	"github.com/bmc-toolbox/bmclib/v2/constants"
-	"github.com/bmc-toolbox/bmclib/v2/internal/httpclient"
 	"github.com/bmc-toolbox/bmclib/v2/providers"
+	"github.com/bmc-toolbox/bmclib/v2/providers/megarac"
 )
 
 const (
@@ -45,20 +43,17 @@ var Features = registrar.Features{
 	providers.FeatureInventoryRead,
 	providers.FeaturePowerSet,
 	providers.FeaturePowerState,
+	providers.FeatureSensorsRead,
 }
 
+// UserAccount is a ASRR BMC user account struct
+type UserAccount = megarac.UserAccount
+
 // ASRockRack holds the status and properties of a connection to a asrockrack bmc
 type ASRockRack struct {
-	loginSession         *loginSession
-	httpClient           *http.Client
-	log                  logr.Logger
-	ip                   string
-	username             string
-	password             string
-	deviceModel          string
-	httpClientSetupFuncs []func(*http.Client)
-	resetRequired        bool
-	skipLogout           bool
+	*megarac.Conn
+
+	opts []megarac.Option
 }
 
 // Config holds the optional configuration for an ASRockRack connection.
@@ -74,14 +69,14 @@ type ASRockOption func(*ASRockRack)
 // Using this option with an nil pool uses the system CAs.
 func WithSecureTLS(rootCAs *x509.CertPool) ASRockOption {
 	return func(r *ASRockRack) {
-		r.httpClientSetupFuncs = append(r.httpClientSetupFuncs, httpclient.SecureTLSOption(rootCAs))
+		r.opts = append(r.opts, megarac.WithSecureTLS(rootCAs))
 	}
 }
 
 // WithHTTPClient sets an HTTP client on the ASRockRack
 func WithHTTPClient(c *http.Client) ASRockOption {
 	return func(ar *ASRockRack) {
-		ar.httpClient = c
+		ar.opts = append(ar.opts, megarac.WithHTTPClient(c))
 	}
 }
 
@@ -93,22 +88,14 @@ func New(ip, username, password string, log logr.Logger) *ASRockRack {
 // NewWithOptions returns a new ASRockRack instance with options ready to be used
 func NewWithOptions(ip, username, password string, log logr.Logger, opts ...ASRockOption) *ASRockRack {
 	r := &ASRockRack{
-		ip:           ip,
-		username:     username,
-		password:     password,
-		log:          log,
-		loginSession: &loginSession{},
+		opts: []megarac.Option{megarac.WithProfile(megarac.ProfileASRockRack)},
 	}
 	for _, opt := range opts {
 		opt(r)
 	}
-	if r.httpClient == nil {
-		r.httpClient = httpclient.Build(r.httpClientSetupFuncs...)
-	} else {
-		for _, setupFunc := range r.httpClientSetupFuncs {
-			setupFunc(r.httpClient)
-		}
-	}
+
+	r.Conn = megarac.New(ip, username, password, log, r.opts...)
+
 	return r
 }
 
@@ -116,74 +103,3 @@ func NewWithOptions(ip, username, password string, log logr.Logger, opts ...ASRo
 func (a *ASRockRack) Name() string {
 	return ProviderName
 }
-
-// Open a connection to a BMC, implements the Opener interface
-func (a *ASRockRack) Open(ctx context.Context) (err error) {
-	if err := a.httpsLogin(ctx); err != nil {
-		return err
-	}
-
-	return a.supported(ctx)
-}
-
-func (a *ASRockRack) supported(ctx context.Context) error {
-	supported := []string{
-		E3C256D4ID_NL,
-		E3C246D4ID_NL,
-		E3C246D4I_NL,
-	}
-
-	if a.deviceModel == "" {
-		device := common.NewDevice()
-		device.Metadata = map[string]string{}
-
-		err := a.fruAttributes(ctx, &device)
-		if err != nil {
-			return errors.Wrap(err, "failed to identify device model")
-		}
-
-		if device.Model == "" {
-			return errors.Wrap(err, "failed to identify device model - empty model attribute")
-		}
-
-		a.deviceModel = device.Model
-	}
-
-	for _, s := range supported {
-		if strings.EqualFold(a.deviceModel, s) {
-			return nil
-		}
-	}
-
-	return fmt.Errorf("device model not supported: %s", a.deviceModel)
-}
-
-// Close a connection to a BMC, implements the Closer interface
-func (a *ASRockRack) Close(ctx context.Context) (err error) {
-	if a.skipLogout {
-		return nil
-	}
-
-	return a.httpsLogout(ctx)
-}
-
-// CheckCredentials verify whether the credentials are valid or not
-func (a *ASRockRack) CheckCredentials(ctx context.Context) (err error) {
-	return a.httpsLogin(ctx)
-}
-
-// PostCode returns the BIOS/UEFI post code status and value.
-func (a *ASRockRack) PostCode(ctx context.Context) (status string, code int, err error) {
-	postInfo, err := a.postCodeInfo(ctx)
-	if err != nil {
-		return status, code, err
-	}
-
-	code = postInfo.PostData
-	status, exists := knownPOSTCodes[code]
-	if !exists {
-		status = constants.POSTCodeUnknown
-	}
-
-	return status, code, nil
-}
@@ -1,58 +1,97 @@
 package asrockrack
 
 import (
+	"bytes"
 	"context"
-	"os"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
-	"time"
 
-	"gopkg.in/go-playground/assert.v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestHttpLogin(t *testing.T) {
-	err := aClient.httpsLogin(context.TODO())
-	if err != nil {
-		t.Errorf("login: %s", err.Error())
-	}
+var (
+	loginResponse   = []byte(`{ "ok": 0, "privilege": 4, "extendedpriv": 259, "racsession_id": 10, "CSRFToken": "l5L29IP7" }`)
+	fwinfoResponse  = []byte(`{ "BMC_fw_version": "0.01.00", "BIOS_fw_version": "L2.07B", "ME_fw_version": "5.1.3.78", "Micro_Code_version": "000000ca", "CPLD_version": "N\/A", "CM_version": "0.13.01", "BPB_version": "0.0.002.0", "Node_id": "2" }`)
+	fruinfoResponse = []byte(`[ { "device": { "id": 0, "name": "BMC_FRU" }, "chassis": { "version": 1, "length": 3, "type": "Main Server Chassis", "serial_number": "K61206147700263" }, "board": { "version": 1, "length": 7, "manufacturer": "ASRockRack", "product_name": "__MODEL__", "serial_number": "197965920000514" } } ]`)
+)
+
+// mockBMC returns a MegaRAC BMC mock with the ASRockRack OEM firmware info endpoint,
+// the FRU board reports the given model.
+func mockBMC(t *testing.T, model string) *httptest.Server {
+	t.Helper()
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/session", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(loginResponse)
+	})
+	handler.HandleFunc("/api/fru", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(bytes.Replace(fruinfoResponse, []byte("__MODEL__"), []byte(model), 1))
+	})
+	handler.HandleFunc("/api/asrr/fw-info", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(fwinfoResponse)
+	})
+	handler.HandleFunc("/api/sensors", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
 
-	assert.Equal(t, "l5L29IP7", aClient.loginSession.CSRFToken)
+	srv := httptest.NewTLSServer(handler)
+	t.Cleanup(srv.Close)
+
+	return srv
 }
 
-func TestClose(t *testing.T) {
-	err := aClient.httpsLogin(context.TODO())
-	if err != nil {
-		t.Errorf("login setup: %s", err.Error())
-	}
+func newClient(t *testing.T, srv *httptest.Server) *ASRockRack {
+	t.Helper()
 
-	err = aClient.httpsLogout(context.TODO())
-	if err != nil {
-		t.Errorf("logout: %s", err.Error())
-	}
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	return NewWithOptions(u.Host, "foo", "bar", logr.Discard(), WithHTTPClient(srv.Client()))
 }
 
-func TestFirwmwareUpdateBMC(t *testing.T) {
-	err := aClient.httpsLogin(context.TODO())
-	if err != nil {
-		t.Errorf("login: %s", err.Error())
-	}
+func TestName(t *testing.T) {
+	assert.Equal(t, ProviderName, New("127.0.0.1", "foo", "bar", logr.Discard()).Name())
+}
 
-	upgradeFile := "/tmp/dummy-E3C246D4I-NL_L0.01.00.ima"
-	_, err = os.Create(upgradeFile)
-	if err != nil {
-		t.Errorf("create file: %s", err.Error())
+func TestOpen(t *testing.T) {
+	testCases := []struct {
+		model     string
+		supported bool
+	}{
+		{E3C246D4I_NL, true},
+		{E3C246D4ID_NL, true},
+		{E3C256D4ID_NL, true},
+		{"ROMED8-2T", false},
 	}
 
-	fh, err := os.Open(upgradeFile)
-	if err != nil {
-		t.Errorf("file open: %s", err.Error())
-	}
+	for _, tc := range testCases {
+		t.Run(tc.model, func(t *testing.T) {
+			client := newClient(t, mockBMC(t, tc.model))
 
-	defer fh.Close()
-	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute*15)
-	defer cancel()
+			err := client.Open(context.TODO())
+			if tc.supported {
+				assert.NoError(t, err)
+				return
+			}
 
-	err = aClient.firmwareUploadBMC(ctx, fh)
-	if err != nil {
-		t.Errorf("upload: %s", err.Error())
+			assert.Error(t, err)
+		})
 	}
 }
+
+func TestInventory(t *testing.T) {
+	client := newClient(t, mockBMC(t, E3C256D4ID_NL))
+
+	require.NoError(t, client.Open(context.TODO()))
+
+	// the firmware versions are read from the ASRockRack OEM endpoint
+	device, err := client.Inventory(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, "ASRockRack", device.Vendor)
+	assert.Equal(t, "0.01.00", device.BMC.Firmware.Installed)
+	assert.Equal(t, "L2.07B", device.BIOS.Firmware.Installed)
+}
@@ -0,0 +1,94 @@
+package cisco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stmcginnis/gofish/schemas"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+// xmlAPITokenPrefix prefixes the BIOS token names of the CIMC XML API and
+// CLI, e.g. "vpIntelHyperThreadingTech", the Redfish attribute name is the
+// token name without the prefix.
+const xmlAPITokenPrefix = "vp"
+
+// errUnknownBiosAttributes is returned when attributes to set are not exposed
+// by the BIOS.
+var errUnknownBiosAttributes = errors.New("unknown BIOS attributes")
+
+// compile-time assertions that the provider implements the BIOS configuration interfaces.
+var (
+	_ bmc.BiosConfigurationGetter = (*Conn)(nil)
+	_ bmc.BiosConfigurationSetter = (*Conn)(nil)
+)
+
+// GetBiosConfiguration returns the current BIOS attributes as a key/value map,
+// read from the ComputerSystem Bios resource Attributes.
+//
+// Implements bmc.BiosConfigurationGetter.
+func (c *Conn) GetBiosConfiguration(ctx context.Context) (biosConfig map[string]string, err error) {
+	return c.redfishwrapper.GetBiosConfiguration(ctx)
+}
+
+// SetBiosConfiguration writes BIOS attributes to the Bios/Settings resource,
+// CIMC applies them on the next host boot.
+//
+// The attributes may be given by their Redfish name or by the CIMC XML API
+// token name. CIMC accepts and silently drops attributes the BIOS does not
+// expose, the attributes are validated against the current BIOS attributes
+// and the unknown attributes are returned as an error before any change is
+// written.
+//
+// Implements bmc.BiosConfigurationSetter.
+func (c *Conn) SetBiosConfiguration(ctx context.Context, biosConfig map[string]string) (err error) {
+	sys, err := c.redfishwrapper.System()
+	if err != nil {
+		return err
+	}
+
+	bios, err := sys.Bios()
+	if err != nil {
+		return err
+	}
+
+	if bios == nil {
+		return bmclibErrs.ErrNoBiosAttributes
+	}
+
+	settingsAttributes := make(schemas.SettingsAttributes, len(biosConfig))
+	var unknown []string
+
+	for attr, value := range biosConfig {
+		name := biosAttributeName(attr, bios.Attributes)
+		if _, ok := bios.Attributes[name]; !ok {
+			unknown = append(unknown, attr)
+			continue
+		}
+
+		settingsAttributes[name] = value
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: %s", errUnknownBiosAttributes, strings.Join(unknown, ", "))
+	}
+
+	// CIMC does not support the "@Redfish.SettingsApplyTime" annotation.
+	return bios.UpdateBiosAttributes(settingsAttributes)
+}
+
+// biosAttributeName returns the Redfish attribute name for a BIOS attribute
+// given by its Redfish name or its XML API token name.
+func biosAttributeName(name string, attributes schemas.SettingsAttributes) string {
+	if _, ok := attributes[name]; ok {
+		return name
+	}
+
+	return strings.TrimPrefix(name, xmlAPITokenPrefix)
+}
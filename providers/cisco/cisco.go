@@ -0,0 +1,236 @@
+// Package cisco implements a bmclib provider for standalone Cisco UCS C-series
+// servers managed by the Cisco Integrated Management Controller (CIMC).
+//
+// The CIMC Redfish service differs from the DMTF reference behavior in enough
+// places that the generic redfish provider misbehaves. This provider is built
+// on top of the shared gofish-backed [redfishwrapper.Client] and handles the
+// CIMC specific behavior in dedicated files:
+//
+//   - power.go sets the boot override with the properties CIMC accepts, the
+//     override mode follows the BIOS boot mode and cannot be set, a persistent
+//     override is not supported.
+//
+//   - bios.go accepts the BIOS token names of the CIMC XML API and rejects
+//     tokens the BIOS does not expose, which CIMC would otherwise ignore.
+//
+//   - logs.go reads the SEL and the CIMC fault list.
+//
+//   - firmware.go installs BIOS and CIMC firmware through the UpdateService and
+//     starts the Host Upgrade Utility (HUU) through the OEM HostUpgrade action.
+package cisco
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/jacobweinstock/registrar"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/internal/httpclient"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+	"github.com/bmc-toolbox/bmclib/v2/providers"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+const (
+	// ProviderName is the registered name of this provider.
+	ProviderName = "cisco"
+	// ProviderProtocol is the transport/protocol this provider speaks.
+	ProviderProtocol = "redfish"
+
+	// vendorCisco is contained in the system manufacturer of the CIMC managed
+	// systems, e.g. "Cisco Systems Inc".
+	vendorCisco = "cisco"
+
+	// systemsOdataIDPrefix is the prefix of the System Odata ID, CIMC names
+	// the system after its serial number, e.g. /redfish/v1/Systems/WZP21330ABC.
+	systemsOdataIDPrefix = "/redfish/v1/Systems/"
+)
+
+// Features is the set of bmclib features this provider implements.
+var Features = registrar.Features{
+	// power and boot
+	providers.FeaturePowerState,
+	providers.FeaturePowerSet,
+	providers.FeatureBootDeviceSet,
+	// inventory
+	providers.FeatureInventoryRead,
+	// SEL
+	providers.FeatureGetSystemEventLog,
+	providers.FeatureGetSystemEventLogRaw,
+	providers.FeatureGetSystemEventLogEntries,
+	providers.FeatureClearSystemEventLog,
+	// UpdateService firmware
+	providers.FeatureFirmwareUploadInitiateInstall,
+	providers.FeatureFirmwareTaskStatus,
+	providers.FeatureFirmwareInstallSteps,
+	// BIOS
+	providers.FeatureGetBiosConfiguration,
+	providers.FeatureSetBiosConfiguration,
+}
+
+// Conn is a connection to a Cisco CIMC BMC.
+type Conn struct {
+	redfishwrapper *redfishwrapper.Client
+	// failInventoryOnError has Inventory fail on the first error reading a
+	// component resource.
+	failInventoryOnError bool
+	Log                  logr.Logger
+}
+
+// Config is the configuration of a CIMC [Conn], it is built by [New] from
+// the given options.
+type Config struct {
+	// HTTPClient sends the CIMC requests, a client with the bmclib defaults is
+	// built when nil.
+	HTTPClient *http.Client
+	// Port is the TCP port the CIMC Redfish service listens on. Defaults to "443".
+	Port string
+	// VersionsNotCompatible are the Redfish versions of the CIMC firmware the
+	// provider is not to be used with.
+	VersionsNotCompatible []string
+	// RootCAs verifies the CIMC certificate against the pool, the certificate
+	// is not verified when nil.
+	RootCAs *x509.CertPool
+	// UseBasicAuth selects HTTP Basic authentication instead of Redfish session
+	// login.
+	UseBasicAuth bool
+	// FailInventoryOnError has Inventory fail on the first error reading a
+	// component resource, by default the components that fail to read are left
+	// out of the inventory.
+	FailInventoryOnError bool
+}
+
+// Option sets a setting of the CIMC [Config].
+type Option func(*Config)
+
+// WithHTTPClient sets the HTTP client for the CIMC requests.
+func WithHTTPClient(c *http.Client) Option {
+	return func(cfg *Config) { cfg.HTTPClient = c }
+}
+
+// WithPort sets the CIMC Redfish service port (default "443").
+func WithPort(port string) Option {
+	return func(cfg *Config) { cfg.Port = port }
+}
+
+// WithVersionsNotCompatible excludes the CIMC firmware reporting one of the
+// Redfish versions.
+func WithVersionsNotCompatible(versions []string) Option {
+	return func(cfg *Config) { cfg.VersionsNotCompatible = versions }
+}
+
+// WithRootCAs has the CIMC certificate verified against the pool.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(cfg *Config) { cfg.RootCAs = pool }
+}
+
+// WithUseBasicAuth authenticates the requests with HTTP Basic auth instead of
+// a CIMC Redfish session.
+func WithUseBasicAuth(use bool) Option {
+	return func(cfg *Config) { cfg.UseBasicAuth = use }
+}
+
+// WithFailInventoryOnError has Inventory fail on the first error reading a
+// component resource.
+func WithFailInventoryOnError(fail bool) Option {
+	return func(cfg *Config) { cfg.FailInventoryOnError = fail }
+}
+
+// New returns a [Conn] for the given CIMC host. The connection is not opened
+// until [Conn.Open] is called.
+func New(host, user, pass string, log logr.Logger, opts ...Option) *Conn {
+	cfg := &Config{
+		HTTPClient:            httpclient.Build(),
+		Port:                  "443",
+		VersionsNotCompatible: []string{},
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	rfOpts := []redfishwrapper.Option{
+		redfishwrapper.WithHTTPClient(cfg.HTTPClient),
+		redfishwrapper.WithVersionsNotCompatible(cfg.VersionsNotCompatible),
+		redfishwrapper.WithBasicAuthEnabled(cfg.UseBasicAuth),
+		redfishwrapper.WithSystemsOdataIDPrefix(systemsOdataIDPrefix),
+	}
+
+	if cfg.RootCAs != nil {
+		rfOpts = append(rfOpts, redfishwrapper.WithSecureTLS(cfg.RootCAs))
+	}
+
+	return &Conn{
+		Log:                  log,
+		failInventoryOnError: cfg.FailInventoryOnError,
+		redfishwrapper:       redfishwrapper.NewClient(host, cfg.Port, user, pass, rfOpts...),
+	}
+}
+
+// Name returns the provider name ("cisco").
+func (c *Conn) Name() string {
+	return ProviderName
+}
+
+// Open opens a Redfish session, or sets up Basic auth when [WithUseBasicAuth]
+// was given.
+func (c *Conn) Open(ctx context.Context) error {
+	return c.redfishwrapper.Open(ctx)
+}
+
+// Close releases the Redfish session.
+func (c *Conn) Close(ctx context.Context) error {
+	return c.redfishwrapper.Close(ctx)
+}
+
+// KeepSessionAlive refreshes the CIMC session, an expired session is re-established.
+//
+// Implements bmc.SessionKeeper.
+func (c *Conn) KeepSessionAlive(ctx context.Context) error {
+	return c.redfishwrapper.KeepSessionAlive(ctx)
+}
+
+// ProbeCapabilities probes the CIMC for the features it supports.
+//
+// Implements bmc.CapabilityProber.
+func (c *Conn) ProbeCapabilities(ctx context.Context) (*bmc.ProbedCapabilities, error) {
+	return c.redfishwrapper.ProbeCapabilities(ctx)
+}
+
+// Compatible reports whether the BMC is a CIMC managing a standalone Cisco UCS
+// server.
+//
+// A CIMC session is opened for the check, the BMC is compatible when its
+// Redfish version is not excluded with [WithVersionsNotCompatible] and the
+// system manufacturer is Cisco.
+func (c *Conn) Compatible(ctx context.Context) bool {
+	if err := c.Open(ctx); err != nil {
+		c.Log.V(2).WithValues("provider", c.Name()).
+			Info(bmclibErrs.ErrCompatibilityCheck.Error(), "error", err.Error())
+
+		return false
+	}
+	defer func() { _ = c.Close(ctx) }()
+
+	if !c.redfishwrapper.VersionCompatible() {
+		c.Log.V(2).WithValues("provider", c.Name()).
+			Info(bmclibErrs.ErrCompatibilityCheck.Error(), "reason", "incompatible redfish version")
+
+		return false
+	}
+
+	vendor, _, err := c.redfishwrapper.DeviceVendorModel(ctx)
+	if err != nil {
+		c.Log.V(2).WithValues("provider", c.Name()).
+			Info(bmclibErrs.ErrCompatibilityCheck.Error(), "error", err.Error())
+
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(vendor), vendorCisco)
+}
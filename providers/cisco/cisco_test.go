@@ -0,0 +1,145 @@
+package cisco
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// Requirement: Provider identity and registration.
+func TestName(t *testing.T) {
+	if ProviderName != "cisco" {
+		t.Fatalf("ProviderName = %q, want %q", ProviderName, "cisco")
+	}
+	c := New("127.0.0.1", "u", "p", logr.Discard())
+	if got := c.Name(); got != ProviderName {
+		t.Fatalf("Name() = %q, want %q", got, ProviderName)
+	}
+}
+
+// Requirement: Connection lifecycle — Open creates a session, Close deletes it.
+func TestOpenClose(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.Close()
+
+	c := ts.client(t)
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if !ts.didCreateSession() {
+		t.Fatal("expected a session to be created on Open")
+	}
+
+	if err := c.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !ts.didDeleteSession() {
+		t.Fatal("expected the session to be deleted on Close")
+	}
+}
+
+// Requirement: Compatible identifies Cisco systems and honors excluded versions.
+func TestCompatible(t *testing.T) {
+	t.Run("Cisco system is compatible", func(t *testing.T) {
+		ts := newTestServer(t)
+		defer ts.Close()
+
+		if !ts.client(t).Compatible(context.Background()) {
+			t.Fatal("expected the CIMC to be compatible")
+		}
+	})
+
+	t.Run("excluded redfish version is not compatible", func(t *testing.T) {
+		ts := newTestServer(t)
+		defer ts.Close()
+
+		c := ts.client(t, WithVersionsNotCompatible([]string{"1.2.0"}))
+		if c.Compatible(context.Background()) {
+			t.Fatal("expected the excluded redfish version to be incompatible")
+		}
+	})
+}
+
+// Requirement: Power control via ComputerSystem.Reset.
+func TestPower(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	state, err := c.PowerStateGet(context.Background())
+	if err != nil {
+		t.Fatalf("PowerStateGet: %v", err)
+	}
+	if state != "On" {
+		t.Fatalf("PowerStateGet = %q, want %q", state, "On")
+	}
+
+	ok, err := c.PowerSet(context.Background(), "soft")
+	if err != nil || !ok {
+		t.Fatalf("PowerSet(soft) = (%v, %v), want (true, nil)", ok, err)
+	}
+	if rt := ts.resetType(); rt != "GracefulShutdown" {
+		t.Fatalf("reset type = %q, want %q", rt, "GracefulShutdown")
+	}
+}
+
+// Requirement: the boot override is sent without the override mode and only
+// as a one time override.
+func TestBootDeviceSet(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	ok, err := c.BootDeviceSet(context.Background(), "pxe", false, true)
+	if err != nil || !ok {
+		t.Fatalf("BootDeviceSet(pxe) = (%v, %v), want (true, nil)", ok, err)
+	}
+
+	boot, _ := ts.systemPatch()["Boot"].(map[string]any)
+	if len(boot) != 2 || boot["BootSourceOverrideTarget"] != "Pxe" || boot["BootSourceOverrideEnabled"] != "Once" {
+		t.Fatalf("system PATCH body = %v", ts.systemPatch())
+	}
+
+	if _, err := c.BootDeviceSet(context.Background(), "pxe", true, true); !errors.Is(err, errPersistentBootOverride) {
+		t.Fatalf("BootDeviceSet(persistent) error = %v, want %v", err, errPersistentBootOverride)
+	}
+
+	if _, err := c.BootDeviceSet(context.Background(), "uefi_http", false, true); err == nil {
+		t.Fatal("expected an error for an unsupported boot device")
+	}
+}
+
+// Requirement: BIOS attributes are read, and set by Redfish or XML API token
+// name, unknown attributes are rejected.
+func TestBiosConfiguration(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	config, err := c.GetBiosConfiguration(context.Background())
+	if err != nil {
+		t.Fatalf("GetBiosConfiguration: %v", err)
+	}
+	if config["IntelHyperThreadingTech"] != "Enabled" {
+		t.Fatalf("IntelHyperThreadingTech = %q, want %q", config["IntelHyperThreadingTech"], "Enabled")
+	}
+
+	err = c.SetBiosConfiguration(context.Background(), map[string]string{
+		"vpIntelHyperThreadingTech": "Disabled",
+		"BootOptionRetry":           "Enabled",
+	})
+	if err != nil {
+		t.Fatalf("SetBiosConfiguration: %v", err)
+	}
+	attributes, _ := ts.biosPatch()["Attributes"].(map[string]any)
+	if attributes["IntelHyperThreadingTech"] != "Disabled" || attributes["BootOptionRetry"] != "Enabled" {
+		t.Fatalf("bios PATCH body = %v", ts.biosPatch())
+	}
+	if _, ok := ts.biosPatch()["@Redfish.SettingsApplyTime"]; ok {
+		t.Fatalf("bios PATCH body includes an apply time: %v", ts.biosPatch())
+	}
+
+	err = c.SetBiosConfiguration(context.Background(), map[string]string{"vpNoSuchToken": "Enabled"})
+	if !errors.Is(err, errUnknownBiosAttributes) {
+		t.Fatalf("SetBiosConfiguration(unknown) error = %v, want %v", err, errUnknownBiosAttributes)
+	}
+}
@@ -0,0 +1,180 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/bmc-toolbox/common"
+	"github.com/pkg/errors"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/constants"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+)
+
+const (
+	// updateServiceURI is the CIMC UpdateService, it lists the OEM HostUpgrade action.
+	updateServiceURI = "/redfish/v1/UpdateService"
+	// firmwareInventoryURI is the CIMC firmware inventory, its members are the
+	// update targets.
+	firmwareInventoryURI = "/redfish/v1/UpdateService/FirmwareInventory"
+
+	// hostUpgradeAction is the suffix of the OEM UpdateService action that
+	// starts the Host Upgrade Utility, the action name prefix differs between
+	// CIMC releases.
+	hostUpgradeAction = ".HostUpgrade"
+)
+
+// firmwareInventoryIDs maps the component slugs to the CIMC firmware inventory
+// member that is the update target of the component.
+var firmwareInventoryIDs = map[string]string{
+	strings.ToLower(common.SlugBIOS): "BIOS",
+	strings.ToLower(common.SlugBMC):  "CIMC",
+}
+
+// compile-time assertions that the provider implements the firmware interfaces.
+var (
+	_ bmc.FirmwareInstallProvider    = (*Conn)(nil)
+	_ bmc.FirmwareTaskVerifier       = (*Conn)(nil)
+	_ bmc.FirmwareInstallStepsGetter = (*Conn)(nil)
+)
+
+// FirmwareInstallSteps returns the ordered steps the provider performs for a
+// firmware install. The multipart push uploads and initiates the install in one
+// step, followed by polling the update task. CIMC flashes the BIOS of a
+// powered off host only, the host is powered off first.
+//
+// Implements bmc.FirmwareInstallStepsGetter.
+func (c *Conn) FirmwareInstallSteps(ctx context.Context, component string) ([]constants.FirmwareInstallStep, error) {
+	switch strings.ToLower(component) {
+	case strings.ToLower(common.SlugBIOS):
+		return []constants.FirmwareInstallStep{
+			constants.FirmwareInstallStepPowerOffHost,
+			constants.FirmwareInstallStepUploadInitiateInstall,
+			constants.FirmwareInstallStepInstallStatus,
+		}, nil
+	case strings.ToLower(common.SlugBMC):
+		return []constants.FirmwareInstallStep{
+			constants.FirmwareInstallStepUploadInitiateInstall,
+			constants.FirmwareInstallStepInstallStatus,
+		}, nil
+	}
+
+	return nil, errors.Wrap(bmclibErrs.ErrFirmwareInstall, "unsupported component: "+component)
+}
+
+// FirmwareInstallUploadAndInitiate uploads a BIOS or CIMC firmware image through
+// the UpdateService multipart push, targeted at the firmware inventory member of
+// the component, and returns the update task id.
+//
+// The upload can take a while, ctx is expected to carry a deadline that covers
+// the upload.
+//
+// Implements bmc.FirmwareInstallProvider.
+func (c *Conn) FirmwareInstallUploadAndInitiate(ctx context.Context, component string, file *os.File) (taskID string, err error) {
+	target, err := c.firmwareInventoryTarget(component)
+	if err != nil {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareInstall, err.Error())
+	}
+
+	params := &redfishwrapper.RedfishUpdateServiceParameters{
+		Targets:            []string{target},
+		OperationApplyTime: constants.Immediate,
+	}
+
+	return c.redfishwrapper.FirmwareUpload(ctx, file, params)
+}
+
+// FirmwareTaskStatus returns the state and status of a firmware update task.
+//
+// Implements bmc.FirmwareTaskVerifier.
+func (c *Conn) FirmwareTaskStatus(ctx context.Context, kind constants.FirmwareInstallStep, component, taskID, installVersion string) (state constants.TaskState, status string, err error) {
+	return c.redfishwrapper.TaskStatus(ctx, taskID)
+}
+
+// HostUpgrade starts the Host Upgrade Utility (HUU) through the OEM HostUpgrade
+// action of the UpdateService, returning the created task id. CIMC fetches the
+// HUU ISO from imageURI and boots the host into it, the HUU updates the
+// firmware of all the server components.
+//
+// transferProtocol is an optional Redfish TransferProtocol (e.g. "HTTP",
+// "NFS"), when empty CIMC infers it from the URI scheme. This is a CIMC specific
+// provider method, it is not part of a bmc.Feature interface.
+func (c *Conn) HostUpgrade(ctx context.Context, imageURI, transferProtocol string) (taskID string, err error) {
+	target, err := c.hostUpgradeTarget()
+	if err != nil {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareInstall, err.Error())
+	}
+
+	payload := map[string]any{"ImageURI": imageURI}
+	if transferProtocol != "" {
+		payload["TransferProtocol"] = transferProtocol
+	}
+
+	resp, err := c.redfishwrapper.PostWithHeaders(ctx, target, payload, nil)
+	if err != nil {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareInstall, err.Error())
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareInstall, "unexpected status code returned: "+resp.Status)
+	}
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareInstall, "host upgrade task location not returned")
+	}
+
+	return path.Base(strings.TrimRight(location, "/")), nil
+}
+
+// firmwareInventoryTarget returns the firmware inventory member that is the
+// update target of the component.
+func (c *Conn) firmwareInventoryTarget(component string) (string, error) {
+	id, ok := firmwareInventoryIDs[strings.ToLower(component)]
+	if !ok {
+		return "", fmt.Errorf("unsupported component: %s", component)
+	}
+
+	members, err := c.redfishwrapper.CollectionMembers(firmwareInventoryURI)
+	if err != nil {
+		return "", err
+	}
+
+	for _, member := range members {
+		if path.Base(member) == id {
+			return member, nil
+		}
+	}
+
+	return "", fmt.Errorf("firmware inventory does not list %s", id)
+}
+
+// hostUpgradeTarget returns the target of the OEM HostUpgrade action.
+func (c *Conn) hostUpgradeTarget() (string, error) {
+	var updateService struct {
+		Actions struct {
+			Oem map[string]struct {
+				Target string `json:"target"`
+			} `json:"Oem"`
+		} `json:"Actions"`
+	}
+
+	if err := c.redfishwrapper.GetJSON(updateServiceURI, &updateService); err != nil {
+		return "", err
+	}
+
+	for name, action := range updateService.Actions.Oem {
+		if strings.HasSuffix(name, hostUpgradeAction) && action.Target != "" {
+			return action.Target, nil
+		}
+	}
+
+	return "", fmt.Errorf("UpdateService does not list the %s action", hostUpgradeAction)
+}
@@ -0,0 +1,117 @@
+package cisco
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/bmc-toolbox/bmclib/v2/constants"
+)
+
+// Requirement: a BIOS install requires the host to be powered off first.
+func TestFirmwareInstallSteps(t *testing.T) {
+	c := New("127.0.0.1", "u", "p", logr.Discard())
+
+	steps, err := c.FirmwareInstallSteps(context.Background(), "bios")
+	if err != nil {
+		t.Fatalf("FirmwareInstallSteps(bios): %v", err)
+	}
+	if len(steps) != 3 || steps[0] != constants.FirmwareInstallStepPowerOffHost {
+		t.Fatalf("FirmwareInstallSteps(bios) = %v", steps)
+	}
+
+	steps, err = c.FirmwareInstallSteps(context.Background(), "bmc")
+	if err != nil {
+		t.Fatalf("FirmwareInstallSteps(bmc): %v", err)
+	}
+	if len(steps) != 2 || steps[0] != constants.FirmwareInstallStepUploadInitiateInstall {
+		t.Fatalf("FirmwareInstallSteps(bmc) = %v", steps)
+	}
+
+	if _, err := c.FirmwareInstallSteps(context.Background(), "nic"); err == nil {
+		t.Fatal("expected an error for an unsupported component")
+	}
+}
+
+// Requirement: firmware is pushed targeted at the firmware inventory member of
+// the component.
+func TestFirmwareInstallUploadAndInitiate(t *testing.T) {
+	tests := []struct {
+		component string
+		target    string
+	}{
+		{"bios", "/redfish/v1/UpdateService/FirmwareInventory/BIOS"},
+		{"bmc", "/redfish/v1/UpdateService/FirmwareInventory/CIMC"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.component, func(t *testing.T) {
+			ts := newTestServer(t)
+			c := ts.openedClient(t)
+
+			path := filepath.Join(t.TempDir(), "firmware.bin")
+			if err := os.WriteFile(path, []byte("firmware"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			file, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			taskID, err := c.FirmwareInstallUploadAndInitiate(ctx, tc.component, file)
+			if err != nil {
+				t.Fatalf("FirmwareInstallUploadAndInitiate: %v", err)
+			}
+			if taskID != "5" {
+				t.Fatalf("task id = %q, want %q", taskID, "5")
+			}
+
+			targets, _ := ts.uploadParameters()["Targets"].([]any)
+			if len(targets) != 1 || targets[0] != tc.target {
+				t.Fatalf("UpdateParameters = %v", ts.uploadParameters())
+			}
+		})
+	}
+}
+
+// Requirement: the update task is polled through the TaskService.
+func TestFirmwareTaskStatus(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	state, _, err := c.FirmwareTaskStatus(context.Background(), constants.FirmwareInstallStepInstallStatus, "bmc", "5", "")
+	if err != nil {
+		t.Fatalf("FirmwareTaskStatus: %v", err)
+	}
+	if state != constants.Running {
+		t.Fatalf("state = %q, want %q", state, constants.Running)
+	}
+}
+
+// Requirement: the HUU is started through the OEM HostUpgrade action.
+func TestHostUpgrade(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	imageURI := "http://10.0.0.1/ucs-c220m5-huu-4.1.3c.iso"
+	taskID, err := c.HostUpgrade(context.Background(), imageURI, "HTTP")
+	if err != nil {
+		t.Fatalf("HostUpgrade: %v", err)
+	}
+	if taskID != "5" {
+		t.Fatalf("task id = %q, want %q", taskID, "5")
+	}
+
+	body := ts.hostUpgrade()
+	if body["ImageURI"] != imageURI || body["TransferProtocol"] != "HTTP" {
+		t.Fatalf("HostUpgrade body = %v", body)
+	}
+}
@@ -0,0 +1,24 @@
+package cisco
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/common"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+var _ bmc.InventoryGetter = (*Conn)(nil)
+
+// Inventory collects the hardware and firmware inventory of the CIMC managed
+// system into a *common.Device through the standard Redfish resources.
+//
+// When the connection's failInventoryOnError is false (the default, set via
+// [WithFailInventoryOnError]), a failure reading one sub-resource does not abort
+// the whole inventory — the provider returns what it could collect. When true,
+// the first sub-resource error is returned.
+//
+// Implements bmc.InventoryGetter.
+func (c *Conn) Inventory(ctx context.Context) (device *common.Device, err error) {
+	return c.redfishwrapper.Inventory(ctx, c.failInventoryOnError)
+}
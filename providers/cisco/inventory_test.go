@@ -0,0 +1,30 @@
+package cisco
+
+import (
+	"context"
+	"testing"
+)
+
+// Requirement: the inventory includes the components of the serial number
+// named System and the CIMC.
+func TestInventory(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	device, err := c.Inventory(context.Background())
+	if err != nil {
+		t.Fatalf("Inventory: %v", err)
+	}
+
+	if device.Serial != "WZP21330ABC" {
+		t.Fatalf("serial = %q, want %q", device.Serial, "WZP21330ABC")
+	}
+
+	if len(device.CPUs) != 1 || device.CPUs[0].Cores != 16 {
+		t.Fatalf("CPUs = %+v", device.CPUs)
+	}
+
+	if device.BMC == nil || device.BMC.Firmware == nil || device.BMC.Firmware.Installed != "4.1(3c)" {
+		t.Fatalf("BMC = %+v", device.BMC)
+	}
+}
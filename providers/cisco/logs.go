@@ -0,0 +1,153 @@
+package cisco
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stmcginnis/gofish/schemas"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+)
+
+// CIMC log-service ids.
+const (
+	// LogServiceSEL is the System Event Log of the host.
+	LogServiceSEL = "SEL"
+	// LogServiceFault is the fault list of the CIMC, it lists the active
+	// faults raised by the CIMC. A fault is removed from the list when the
+	// condition that raised it clears.
+	LogServiceFault = "Fault"
+)
+
+// compile-time assertions that the provider implements the interfaces.
+var (
+	_ bmc.SystemEventLog              = (*Conn)(nil)
+	_ bmc.SystemEventLogEntriesGetter = (*Conn)(nil)
+)
+
+// GetSystemEventLog returns the SEL entries as rows of
+// [id, created, severity, message].
+//
+// Implements bmc.SystemEventLog.
+func (c *Conn) GetSystemEventLog(ctx context.Context) (entries [][]string, err error) {
+	lentries, err := c.logEntries(ctx, LogServiceSEL)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range lentries {
+		entries = append(entries, []string{entry.ID, entry.Created, string(entry.Severity), entry.Message})
+	}
+
+	return entries, nil
+}
+
+// GetSystemEventLogEntries returns the SEL entries as typed entries.
+//
+// Implements bmc.SystemEventLogEntriesGetter.
+func (c *Conn) GetSystemEventLogEntries(ctx context.Context) (entries []bmc.SystemEventLogEntry, err error) {
+	return c.typedLogEntries(ctx, LogServiceSEL)
+}
+
+// GetSystemEventLogRaw returns the raw JSON of the SEL entries.
+//
+// Implements bmc.SystemEventLog.
+func (c *Conn) GetSystemEventLogRaw(ctx context.Context) (eventlog string, err error) {
+	lentries, err := c.logEntries(ctx, LogServiceSEL)
+	if err != nil {
+		return "", err
+	}
+
+	raw, err := json.Marshal(lentries)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+// ClearSystemEventLog clears the SEL through the LogService.ClearLog action.
+// The fault list cannot be cleared, faults clear with their condition.
+//
+// Implements bmc.SystemEventLog.
+func (c *Conn) ClearSystemEventLog(ctx context.Context) (err error) {
+	ls, err := c.logService(ctx, LogServiceSEL)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ls.ClearLog(""); err != nil {
+		return fmt.Errorf("clearing log service %q: %w", ls.ID, err)
+	}
+
+	return nil
+}
+
+// Faults returns the active faults of the CIMC fault list.
+func (c *Conn) Faults(ctx context.Context) (faults []bmc.SystemEventLogEntry, err error) {
+	return c.typedLogEntries(ctx, LogServiceFault)
+}
+
+// typedLogEntries returns the entries of the log service as typed entries.
+func (c *Conn) typedLogEntries(ctx context.Context, id string) ([]bmc.SystemEventLogEntry, error) {
+	lentries, err := c.logEntries(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]bmc.SystemEventLogEntry, 0, len(lentries))
+	for _, entry := range lentries {
+		entries = append(entries, redfishwrapper.SystemEventLogEntryFromLogEntry(entry))
+	}
+
+	return entries, nil
+}
+
+// logEntries returns the entries of the log service.
+func (c *Conn) logEntries(ctx context.Context, id string) ([]*schemas.LogEntry, error) {
+	ls, err := c.logService(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	lentries, err := ls.Entries()
+	if err != nil {
+		return nil, fmt.Errorf("reading entries of log service %q: %w", id, err)
+	}
+
+	return lentries, nil
+}
+
+// logService returns the log service with the id, CIMC firmware releases list
+// the SEL and the fault list either under the system or under the manager.
+func (c *Conn) logService(ctx context.Context, id string) (*schemas.LogService, error) {
+	sys, err := c.redfishwrapper.System()
+	if err != nil {
+		return nil, err
+	}
+
+	logServices, err := sys.LogServices()
+	if err != nil {
+		return nil, err
+	}
+
+	manager, err := c.redfishwrapper.Manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	managerLogServices, err := manager.LogServices()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ls := range append(logServices, managerLogServices...) {
+		if ls.ID == id {
+			return ls, nil
+		}
+	}
+
+	return nil, fmt.Errorf("log service %q not found", id)
+}
@@ -0,0 +1,52 @@
+package cisco
+
+import (
+	"context"
+	"testing"
+)
+
+// Requirement: the SEL is read and cleared.
+func TestSystemEventLog(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	entries, err := c.GetSystemEventLogEntries(context.Background())
+	if err != nil {
+		t.Fatalf("GetSystemEventLogEntries: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d SEL entries, want 1", len(entries))
+	}
+	if entries[0].RecordID != "1" || entries[0].Severity != "critical" {
+		t.Fatalf("SEL entry = %+v", entries[0])
+	}
+
+	rows, err := c.GetSystemEventLog(context.Background())
+	if err != nil {
+		t.Fatalf("GetSystemEventLog: %v", err)
+	}
+	if len(rows) != 1 || rows[0][3] != "Platform alert LED_PSU_STATUS: Asserted" {
+		t.Fatalf("GetSystemEventLog = %v", rows)
+	}
+
+	if err := c.ClearSystemEventLog(context.Background()); err != nil {
+		t.Fatalf("ClearSystemEventLog: %v", err)
+	}
+	if !ts.didClearSEL() {
+		t.Fatal("expected the SEL LogService.ClearLog action to be posted")
+	}
+}
+
+// Requirement: the fault list is read from the system log services.
+func TestFaults(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	faults, err := c.Faults(context.Background())
+	if err != nil {
+		t.Fatalf("Faults: %v", err)
+	}
+	if len(faults) != 1 || faults[0].RecordID != "F0883" || faults[0].Severity != "warning" {
+		t.Fatalf("Faults = %+v", faults)
+	}
+}
@@ -0,0 +1,287 @@
+package cisco
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+const fixturesDir = "./fixtures/v1"
+
+// systemURI is the System of the fixtures, CIMC names it after the serial number.
+const systemURI = "/redfish/v1/Systems/WZP21330ABC"
+
+// testServer is an httptest-backed CIMC Redfish mock. It serves recorded JSON
+// fixtures and emulates Redfish session create/delete, the UpdateService
+// multipart push and the OEM HostUpgrade action so the provider can be
+// exercised entirely offline.
+type testServer struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	sessionCreated bool
+	sessionDeleted bool
+	// lastResetType records the ResetType posted to ComputerSystem.Reset.
+	lastResetType string
+	// systemPatchBody records the decoded body of the last System PATCH.
+	systemPatchBody map[string]any
+	// biosPatchBody records the decoded body of the last Bios settings PATCH.
+	biosPatchBody map[string]any
+	// selCleared records whether the SEL LogService.ClearLog action was posted.
+	selCleared bool
+	// updateParameters records the UpdateParameters part of the multipart push.
+	updateParameters map[string]any
+	// hostUpgradeBody records the decoded body posted to the HostUpgrade action.
+	hostUpgradeBody map[string]any
+}
+
+// newTestServer builds and starts a TLS mock CIMC server.
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	ts := &testServer{}
+
+	// path -> fixture file for plain GETs.
+	routes := map[string]string{
+		"/redfish/v1/":                                        "serviceroot.json",
+		"/redfish/v1/Systems":                                 "systems.json",
+		systemURI:                                             "system.json",
+		systemURI + "/Bios":                                   "bios.json",
+		systemURI + "/Bios/Settings":                          "bios.settings.json",
+		systemURI + "/Processors":                             "processors.json",
+		systemURI + "/Processors/CPU1":                        "processor.cpu1.json",
+		systemURI + "/LogServices":                            "system.logservices.json",
+		systemURI + "/LogServices/Fault":                      "ls.fault.json",
+		systemURI + "/LogServices/Fault/Entries":              "ls.fault.entries.json",
+		systemURI + "/LogServices/Fault/Entries/F0883":        "ls.fault.entry.f0883.json",
+		"/redfish/v1/Chassis":                                 "chassis.json",
+		"/redfish/v1/Chassis/1":                               "chassis.1.json",
+		"/redfish/v1/Managers":                                "managers.json",
+		"/redfish/v1/Managers/CIMC":                           "manager.cimc.json",
+		"/redfish/v1/Managers/CIMC/LogServices":               "manager.logservices.json",
+		"/redfish/v1/Managers/CIMC/LogServices/SEL":           "ls.sel.json",
+		"/redfish/v1/Managers/CIMC/LogServices/SEL/Entries":   "ls.sel.entries.json",
+		"/redfish/v1/Managers/CIMC/LogServices/SEL/Entries/1": "ls.sel.entry.1.json",
+		"/redfish/v1/Managers/CIMC/LogServices/CIMC":          "ls.cimc.json",
+		"/redfish/v1/UpdateService":                           "updateservice.json",
+		"/redfish/v1/UpdateService/FirmwareInventory":         "firmwareinventory.json",
+		"/redfish/v1/UpdateService/FirmwareInventory/CIMC":    "firmwareinventory.cimc.json",
+		"/redfish/v1/UpdateService/FirmwareInventory/BIOS":    "firmwareinventory.bios.json",
+		"/redfish/v1/TaskService":                             "taskservice.json",
+		"/redfish/v1/TaskService/Tasks":                       "tasks.json",
+		"/redfish/v1/TaskService/Tasks/5":                     "task.5.json",
+	}
+
+	mux := http.NewServeMux()
+
+	// ComputerSystem.Reset action — records the requested ResetType.
+	mux.HandleFunc(systemURI+"/Actions/ComputerSystem.Reset", func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			ResetType string `json:"ResetType"`
+		}
+		if body, err := io.ReadAll(r.Body); err == nil {
+			_ = json.Unmarshal(body, &payload)
+		}
+		ts.mu.Lock()
+		ts.lastResetType = payload.ResetType
+		ts.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// SEL LogService.ClearLog action.
+	mux.HandleFunc("/redfish/v1/Managers/CIMC/LogServices/SEL/Actions/LogService.ClearLog", func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		ts.selCleared = true
+		ts.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// UpdateService multipart push: records the UpdateParameters and returns
+	// the update task Location.
+	mux.HandleFunc("/redfish/v1/UpdateService/upload", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var params map[string]any
+		if err := json.Unmarshal([]byte(r.FormValue("UpdateParameters")), &params); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		ts.mu.Lock()
+		ts.updateParameters = params
+		ts.mu.Unlock()
+		w.Header().Set("Location", "/redfish/v1/TaskService/Tasks/5")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	// OEM HostUpgrade action.
+	mux.HandleFunc("/redfish/v1/UpdateService/Actions/Oem/CiscoUCSExtensions.HostUpgrade", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if b, err := io.ReadAll(r.Body); err == nil {
+			_ = json.Unmarshal(b, &body)
+		}
+		ts.mu.Lock()
+		ts.hostUpgradeBody = body
+		ts.mu.Unlock()
+		w.Header().Set("Location", "/redfish/v1/TaskService/Tasks/5")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	// Session create: returns an X-Auth-Token and the session Location.
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		ts.mu.Lock()
+		ts.sessionCreated = true
+		ts.mu.Unlock()
+
+		w.Header().Set("X-Auth-Token", "test-token")
+		w.Header().Set("Location", "/redfish/v1/SessionService/Sessions/1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"@odata.id":"/redfish/v1/SessionService/Sessions/1","Id":"1","Name":"Session"}`))
+	})
+
+	// A created session is deleted here on Close.
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			ts.mu.Lock()
+			ts.sessionDeleted = true
+			ts.mu.Unlock()
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Catch-all for the rest of the Redfish tree.
+	//
+	// GETs are served from fixtures. Writes on a known resource are accepted
+	// with 204 and the System and Bios settings PATCH bodies are recorded.
+	mux.HandleFunc("/redfish/v1/", func(w http.ResponseWriter, r *http.Request) {
+		file, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			var body map[string]any
+			if b, err := io.ReadAll(r.Body); err == nil {
+				_ = json.Unmarshal(b, &body)
+			}
+
+			ts.mu.Lock()
+			switch r.URL.Path {
+			case systemURI:
+				ts.systemPatchBody = body
+			case systemURI + "/Bios/Settings":
+				ts.biosPatchBody = body
+			}
+			ts.mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		body, err := os.ReadFile(filepath.Join(fixturesDir, file))
+		if err != nil {
+			t.Errorf("failed to read fixture %q for %s: %v", file, r.URL.Path, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	})
+
+	ts.Server = httptest.NewTLSServer(mux)
+
+	return ts
+}
+
+// client returns a *Conn pointed at the mock server. Extra options are appended
+// after the mandatory port option.
+func (ts *testServer) client(t *testing.T, opts ...Option) *Conn {
+	t.Helper()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("parse mock url: %v", err)
+	}
+	opts = append([]Option{WithPort(u.Port())}, opts...)
+	return New(u.Hostname(), "user", "pass", logr.Discard(), opts...)
+}
+
+// openedClient returns a *Conn with an established session and registers Close
+// + server shutdown for cleanup.
+func (ts *testServer) openedClient(t *testing.T, opts ...Option) *Conn {
+	t.Helper()
+	c := ts.client(t, opts...)
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = c.Close(context.Background())
+		ts.Close()
+	})
+	return c
+}
+
+func (ts *testServer) didCreateSession() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.sessionCreated
+}
+
+func (ts *testServer) didDeleteSession() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.sessionDeleted
+}
+
+func (ts *testServer) resetType() string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.lastResetType
+}
+
+func (ts *testServer) systemPatch() map[string]any {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.systemPatchBody
+}
+
+func (ts *testServer) biosPatch() map[string]any {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.biosPatchBody
+}
+
+func (ts *testServer) didClearSEL() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.selCleared
+}
+
+func (ts *testServer) uploadParameters() map[string]any {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.updateParameters
+}
+
+func (ts *testServer) hostUpgrade() map[string]any {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.hostUpgradeBody
+}
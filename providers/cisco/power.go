@@ -0,0 +1,93 @@
+package cisco
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stmcginnis/gofish/schemas"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// errPersistentBootOverride is returned when a persistent boot override is
+// requested, CIMC only supports a one time boot override.
+var errPersistentBootOverride = errors.New("CIMC does not support a persistent boot override")
+
+// bootSourceTargets maps the boot devices to the boot override targets CIMC
+// accepts.
+var bootSourceTargets = map[bmc.BootDeviceType]schemas.BootSource{
+	bmc.BootDeviceTypeBIOS:   schemas.BiosSetupBootSource,
+	bmc.BootDeviceTypeCDROM:  schemas.CdBootSource,
+	bmc.BootDeviceTypeDiag:   schemas.DiagsBootSource,
+	bmc.BootDeviceTypeFloppy: schemas.FloppyBootSource,
+	bmc.BootDeviceTypeDisk:   schemas.HddBootSource,
+	bmc.BootDeviceTypeNone:   schemas.NoneBootSource,
+	bmc.BootDeviceTypePXE:    schemas.PxeBootSource,
+	bmc.BootDeviceTypeUSB:    schemas.UsbBootSource,
+}
+
+// compile-time assertions that the provider implements the interfaces.
+var (
+	_ bmc.PowerStateGetter         = (*Conn)(nil)
+	_ bmc.PowerSetter              = (*Conn)(nil)
+	_ bmc.BootDeviceSetter         = (*Conn)(nil)
+	_ bmc.BootDeviceOverrideGetter = (*Conn)(nil)
+)
+
+// PowerStateGet returns the power state of the system, read from the
+// ComputerSystem PowerState property.
+//
+// Implements bmc.PowerStateGetter.
+func (c *Conn) PowerStateGet(ctx context.Context) (state string, err error) {
+	return c.redfishwrapper.SystemPowerStatus(ctx)
+}
+
+// PowerSet sets the system power state through the ComputerSystem.Reset action.
+//
+// Implements bmc.PowerSetter.
+func (c *Conn) PowerSet(ctx context.Context, state string) (ok bool, err error) {
+	return c.redfishwrapper.PowerSet(ctx, state)
+}
+
+// BootDeviceSet sets the boot device for the next boot.
+//
+// CIMC rejects a Boot PATCH that includes BootSourceOverrideMode, the mode
+// follows the boot mode configured in the BIOS, so only the target and a one
+// time override are sent and efiBoot is ignored. A persistent override is not
+// supported and returns an error.
+//
+// Implements bmc.BootDeviceSetter.
+func (c *Conn) BootDeviceSet(ctx context.Context, bootDevice string, setPersistent, efiBoot bool) (ok bool, err error) {
+	if setPersistent {
+		return false, errPersistentBootOverride
+	}
+
+	target, ok := bootSourceTargets[bmc.BootDeviceType(bootDevice)]
+	if !ok {
+		return false, errors.New("unsupported boot device: " + bootDevice)
+	}
+
+	sys, err := c.redfishwrapper.System()
+	if err != nil {
+		return false, err
+	}
+
+	boot := &schemas.Boot{
+		BootSourceOverrideTarget:  target,
+		BootSourceOverrideEnabled: schemas.OnceBootSourceOverrideEnabled,
+	}
+
+	if err := sys.SetBoot(boot); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// BootDeviceOverrideGet returns the boot override read from the ComputerSystem
+// Boot object.
+//
+// Implements bmc.BootDeviceOverrideGetter.
+func (c *Conn) BootDeviceOverrideGet(ctx context.Context) (override bmc.BootDeviceOverride, err error) {
+	return c.redfishwrapper.GetBootDeviceOverride(ctx)
+}
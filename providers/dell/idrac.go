@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/bmc-toolbox/common"
 	"github.com/go-logr/logr"
@@ -51,11 +52,34 @@ var (
 		providers.FeatureGetSecureBoot,
 		providers.FeatureSetSecureBoot,
 		providers.FeatureResetSecureBootKeys,
+		providers.FeatureGetSystemEventLogEntries,
+		providers.FeatureSensorsRead,
+		providers.FeaturePowerConsumption,
+		providers.FeatureSetPowerLimit,
+		providers.FeatureSetIdentifyLED,
+		providers.FeatureGetIdentifyLED,
+		providers.FeatureGetBootOrder,
+		providers.FeatureSetBootOrder,
+		providers.FeatureGetBMCNetwork,
+		providers.FeatureSetBMCNetwork,
+		providers.FeatureGenerateCSR,
+		providers.FeatureReplaceCertificate,
+		providers.FeatureListCertificates,
+		providers.FeatureGetBMCTime,
+		providers.FeatureSetBMCTime,
+		providers.FeatureSetNTPServers,
+		providers.FeatureGetDirectoryService,
+		providers.FeatureSetDirectoryService,
 	}
 
 	errManufacturerUnknown = errors.New("error identifying device manufacturer")
+	errBootOrderBootMode   = errors.New("boot order can only be set in the UEFI boot mode")
+	errDirectoryRoleGroups = errors.New("too many directory role mappings for the iDRAC role groups")
 )
 
+// idracDirectoryRoleGroups is the number of fixed role group slots iDRAC holds for each directory service.
+const idracDirectoryRoleGroups = 5
+
 // Config holds the optional configuration values for a Dell iDRAC connection.
 type Config struct {
 	HttpClient            *http.Client //nolint:revive // exported field kept for backwards compatibility
@@ -269,6 +293,142 @@ func (c *Conn) ResetSecureBootKeys(ctx context.Context, resetType string) (err e
 	return c.redfishwrapper.ResetSecureBootKeys(ctx, resetType)
 }
 
+// GetSystemEventLogEntries returns the iDRAC System Event Log as typed entries
+func (c *Conn) GetSystemEventLogEntries(ctx context.Context) (entries []bmc.SystemEventLogEntry, err error) {
+	return c.redfishwrapper.GetSystemEventLogEntries(ctx)
+}
+
+// Sensors returns the iDRAC sensor readings
+func (c *Conn) Sensors(ctx context.Context) (sensors []bmc.SensorReading, err error) {
+	return c.redfishwrapper.Sensors(ctx)
+}
+
+// PowerConsumption returns the host power consumption
+func (c *Conn) PowerConsumption(ctx context.Context) (consumption *bmc.PowerConsumption, err error) {
+	return c.redfishwrapper.PowerConsumption(ctx)
+}
+
+// SetPowerLimit sets the host power limit
+func (c *Conn) SetPowerLimit(ctx context.Context, limit bmc.PowerLimit) (err error) {
+	return c.redfishwrapper.SetPowerLimit(ctx, limit)
+}
+
+// SetIdentifyLED turns the chassis identify LED on or off
+func (c *Conn) SetIdentifyLED(ctx context.Context, on bool, duration time.Duration) (err error) {
+	return c.redfishwrapper.SetIdentifyLED(ctx, on, duration)
+}
+
+// GetIdentifyLED returns the chassis identify LED state
+func (c *Conn) GetIdentifyLED(ctx context.Context) (on bool, err error) {
+	return c.redfishwrapper.GetIdentifyLED(ctx)
+}
+
+// GetBootOrder returns the persistent boot order
+func (c *Conn) GetBootOrder(ctx context.Context) (order []bmc.BootOption, err error) {
+	return c.redfishwrapper.GetBootOrder(ctx)
+}
+
+// SetBootOrder sets the persistent boot order, the change is applied by the BIOS on the next host reboot.
+//
+// iDRAC only manages the UEFI boot order through Boot.BootOrder, in the legacy BIOS boot mode the
+// boot sequence is held in the BIOS attributes and boot order changes are rejected.
+func (c *Conn) SetBootOrder(ctx context.Context, order []string) (err error) {
+	if biosConfig, err := c.redfishwrapper.GetBiosConfiguration(ctx); err == nil {
+		if mode := biosConfig["BootMode"]; mode != "" && mode != "Uefi" {
+			return errors.Wrapf(errBootOrderBootMode, "BootMode: %s", mode)
+		}
+	}
+
+	return c.redfishwrapper.SetBootOrder(ctx, order)
+}
+
+// GetBMCNetwork returns the BMC management network configuration
+func (c *Conn) GetBMCNetwork(ctx context.Context) (config *bmc.BMCNetworkConfig, err error) {
+	return c.redfishwrapper.GetBMCNetwork(ctx)
+}
+
+// SetBMCNetwork applies the configuration to the BMC management interface
+func (c *Conn) SetBMCNetwork(ctx context.Context, config bmc.BMCNetworkConfig) (err error) {
+	return c.redfishwrapper.SetBMCNetwork(ctx, config)
+}
+
+// GenerateCSR has the BMC generate a key pair and returns the certificate signing request
+func (c *Conn) GenerateCSR(ctx context.Context, request bmc.CSRRequest) (csr string, err error) {
+	return c.redfishwrapper.GenerateCSR(ctx, request)
+}
+
+// ReplaceCertificate installs the certificate in place of the given BMC certificate
+func (c *Conn) ReplaceCertificate(ctx context.Context, certificateID, certificate string) (err error) {
+	return c.redfishwrapper.ReplaceCertificate(ctx, certificateID, certificate)
+}
+
+// ListCertificates returns the BMC certificates
+func (c *Conn) ListCertificates(ctx context.Context) (certificates []bmc.Certificate, err error) {
+	return c.redfishwrapper.ListCertificates(ctx)
+}
+
+// GetBMCTime returns the BMC clock and its NTP configuration
+func (c *Conn) GetBMCTime(ctx context.Context) (bmcTime *bmc.BMCTime, err error) {
+	return c.redfishwrapper.GetBMCTime(ctx)
+}
+
+// SetBMCTime sets the BMC clock
+func (c *Conn) SetBMCTime(ctx context.Context, t time.Time) (err error) {
+	return c.redfishwrapper.SetBMCTime(ctx, t)
+}
+
+// SetNTPServers sets the BMC NTP servers
+func (c *Conn) SetNTPServers(ctx context.Context, servers []string) (err error) {
+	return c.redfishwrapper.SetNTPServers(ctx, servers)
+}
+
+// GetDirectoryService returns the BMC LDAP or Active Directory configuration
+func (c *Conn) GetDirectoryService(ctx context.Context, serviceType bmc.DirectoryServiceType) (config *bmc.DirectoryServiceConfig, err error) {
+	return c.redfishwrapper.GetDirectoryService(ctx, serviceType)
+}
+
+// SetDirectoryService sets the BMC LDAP or Active Directory configuration
+func (c *Conn) SetDirectoryService(ctx context.Context, config bmc.DirectoryServiceConfig) (err error) {
+	payload, err := idracDirectoryServicePayload(config)
+	if err != nil {
+		return err
+	}
+
+	return c.redfishwrapper.PatchDirectoryService(ctx, string(config.Type), payload)
+}
+
+// idracDirectoryServicePayload returns the directory service PATCH payload for iDRAC.
+//
+// iDRAC holds the role mappings in a fixed number of role group slots, the slots past the given role mappings
+// are cleared with null entries so that a shorter list of role mappings does not leave stale role groups behind.
+func idracDirectoryServicePayload(config bmc.DirectoryServiceConfig) (map[string]any, error) {
+	if len(config.RoleMappings) > idracDirectoryRoleGroups {
+		return nil, errors.Wrapf(errDirectoryRoleGroups, "got %d, iDRAC supports %d", len(config.RoleMappings), idracDirectoryRoleGroups)
+	}
+
+	payload := redfishwrapper.DirectoryServicePayload(config)
+
+	if mappings, ok := payload["RemoteRoleMapping"].([]any); ok {
+		for len(mappings) < idracDirectoryRoleGroups {
+			mappings = append(mappings, nil)
+		}
+
+		payload["RemoteRoleMapping"] = mappings
+	}
+
+	return payload, nil
+}
+
+// KeepSessionAlive refreshes the redfish session, an expired session is re-established
+func (c *Conn) KeepSessionAlive(ctx context.Context) (err error) {
+	return c.redfishwrapper.KeepSessionAlive(ctx)
+}
+
+// ProbeCapabilities probes the BMC for the features it supports, like virtual media which requires a license on some BMCs
+func (c *Conn) ProbeCapabilities(ctx context.Context) (*bmc.ProbedCapabilities, error) {
+	return c.redfishwrapper.ProbeCapabilities(ctx)
+}
+
 // SendNMI tells the BMC to issue an NMI to the device
 func (c *Conn) SendNMI(ctx context.Context) error {
 	return c.redfishwrapper.SendNMI(ctx)
@@ -16,6 +16,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
 	berrors "github.com/bmc-toolbox/bmclib/v2/errors"
 )
 
@@ -173,3 +174,38 @@ func TestOpenErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestIdracDirectoryServicePayload(t *testing.T) {
+	admins := bmc.DirectoryRoleMapping{RemoteGroup: "bmc-admins", LocalRole: "Administrator"}
+
+	tests := map[string]struct {
+		config   bmc.DirectoryServiceConfig
+		mappings any
+		err      error
+	}{
+		"padded role groups": {
+			config:   bmc.DirectoryServiceConfig{Type: bmc.DirectoryServiceActiveDirectory, RoleMappings: []bmc.DirectoryRoleMapping{admins}},
+			mappings: []any{map[string]any{"RemoteGroup": "bmc-admins", "LocalRole": "Administrator"}, nil, nil, nil, nil},
+		},
+		"role mappings unchanged": {
+			config: bmc.DirectoryServiceConfig{Type: bmc.DirectoryServiceLDAP},
+		},
+		"too many role mappings": {
+			config: bmc.DirectoryServiceConfig{Type: bmc.DirectoryServiceLDAP, RoleMappings: []bmc.DirectoryRoleMapping{admins, admins, admins, admins, admins, admins}},
+			err:    errDirectoryRoleGroups,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			payload, err := idracDirectoryServicePayload(tc.config)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.mappings, payload["RemoteRoleMapping"])
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package fujitsu
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// compile-time assertions that the provider implements the BIOS configuration interfaces.
+var (
+	_ bmc.BiosConfigurationGetter   = (*Conn)(nil)
+	_ bmc.BiosConfigurationSetter   = (*Conn)(nil)
+	_ bmc.BiosConfigurationResetter = (*Conn)(nil)
+)
+
+// GetBiosConfiguration returns the current BIOS attributes as a key/value map,
+// read from the ComputerSystem Bios resource Attributes.
+//
+// Implements bmc.BiosConfigurationGetter.
+func (c *Conn) GetBiosConfiguration(ctx context.Context) (biosConfig map[string]string, err error) {
+	return c.redfishwrapper.GetBiosConfiguration(ctx)
+}
+
+// SetBiosConfiguration writes BIOS attributes to the Bios/Settings resource
+// with an OnReset apply time, the iRMC applies them on the next host reset.
+//
+// Implements bmc.BiosConfigurationSetter.
+func (c *Conn) SetBiosConfiguration(ctx context.Context, biosConfig map[string]string) (err error) {
+	return c.redfishwrapper.SetBiosConfiguration(ctx, biosConfig)
+}
+
+// ResetBiosConfiguration restores BIOS settings to their default values via the
+// Bios.ResetBios action.
+//
+// Implements bmc.BiosConfigurationResetter.
+func (c *Conn) ResetBiosConfiguration(ctx context.Context) (err error) {
+	return c.redfishwrapper.ResetBiosConfiguration(ctx)
+}
@@ -0,0 +1,148 @@
+package fujitsu
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmc-toolbox/common"
+	"github.com/pkg/errors"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/constants"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+// updateServiceURI is the iRMC UpdateService, it lists the OEM update actions.
+const updateServiceURI = "/redfish/v1/UpdateService"
+
+// iRMC OEM UpdateService actions, keyed by the component slug they update.
+var updateActions = map[string]string{
+	strings.ToLower(common.SlugBIOS): "#FTSUpdateService.BiosUpdate",
+	strings.ToLower(common.SlugBMC):  "#FTSUpdateService.iRMCUpdate",
+}
+
+// compile-time assertions that the provider implements the firmware interfaces.
+var (
+	_ bmc.FirmwareInstallProvider    = (*Conn)(nil)
+	_ bmc.FirmwareTaskVerifier       = (*Conn)(nil)
+	_ bmc.FirmwareInstallStepsGetter = (*Conn)(nil)
+)
+
+// FirmwareInstallSteps returns the ordered steps the provider performs for a
+// firmware install: the OEM update action uploads and initiates the install in
+// one step, followed by polling the update task.
+//
+// Implements bmc.FirmwareInstallStepsGetter.
+func (c *Conn) FirmwareInstallSteps(ctx context.Context, component string) ([]constants.FirmwareInstallStep, error) {
+	if _, ok := updateActions[strings.ToLower(component)]; !ok {
+		return nil, errors.Wrap(bmclibErrs.ErrFirmwareInstall, "unsupported component: "+component)
+	}
+
+	return []constants.FirmwareInstallStep{
+		constants.FirmwareInstallStepUploadInitiateInstall,
+		constants.FirmwareInstallStepInstallStatus,
+	}, nil
+}
+
+// FirmwareInstallUploadAndInitiate uploads a BIOS or iRMC firmware image to the
+// OEM update action of the iRMC UpdateService, returning the update task id.
+//
+// component is one of the "bios" or "bmc" component slugs. A BIOS update on a
+// powered on host is staged by the iRMC and flashed on the next host power off.
+//
+// Implements bmc.FirmwareInstallProvider.
+func (c *Conn) FirmwareInstallUploadAndInitiate(ctx context.Context, component string, file *os.File) (taskID string, err error) {
+	target, err := c.updateActionTarget(component)
+	if err != nil {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareInstall, err.Error())
+	}
+
+	payload, contentType, err := updatePayload(filepath.Base(file.Name()), file)
+	if err != nil {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareUpload, err.Error())
+	}
+
+	resp, err := c.redfishwrapper.RunRawRequestWithHeaders(http.MethodPost, target, payload, contentType, nil)
+	if err != nil {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareUpload, err.Error())
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareUpload, "unexpected status code returned: "+resp.Status)
+	}
+
+	// The response contains a location header pointing to the task URI
+	// Location: /redfish/v1/TaskService/Tasks/3
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareInstall, "update task location not returned")
+	}
+
+	return path.Base(strings.TrimRight(location, "/")), nil
+}
+
+// FirmwareTaskStatus returns the state and status of a firmware update task.
+//
+// Implements bmc.FirmwareTaskVerifier.
+func (c *Conn) FirmwareTaskStatus(ctx context.Context, kind constants.FirmwareInstallStep, component, taskID, installVersion string) (state constants.TaskState, status string, err error) {
+	return c.redfishwrapper.TaskStatus(ctx, taskID)
+}
+
+// updateActionTarget returns the target of the OEM UpdateService action that
+// updates the component.
+func (c *Conn) updateActionTarget(component string) (string, error) {
+	action, ok := updateActions[strings.ToLower(component)]
+	if !ok {
+		return "", fmt.Errorf("unsupported component: %s", component)
+	}
+
+	var updateService struct {
+		Actions struct {
+			Oem map[string]struct {
+				Target string `json:"target"`
+			} `json:"Oem"`
+		} `json:"Actions"`
+	}
+
+	if err := c.redfishwrapper.GetJSON(updateServiceURI, &updateService); err != nil {
+		return "", err
+	}
+
+	target := updateService.Actions.Oem[action].Target
+	if target == "" {
+		return "", fmt.Errorf("UpdateService does not list the %s action", action)
+	}
+
+	return target, nil
+}
+
+// updatePayload returns the multipart form the OEM update actions expect, the
+// image is sent in the "data" part.
+func updatePayload(filename string, file io.Reader) (io.ReadSeeker, string, error) {
+	buf := &bytes.Buffer{}
+	form := multipart.NewWriter(buf)
+
+	part, err := form.CreateFormFile("data", filename)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, "", fmt.Errorf("reading firmware image: %w", err)
+	}
+
+	if err := form.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return bytes.NewReader(buf.Bytes()), form.FormDataContentType(), nil
+}
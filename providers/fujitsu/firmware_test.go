@@ -0,0 +1,71 @@
+package fujitsu
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bmc-toolbox/bmclib/v2/constants"
+)
+
+// Requirement: firmware is uploaded to the OEM update action of the component.
+func TestFirmwareInstallUploadAndInitiate(t *testing.T) {
+	tests := []struct {
+		component string
+		action    string
+		wantErr   bool
+	}{
+		{"bios", "BiosUpdate", false},
+		{"bmc", "iRMCUpdate", false},
+		{"nic", "", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.component, func(t *testing.T) {
+			ts := newTestServer(t, testServerOpts{})
+			c := ts.openedClient(t)
+
+			path := filepath.Join(t.TempDir(), "firmware.upd")
+			if err := os.WriteFile(path, []byte("firmware"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			file, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			taskID, err := c.FirmwareInstallUploadAndInitiate(context.Background(), tc.component, file)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error for an unsupported component")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FirmwareInstallUploadAndInitiate: %v", err)
+			}
+			if taskID != "3" {
+				t.Fatalf("task id = %q, want %q", taskID, "3")
+			}
+			if image, ok := ts.uploaded(tc.action); !ok || image != "firmware" {
+				t.Fatalf("%s upload = (%q, %v), want (%q, true)", tc.action, image, ok, "firmware")
+			}
+		})
+	}
+}
+
+// Requirement: the update task is polled through the TaskService.
+func TestFirmwareTaskStatus(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	state, _, err := c.FirmwareTaskStatus(context.Background(), constants.FirmwareInstallStepInstallStatus, "bios", "3", "")
+	if err != nil {
+		t.Fatalf("FirmwareTaskStatus: %v", err)
+	}
+	if state != constants.Running {
+		t.Fatalf("state = %q, want %q", state, constants.Running)
+	}
+}
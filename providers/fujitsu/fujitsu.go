@@ -0,0 +1,226 @@
+// Package fujitsu implements a bmclib provider for Fujitsu PRIMERGY servers
+// managed by the integrated Remote Management Controller (iRMC) S5 and S6.
+//
+// iRMC implements the DMTF Redfish standard with Fujitsu OEM extensions under
+// the "Oem.ts_fujitsu" properties. This provider is built on top of the shared
+// gofish-backed [redfishwrapper.Client] and layers the iRMC specific behavior
+// on top in dedicated files:
+//
+//   - inventory.go adds the BIOS of the OEM FTSBIOS resource and the RAID
+//     adapters and disks of the OEM FTSRAID resources.
+//
+//   - firmware.go uploads and installs BIOS and iRMC firmware through the OEM
+//     actions of the iRMC UpdateService.
+//
+//   - screenshot.go captures the video screenshot through the OEM
+//     VideoScreenshot resource of the iRMC.
+package fujitsu
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/jacobweinstock/registrar"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/internal/httpclient"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+	"github.com/bmc-toolbox/bmclib/v2/providers"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+const (
+	// ProviderName is the registered name of this provider.
+	ProviderName = "fujitsu"
+	// ProviderProtocol is the transport/protocol this provider speaks.
+	ProviderProtocol = "redfish"
+
+	// vendorFujitsu is contained in the system manufacturer of the iRMC
+	// managed systems, e.g. "FUJITSU" or "Fujitsu Technology Solutions".
+	vendorFujitsu = "fujitsu"
+)
+
+// Features is the set of bmclib features this provider implements.
+var Features = registrar.Features{
+	// power and boot
+	providers.FeaturePowerState,
+	providers.FeaturePowerSet,
+	providers.FeatureBootDeviceSet,
+	// inventory
+	providers.FeatureInventoryRead,
+	// UpdateService OEM firmware
+	providers.FeatureFirmwareUploadInitiateInstall,
+	providers.FeatureFirmwareTaskStatus,
+	providers.FeatureFirmwareInstallSteps,
+	// BIOS
+	providers.FeatureGetBiosConfiguration,
+	providers.FeatureSetBiosConfiguration,
+	providers.FeatureResetBiosConfiguration,
+	// video
+	providers.FeatureScreenshot,
+}
+
+// Conn is a connection to a Fujitsu iRMC BMC.
+type Conn struct {
+	redfishwrapper *redfishwrapper.Client
+	// failInventoryOnError has Inventory fail on the first error reading the
+	// OEM BIOS and RAID adapter resources.
+	failInventoryOnError bool
+	Log                  logr.Logger
+}
+
+// Config is the configuration of an iRMC [Conn], it is built by [New] from
+// the given options.
+type Config struct {
+	// HTTPClient sends the iRMC requests, a client with the bmclib defaults is
+	// built when nil.
+	HTTPClient *http.Client
+	// Port is the TCP port the iRMC Redfish service listens on. Defaults to "443".
+	Port string
+	// VersionsNotCompatible are the Redfish versions of the iRMC firmware the
+	// provider is not to be used with.
+	VersionsNotCompatible []string
+	// RootCAs verifies the iRMC certificate against the pool, the certificate
+	// is not verified when nil.
+	RootCAs *x509.CertPool
+	// UseBasicAuth selects HTTP Basic authentication instead of Redfish session
+	// login.
+	UseBasicAuth bool
+	// FailInventoryOnError has Inventory fail on the first error reading a
+	// component, including the OEM FTSBIOS and FTSRAID resources, by default
+	// these are left out of the inventory.
+	FailInventoryOnError bool
+}
+
+// Option sets a setting of the iRMC [Config].
+type Option func(*Config)
+
+// WithHTTPClient sets the HTTP client for the iRMC requests.
+func WithHTTPClient(c *http.Client) Option {
+	return func(cfg *Config) { cfg.HTTPClient = c }
+}
+
+// WithPort sets the iRMC Redfish service port (default "443").
+func WithPort(port string) Option {
+	return func(cfg *Config) { cfg.Port = port }
+}
+
+// WithVersionsNotCompatible excludes the iRMC firmware reporting one of the
+// Redfish versions.
+func WithVersionsNotCompatible(versions []string) Option {
+	return func(cfg *Config) { cfg.VersionsNotCompatible = versions }
+}
+
+// WithRootCAs has the iRMC certificate verified against the pool.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(cfg *Config) { cfg.RootCAs = pool }
+}
+
+// WithUseBasicAuth authenticates the requests with HTTP Basic auth instead of
+// an iRMC Redfish session.
+func WithUseBasicAuth(use bool) Option {
+	return func(cfg *Config) { cfg.UseBasicAuth = use }
+}
+
+// WithFailInventoryOnError has Inventory fail on the first error reading the
+// OEM FTSBIOS and FTSRAID resources.
+func WithFailInventoryOnError(fail bool) Option {
+	return func(cfg *Config) { cfg.FailInventoryOnError = fail }
+}
+
+// New returns a [Conn] for the given iRMC host. The connection is not opened
+// until [Conn.Open] is called.
+func New(host, user, pass string, log logr.Logger, opts ...Option) *Conn {
+	cfg := &Config{
+		HTTPClient:            httpclient.Build(),
+		Port:                  "443",
+		VersionsNotCompatible: []string{},
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	rfOpts := []redfishwrapper.Option{
+		redfishwrapper.WithHTTPClient(cfg.HTTPClient),
+		redfishwrapper.WithVersionsNotCompatible(cfg.VersionsNotCompatible),
+		redfishwrapper.WithBasicAuthEnabled(cfg.UseBasicAuth),
+	}
+
+	if cfg.RootCAs != nil {
+		rfOpts = append(rfOpts, redfishwrapper.WithSecureTLS(cfg.RootCAs))
+	}
+
+	return &Conn{
+		Log:                  log,
+		failInventoryOnError: cfg.FailInventoryOnError,
+		redfishwrapper:       redfishwrapper.NewClient(host, cfg.Port, user, pass, rfOpts...),
+	}
+}
+
+// Name returns the provider name ("fujitsu").
+func (c *Conn) Name() string {
+	return ProviderName
+}
+
+// Open opens a Redfish session, or sets up Basic auth when [WithUseBasicAuth]
+// was given.
+func (c *Conn) Open(ctx context.Context) error {
+	return c.redfishwrapper.Open(ctx)
+}
+
+// Close releases the Redfish session.
+func (c *Conn) Close(ctx context.Context) error {
+	return c.redfishwrapper.Close(ctx)
+}
+
+// KeepSessionAlive refreshes the iRMC session, an expired session is re-established.
+//
+// Implements bmc.SessionKeeper.
+func (c *Conn) KeepSessionAlive(ctx context.Context) error {
+	return c.redfishwrapper.KeepSessionAlive(ctx)
+}
+
+// ProbeCapabilities probes the iRMC for the features it supports.
+//
+// Implements bmc.CapabilityProber.
+func (c *Conn) ProbeCapabilities(ctx context.Context) (*bmc.ProbedCapabilities, error) {
+	return c.redfishwrapper.ProbeCapabilities(ctx)
+}
+
+// Compatible reports whether the BMC is an iRMC managing a Fujitsu PRIMERGY
+// system.
+//
+// An iRMC session is opened for the check, the BMC is compatible when its
+// Redfish version is not excluded with [WithVersionsNotCompatible] and the
+// system manufacturer is Fujitsu.
+func (c *Conn) Compatible(ctx context.Context) bool {
+	if err := c.Open(ctx); err != nil {
+		c.Log.V(2).WithValues("provider", c.Name()).
+			Info(bmclibErrs.ErrCompatibilityCheck.Error(), "error", err.Error())
+
+		return false
+	}
+	defer func() { _ = c.Close(ctx) }()
+
+	if !c.redfishwrapper.VersionCompatible() {
+		c.Log.V(2).WithValues("provider", c.Name()).
+			Info(bmclibErrs.ErrCompatibilityCheck.Error(), "reason", "incompatible redfish version")
+
+		return false
+	}
+
+	vendor, _, err := c.redfishwrapper.DeviceVendorModel(ctx)
+	if err != nil {
+		c.Log.V(2).WithValues("provider", c.Name()).
+			Info(bmclibErrs.ErrCompatibilityCheck.Error(), "error", err.Error())
+
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(vendor), vendorFujitsu)
+}
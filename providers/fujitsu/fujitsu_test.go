@@ -0,0 +1,130 @@
+package fujitsu
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// Requirement: Provider identity and registration.
+func TestName(t *testing.T) {
+	if ProviderName != "fujitsu" {
+		t.Fatalf("ProviderName = %q, want %q", ProviderName, "fujitsu")
+	}
+	c := New("127.0.0.1", "u", "p", logr.Discard())
+	if got := c.Name(); got != ProviderName {
+		t.Fatalf("Name() = %q, want %q", got, ProviderName)
+	}
+}
+
+// Requirement: Connection lifecycle — Open creates a session, Close deletes it.
+func TestOpenClose(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	defer ts.Close()
+
+	c := ts.client(t)
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if !ts.didCreateSession() {
+		t.Fatal("expected a session to be created on Open")
+	}
+
+	if err := c.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !ts.didDeleteSession() {
+		t.Fatal("expected the session to be deleted on Close")
+	}
+}
+
+// Requirement: Compatible identifies Fujitsu systems and honors excluded versions.
+func TestCompatible(t *testing.T) {
+	t.Run("Fujitsu system is compatible", func(t *testing.T) {
+		ts := newTestServer(t, testServerOpts{})
+		defer ts.Close()
+
+		if !ts.client(t).Compatible(context.Background()) {
+			t.Fatal("expected the iRMC to be compatible")
+		}
+	})
+
+	t.Run("excluded redfish version is not compatible", func(t *testing.T) {
+		ts := newTestServer(t, testServerOpts{})
+		defer ts.Close()
+
+		c := ts.client(t, WithVersionsNotCompatible([]string{"1.11.0"}))
+		if c.Compatible(context.Background()) {
+			t.Fatal("expected the excluded redfish version to be incompatible")
+		}
+	})
+}
+
+// Requirement: Power control via ComputerSystem.Reset.
+func TestPower(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	state, err := c.PowerStateGet(context.Background())
+	if err != nil {
+		t.Fatalf("PowerStateGet: %v", err)
+	}
+	if state != "On" {
+		t.Fatalf("PowerStateGet = %q, want %q", state, "On")
+	}
+
+	ok, err := c.PowerSet(context.Background(), "soft")
+	if err != nil || !ok {
+		t.Fatalf("PowerSet(soft) = (%v, %v), want (true, nil)", ok, err)
+	}
+	if rt := ts.resetType(); rt != "GracefulShutdown" {
+		t.Fatalf("reset type = %q, want %q", rt, "GracefulShutdown")
+	}
+}
+
+// Requirement: BIOS configuration get, set and reset.
+func TestBiosConfiguration(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	config, err := c.GetBiosConfiguration(context.Background())
+	if err != nil {
+		t.Fatalf("GetBiosConfiguration: %v", err)
+	}
+	if config["HyperThreading"] != "Enabled" {
+		t.Fatalf("HyperThreading = %q, want %q", config["HyperThreading"], "Enabled")
+	}
+
+	if err := c.SetBiosConfiguration(context.Background(), map[string]string{"HyperThreading": "Disabled"}); err != nil {
+		t.Fatalf("SetBiosConfiguration: %v", err)
+	}
+	attributes, _ := ts.biosPatch()["Attributes"].(map[string]any)
+	if attributes["HyperThreading"] != "Disabled" {
+		t.Fatalf("bios PATCH body = %v", ts.biosPatch())
+	}
+
+	if err := c.ResetBiosConfiguration(context.Background()); err != nil {
+		t.Fatalf("ResetBiosConfiguration: %v", err)
+	}
+	if !ts.didResetBios() {
+		t.Fatal("expected the Bios.ResetBios action to be posted")
+	}
+}
+
+// Requirement: Screenshot through the OEM VideoScreenshot resource.
+func TestScreenshot(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	image, fileType, err := c.Screenshot(context.Background())
+	if err != nil {
+		t.Fatalf("Screenshot: %v", err)
+	}
+	if !ts.didMakeScreenshot() {
+		t.Fatal("expected the MakeScreenshot action to be posted")
+	}
+	if string(image) != pngHeader || fileType != "png" {
+		t.Fatalf("Screenshot = (%q, %q), want (%q, %q)", image, fileType, pngHeader, "png")
+	}
+}
@@ -0,0 +1,208 @@
+package fujitsu
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bmc-toolbox/common"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+)
+
+var _ bmc.InventoryGetter = (*Conn)(nil)
+
+// oemStatus is the Redfish status shape of the iRMC OEM resources.
+type oemStatus struct {
+	Health string `json:"Health"`
+	State  string `json:"State"`
+}
+
+// ftsBIOS is the OEM FTSBIOS resource of the system.
+type ftsBIOS struct {
+	BiosVersion string `json:"BiosVersion"`
+	Vendor      string `json:"Vendor"`
+	ReleaseDate string `json:"ReleaseDate"`
+}
+
+// ftsRAIDAdapter is an OEM FTSRAID adapter resource, the physical disks are
+// embedded in the adapter.
+type ftsRAIDAdapter struct {
+	ID              string    `json:"Id"`
+	Name            string    `json:"Name"`
+	Vendor          string    `json:"Vendor"`
+	Model           string    `json:"Model"`
+	SerialNumber    string    `json:"SerialNumber"`
+	FirmwareVersion string    `json:"FirmwareVersion"`
+	Status          oemStatus `json:"Status"`
+	PhysicalDisks   []struct {
+		ID              string    `json:"Id"`
+		Slot            string    `json:"Slot"`
+		Vendor          string    `json:"Vendor"`
+		Model           string    `json:"Model"`
+		SerialNumber    string    `json:"SerialNumber"`
+		FirmwareVersion string    `json:"FirmwareVersion"`
+		CapacityBytes   int64     `json:"CapacityBytes"`
+		MediaType       string    `json:"MediaType"`
+		Protocol        string    `json:"Protocol"`
+		Status          oemStatus `json:"Status"`
+	} `json:"PhysicalDisks"`
+}
+
+// Inventory collects the hardware and firmware inventory of the iRMC managed
+// system into a *common.Device.
+//
+// The standard Redfish inventory is extended with the BIOS of the OEM FTSBIOS
+// resource and the RAID adapters and disks of the OEM FTSRAID resources, the
+// iRMC does not list the RAID adapters in the standard Storage resources.
+// Components already reported through the standard resources are not added
+// twice.
+//
+// When the connection's failInventoryOnError is false (the default, set via
+// [WithFailInventoryOnError]), a failure reading one sub-resource does not abort
+// the whole inventory — the provider returns what it could collect. When true,
+// the first sub-resource error is returned.
+//
+// Implements bmc.InventoryGetter.
+func (c *Conn) Inventory(ctx context.Context) (device *common.Device, err error) {
+	device, err = c.redfishwrapper.Inventory(ctx, c.failInventoryOnError)
+	if err != nil {
+		return nil, err
+	}
+
+	sys, err := c.redfishwrapper.System()
+	if err != nil {
+		if c.failInventoryOnError {
+			return nil, err
+		}
+
+		return device, nil
+	}
+
+	collectors := []func(systemURL string, device *common.Device) error{
+		c.collectBIOS,
+		c.collectRAIDAdapters,
+	}
+
+	for _, collect := range collectors {
+		if err := collect(sys.ODataID, device); err != nil && c.failInventoryOnError {
+			return nil, err
+		}
+	}
+
+	return device, nil
+}
+
+// collectBIOS fills in the BIOS vendor and version from the FTSBIOS resource
+// where the standard inventory left them empty.
+func (c *Conn) collectBIOS(systemURL string, device *common.Device) error {
+	var bios ftsBIOS
+	if err := c.redfishwrapper.GetJSON(systemURL+"/Oem/ts_fujitsu/BIOS", &bios); err != nil {
+		if redfishwrapper.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if device.BIOS == nil {
+		device.BIOS = &common.BIOS{}
+	}
+
+	if device.BIOS.Firmware == nil {
+		device.BIOS.Firmware = &common.Firmware{}
+	}
+
+	if device.BIOS.Firmware.Installed == "" {
+		device.BIOS.Firmware.Installed = bios.BiosVersion
+	}
+
+	if device.BIOS.Vendor == "" {
+		device.BIOS.Vendor = common.FormatVendorName(bios.Vendor)
+	}
+
+	if device.BIOS.Description == "" && bios.ReleaseDate != "" {
+		device.BIOS.Description = "released " + bios.ReleaseDate
+	}
+
+	return nil
+}
+
+// collectRAIDAdapters adds the FTSRAID adapters and their physical disks to
+// the device, systems without a RAID adapter are skipped.
+func (c *Conn) collectRAIDAdapters(systemURL string, device *common.Device) error {
+	members, err := c.redfishwrapper.CollectionMembers(systemURL + "/Oem/ts_fujitsu/RAIDAdapters")
+	if err != nil {
+		if redfishwrapper.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, member := range members {
+		var adapter ftsRAIDAdapter
+		if err := c.redfishwrapper.GetJSON(member, &adapter); err != nil {
+			return err
+		}
+
+		if !hasStorageController(device, adapter.SerialNumber) {
+			device.StorageControllers = append(device.StorageControllers, &common.StorageController{
+				Common: common.Common{
+					Description: adapter.Name,
+					Vendor:      common.FormatVendorName(adapter.Vendor),
+					Model:       adapter.Model,
+					Serial:      adapter.SerialNumber,
+					Firmware:    &common.Firmware{Installed: adapter.FirmwareVersion},
+					Status:      &common.Status{Health: adapter.Status.Health, State: adapter.Status.State},
+				},
+				ID: adapter.ID,
+			})
+		}
+
+		for _, disk := range adapter.PhysicalDisks {
+			if hasDrive(device, disk.SerialNumber) {
+				continue
+			}
+
+			device.Drives = append(device.Drives, &common.Drive{
+				Common: common.Common{
+					Description: disk.Slot,
+					ProductName: disk.Model,
+					Vendor:      common.FormatVendorName(disk.Vendor),
+					Model:       disk.Model,
+					Serial:      disk.SerialNumber,
+					Firmware:    &common.Firmware{Installed: disk.FirmwareVersion},
+					Status:      &common.Status{Health: disk.Status.Health, State: disk.Status.State},
+				},
+				ID:                disk.ID,
+				Type:              disk.MediaType,
+				Protocol:          disk.Protocol,
+				StorageController: adapter.ID,
+				CapacityBytes:     disk.CapacityBytes,
+			})
+		}
+	}
+
+	return nil
+}
+
+func hasStorageController(device *common.Device, serial string) bool {
+	for _, controller := range device.StorageControllers {
+		if serial != "" && strings.EqualFold(controller.Serial, serial) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasDrive(device *common.Device, serial string) bool {
+	for _, drive := range device.Drives {
+		if serial != "" && strings.EqualFold(drive.Serial, serial) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,51 @@
+package fujitsu
+
+import (
+	"context"
+	"testing"
+)
+
+// Requirement: inventory includes the OEM FTSBIOS and FTSRAID resources.
+func TestInventory(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	device, err := c.Inventory(context.Background())
+	if err != nil {
+		t.Fatalf("Inventory: %v", err)
+	}
+
+	if device.BIOS == nil || device.BIOS.Firmware == nil || device.BIOS.Firmware.Installed != "V5.0.0.14 R1.26.0 for D3384-A1x" {
+		t.Fatalf("BIOS = %+v", device.BIOS)
+	}
+
+	if len(device.StorageControllers) != 1 {
+		t.Fatalf("got %d storage controllers, want 1", len(device.StorageControllers))
+	}
+	controller := device.StorageControllers[0]
+	if controller.Model != "PRAID EP540i" || controller.Firmware.Installed != "5.140.00-3319" {
+		t.Fatalf("storage controller = %q firmware %q", controller.Model, controller.Firmware.Installed)
+	}
+
+	if len(device.Drives) != 2 {
+		t.Fatalf("got %d drives, want 2", len(device.Drives))
+	}
+	drive := device.Drives[1]
+	if drive.Serial != "PHYG0123456" || drive.Type != "SSD" || drive.Protocol != "SATA" || drive.StorageController != "0" {
+		t.Fatalf("drive = %+v", drive)
+	}
+}
+
+// Requirement: systems without the OEM resources still return an inventory.
+func TestInventoryWithoutOEMResources(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{oemInventoryNotFound: true})
+	c := ts.openedClient(t, WithFailInventoryOnError(true))
+
+	device, err := c.Inventory(context.Background())
+	if err != nil {
+		t.Fatalf("Inventory: %v", err)
+	}
+	if len(device.StorageControllers) != 0 || len(device.Drives) != 0 {
+		t.Fatalf("unexpected OEM components: %d controllers, %d drives", len(device.StorageControllers), len(device.Drives))
+	}
+}
@@ -0,0 +1,285 @@
+package fujitsu
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+const fixturesDir = "./fixtures/v1"
+
+// pngHeader is served as the screenshot image.
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+// testServer is an httptest-backed iRMC Redfish mock. It serves recorded JSON
+// fixtures and emulates Redfish session create/delete, the OEM UpdateService
+// actions and the OEM VideoScreenshot resource so the provider can be exercised
+// entirely offline.
+type testServer struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	sessionCreated bool
+	sessionDeleted bool
+	// lastResetType records the ResetType posted to ComputerSystem.Reset.
+	lastResetType string
+	// biosReset records whether the Bios.ResetBios action was posted.
+	biosReset bool
+	// biosPatchBody records the decoded body of the last Bios settings PATCH.
+	biosPatchBody map[string]any
+	// updates records the image uploaded to each OEM update action, keyed by
+	// the action name.
+	updates map[string]string
+	// screenshotMade records whether the MakeScreenshot action was posted.
+	screenshotMade bool
+}
+
+// testServerOpts configures a testServer.
+type testServerOpts struct {
+	// oemInventoryNotFound drops the FTSBIOS and FTSRAID routes so the mock
+	// returns 404, emulating systems without these OEM resources.
+	oemInventoryNotFound bool
+}
+
+// newTestServer builds and starts a TLS mock iRMC server.
+func newTestServer(t *testing.T, opts testServerOpts) *testServer {
+	t.Helper()
+
+	ts := &testServer{updates: map[string]string{}}
+
+	// path -> fixture file for plain GETs.
+	routes := map[string]string{
+		"/redfish/v1/":                                        "serviceroot.json",
+		"/redfish/v1/Systems":                                 "systems.json",
+		"/redfish/v1/Systems/0":                               "system.0.json",
+		"/redfish/v1/Systems/0/Bios":                          "bios.json",
+		"/redfish/v1/Systems/0/Bios/Settings":                 "bios.settings.json",
+		"/redfish/v1/Systems/0/Oem/ts_fujitsu/BIOS":           "fts.bios.json",
+		"/redfish/v1/Systems/0/Oem/ts_fujitsu/RAIDAdapters":   "fts.raidadapters.json",
+		"/redfish/v1/Systems/0/Oem/ts_fujitsu/RAIDAdapters/0": "fts.raidadapter.0.json",
+		"/redfish/v1/Chassis":                                 "chassis.json",
+		"/redfish/v1/Chassis/0":                               "chassis.0.json",
+		"/redfish/v1/Managers":                                "managers.json",
+		"/redfish/v1/Managers/iRMC":                           "manager.irmc.json",
+		"/redfish/v1/UpdateService":                           "updateservice.json",
+		"/redfish/v1/UpdateService/FirmwareInventory":         "firmwareinventory.json",
+		"/redfish/v1/UpdateService/FirmwareInventory/BMC":     "firmwareinventory.bmc.json",
+		"/redfish/v1/TaskService":                             "taskservice.json",
+		"/redfish/v1/TaskService/Tasks":                       "tasks.json",
+		"/redfish/v1/TaskService/Tasks/3":                     "task.3.json",
+	}
+
+	if opts.oemInventoryNotFound {
+		for path := range routes {
+			if strings.HasPrefix(path, "/redfish/v1/Systems/0/Oem/ts_fujitsu") {
+				delete(routes, path)
+			}
+		}
+	}
+
+	mux := http.NewServeMux()
+
+	// ComputerSystem.Reset action — records the requested ResetType.
+	mux.HandleFunc("/redfish/v1/Systems/0/Actions/ComputerSystem.Reset", func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			ResetType string `json:"ResetType"`
+		}
+		if body, err := io.ReadAll(r.Body); err == nil {
+			_ = json.Unmarshal(body, &payload)
+		}
+		ts.mu.Lock()
+		ts.lastResetType = payload.ResetType
+		ts.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// Bios.ResetBios action.
+	mux.HandleFunc("/redfish/v1/Systems/0/Bios/Actions/Bios.ResetBios", func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		ts.biosReset = true
+		ts.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// OEM UpdateService actions: record the uploaded "data" part and return the
+	// update task Location.
+	updateHandler := func(action string) func(http.ResponseWriter, *http.Request) {
+		return func(w http.ResponseWriter, r *http.Request) {
+			file, _, err := r.FormFile("data")
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			defer file.Close()
+
+			b, _ := io.ReadAll(file)
+			ts.mu.Lock()
+			ts.updates[action] = string(b)
+			ts.mu.Unlock()
+			w.Header().Set("Location", "/redfish/v1/TaskService/Tasks/3")
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}
+	mux.HandleFunc("/redfish/v1/UpdateService/Actions/Oem/FTSUpdateService.BiosUpdate", updateHandler("BiosUpdate"))
+	mux.HandleFunc("/redfish/v1/UpdateService/Actions/Oem/FTSUpdateService.iRMCUpdate", updateHandler("iRMCUpdate"))
+
+	// OEM VideoScreenshot actions.
+	mux.HandleFunc("/redfish/v1/Managers/iRMC/Oem/ts_fujitsu/VideoScreenshot/Actions/FTSVideoScreenshotResource.MakeScreenshot", func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		ts.screenshotMade = true
+		ts.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/redfish/v1/Managers/iRMC/Oem/ts_fujitsu/VideoScreenshot/Actions/FTSVideoScreenshotResource.GetScreenshot", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte(pngHeader))
+	})
+
+	// Session create: returns an X-Auth-Token and the session Location.
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		ts.mu.Lock()
+		ts.sessionCreated = true
+		ts.mu.Unlock()
+
+		w.Header().Set("X-Auth-Token", "test-token")
+		w.Header().Set("Location", "/redfish/v1/SessionService/Sessions/1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"@odata.id":"/redfish/v1/SessionService/Sessions/1","Id":"1","Name":"Session"}`))
+	})
+
+	// A created session is deleted here on Close.
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			ts.mu.Lock()
+			ts.sessionDeleted = true
+			ts.mu.Unlock()
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Catch-all for the rest of the Redfish tree.
+	//
+	// GETs are served from fixtures. Writes on a known resource are accepted
+	// with 204 and recorded.
+	mux.HandleFunc("/redfish/v1/", func(w http.ResponseWriter, r *http.Request) {
+		file, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			ts.mu.Lock()
+			if r.URL.Path == "/redfish/v1/Systems/0/Bios/Settings" {
+				if b, err := io.ReadAll(r.Body); err == nil {
+					var body map[string]any
+					if json.Unmarshal(b, &body) == nil {
+						ts.biosPatchBody = body
+					}
+				}
+			}
+			ts.mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		body, err := os.ReadFile(filepath.Join(fixturesDir, file))
+		if err != nil {
+			t.Errorf("failed to read fixture %q for %s: %v", file, r.URL.Path, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	})
+
+	ts.Server = httptest.NewTLSServer(mux)
+
+	return ts
+}
+
+// client returns a *Conn pointed at the mock server. Extra options are appended
+// after the mandatory port option.
+func (ts *testServer) client(t *testing.T, opts ...Option) *Conn {
+	t.Helper()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("parse mock url: %v", err)
+	}
+	opts = append([]Option{WithPort(u.Port())}, opts...)
+	return New(u.Hostname(), "user", "pass", logr.Discard(), opts...)
+}
+
+// openedClient returns a *Conn with an established session and registers Close
+// + server shutdown for cleanup.
+func (ts *testServer) openedClient(t *testing.T, opts ...Option) *Conn {
+	t.Helper()
+	c := ts.client(t, opts...)
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = c.Close(context.Background())
+		ts.Close()
+	})
+	return c
+}
+
+func (ts *testServer) didCreateSession() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.sessionCreated
+}
+
+func (ts *testServer) didDeleteSession() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.sessionDeleted
+}
+
+func (ts *testServer) resetType() string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.lastResetType
+}
+
+func (ts *testServer) didResetBios() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.biosReset
+}
+
+func (ts *testServer) biosPatch() map[string]any {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.biosPatchBody
+}
+
+func (ts *testServer) uploaded(action string) (string, bool) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	image, ok := ts.updates[action]
+	return image, ok
+}
+
+func (ts *testServer) didMakeScreenshot() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.screenshotMade
+}
@@ -0,0 +1,46 @@
+package fujitsu
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// compile-time assertions that the provider implements the interfaces.
+var (
+	_ bmc.PowerStateGetter         = (*Conn)(nil)
+	_ bmc.PowerSetter              = (*Conn)(nil)
+	_ bmc.BootDeviceSetter         = (*Conn)(nil)
+	_ bmc.BootDeviceOverrideGetter = (*Conn)(nil)
+)
+
+// PowerStateGet returns the power state of the system, read from the
+// ComputerSystem PowerState property.
+//
+// Implements bmc.PowerStateGetter.
+func (c *Conn) PowerStateGet(ctx context.Context) (state string, err error) {
+	return c.redfishwrapper.SystemPowerStatus(ctx)
+}
+
+// PowerSet sets the system power state through the ComputerSystem.Reset action.
+//
+// Implements bmc.PowerSetter.
+func (c *Conn) PowerSet(ctx context.Context, state string) (ok bool, err error) {
+	return c.redfishwrapper.PowerSet(ctx, state)
+}
+
+// BootDeviceSet sets the next boot device through the ComputerSystem Boot
+// override.
+//
+// Implements bmc.BootDeviceSetter.
+func (c *Conn) BootDeviceSet(ctx context.Context, bootDevice string, setPersistent, efiBoot bool) (ok bool, err error) {
+	return c.redfishwrapper.SystemBootDeviceSet(ctx, bootDevice, setPersistent, efiBoot)
+}
+
+// BootDeviceOverrideGet returns the boot override read from the ComputerSystem
+// Boot object.
+//
+// Implements bmc.BootDeviceOverrideGetter.
+func (c *Conn) BootDeviceOverrideGet(ctx context.Context) (override bmc.BootDeviceOverride, err error) {
+	return c.redfishwrapper.GetBootDeviceOverride(ctx)
+}
@@ -0,0 +1,75 @@
+package fujitsu
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+// videoScreenshotPath is the OEM VideoScreenshot resource, relative to the manager.
+const videoScreenshotPath = "/Oem/ts_fujitsu/VideoScreenshot"
+
+var _ bmc.ScreenshotGetter = (*Conn)(nil)
+
+// Screenshot captures a screenshot of the server console and returns the image
+// bytes and file type.
+//
+// The iRMC first makes the screenshot through the
+// FTSVideoScreenshotResource.MakeScreenshot action, the image is then
+// downloaded from the FTSVideoScreenshotResource.GetScreenshot action.
+//
+// Implements bmc.ScreenshotGetter.
+func (c *Conn) Screenshot(ctx context.Context) (image []byte, fileType string, err error) {
+	manager, err := c.redfishwrapper.Manager(ctx)
+	if err != nil {
+		return nil, "", errors.Wrap(bmclibErrs.ErrScreenshot, err.Error())
+	}
+
+	actions := manager.ODataID + videoScreenshotPath + "/Actions/"
+
+	resp, err := c.redfishwrapper.PostWithHeaders(ctx, actions+"FTSVideoScreenshotResource.MakeScreenshot", struct{}{}, nil)
+	if err != nil {
+		return nil, "", errors.Wrap(bmclibErrs.ErrScreenshot, err.Error())
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, "", errors.Wrap(bmclibErrs.ErrScreenshot, "make screenshot: "+resp.Status)
+	}
+
+	resp, err = c.redfishwrapper.Get(actions + "FTSVideoScreenshotResource.GetScreenshot")
+	if err != nil {
+		return nil, "", errors.Wrap(bmclibErrs.ErrScreenshot, err.Error())
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	image, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", errors.Wrap(bmclibErrs.ErrScreenshot, err.Error())
+	}
+
+	if len(image) == 0 {
+		return nil, "", errors.Wrap(bmclibErrs.ErrScreenshot, "no screencapture data in response")
+	}
+
+	return image, imageFileType(resp.Header.Get("Content-Type")), nil
+}
+
+// imageFileType returns the file type for an image content type, the iRMC
+// returns PNG screenshots unless configured otherwise.
+func imageFileType(contentType string) string {
+	switch {
+	case strings.Contains(contentType, "jpeg"):
+		return "jpg"
+	case strings.Contains(contentType, "bmp"):
+		return "bmp"
+	default:
+		return "png"
+	}
+}
@@ -0,0 +1,114 @@
+package hpe
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stmcginnis/gofish/schemas"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+)
+
+// compile-time assertions that the provider implements the BIOS configuration interfaces.
+var (
+	_ bmc.BiosConfigurationGetter   = (*Conn)(nil)
+	_ bmc.BiosConfigurationSetter   = (*Conn)(nil)
+	_ bmc.BiosConfigurationResetter = (*Conn)(nil)
+)
+
+// GetBiosConfiguration returns the current BIOS attributes as a key/value map,
+// read from the ComputerSystem Bios resource Attributes.
+//
+// Implements bmc.BiosConfigurationGetter.
+func (c *Conn) GetBiosConfiguration(ctx context.Context) (biosConfig map[string]string, err error) {
+	return c.redfishwrapper.GetBiosConfiguration(ctx)
+}
+
+// SetBiosConfiguration stages BIOS attributes in the iLO pending settings
+// resource (Bios/Settings), iLO applies them on the next host reset.
+//
+// iLO does not support the "@Redfish.SettingsApplyTime" annotation the shared
+// redfishwrapper.SetBiosConfiguration sends, the attributes are PATCHed
+// without an apply time.
+//
+// Implements bmc.BiosConfigurationSetter.
+func (c *Conn) SetBiosConfiguration(ctx context.Context, biosConfig map[string]string) (err error) {
+	sys, err := c.redfishwrapper.System()
+	if err != nil {
+		return err
+	}
+
+	bios, err := sys.Bios()
+	if err != nil {
+		return err
+	}
+
+	if bios == nil {
+		return bmclibErrs.ErrNoBiosAttributes
+	}
+
+	settingsAttributes := make(schemas.SettingsAttributes, len(biosConfig))
+	for attr, value := range biosConfig {
+		settingsAttributes[attr] = value
+	}
+
+	return bios.UpdateBiosAttributes(settingsAttributes)
+}
+
+// ResetBiosConfiguration restores BIOS settings to their default values via the
+// Bios.ResetBios action.
+//
+// Implements bmc.BiosConfigurationResetter.
+func (c *Conn) ResetBiosConfiguration(ctx context.Context) (err error) {
+	return c.redfishwrapper.ResetBiosConfiguration(ctx)
+}
+
+// GetBiosPendingConfiguration returns the BIOS attributes staged in the pending
+// settings resource that differ from the current attributes, these are applied
+// on the next host reset.
+//
+// This is an iLO specific provider method (not part of a bmc.Feature interface).
+func (c *Conn) GetBiosPendingConfiguration(ctx context.Context) (map[string]string, error) {
+	sys, err := c.redfishwrapper.System()
+	if err != nil {
+		return nil, err
+	}
+
+	var current struct {
+		Attributes map[string]any `json:"Attributes"`
+		Settings   struct {
+			SettingsObject redfishwrapper.ODataID `json:"SettingsObject"`
+		} `json:"@Redfish.Settings"`
+	}
+
+	if err := c.redfishwrapper.GetJSON(sys.ODataID+"/Bios", &current); err != nil {
+		return nil, err
+	}
+
+	if current.Settings.SettingsObject.ODataID == "" {
+		return nil, bmclibErrs.ErrNoBiosAttributes
+	}
+
+	var pending struct {
+		Attributes map[string]any `json:"Attributes"`
+	}
+
+	if err := c.redfishwrapper.GetJSON(current.Settings.SettingsObject.ODataID, &pending); err != nil {
+		return nil, err
+	}
+
+	changed := make(map[string]string)
+	for attr, value := range pending.Attributes {
+		pendingValue := fmt.Sprint(value)
+
+		if currentValue, ok := current.Attributes[attr]; ok && fmt.Sprint(currentValue) == pendingValue {
+			continue
+		}
+
+		changed[attr] = pendingValue
+	}
+
+	return changed, nil
+}
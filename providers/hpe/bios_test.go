@@ -0,0 +1,67 @@
+package hpe
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// Requirement: BIOS attributes are read from the current settings.
+func TestGetBiosConfiguration(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	config, err := c.GetBiosConfiguration(context.Background())
+	if err != nil {
+		t.Fatalf("GetBiosConfiguration: %v", err)
+	}
+	if config["ProcHyperthreading"] != "Enabled" {
+		t.Fatalf("ProcHyperthreading = %q, want %q", config["ProcHyperthreading"], "Enabled")
+	}
+}
+
+// Requirement: BIOS attributes are staged in the pending settings without an
+// apply time.
+func TestSetBiosConfiguration(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	if err := c.SetBiosConfiguration(context.Background(), map[string]string{"Sriov": "Disabled"}); err != nil {
+		t.Fatalf("SetBiosConfiguration: %v", err)
+	}
+
+	body := ts.biosPatch()
+	want := map[string]any{"Attributes": map[string]any{"Sriov": "Disabled"}}
+	if !reflect.DeepEqual(body, want) {
+		t.Fatalf("bios PATCH body = %v, want %v", body, want)
+	}
+}
+
+// Requirement: pending settings that differ from the current settings.
+func TestGetBiosPendingConfiguration(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	pending, err := c.GetBiosPendingConfiguration(context.Background())
+	if err != nil {
+		t.Fatalf("GetBiosPendingConfiguration: %v", err)
+	}
+
+	want := map[string]string{"ProcHyperthreading": "Disabled"}
+	if !reflect.DeepEqual(pending, want) {
+		t.Fatalf("pending = %v, want %v", pending, want)
+	}
+}
+
+// Requirement: BIOS reset through the Bios.ResetBios action.
+func TestResetBiosConfiguration(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	if err := c.ResetBiosConfiguration(context.Background()); err != nil {
+		t.Fatalf("ResetBiosConfiguration: %v", err)
+	}
+	if !ts.didResetBios() {
+		t.Fatal("expected the Bios.ResetBios action to be posted")
+	}
+}
@@ -0,0 +1,262 @@
+package hpe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/constants"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+)
+
+const (
+	// uploadFileURI is the iLO endpoint that accepts firmware images into the
+	// iLO repository.
+	uploadFileURI = "/cgi-bin/uploadFile"
+	// componentRepositoryURI lists the images stored in the iLO repository.
+	componentRepositoryURI = "/redfish/v1/UpdateService/ComponentRepository"
+	// updateTaskQueueURI is the iLO update task queue, a task applies a
+	// repository image.
+	updateTaskQueueURI = "/redfish/v1/UpdateService/UpdateTaskQueue"
+)
+
+// compile-time assertions that the provider implements the firmware interfaces.
+var (
+	_ bmc.FirmwareUploader           = (*Conn)(nil)
+	_ bmc.FirmwareInstallerUploaded  = (*Conn)(nil)
+	_ bmc.FirmwareTaskVerifier       = (*Conn)(nil)
+	_ bmc.FirmwareInstallStepsGetter = (*Conn)(nil)
+)
+
+// FirmwareInstallSteps returns the ordered steps the provider performs for a
+// firmware install: the image is uploaded to the iLO repository, then applied
+// through the iLO update task queue.
+//
+// Implements bmc.FirmwareInstallStepsGetter.
+func (c *Conn) FirmwareInstallSteps(ctx context.Context, component string) ([]constants.FirmwareInstallStep, error) {
+	return []constants.FirmwareInstallStep{
+		constants.FirmwareInstallStepUpload,
+		constants.FirmwareInstallStepUploadStatus,
+		constants.FirmwareInstallStepInstallUploaded,
+		constants.FirmwareInstallStepInstallStatus,
+	}, nil
+}
+
+// FirmwareUpload uploads a firmware image to the iLO repository, returning the
+// image filename as the upload task id.
+//
+// The iLO accepts the image on its /cgi-bin/uploadFile endpoint authenticated
+// by the Redfish session token, the upload requires a Redfish session and is
+// not available with [WithUseBasicAuth]. The image is streamed from file.
+//
+// Implements bmc.FirmwareUploader.
+func (c *Conn) FirmwareUpload(ctx context.Context, component string, file *os.File) (uploadVerifyTaskID string, err error) {
+	token, err := c.redfishwrapper.SessionToken()
+	if err != nil {
+		if errors.Is(err, redfishwrapper.ErrNoSession) {
+			return "", errors.Wrap(bmclibErrs.ErrFirmwareUpload, "the iLO repository upload requires a Redfish session, basic auth is in use")
+		}
+
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareUpload, err.Error())
+	}
+
+	finfo, err := file.Stat()
+	if err != nil {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareUpload, err.Error())
+	}
+
+	filename := filepath.Base(file.Name())
+
+	payload, contentType, size, err := uploadFilePayload(token, filename, file, finfo.Size())
+	if err != nil {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareUpload, err.Error())
+	}
+
+	resp, err := c.redfishwrapper.RunRawRequestWithContext(
+		ctx,
+		http.MethodPost,
+		uploadFileURI,
+		payload,
+		contentType,
+		map[string]string{
+			"Cookie":         "sessionKey=" + token,
+			"Content-Length": strconv.FormatInt(size, 10),
+		},
+	)
+	if err != nil {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareUpload, err.Error())
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareUpload, "unexpected status code returned: "+resp.Status)
+	}
+
+	return filename, nil
+}
+
+// FirmwareInstallUploaded queues the install of an image uploaded to the iLO
+// repository with FirmwareUpload, returning the update task queue item id.
+//
+// Implements bmc.FirmwareInstallerUploaded.
+func (c *Conn) FirmwareInstallUploaded(ctx context.Context, component, uploadTaskID string) (installTaskID string, err error) {
+	payload := map[string]any{
+		"Name":        "bmclib update " + uploadTaskID,
+		"Filename":    uploadTaskID,
+		"Command":     "ApplyUpdate",
+		"UpdatableBy": []string{"Bmc"},
+	}
+
+	resp, err := c.redfishwrapper.PostWithHeaders(ctx, updateTaskQueueURI, payload, nil)
+	if err != nil {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareInstall, err.Error())
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareInstall, "unexpected status code returned: "+resp.Status)
+	}
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareInstall, "update task queue item location not returned")
+	}
+
+	return path.Base(strings.TrimRight(location, "/")), nil
+}
+
+// FirmwareTaskStatus returns the state and status of a firmware upload or
+// install.
+//
+// For the upload-status step the taskID is the image filename, the upload is
+// complete once the image is listed in the iLO repository. For the
+// install-status step the taskID is the update task queue item id.
+//
+// Implements bmc.FirmwareTaskVerifier.
+func (c *Conn) FirmwareTaskStatus(ctx context.Context, kind constants.FirmwareInstallStep, component, taskID, installVersion string) (state constants.TaskState, status string, err error) {
+	switch kind {
+	case constants.FirmwareInstallStepUploadStatus:
+		return c.repositoryImageStatus(taskID)
+	case constants.FirmwareInstallStepInstallStatus:
+		return c.updateTaskStatus(taskID)
+	default:
+		return "", "", errors.Wrap(bmclibErrs.ErrFirmwareTaskStatus, "unsupported firmware install step: "+string(kind))
+	}
+}
+
+// repositoryImageStatus returns Complete when the image is in the iLO repository.
+func (c *Conn) repositoryImageStatus(filename string) (constants.TaskState, string, error) {
+	members, err := c.redfishwrapper.CollectionMembers(componentRepositoryURI)
+	if err != nil {
+		return "", "", errors.Wrap(bmclibErrs.ErrFirmwareTaskStatus, err.Error())
+	}
+
+	for _, member := range members {
+		var component struct {
+			Filename string `json:"Filename"`
+		}
+
+		if err := c.redfishwrapper.GetJSON(member, &component); err != nil {
+			return "", "", errors.Wrap(bmclibErrs.ErrFirmwareTaskStatus, err.Error())
+		}
+
+		if component.Filename == filename {
+			return constants.Complete, "image " + filename + " in repository", nil
+		}
+	}
+
+	return constants.Failed, "image " + filename + " not found in repository", nil
+}
+
+// updateTaskStatus returns the state of an iLO update task queue item.
+func (c *Conn) updateTaskStatus(taskID string) (constants.TaskState, string, error) {
+	var task struct {
+		State  string `json:"State"`
+		Result struct {
+			MessageID string `json:"MessageId"`
+		} `json:"Result"`
+	}
+
+	if err := c.redfishwrapper.GetJSON(updateTaskQueueURI+"/"+taskID, &task); err != nil {
+		return "", "", errors.Wrap(bmclibErrs.ErrFirmwareTaskStatus, err.Error())
+	}
+
+	status := task.State
+	if task.Result.MessageID != "" {
+		status += ": " + task.Result.MessageID
+	}
+
+	return updateTaskState(task.State), status, nil
+}
+
+// updateTaskState maps an iLO update task queue item state to a TaskState.
+func updateTaskState(state string) constants.TaskState {
+	switch strings.ToLower(state) {
+	case "pending":
+		return constants.Queued
+	case "inprogress":
+		return constants.Running
+	case "complete":
+		return constants.Complete
+	case "exception", "expired", "canceled":
+		return constants.Failed
+	default:
+		return constants.Unknown
+	}
+}
+
+// uploadFilePayload returns the multipart form the iLO upload endpoint expects
+// and its length, the file part must follow the sessionKey and parameters
+// parts. The image is read from file as the form is sent.
+func uploadFilePayload(token, filename string, file io.Reader, fileSize int64) (form io.Reader, contentType string, size int64, err error) {
+	parameters, err := json.Marshal(map[string]any{
+		"UpdateRepository": true,
+		"UpdateTarget":     false,
+		"ETag":             filename,
+		"Section":          0,
+	})
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	// head holds the parts up to the file contents, tail the terminating boundary
+	head, tail := &bytes.Buffer{}, &bytes.Buffer{}
+	writer := multipart.NewWriter(head)
+
+	if err := writer.WriteField("sessionKey", token); err != nil {
+		return nil, "", 0, err
+	}
+
+	if err := writer.WriteField("parameters", string(parameters)); err != nil {
+		return nil, "", 0, err
+	}
+
+	if _, err := writer.CreateFormFile("file", filename); err != nil {
+		return nil, "", 0, err
+	}
+
+	closing := multipart.NewWriter(tail)
+	if err := closing.SetBoundary(writer.Boundary()); err != nil {
+		return nil, "", 0, err
+	}
+
+	if err := closing.Close(); err != nil {
+		return nil, "", 0, err
+	}
+
+	size = int64(head.Len()) + fileSize + int64(tail.Len())
+
+	return io.MultiReader(head, file, tail), writer.FormDataContentType(), size, nil
+}
@@ -0,0 +1,167 @@
+package hpe
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bmc-toolbox/bmclib/v2/constants"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+// Requirement: firmware is uploaded to the iLO repository with the session key.
+func TestFirmwareUpload(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "ilo5_278.fwpkg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString("firmware"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.Seek(0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	taskID, err := c.FirmwareUpload(context.Background(), "bmc", file)
+	if err != nil {
+		t.Fatalf("FirmwareUpload: %v", err)
+	}
+	if taskID != "ilo5_278.fwpkg" {
+		t.Fatalf("task id = %q, want %q", taskID, "ilo5_278.fwpkg")
+	}
+
+	cookie, form, contents := ts.upload()
+	if cookie != "sessionKey=test-token" {
+		t.Fatalf("upload cookie = %q, want %q", cookie, "sessionKey=test-token")
+	}
+	if want := []string{"sessionKey", "parameters", "file"}; !reflect.DeepEqual(form, want) {
+		t.Fatalf("upload form parts = %v, want %v", form, want)
+	}
+	if contents != "firmware" {
+		t.Fatalf("uploaded file = %q, want %q", contents, "firmware")
+	}
+}
+
+// Requirement: the upload reports that it needs a session under basic auth.
+func TestFirmwareUploadBasicAuth(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t, WithUseBasicAuth(true))
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "ilo5_278.fwpkg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	_, err = c.FirmwareUpload(context.Background(), "bmc", file)
+	if err == nil || !strings.Contains(err.Error(), "requires a Redfish session") {
+		t.Fatalf("FirmwareUpload error = %v, want the session requirement", err)
+	}
+
+	if cookie, _, _ := ts.upload(); cookie != "" {
+		t.Fatal("image uploaded without a session")
+	}
+}
+
+// Requirement: the upload is aborted with its context.
+func TestFirmwareUploadCancelled(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "ilo5_278.fwpkg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.FirmwareUpload(ctx, "bmc", file); !errors.Is(err, bmclibErrs.ErrFirmwareUpload) || !strings.Contains(err.Error(), context.Canceled.Error()) {
+		t.Fatalf("FirmwareUpload error = %v, want the context cancellation", err)
+	}
+}
+
+// Requirement: an uploaded image is installed through the update task queue.
+func TestFirmwareInstallUploaded(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	taskID, err := c.FirmwareInstallUploaded(context.Background(), "bmc", "ilo5_278.fwpkg")
+	if err != nil {
+		t.Fatalf("FirmwareInstallUploaded: %v", err)
+	}
+	if taskID != "1" {
+		t.Fatalf("task id = %q, want %q", taskID, "1")
+	}
+
+	request := ts.taskQueueRequest()
+	if request["Filename"] != "ilo5_278.fwpkg" || request["Command"] != "ApplyUpdate" {
+		t.Fatalf("task queue request = %v", request)
+	}
+}
+
+// Requirement: upload and install status are reported per install step.
+func TestFirmwareTaskStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		kind      constants.FirmwareInstallStep
+		taskID    string
+		wantState constants.TaskState
+		wantErr   bool
+	}{
+		{"uploaded image in repository", constants.FirmwareInstallStepUploadStatus, "ilo5_278.fwpkg", constants.Complete, false},
+		{"image missing from repository", constants.FirmwareInstallStepUploadStatus, "missing.fwpkg", constants.Failed, false},
+		{"completed update task", constants.FirmwareInstallStepInstallStatus, "1", constants.Complete, false},
+		{"unknown update task", constants.FirmwareInstallStepInstallStatus, "2", "", true},
+		{"unsupported step", constants.FirmwareInstallStepUpload, "1", "", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := newTestServer(t, testServerOpts{})
+			c := ts.openedClient(t)
+
+			state, _, err := c.FirmwareTaskStatus(context.Background(), tc.kind, "bmc", tc.taskID, "")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FirmwareTaskStatus: %v", err)
+			}
+			if state != tc.wantState {
+				t.Fatalf("state = %q, want %q", state, tc.wantState)
+			}
+		})
+	}
+}
+
+func TestUpdateTaskState(t *testing.T) {
+	tests := map[string]constants.TaskState{
+		"Pending":    constants.Queued,
+		"InProgress": constants.Running,
+		"Complete":   constants.Complete,
+		"Exception":  constants.Failed,
+		"Expired":    constants.Failed,
+		"Canceled":   constants.Failed,
+		"Unknown":    constants.Unknown,
+	}
+
+	for state, want := range tests {
+		if got := updateTaskState(state); got != want {
+			t.Errorf("updateTaskState(%q) = %q, want %q", state, got, want)
+		}
+	}
+}
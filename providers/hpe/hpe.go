@@ -0,0 +1,234 @@
+// Package hpe implements a bmclib provider for HPE ProLiant servers managed by
+// the HPE Integrated Lights-Out (iLO) 5 and 6 BMCs.
+//
+// iLO implements the DMTF Redfish standard with HPE OEM extensions under the
+// "Oem.Hpe" properties. This provider is built on top of the shared
+// gofish-backed [redfishwrapper.Client] and layers the iLO specific behavior on
+// top in dedicated files:
+//
+//   - logs.go reads and clears the Integrated Management Log (IML) of the
+//     system and the iLO Event Log (IEL) of the manager.
+//
+//   - inventory.go adds the OEM SmartStorage array controllers and drives and
+//     the OEM BaseNetworkAdapters, iLO 5 does not report these through the
+//     standard Storage and NetworkAdapters resources.
+//
+//   - firmware.go uploads firmware to the iLO repository and installs it
+//     through the iLO update task queue.
+//
+//   - bios.go stages BIOS settings in the pending settings resource, iLO
+//     applies them on the next host reset.
+package hpe
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+
+	"github.com/bmc-toolbox/common"
+	"github.com/go-logr/logr"
+	"github.com/jacobweinstock/registrar"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/internal/httpclient"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+	"github.com/bmc-toolbox/bmclib/v2/providers"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+const (
+	// ProviderName is the registered name of this provider.
+	ProviderName = "hpe"
+	// ProviderProtocol is the transport/protocol this provider speaks.
+	ProviderProtocol = "redfish"
+)
+
+// Features is the set of bmclib features this provider implements.
+var Features = registrar.Features{
+	// power and boot
+	providers.FeaturePowerState,
+	providers.FeaturePowerSet,
+	providers.FeatureBootDeviceSet,
+	// virtual media
+	providers.FeatureVirtualMedia,
+	// IML and IEL logs
+	providers.FeatureGetSystemEventLog,
+	providers.FeatureGetSystemEventLogRaw,
+	providers.FeatureGetSystemEventLogEntries,
+	providers.FeatureClearSystemEventLog,
+	// inventory
+	providers.FeatureInventoryRead,
+	// iLO repository firmware
+	providers.FeatureFirmwareUpload,
+	providers.FeatureFirmwareInstallUploaded,
+	providers.FeatureFirmwareTaskStatus,
+	providers.FeatureFirmwareInstallSteps,
+	// BIOS
+	providers.FeatureGetBiosConfiguration,
+	providers.FeatureSetBiosConfiguration,
+	providers.FeatureResetBiosConfiguration,
+}
+
+// Conn is a connection to an HPE iLO BMC.
+type Conn struct {
+	redfishwrapper *redfishwrapper.Client
+	// failInventoryOnError has Inventory fail on the first error reading the
+	// OEM storage and network adapter resources.
+	failInventoryOnError bool
+	Log                  logr.Logger
+}
+
+// Config is the configuration of an iLO [Conn], it is built by [New] from the
+// given options.
+type Config struct {
+	// HTTPClient sends the iLO requests, a client with the bmclib defaults is
+	// built when nil.
+	HTTPClient *http.Client
+	// Port is the TCP port the iLO Redfish service listens on. Defaults to "443".
+	Port string
+	// VersionsNotCompatible are the Redfish versions of the iLO firmware the
+	// provider is not to be used with.
+	VersionsNotCompatible []string
+	// RootCAs verifies the iLO certificate against the pool, iLO ships with a
+	// self-signed certificate which is not verified when nil.
+	RootCAs *x509.CertPool
+	// UseBasicAuth selects HTTP Basic authentication instead of Redfish session
+	// login. The iLO repository upload requires a session, firmware uploads
+	// fail with Basic auth.
+	UseBasicAuth bool
+	// FailInventoryOnError has Inventory fail on the first error reading a
+	// component, including the OEM SmartStorage and BaseNetworkAdapters
+	// resources, by default these are left out of the inventory.
+	FailInventoryOnError bool
+}
+
+// Option sets a setting of the iLO [Config].
+type Option func(*Config)
+
+// WithHTTPClient sets the HTTP client for the iLO requests.
+func WithHTTPClient(c *http.Client) Option {
+	return func(cfg *Config) { cfg.HTTPClient = c }
+}
+
+// WithPort sets the iLO Redfish service port (default "443").
+func WithPort(port string) Option {
+	return func(cfg *Config) { cfg.Port = port }
+}
+
+// WithVersionsNotCompatible excludes the iLO firmware reporting one of the
+// Redfish versions.
+func WithVersionsNotCompatible(versions []string) Option {
+	return func(cfg *Config) { cfg.VersionsNotCompatible = versions }
+}
+
+// WithRootCAs has the iLO certificate verified against the pool, for an iLO
+// with a certificate signed by a known CA.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(cfg *Config) { cfg.RootCAs = pool }
+}
+
+// WithUseBasicAuth authenticates the requests with HTTP Basic auth instead of
+// an iLO session, firmware uploads to the iLO repository are then unavailable.
+func WithUseBasicAuth(use bool) Option {
+	return func(cfg *Config) { cfg.UseBasicAuth = use }
+}
+
+// WithFailInventoryOnError has Inventory fail on the first error reading the
+// OEM SmartStorage and BaseNetworkAdapters resources.
+func WithFailInventoryOnError(fail bool) Option {
+	return func(cfg *Config) { cfg.FailInventoryOnError = fail }
+}
+
+// New returns a [Conn] for the given iLO host. The connection is not opened
+// until [Conn.Open] is called.
+func New(host, user, pass string, log logr.Logger, opts ...Option) *Conn {
+	cfg := &Config{
+		HTTPClient:            httpclient.Build(),
+		Port:                  "443",
+		VersionsNotCompatible: []string{},
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	rfOpts := []redfishwrapper.Option{
+		redfishwrapper.WithHTTPClient(cfg.HTTPClient),
+		redfishwrapper.WithVersionsNotCompatible(cfg.VersionsNotCompatible),
+		redfishwrapper.WithBasicAuthEnabled(cfg.UseBasicAuth),
+	}
+
+	if cfg.RootCAs != nil {
+		rfOpts = append(rfOpts, redfishwrapper.WithSecureTLS(cfg.RootCAs))
+	}
+
+	return &Conn{
+		Log:                  log,
+		failInventoryOnError: cfg.FailInventoryOnError,
+		redfishwrapper:       redfishwrapper.NewClient(host, cfg.Port, user, pass, rfOpts...),
+	}
+}
+
+// Name returns the provider name ("hpe").
+func (c *Conn) Name() string {
+	return ProviderName
+}
+
+// Open opens a Redfish session, or sets up Basic auth when [WithUseBasicAuth]
+// was given.
+func (c *Conn) Open(ctx context.Context) error {
+	return c.redfishwrapper.Open(ctx)
+}
+
+// Close releases the Redfish session.
+func (c *Conn) Close(ctx context.Context) error {
+	return c.redfishwrapper.Close(ctx)
+}
+
+// KeepSessionAlive refreshes the iLO session, an expired session is re-established.
+//
+// Implements bmc.SessionKeeper.
+func (c *Conn) KeepSessionAlive(ctx context.Context) error {
+	return c.redfishwrapper.KeepSessionAlive(ctx)
+}
+
+// ProbeCapabilities probes the iLO for the features it supports, virtual media
+// requires an iLO Advanced license.
+//
+// Implements bmc.CapabilityProber.
+func (c *Conn) ProbeCapabilities(ctx context.Context) (*bmc.ProbedCapabilities, error) {
+	return c.redfishwrapper.ProbeCapabilities(ctx)
+}
+
+// Compatible reports whether the BMC is an iLO managing an HPE system.
+//
+// An iLO session is opened for the check, the BMC is compatible when its
+// Redfish version is not excluded with [WithVersionsNotCompatible] and the
+// system manufacturer is HPE.
+func (c *Conn) Compatible(ctx context.Context) bool {
+	if err := c.Open(ctx); err != nil {
+		c.Log.V(2).WithValues("provider", c.Name()).
+			Info(bmclibErrs.ErrCompatibilityCheck.Error(), "error", err.Error())
+
+		return false
+	}
+	defer func() { _ = c.Close(ctx) }()
+
+	if !c.redfishwrapper.VersionCompatible() {
+		c.Log.V(2).WithValues("provider", c.Name()).
+			Info(bmclibErrs.ErrCompatibilityCheck.Error(), "reason", "incompatible redfish version")
+
+		return false
+	}
+
+	vendor, _, err := c.redfishwrapper.DeviceVendorModel(ctx)
+	if err != nil {
+		c.Log.V(2).WithValues("provider", c.Name()).
+			Info(bmclibErrs.ErrCompatibilityCheck.Error(), "error", err.Error())
+
+		return false
+	}
+
+	return common.FormatVendorName(vendor) == common.VendorHPE
+}
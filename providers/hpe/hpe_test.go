@@ -0,0 +1,106 @@
+package hpe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// Requirement: Provider identity and registration.
+func TestName(t *testing.T) {
+	if ProviderName != "hpe" {
+		t.Fatalf("ProviderName = %q, want %q", ProviderName, "hpe")
+	}
+	c := New("127.0.0.1", "u", "p", logr.Discard())
+	if got := c.Name(); got != ProviderName {
+		t.Fatalf("Name() = %q, want %q", got, ProviderName)
+	}
+}
+
+// Requirement: Connection lifecycle — Open creates a session, Close deletes it.
+func TestOpenClose(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	defer ts.Close()
+
+	c := ts.client(t)
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if !ts.didCreateSession() {
+		t.Fatal("expected a session to be created on Open")
+	}
+
+	if err := c.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !ts.didDeleteSession() {
+		t.Fatal("expected the session to be deleted on Close")
+	}
+}
+
+// Requirement: Compatible identifies HPE systems and honors excluded versions.
+func TestCompatible(t *testing.T) {
+	t.Run("HPE system is compatible", func(t *testing.T) {
+		ts := newTestServer(t, testServerOpts{})
+		defer ts.Close()
+
+		if !ts.client(t).Compatible(context.Background()) {
+			t.Fatal("expected the iLO to be compatible")
+		}
+	})
+
+	t.Run("excluded redfish version is not compatible", func(t *testing.T) {
+		ts := newTestServer(t, testServerOpts{})
+		defer ts.Close()
+
+		c := ts.client(t, WithVersionsNotCompatible([]string{"1.6.0"}))
+		if c.Compatible(context.Background()) {
+			t.Fatal("expected the excluded redfish version to be incompatible")
+		}
+	})
+}
+
+// Requirement: Power control and boot override.
+func TestPowerAndBoot(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	state, err := c.PowerStateGet(context.Background())
+	if err != nil {
+		t.Fatalf("PowerStateGet: %v", err)
+	}
+	if state != "On" {
+		t.Fatalf("PowerStateGet = %q, want %q", state, "On")
+	}
+
+	ok, err := c.PowerSet(context.Background(), "off")
+	if err != nil || !ok {
+		t.Fatalf("PowerSet(off) = (%v, %v), want (true, nil)", ok, err)
+	}
+	if rt := ts.resetType(); rt != "ForceOff" {
+		t.Fatalf("reset type = %q, want %q", rt, "ForceOff")
+	}
+
+	ok, err = c.BootDeviceSet(context.Background(), "pxe", false, true)
+	if err != nil || !ok {
+		t.Fatalf("BootDeviceSet(pxe) = (%v, %v), want (true, nil)", ok, err)
+	}
+	if !ts.didPatchSystem() {
+		t.Fatal("expected the system to be PATCHed with the boot override")
+	}
+}
+
+// Requirement: Virtual media insert through the InsertMedia action.
+func TestSetVirtualMedia(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	ok, err := c.SetVirtualMedia(context.Background(), "CD", "http://10.0.0.10/boot.iso")
+	if err != nil || !ok {
+		t.Fatalf("SetVirtualMedia = (%v, %v), want (true, nil)", ok, err)
+	}
+	if !ts.didInsertMedia() {
+		t.Fatal("expected the InsertMedia action to be posted")
+	}
+}
@@ -0,0 +1,273 @@
+package hpe
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/bmc-toolbox/common"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+)
+
+var _ bmc.InventoryGetter = (*Conn)(nil)
+
+// oemStatus is the Redfish status shape of the iLO OEM resources.
+type oemStatus struct {
+	Health string `json:"Health"`
+	State  string `json:"State"`
+}
+
+// oemFirmware is the iLO OEM firmware version shape: {"Current": {"VersionString": "1.98"}}.
+type oemFirmware struct {
+	Current struct {
+		VersionString string `json:"VersionString"`
+	} `json:"Current"`
+}
+
+// arrayController is a SmartStorage ArrayController resource.
+type arrayController struct {
+	ID              string      `json:"Id"`
+	Model           string      `json:"Model"`
+	SerialNumber    string      `json:"SerialNumber"`
+	Location        string      `json:"Location"`
+	FirmwareVersion oemFirmware `json:"FirmwareVersion"`
+	Status          oemStatus   `json:"Status"`
+	Links           struct {
+		PhysicalDrives redfishwrapper.ODataID `json:"PhysicalDrives"`
+	} `json:"Links"`
+}
+
+// diskDrive is a SmartStorage DiskDrive resource.
+type diskDrive struct {
+	ID              string      `json:"Id"`
+	Model           string      `json:"Model"`
+	SerialNumber    string      `json:"SerialNumber"`
+	CapacityMiB     int64       `json:"CapacityMiB"`
+	MediaType       string      `json:"MediaType"`
+	InterfaceType   string      `json:"InterfaceType"`
+	Location        string      `json:"Location"`
+	FirmwareVersion oemFirmware `json:"FirmwareVersion"`
+	Status          oemStatus   `json:"Status"`
+}
+
+// baseNetworkAdapter is a BaseNetworkAdapter resource.
+type baseNetworkAdapter struct {
+	ID            string      `json:"Id"`
+	Name          string      `json:"Name"`
+	PartNumber    string      `json:"PartNumber"`
+	SerialNumber  string      `json:"SerialNumber"`
+	Firmware      oemFirmware `json:"Firmware"`
+	Status        oemStatus   `json:"Status"`
+	PhysicalPorts []struct {
+		MacAddress string `json:"MacAddress"`
+		LinkStatus string `json:"LinkStatus"`
+		SpeedMbps  int64  `json:"SpeedMbps"`
+	} `json:"PhysicalPorts"`
+}
+
+// Inventory collects the hardware and firmware inventory of the iLO managed
+// system into a *common.Device.
+//
+// The standard Redfish inventory is extended with the OEM SmartStorage array
+// controllers and drives and the OEM BaseNetworkAdapters, iLO 5 only reports
+// the devices in the standard Storage and NetworkAdapters resources once the
+// server has been booted with an agentless management service. Components
+// already reported through the standard resources are not added twice.
+//
+// When the connection's failInventoryOnError is false (the default, set via
+// [WithFailInventoryOnError]), a failure reading one sub-resource does not abort
+// the whole inventory — the provider returns what it could collect. When true,
+// the first sub-resource error is returned.
+//
+// Implements bmc.InventoryGetter.
+func (c *Conn) Inventory(ctx context.Context) (device *common.Device, err error) {
+	device, err = c.redfishwrapper.Inventory(ctx, c.failInventoryOnError)
+	if err != nil {
+		return nil, err
+	}
+
+	sys, err := c.redfishwrapper.System()
+	if err != nil {
+		if c.failInventoryOnError {
+			return nil, err
+		}
+
+		return device, nil
+	}
+
+	collectors := []func(systemURL string, device *common.Device) error{
+		c.collectSmartStorage,
+		c.collectBaseNetworkAdapters,
+	}
+
+	for _, collect := range collectors {
+		if err := collect(sys.ODataID, device); err != nil && c.failInventoryOnError {
+			return nil, err
+		}
+	}
+
+	return device, nil
+}
+
+// collectSmartStorage adds the SmartStorage array controllers and their drives
+// to the device, systems without SmartStorage are skipped.
+func (c *Conn) collectSmartStorage(systemURL string, device *common.Device) error {
+	members, err := c.redfishwrapper.CollectionMembers(systemURL + "/SmartStorage/ArrayControllers")
+	if err != nil {
+		if redfishwrapper.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, member := range members {
+		var controller arrayController
+		if err := c.redfishwrapper.GetJSON(member, &controller); err != nil {
+			return err
+		}
+
+		if !hasStorageController(device, controller.SerialNumber) {
+			device.StorageControllers = append(device.StorageControllers, &common.StorageController{
+				Common: common.Common{
+					Description: controller.Location,
+					Vendor:      common.VendorHPE,
+					Model:       controller.Model,
+					Serial:      controller.SerialNumber,
+					Firmware:    &common.Firmware{Installed: controller.FirmwareVersion.Current.VersionString},
+					Status:      &common.Status{Health: controller.Status.Health, State: controller.Status.State},
+				},
+				ID: controller.ID,
+			})
+		}
+
+		if controller.Links.PhysicalDrives.ODataID == "" {
+			continue
+		}
+
+		drives, err := c.redfishwrapper.CollectionMembers(controller.Links.PhysicalDrives.ODataID)
+		if err != nil {
+			return err
+		}
+
+		for _, driveURL := range drives {
+			var drive diskDrive
+			if err := c.redfishwrapper.GetJSON(driveURL, &drive); err != nil {
+				return err
+			}
+
+			if hasDrive(device, drive.SerialNumber) {
+				continue
+			}
+
+			device.Drives = append(device.Drives, &common.Drive{
+				Common: common.Common{
+					Description: drive.Location,
+					ProductName: drive.Model,
+					Model:       drive.Model,
+					Serial:      drive.SerialNumber,
+					Firmware:    &common.Firmware{Installed: drive.FirmwareVersion.Current.VersionString},
+					Status:      &common.Status{Health: drive.Status.Health, State: drive.Status.State},
+				},
+				ID:                drive.ID,
+				Type:              drive.MediaType,
+				Protocol:          drive.InterfaceType,
+				StorageController: controller.ID,
+				CapacityBytes:     drive.CapacityMiB * 1024 * 1024,
+			})
+		}
+	}
+
+	return nil
+}
+
+// collectBaseNetworkAdapters adds the BaseNetworkAdapters to the device,
+// systems without BaseNetworkAdapters are skipped.
+func (c *Conn) collectBaseNetworkAdapters(systemURL string, device *common.Device) error {
+	members, err := c.redfishwrapper.CollectionMembers(systemURL + "/BaseNetworkAdapters")
+	if err != nil {
+		if redfishwrapper.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, member := range members {
+		var adapter baseNetworkAdapter
+		if err := c.redfishwrapper.GetJSON(member, &adapter); err != nil {
+			return err
+		}
+
+		nic := &common.NIC{
+			Common: common.Common{
+				Description: adapter.Name,
+				ProductName: adapter.PartNumber,
+				Model:       adapter.Name,
+				Serial:      adapter.SerialNumber,
+				Firmware:    &common.Firmware{Installed: adapter.Firmware.Current.VersionString},
+				Status:      &common.Status{Health: adapter.Status.Health, State: adapter.Status.State},
+			},
+			ID: adapter.ID,
+		}
+
+		for i, port := range adapter.PhysicalPorts {
+			nic.NICPorts = append(nic.NICPorts, &common.NICPort{
+				ID:         strconv.Itoa(i + 1),
+				MacAddress: port.MacAddress,
+				LinkStatus: port.LinkStatus,
+				SpeedBits:  port.SpeedMbps * 1000 * 1000,
+			})
+		}
+
+		if hasNIC(device, nic) {
+			continue
+		}
+
+		device.NICs = append(device.NICs, nic)
+	}
+
+	return nil
+}
+
+func hasStorageController(device *common.Device, serial string) bool {
+	for _, controller := range device.StorageControllers {
+		if serial != "" && strings.EqualFold(controller.Serial, serial) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasDrive(device *common.Device, serial string) bool {
+	for _, drive := range device.Drives {
+		if serial != "" && strings.EqualFold(drive.Serial, serial) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasNIC returns true when the device lists a NIC with the same serial or a
+// port with one of the MAC addresses of nic.
+func hasNIC(device *common.Device, nic *common.NIC) bool {
+	for _, existing := range device.NICs {
+		if nic.Serial != "" && strings.EqualFold(existing.Serial, nic.Serial) {
+			return true
+		}
+
+		for _, existingPort := range existing.NICPorts {
+			for _, port := range nic.NICPorts {
+				if port.MacAddress != "" && strings.EqualFold(existingPort.MacAddress, port.MacAddress) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
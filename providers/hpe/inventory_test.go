@@ -0,0 +1,62 @@
+package hpe
+
+import (
+	"context"
+	"testing"
+)
+
+// Requirement: inventory includes the OEM SmartStorage and BaseNetworkAdapters.
+func TestInventory(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	device, err := c.Inventory(context.Background())
+	if err != nil {
+		t.Fatalf("Inventory: %v", err)
+	}
+
+	if len(device.StorageControllers) != 1 {
+		t.Fatalf("got %d storage controllers, want 1", len(device.StorageControllers))
+	}
+	controller := device.StorageControllers[0]
+	if controller.Model != "HPE Smart Array P408i-a SR Gen10" || controller.Firmware.Installed != "3.53" {
+		t.Fatalf("storage controller = %q firmware %q", controller.Model, controller.Firmware.Installed)
+	}
+
+	if len(device.Drives) != 1 {
+		t.Fatalf("got %d drives, want 1", len(device.Drives))
+	}
+	drive := device.Drives[0]
+	if drive.Serial != "WCJ1ABCD" || drive.CapacityBytes != 953869*1024*1024 || drive.Protocol != "SATA" {
+		t.Fatalf("drive = %q capacity %d protocol %q", drive.Serial, drive.CapacityBytes, drive.Protocol)
+	}
+	if drive.StorageController != "0" {
+		t.Fatalf("drive storage controller = %q, want %q", drive.StorageController, "0")
+	}
+
+	if len(device.NICs) != 1 {
+		t.Fatalf("got %d NICs, want 1", len(device.NICs))
+	}
+	nic := device.NICs[0]
+	if len(nic.NICPorts) != 2 || nic.NICPorts[0].MacAddress != "94:40:c9:12:34:50" || nic.NICPorts[0].SpeedBits != 1000*1000*1000 {
+		t.Fatalf("NIC ports = %+v", nic.NICPorts)
+	}
+	if nic.Firmware.Installed != "20.14.41" {
+		t.Fatalf("NIC firmware = %q, want %q", nic.Firmware.Installed, "20.14.41")
+	}
+}
+
+// Requirement: systems without the OEM resources still return an inventory.
+func TestInventoryWithoutOEMResources(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{oemInventoryNotFound: true})
+	c := ts.openedClient(t, WithFailInventoryOnError(true))
+
+	device, err := c.Inventory(context.Background())
+	if err != nil {
+		t.Fatalf("Inventory: %v", err)
+	}
+	if len(device.StorageControllers) != 0 || len(device.Drives) != 0 || len(device.NICs) != 0 {
+		t.Fatalf("unexpected OEM components: %d controllers, %d drives, %d NICs",
+			len(device.StorageControllers), len(device.Drives), len(device.NICs))
+	}
+}
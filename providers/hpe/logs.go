@@ -0,0 +1,163 @@
+package hpe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/stmcginnis/gofish/schemas"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+)
+
+// iLO log-service ids.
+const (
+	// LogServiceIML is the Integrated Management Log of the system, it records
+	// the hardware events of the server.
+	LogServiceIML = "IML"
+	// LogServiceIEL is the iLO Event Log of the manager, it records the iLO
+	// events like logins, resets and configuration changes.
+	LogServiceIEL = "IEL"
+)
+
+var errNoEventLogs = errors.New("no IML or IEL log service found")
+
+// compile-time assertions that the provider implements the interfaces.
+var (
+	_ bmc.SystemEventLog              = (*Conn)(nil)
+	_ bmc.SystemEventLogEntriesGetter = (*Conn)(nil)
+)
+
+// GetSystemEventLog returns the entries of the IML followed by the entries of
+// the IEL as rows of [id, created, log service id, message].
+//
+// Implements bmc.SystemEventLog.
+func (c *Conn) GetSystemEventLog(ctx context.Context) (entries [][]string, err error) {
+	err = c.eachEventLogEntry(ctx, func(logServiceID string, entry *schemas.LogEntry) {
+		entries = append(entries, []string{entry.ID, entry.Created, logServiceID, entry.Message})
+	})
+
+	return entries, err
+}
+
+// GetSystemEventLogEntries returns the entries of the IML followed by the
+// entries of the IEL as typed entries. The record ids of the two logs overlap,
+// the record id is prefixed with the log service id, e.g. "IML:12".
+//
+// Implements bmc.SystemEventLogEntriesGetter.
+func (c *Conn) GetSystemEventLogEntries(ctx context.Context) (entries []bmc.SystemEventLogEntry, err error) {
+	err = c.eachEventLogEntry(ctx, func(logServiceID string, entry *schemas.LogEntry) {
+		e := redfishwrapper.SystemEventLogEntryFromLogEntry(entry)
+		e.RecordID = logServiceID + ":" + e.RecordID
+
+		entries = append(entries, e)
+	})
+
+	return entries, err
+}
+
+// GetSystemEventLogRaw returns the raw JSON of the IML and IEL entries.
+//
+// Implements bmc.SystemEventLog.
+func (c *Conn) GetSystemEventLogRaw(ctx context.Context) (eventlog string, err error) {
+	var all []*schemas.LogEntry
+
+	err = c.eachEventLogEntry(ctx, func(_ string, entry *schemas.LogEntry) {
+		all = append(all, entry)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	raw, err := json.Marshal(all)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+// ClearSystemEventLog clears the IML and the IEL through the
+// LogService.ClearLog action.
+//
+// Implements bmc.SystemEventLog.
+func (c *Conn) ClearSystemEventLog(ctx context.Context) (err error) {
+	logServices, err := c.eventLogServices(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, ls := range logServices {
+		if _, err := ls.ClearLog(""); err != nil {
+			return fmt.Errorf("clearing log service %q: %w", ls.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// eachEventLogEntry calls fn with the entries of the IML and IEL log services.
+func (c *Conn) eachEventLogEntry(ctx context.Context, fn func(logServiceID string, entry *schemas.LogEntry)) error {
+	logServices, err := c.eventLogServices(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, ls := range logServices {
+		lentries, err := ls.Entries()
+		if err != nil {
+			return fmt.Errorf("reading entries of log service %q: %w", ls.ID, err)
+		}
+
+		for _, entry := range lentries {
+			fn(ls.ID, entry)
+		}
+	}
+
+	return nil
+}
+
+// eventLogServices returns the IML log service of the system and the IEL log
+// service of the manager.
+func (c *Conn) eventLogServices(ctx context.Context) ([]*schemas.LogService, error) {
+	sys, err := c.redfishwrapper.System()
+	if err != nil {
+		return nil, err
+	}
+
+	systemLogServices, err := sys.LogServices()
+	if err != nil {
+		return nil, err
+	}
+
+	manager, err := c.redfishwrapper.Manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	managerLogServices, err := manager.LogServices()
+	if err != nil {
+		return nil, err
+	}
+
+	var found []*schemas.LogService
+	for _, ls := range systemLogServices {
+		if ls.ID == LogServiceIML {
+			found = append(found, ls)
+		}
+	}
+
+	for _, ls := range managerLogServices {
+		if ls.ID == LogServiceIEL {
+			found = append(found, ls)
+		}
+	}
+
+	if len(found) == 0 {
+		return nil, errNoEventLogs
+	}
+
+	return found, nil
+}
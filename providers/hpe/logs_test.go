@@ -0,0 +1,81 @@
+package hpe
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// Requirement: IML and IEL entries through the SystemEventLog interface.
+func TestGetSystemEventLog(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	entries, err := c.GetSystemEventLog(context.Background())
+	if err != nil {
+		t.Fatalf("GetSystemEventLog: %v", err)
+	}
+
+	want := [][]string{
+		{"1", "2024-05-01T12:00:00Z", LogServiceIML, "Uncorrectable Memory Error detected (Processor 1, DIMM 4)."},
+		{"1", "2024-05-01T12:05:00Z", LogServiceIEL, "Browser login: admin - 10.0.0.10(DNS name not found)."},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Fatalf("GetSystemEventLog = %v, want %v", entries, want)
+	}
+}
+
+// Requirement: typed entries carry the log service in the record id.
+func TestGetSystemEventLogEntries(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	entries, err := c.GetSystemEventLogEntries(context.Background())
+	if err != nil {
+		t.Fatalf("GetSystemEventLogEntries: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if entries[0].RecordID != "IML:1" || entries[1].RecordID != "IEL:1" {
+		t.Fatalf("record ids = %q, %q, want %q, %q", entries[0].RecordID, entries[1].RecordID, "IML:1", "IEL:1")
+	}
+	if entries[0].Severity != "critical" {
+		t.Fatalf("severity = %q, want %q", entries[0].Severity, "critical")
+	}
+}
+
+// Requirement: raw log output is the JSON of all entries.
+func TestGetSystemEventLogRaw(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	raw, err := c.GetSystemEventLogRaw(context.Background())
+	if err != nil {
+		t.Fatalf("GetSystemEventLogRaw: %v", err)
+	}
+
+	var entries []map[string]any
+	if err := json.Unmarshal([]byte(raw), &entries); err != nil {
+		t.Fatalf("raw log is not a JSON array: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d raw entries, want 2", len(entries))
+	}
+}
+
+// Requirement: clearing the log clears both the IML and the IEL.
+func TestClearSystemEventLog(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	if err := c.ClearSystemEventLog(context.Background()); err != nil {
+		t.Fatalf("ClearSystemEventLog: %v", err)
+	}
+
+	want := []string{LogServiceIML, LogServiceIEL}
+	if got := ts.clearedLogs(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("cleared logs = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,376 @@
+package hpe
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+const fixturesDir = "./fixtures/v1"
+
+// testServer is an httptest-backed iLO Redfish mock. It serves recorded JSON
+// fixtures and emulates Redfish session create/delete, the iLO repository
+// upload endpoint and the update task queue so the provider can be exercised
+// entirely offline.
+type testServer struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	sessionCreated bool
+	sessionDeleted bool
+	// lastResetType records the ResetType posted to ComputerSystem.Reset.
+	lastResetType string
+	// systemPatched records whether the ComputerSystem was PATCHed (boot set).
+	systemPatched bool
+	// biosReset records whether the Bios.ResetBios action was posted.
+	biosReset bool
+	// biosPatchBody records the decoded body of the last Bios settings PATCH.
+	biosPatchBody map[string]any
+	// logsCleared records the log services the ClearLog action was posted to.
+	logsCleared []string
+	// mediaInserted records whether the VirtualMedia.InsertMedia action ran.
+	mediaInserted bool
+	// uploadCookie records the Cookie header of the repository upload.
+	uploadCookie string
+	// uploadForm records the multipart form parts of the repository upload, in
+	// the order they were sent, and the file part contents.
+	uploadForm []string
+	uploadFile string
+	// taskQueuePost records the decoded body of the update task queue POST.
+	taskQueuePost map[string]any
+}
+
+// testServerOpts configures a testServer.
+type testServerOpts struct {
+	// oemInventoryNotFound drops the SmartStorage and BaseNetworkAdapters routes
+	// so the mock returns 404, emulating systems without these OEM resources.
+	oemInventoryNotFound bool
+}
+
+// newTestServer builds and starts a TLS mock iLO server.
+func newTestServer(t *testing.T, opts testServerOpts) *testServer {
+	t.Helper()
+
+	ts := &testServer{}
+
+	// path -> fixture file for plain GETs.
+	routes := map[string]string{
+		"/redfish/v1/":                                                       "serviceroot.json",
+		"/redfish/v1/Systems":                                                "systems.json",
+		"/redfish/v1/Systems/1":                                              "system.1.json",
+		"/redfish/v1/Systems/1/Bios":                                         "bios.json",
+		"/redfish/v1/Systems/1/Bios/Settings":                                "bios.settings.json",
+		"/redfish/v1/Systems/1/LogServices":                                  "system.logservices.json",
+		"/redfish/v1/Systems/1/LogServices/IML":                              "ls.iml.json",
+		"/redfish/v1/Systems/1/LogServices/IML/Entries":                      "ls.iml.entries.json",
+		"/redfish/v1/Systems/1/LogServices/IML/Entries/1":                    "ls.iml.entry.1.json",
+		"/redfish/v1/Systems/1/SmartStorage/ArrayControllers":                "smartstorage.arraycontrollers.json",
+		"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0":              "smartstorage.arraycontroller.0.json",
+		"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/DiskDrives":   "smartstorage.diskdrives.json",
+		"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/DiskDrives/0": "smartstorage.diskdrive.0.json",
+		"/redfish/v1/Systems/1/BaseNetworkAdapters":                          "basenetworkadapters.json",
+		"/redfish/v1/Systems/1/BaseNetworkAdapters/1":                        "basenetworkadapter.1.json",
+		"/redfish/v1/Chassis":                                                "chassis.json",
+		"/redfish/v1/Chassis/1":                                              "chassis.1.json",
+		"/redfish/v1/Managers":                                               "managers.json",
+		"/redfish/v1/Managers/1":                                             "manager.1.json",
+		"/redfish/v1/Managers/1/VirtualMedia":                                "manager.virtualmedia.json",
+		"/redfish/v1/Managers/1/VirtualMedia/2":                              "vm.2.json",
+		"/redfish/v1/Managers/1/LogServices":                                 "manager.logservices.json",
+		"/redfish/v1/Managers/1/LogServices/IEL":                             "ls.iel.json",
+		"/redfish/v1/Managers/1/LogServices/IEL/Entries":                     "ls.iel.entries.json",
+		"/redfish/v1/Managers/1/LogServices/IEL/Entries/1":                   "ls.iel.entry.1.json",
+		"/redfish/v1/UpdateService":                                          "updateservice.json",
+		"/redfish/v1/UpdateService/FirmwareInventory":                        "firmwareinventory.json",
+		"/redfish/v1/UpdateService/FirmwareInventory/1":                      "firmwareinventory.1.json",
+		"/redfish/v1/UpdateService/ComponentRepository":                      "componentrepository.json",
+		"/redfish/v1/UpdateService/ComponentRepository/1":                    "componentrepository.1.json",
+		"/redfish/v1/UpdateService/UpdateTaskQueue/1":                        "updatetaskqueue.1.json",
+	}
+
+	if opts.oemInventoryNotFound {
+		for path := range routes {
+			if strings.HasPrefix(path, "/redfish/v1/Systems/1/SmartStorage") ||
+				strings.HasPrefix(path, "/redfish/v1/Systems/1/BaseNetworkAdapters") {
+				delete(routes, path)
+			}
+		}
+	}
+
+	mux := http.NewServeMux()
+
+	// ComputerSystem.Reset action — records the requested ResetType.
+	mux.HandleFunc("/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			ResetType string `json:"ResetType"`
+		}
+		if body, err := io.ReadAll(r.Body); err == nil {
+			_ = json.Unmarshal(body, &payload)
+		}
+		ts.mu.Lock()
+		ts.lastResetType = payload.ResetType
+		ts.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// Bios.ResetBios action.
+	mux.HandleFunc("/redfish/v1/Systems/1/Bios/Actions/Bios.ResetBios", func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		ts.biosReset = true
+		ts.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// LogService.ClearLog actions of the IML and IEL.
+	clearLogHandler := func(logServiceID string) func(http.ResponseWriter, *http.Request) {
+		return func(w http.ResponseWriter, r *http.Request) {
+			ts.mu.Lock()
+			ts.logsCleared = append(ts.logsCleared, logServiceID)
+			ts.mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+	mux.HandleFunc("/redfish/v1/Systems/1/LogServices/IML/Actions/LogService.ClearLog", clearLogHandler(LogServiceIML))
+	mux.HandleFunc("/redfish/v1/Managers/1/LogServices/IEL/Actions/LogService.ClearLog", clearLogHandler(LogServiceIEL))
+
+	// VirtualMedia.InsertMedia and EjectMedia actions.
+	mux.HandleFunc("/redfish/v1/Managers/1/VirtualMedia/2/Actions/VirtualMedia.InsertMedia", func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		ts.mediaInserted = true
+		ts.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/redfish/v1/Managers/1/VirtualMedia/2/Actions/VirtualMedia.EjectMedia", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// iLO repository upload endpoint (note: this lives outside the /redfish/v1/ tree).
+	mux.HandleFunc("/cgi-bin/uploadFile", func(w http.ResponseWriter, r *http.Request) {
+		reader, err := r.MultipartReader()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var parts []string
+		var file string
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			b, _ := io.ReadAll(part)
+			parts = append(parts, part.FormName())
+			if part.FormName() == "file" {
+				file = string(b)
+			}
+		}
+
+		ts.mu.Lock()
+		ts.uploadCookie = r.Header.Get("Cookie")
+		ts.uploadForm = parts
+		ts.uploadFile = file
+		ts.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Update task queue: POST queues an update and returns its Location.
+	mux.HandleFunc("/redfish/v1/UpdateService/UpdateTaskQueue", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var body map[string]any
+			if b, err := io.ReadAll(r.Body); err == nil {
+				_ = json.Unmarshal(b, &body)
+			}
+			ts.mu.Lock()
+			ts.taskQueuePost = body
+			ts.mu.Unlock()
+			w.Header().Set("Location", "/redfish/v1/UpdateService/UpdateTaskQueue/1/")
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(fixtureBytes(t, "updatetaskqueue.json"))
+	})
+
+	// Session create: returns an X-Auth-Token and the session Location.
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		ts.mu.Lock()
+		ts.sessionCreated = true
+		ts.mu.Unlock()
+
+		w.Header().Set("X-Auth-Token", "test-token")
+		w.Header().Set("Location", "/redfish/v1/SessionService/Sessions/1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"@odata.id":"/redfish/v1/SessionService/Sessions/1","Id":"1","Name":"Session"}`))
+	})
+
+	// A created session is deleted here on Close.
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			ts.mu.Lock()
+			ts.sessionDeleted = true
+			ts.mu.Unlock()
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Catch-all for the rest of the Redfish tree.
+	//
+	// GETs are served from fixtures. Writes on a known resource are accepted
+	// with 204 and recorded.
+	mux.HandleFunc("/redfish/v1/", func(w http.ResponseWriter, r *http.Request) {
+		file, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			ts.mu.Lock()
+			switch r.URL.Path {
+			case "/redfish/v1/Systems/1":
+				ts.systemPatched = true
+			case "/redfish/v1/Systems/1/Bios", "/redfish/v1/Systems/1/Bios/Settings":
+				if b, err := io.ReadAll(r.Body); err == nil {
+					var body map[string]any
+					if json.Unmarshal(b, &body) == nil {
+						ts.biosPatchBody = body
+					}
+				}
+			}
+			ts.mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		body, err := os.ReadFile(filepath.Join(fixturesDir, file))
+		if err != nil {
+			t.Errorf("failed to read fixture %q for %s: %v", file, r.URL.Path, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	})
+
+	ts.Server = httptest.NewTLSServer(mux)
+
+	return ts
+}
+
+// fixtureBytes reads a fixture file from the fixtures dir.
+func fixtureBytes(t *testing.T, file string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(fixturesDir, file))
+	if err != nil {
+		t.Fatalf("failed to read fixture %q: %v", file, err)
+	}
+	return b
+}
+
+// client returns a *Conn pointed at the mock server. Extra options are appended
+// after the mandatory port option.
+func (ts *testServer) client(t *testing.T, opts ...Option) *Conn {
+	t.Helper()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("parse mock url: %v", err)
+	}
+	opts = append([]Option{WithPort(u.Port())}, opts...)
+	return New(u.Hostname(), "user", "pass", logr.Discard(), opts...)
+}
+
+// openedClient returns a *Conn with an established session and registers Close
+// + server shutdown for cleanup.
+func (ts *testServer) openedClient(t *testing.T, opts ...Option) *Conn {
+	t.Helper()
+	c := ts.client(t, opts...)
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = c.Close(context.Background())
+		ts.Close()
+	})
+	return c
+}
+
+func (ts *testServer) didCreateSession() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.sessionCreated
+}
+
+func (ts *testServer) didDeleteSession() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.sessionDeleted
+}
+
+func (ts *testServer) resetType() string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.lastResetType
+}
+
+func (ts *testServer) didPatchSystem() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.systemPatched
+}
+
+func (ts *testServer) didResetBios() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.biosReset
+}
+
+func (ts *testServer) biosPatch() map[string]any {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.biosPatchBody
+}
+
+func (ts *testServer) clearedLogs() []string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return append([]string(nil), ts.logsCleared...)
+}
+
+func (ts *testServer) didInsertMedia() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.mediaInserted
+}
+
+func (ts *testServer) upload() (cookie string, form []string, file string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.uploadCookie, append([]string(nil), ts.uploadForm...), ts.uploadFile
+}
+
+func (ts *testServer) taskQueueRequest() map[string]any {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.taskQueuePost
+}
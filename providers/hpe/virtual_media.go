@@ -0,0 +1,20 @@
+package hpe
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// compile-time assertion that the provider implements the interface.
+var _ bmc.VirtualMediaSetter = (*Conn)(nil)
+
+// SetVirtualMedia inserts the media at mediaURL into the iLO virtual media slot
+// of the given kind, an empty mediaURL ejects the media.
+//
+// iLO requires an iLO Advanced license for virtual media, use
+// bmclib.Client.Capabilities to find out if the license is present. Implements
+// bmc.VirtualMediaSetter.
+func (c *Conn) SetVirtualMedia(ctx context.Context, kind, mediaURL string) (ok bool, err error) {
+	return c.redfishwrapper.SetVirtualMedia(ctx, kind, mediaURL)
+}
@@ -0,0 +1,91 @@
+package intelamt
+
+import (
+	"context"
+	"strconv"
+)
+
+const (
+	uriBootSettingData   = "http://intel.com/wbem/wscim/1/amt-schema/1/AMT_BootSettingData"
+	uriBootService       = "http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_BootService"
+	uriBootConfigSetting = "http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_BootConfigSetting"
+	uriBootSourceSetting = "http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_BootSourceSetting"
+
+	bootConfigSetting = "Intel(r) AMT: Boot Configuration 0"
+)
+
+// bootOverride is the AMT_BootSettingData and the boot source of a boot
+// device, an empty source boots from the BIOS order.
+type bootOverride struct {
+	source    string
+	biosSetup bool
+	// ider is the IDE redirection (IDE-R) drive to boot from, its image is
+	// attached by SetVirtualMedia.
+	ider *iderDrive
+}
+
+// bootOverrides of the boot devices other than pxe, which is set by the iamt
+// client.
+var bootOverrides = map[string]bootOverride{
+	"disk":   {source: "Intel(r) AMT: Force Hard-drive Boot"},
+	"bios":   {biosSetup: true},
+	"cdrom":  {ider: &iderCDROM},
+	"floppy": {ider: &iderFloppy},
+}
+
+// bootSettingDataReadOnly are the AMT_BootSettingData properties rejected by a
+// Put.
+var bootSettingDataReadOnly = map[string]bool{
+	"WinREBootEnabled":         true,
+	"UEFILocalPBABootEnabled":  true,
+	"UEFIHTTPSBootEnabled":     true,
+	"SecureBootControlEnabled": true,
+	"BootguardStatus":          true,
+	"OptionsCleared":           true,
+	"BIOSLastStatus":           true,
+	"UefiBootParametersArray":  true,
+	"RPEEnabled":               true,
+	"RSEEnabled":               true,
+}
+
+// setBootOverride updates the AMT_BootSettingData, sets the boot source and
+// enables the boot configuration for the next boot.
+func (c *Conn) setBootOverride(ctx context.Context, override bootOverride) error {
+	settings, err := c.wsman.get(ctx, uriBootSettingData)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]string{
+		"BIOSPause":      "false",
+		"BIOSSetup":      strconv.FormatBool(override.biosSetup),
+		"BootMediaIndex": "0",
+		"UseIDER":        "false",
+		"IDERBootDevice": "0",
+	}
+
+	if override.ider != nil {
+		values["UseIDER"] = "true"
+		values["IDERBootDevice"] = strconv.Itoa(override.ider.bootDevice)
+	}
+
+	if err := c.wsman.put(ctx, uriBootSettingData, instance(uriBootSettingData, settings, values, bootSettingDataReadOnly)); err != nil {
+		return err
+	}
+
+	var source string
+	if override.source != "" {
+		source = endpointReference("Source", uriBootSourceSetting, override.source)
+	}
+
+	err = c.wsman.invoke(ctx, uriBootConfigSetting, "ChangeBootOrder", source,
+		selector{Name: "InstanceID", Value: bootConfigSetting})
+	if err != nil {
+		return err
+	}
+
+	// Role 1 is IsNext, the configuration applies to the next boot only.
+	return c.wsman.invoke(ctx, uriBootService, "SetBootConfigRole",
+		endpointReference("BootConfigSetting", uriBootConfigSetting, bootConfigSetting)+"<h:Role>1</h:Role>",
+		selector{Name: "Name", Value: "Intel(r) AMT Boot Service"})
+}
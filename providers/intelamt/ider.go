@@ -0,0 +1,692 @@
+package intelamt
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// IDE-R commands, see the storage redirection protocol of the Intel AMT SDK.
+// Every message starts with the command, two reserved bytes, the attributes
+// and a little endian sequence number.
+const (
+	iderOpenSession                = 0x40
+	iderOpenSessionReply           = 0x41
+	iderCloseSession               = 0x42
+	iderCloseSessionReply          = 0x43
+	iderKeepAlivePing              = 0x44
+	iderKeepAlivePong              = 0x45
+	iderResetOccurred              = 0x46
+	iderResetOccurredResponse      = 0x47
+	iderDisableEnableFeatures      = 0x48
+	iderDisableEnableFeaturesReply = 0x49
+	iderErrorOccurred              = 0x4a
+	iderHeartbeat                  = 0x4b
+	iderCommandWritten             = 0x50
+	iderCommandEndResponse         = 0x51
+	iderDataFromHost               = 0x53
+	iderDataToHost                 = 0x54
+
+	iderHeaderLength = 8
+
+	// iderAttributeCompleted ends the command, iderAttributeDMA transfers its
+	// data with DMA.
+	iderAttributeDMA       = 0x01
+	iderAttributeCompleted = 0x02
+
+	iderVersion          = 1
+	iderRxTimeout        = 30 * time.Second
+	iderHeartbeatTimeout = 20 * time.Second
+
+	// iderFeaturesQuery gets the supported features, iderFeaturesSet sets them,
+	// iderFeatureEnable enables the IDE-R devices, immediately.
+	iderFeaturesQuery = 1
+	iderFeaturesSet   = 3
+	iderFeatureEnable = 0x01 | 0x18
+
+	// iderDefaultReadBuffer is used when the device does not report its buffer.
+	iderDefaultReadBuffer = 8192
+)
+
+// iderDrive is one of the two drives of an IDE-R session.
+type iderDrive struct {
+	// kind is the virtual media kind of the drive.
+	kind string
+	// device is the ATA device register of the drive, bit 4 selects the slave.
+	device    byte
+	blockSize int64
+	// bootDevice is the AMT_BootSettingData IDERBootDevice of the drive.
+	bootDevice int
+}
+
+var (
+	iderFloppy = iderDrive{kind: "Floppy", device: 0xa0, blockSize: 512, bootDevice: 0}
+	iderCDROM  = iderDrive{kind: "CD", device: 0xb0, blockSize: 2048, bootDevice: 1}
+)
+
+// iderDrives by virtual media kind.
+var iderDrives = map[string]iderDrive{
+	iderFloppy.kind: iderFloppy,
+	iderCDROM.kind:  iderCDROM,
+}
+
+// errIDERClosed is returned when the device closes the session.
+var errIDERClosed = errors.New("ide-r: session closed by the device")
+
+// SCSI operation codes of the ATAPI commands.
+const (
+	scsiTestUnitReady         = 0x00
+	scsiRequestSense          = 0x03
+	scsiRead6                 = 0x08
+	scsiWrite6                = 0x0a
+	scsiInquiry               = 0x12
+	scsiModeSense6            = 0x1a
+	scsiStartStopUnit         = 0x1b
+	scsiPreventAllowRemoval   = 0x1e
+	scsiReadFormatCapacities  = 0x23
+	scsiReadCapacity          = 0x25
+	scsiRead10                = 0x28
+	scsiWrite10               = 0x2a
+	scsiWriteAndVerify10      = 0x2e
+	scsiReadTOC               = 0x43
+	scsiGetConfiguration      = 0x46
+	scsiModeSense10           = 0x5a
+	scsiRead12                = 0xa8
+	scsiWrite12               = 0xaa
+	scsiReadTOCFormatSession  = 0x01
+	scsiReadTOCMSF            = 0x02
+	scsiReadTOCLeadOut        = 0xaa
+	scsiProfileCDROM          = 0x0008
+	scsiModeWriteProtected    = 0x80
+	scsiPeripheralCDROM       = 0x05
+	scsiPeripheralDirectBlock = 0x00
+	scsiRemovableMedium       = 0x80
+)
+
+// scsiSense is the sense key, additional sense code and qualifier of a check
+// condition.
+type scsiSense struct {
+	key, asc, ascq byte
+}
+
+var (
+	senseNone           = scsiSense{}
+	senseNoMedium       = scsiSense{0x02, 0x3a, 0x00}
+	senseReadError      = scsiSense{0x03, 0x11, 0x00}
+	senseInvalidCommand = scsiSense{0x05, 0x20, 0x00}
+	senseLBAOutOfRange  = scsiSense{0x05, 0x21, 0x00}
+	senseInvalidField   = scsiSense{0x05, 0x24, 0x00}
+	senseMediumChanged  = scsiSense{0x06, 0x28, 0x00}
+	senseWriteProtected = scsiSense{0x07, 0x27, 0x00}
+)
+
+// iderMedia is an image attached to a drive.
+type iderMedia struct {
+	image     image
+	blockSize int64
+	// ready is set once a TEST UNIT READY reported the medium change.
+	ready bool
+}
+
+// iderSession is an IDE-R session serving the images attached to its drives.
+type iderSession struct {
+	conn       net.Conn
+	reader     *bufio.Reader
+	log        logr.Logger
+	readBuffer int
+	enabled    bool
+
+	writeMu sync.Mutex
+	seq     uint32
+
+	// mu guards media, which is changed by attach and detach while the session
+	// is served.
+	mu    sync.Mutex
+	media map[byte]*iderMedia
+	// sense is the last check condition of each drive, for REQUEST SENSE.
+	sense map[byte]scsiSense
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// startIDER opens an IDE-R session with the media attached to the drive and
+// serves it until it is closed. The context bounds the session setup only.
+func startIDER(ctx context.Context, config redirectionConfig, log logr.Logger, drive iderDrive, media image) (*iderSession, error) {
+	conn, err := dialRedirection(ctx, config, "IDER")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &iderSession{
+		conn:       conn,
+		reader:     bufio.NewReader(conn),
+		log:        log,
+		readBuffer: iderDefaultReadBuffer,
+		media:      map[byte]*iderMedia{},
+		sense:      map[byte]scsiSense{},
+		done:       make(chan struct{}),
+	}
+
+	s.attach(drive, media)
+
+	if err := s.open(ctx); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("ide-r: %w", err)
+	}
+
+	serveCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	go s.heartbeat(serveCtx)
+	go s.serve(serveCtx)
+
+	return s, nil
+}
+
+// attach inserts the image in the drive, the next TEST UNIT READY reports the
+// medium change.
+func (s *iderSession) attach(drive iderDrive, media image) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.media[drive.device] = &iderMedia{image: media, blockSize: drive.blockSize}
+}
+
+// detach ejects the image of the drive and returns the number of drives still
+// holding an image.
+func (s *iderSession) detach(drive iderDrive) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.media, drive.device)
+
+	return len(s.media)
+}
+
+// attached returns whether the drive holds an image of a served session.
+func (s *iderSession) attached(drive iderDrive) bool {
+	if s.closed() {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.media[drive.device] != nil
+}
+
+// closed returns whether the session ended.
+func (s *iderSession) closed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// close ends the session.
+func (s *iderSession) close() {
+	s.cancel()
+	_ = s.send(iderCloseSession, nil, 0)
+	s.conn.Close()
+	<-s.done
+}
+
+// open opens the session and enables the drives, the connection deadline
+// follows the context.
+func (s *iderSession) open(ctx context.Context) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = s.conn.SetDeadline(deadline)
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		_ = s.conn.SetDeadline(time.Now())
+	})
+
+	defer stop()
+
+	payload := make([]byte, 10)
+	binary.LittleEndian.PutUint16(payload[0:], uint16(iderRxTimeout.Milliseconds()))
+	// payload[2:4] is the transmit timeout, none
+	binary.LittleEndian.PutUint16(payload[4:], uint16(iderHeartbeatTimeout.Milliseconds()))
+	binary.LittleEndian.PutUint32(payload[6:], iderVersion)
+
+	err := s.send(iderOpenSession, payload, 0)
+
+	for err == nil && !s.enabled {
+		var msg []byte
+
+		if msg, err = readIDERMessage(s.reader); err == nil {
+			err = s.handle(ctx, msg)
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return s.conn.SetDeadline(time.Time{})
+}
+
+// heartbeat keeps the session open while the host does not use the drives.
+func (s *iderSession) heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(iderHeartbeatTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.send(iderHeartbeat, nil, 0); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// serve answers the messages of the device until the session ends.
+func (s *iderSession) serve(ctx context.Context) {
+	defer close(s.done)
+	defer s.cancel()
+	defer s.conn.Close()
+
+	for {
+		msg, err := readIDERMessage(s.reader)
+		if err == nil {
+			err = s.handle(ctx, msg)
+		}
+
+		if err != nil {
+			if ctx.Err() == nil {
+				s.log.Error(err, "IDE-R session ended")
+			}
+
+			return
+		}
+	}
+}
+
+// handle answers a message of the device.
+func (s *iderSession) handle(ctx context.Context, msg []byte) error {
+	switch msg[0] {
+	case iderOpenSessionReply:
+		if readBuffer := int(binary.LittleEndian.Uint16(msg[16:])); readBuffer > 0 {
+			s.readBuffer = readBuffer
+		}
+
+		return s.send(iderDisableEnableFeatures, []byte{iderFeaturesQuery}, 0)
+	case iderDisableEnableFeaturesReply:
+		return s.handleFeatures(msg[8], binary.LittleEndian.Uint32(msg[9:]))
+	case iderKeepAlivePing:
+		return s.send(iderKeepAlivePong, nil, 0)
+	case iderResetOccurred:
+		s.mu.Lock()
+		for _, media := range s.media {
+			media.ready = false
+		}
+		s.mu.Unlock()
+
+		return s.send(iderResetOccurredResponse, nil, 0)
+	case iderErrorOccurred:
+		s.log.V(1).Info("IDE-R error reported by the device", "code", msg[10])
+	case iderCloseSession, iderCloseSessionReply:
+		return errIDERClosed
+	case iderCommandWritten:
+		device := iderFloppy.device
+		if msg[14]&0x10 != 0 {
+			device = iderCDROM.device
+		}
+
+		dma := msg[9]&0x01 != 0
+
+		return s.command(ctx, device, msg[16:28], dma)
+	}
+
+	// keep alive pongs, heartbeats and data from the host, which is never
+	// requested, need no answer.
+	return nil
+}
+
+// handleFeatures enables the drives once the device reports IDE-R support.
+func (s *iderSession) handleFeatures(featureType byte, value uint32) error {
+	switch featureType {
+	case iderFeaturesQuery:
+		if value&0x01 == 0 {
+			return errors.New("IDE-R is not supported by the device")
+		}
+
+		payload := make([]byte, 5)
+		payload[0] = iderFeaturesSet
+		binary.LittleEndian.PutUint32(payload[1:], iderFeatureEnable)
+
+		return s.send(iderDisableEnableFeatures, payload, 0)
+	case iderFeaturesSet:
+		if value != 1 {
+			return fmt.Errorf("enabling the IDE-R drives failed, status %d", value)
+		}
+
+		s.enabled = true
+	}
+
+	return nil
+}
+
+// command answers an ATAPI command of the host.
+func (s *iderSession) command(ctx context.Context, device byte, cdb []byte, dma bool) error {
+	s.mu.Lock()
+	media := s.media[device]
+	s.mu.Unlock()
+
+	switch cdb[0] {
+	case scsiInquiry:
+		return s.reply(device, inquiry(device), int(cdb[4]), dma)
+	case scsiRequestSense:
+		sense := s.sense[device]
+		delete(s.sense, device)
+
+		return s.reply(device, []byte{0x70, 0, sense.key, 0, 0, 0, 0, 10, 0, 0, 0, 0, sense.asc, sense.ascq, 0, 0, 0, 0}, int(cdb[4]), dma)
+	case scsiStartStopUnit, scsiPreventAllowRemoval:
+		return s.end(device, senseNone)
+	case scsiWrite6, scsiWrite10, scsiWrite12, scsiWriteAndVerify10:
+		return s.end(device, senseWriteProtected)
+	}
+
+	if media == nil {
+		return s.end(device, senseNoMedium)
+	}
+
+	blocks := media.image.Size() / media.blockSize
+
+	switch cdb[0] {
+	case scsiTestUnitReady:
+		if !media.ready {
+			media.ready = true
+			return s.end(device, senseMediumChanged)
+		}
+
+		return s.end(device, senseNone)
+	case scsiRead6:
+		count := uint32(cdb[4])
+		if count == 0 {
+			count = 256
+		}
+
+		lba := uint32(cdb[1]&0x1f)<<16 | uint32(cdb[2])<<8 | uint32(cdb[3])
+
+		return s.read(ctx, device, media, lba, count, dma)
+	case scsiRead10:
+		return s.read(ctx, device, media, binary.BigEndian.Uint32(cdb[2:]), uint32(binary.BigEndian.Uint16(cdb[7:])), dma)
+	case scsiRead12:
+		return s.read(ctx, device, media, binary.BigEndian.Uint32(cdb[2:]), binary.BigEndian.Uint32(cdb[6:]), dma)
+	case scsiReadCapacity:
+		data := make([]byte, 8)
+		binary.BigEndian.PutUint32(data[0:], uint32(blocks-1))
+		binary.BigEndian.PutUint32(data[4:], uint32(media.blockSize))
+
+		return s.reply(device, data, len(data), dma)
+	case scsiReadFormatCapacities:
+		data := make([]byte, 12)
+		data[3] = 8
+		binary.BigEndian.PutUint32(data[4:], uint32(blocks))
+		// formatted media, the block length is 3 bytes
+		binary.BigEndian.PutUint32(data[8:], uint32(media.blockSize))
+		data[8] = 0x02
+
+		return s.reply(device, data, int(binary.BigEndian.Uint16(cdb[7:])), dma)
+	case scsiModeSense6:
+		return s.reply(device, []byte{3, 0, scsiModeWriteProtected, 0}, int(cdb[4]), dma)
+	case scsiModeSense10:
+		return s.reply(device, []byte{0, 6, 0, scsiModeWriteProtected, 0, 0, 0, 0}, int(binary.BigEndian.Uint16(cdb[7:])), dma)
+	}
+
+	if device != iderCDROM.device {
+		return s.end(device, senseInvalidCommand)
+	}
+
+	switch cdb[0] {
+	case scsiReadTOC:
+		data, ok := readTOC(cdb, uint32(blocks))
+		if !ok {
+			return s.end(device, senseInvalidField)
+		}
+
+		return s.reply(device, data, int(binary.BigEndian.Uint16(cdb[7:])), dma)
+	case scsiGetConfiguration:
+		data := make([]byte, 8)
+		data[3] = 4
+		binary.BigEndian.PutUint16(data[6:], scsiProfileCDROM)
+
+		return s.reply(device, data, int(binary.BigEndian.Uint16(cdb[7:])), dma)
+	}
+
+	return s.end(device, senseInvalidCommand)
+}
+
+// read sends the blocks of the image, in chunks of the device read buffer.
+func (s *iderSession) read(ctx context.Context, device byte, media *iderMedia, lba, count uint32, dma bool) error {
+	if int64(lba)+int64(count) > media.image.Size()/media.blockSize {
+		return s.end(device, senseLBAOutOfRange)
+	}
+
+	if count == 0 {
+		return s.end(device, senseNone)
+	}
+
+	offset := int64(lba) * media.blockSize
+	remaining := int64(count) * media.blockSize
+
+	for remaining > 0 {
+		data := make([]byte, min(remaining, int64(s.readBuffer)))
+
+		if err := media.image.ReadAt(ctx, data, offset); err != nil {
+			s.log.Error(err, "IDE-R image read failed", "lba", lba, "blocks", count)
+			return s.end(device, senseReadError)
+		}
+
+		offset += int64(len(data))
+		remaining -= int64(len(data))
+
+		if err := s.dataToHost(device, data, remaining == 0, dma); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reply sends the data of a command, truncated to the allocation length.
+func (s *iderSession) reply(device byte, data []byte, allocation int, dma bool) error {
+	if len(data) > allocation {
+		data = data[:allocation]
+	}
+
+	if len(data) == 0 {
+		return s.end(device, senseNone)
+	}
+
+	return s.dataToHost(device, data, true, dma)
+}
+
+// dataToHost sends data of a command, the completed chunk ends the command with
+// a good status.
+func (s *iderSession) dataToHost(device byte, data []byte, completed, dma bool) error {
+	payload := make([]byte, 20, 20+len(data))
+	binary.LittleEndian.PutUint16(payload[1:], uint16(len(data)))
+
+	// the ATA registers of the transfer: interrupt reason, byte count, device
+	// and status.
+	payload[4] = 0xb5
+	if dma {
+		payload[4] = 0xb4
+	} else {
+		binary.LittleEndian.PutUint16(payload[8:], uint16(len(data)))
+	}
+
+	payload[6] = 0x02
+	payload[10] = device
+	payload[11] = 0x58
+
+	var attributes byte
+
+	if completed {
+		copy(payload[12:], []byte{0x85, 0, 0x03, 0, 0, 0, 0, 0x50})
+
+		attributes |= iderAttributeCompleted
+	}
+
+	if dma {
+		attributes |= iderAttributeDMA
+	}
+
+	return s.send(iderDataToHost, append(payload, data...), attributes)
+}
+
+// end ends a command with a good status or a check condition of the sense.
+func (s *iderSession) end(device byte, sense scsiSense) error {
+	payload := []byte{0, 0, 0, 0, 0xc5, 0, 0x03, 0, 0, 0, device, 0x50, 0, 0, 0}
+
+	if sense != senseNone {
+		s.sense[device] = sense
+		payload = []byte{0, 0, 0, 0, 0x87, sense.key << 4, 0x03, 0, 0, 0, device, 0x51, sense.key, sense.asc, sense.ascq}
+	}
+
+	return s.send(iderCommandEndResponse, payload, iderAttributeCompleted)
+}
+
+// send sends a message, the sessions sends from the heartbeat and the serving
+// goroutines.
+func (s *iderSession) send(command byte, payload []byte, attributes byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	msg := make([]byte, iderHeaderLength, iderHeaderLength+len(payload))
+	msg[0] = command
+	msg[3] = attributes
+	binary.LittleEndian.PutUint32(msg[4:], s.seq)
+	s.seq++
+
+	_, err := s.conn.Write(append(msg, payload...))
+
+	return err
+}
+
+// readIDERMessage reads a message of the device, its length depends on the
+// command.
+func readIDERMessage(r io.Reader) ([]byte, error) {
+	msg, err := readMessage(r, nil, iderHeaderLength)
+	if err != nil {
+		return nil, err
+	}
+
+	switch msg[0] {
+	case iderCloseSession, iderCloseSessionReply, iderKeepAlivePing, iderKeepAlivePong, iderHeartbeat:
+		return msg, nil
+	case iderResetOccurred:
+		return readMessage(r, msg, 9)
+	case iderErrorOccurred:
+		return readMessage(r, msg, 11)
+	case iderDisableEnableFeaturesReply:
+		return readMessage(r, msg, 13)
+	case iderCommandWritten:
+		return readMessage(r, msg, 28)
+	case iderOpenSessionReply:
+		if msg, err = readMessage(r, msg, 30); err != nil {
+			return nil, err
+		}
+
+		// the reply ends with OEM defined data
+		return readMessage(r, msg, 30+int(msg[29]))
+	case iderDataFromHost:
+		if msg, err = readMessage(r, msg, 14); err != nil {
+			return nil, err
+		}
+
+		return readMessage(r, msg, 14+int(binary.LittleEndian.Uint16(msg[9:])))
+	}
+
+	return nil, fmt.Errorf("ide-r: unknown command 0x%02x", msg[0])
+}
+
+// readMessage reads the message up to the length.
+func readMessage(r io.Reader, msg []byte, length int) ([]byte, error) {
+	read := len(msg)
+	msg = append(msg, make([]byte, length-read)...)
+
+	if _, err := io.ReadFull(r, msg[read:]); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// inquiry returns the INQUIRY data of the drive.
+func inquiry(device byte) []byte {
+	data := make([]byte, 36)
+	data[0] = scsiPeripheralDirectBlock
+	product := "Virtual Floppy"
+
+	if device == iderCDROM.device {
+		data[0] = scsiPeripheralCDROM
+		product = "Virtual CDROM"
+	}
+
+	data[1] = scsiRemovableMedium
+	// SPC-3, with the response data format 2
+	data[2] = 0x05
+	data[3] = 0x02
+	data[4] = byte(len(data) - 5)
+
+	copy(data[8:], fmt.Sprintf("%-8s%-16s%-4s", "Intel", product, "1.00"))
+
+	return data
+}
+
+// readTOC returns the table of contents of the CD, a single data track.
+func readTOC(cdb []byte, blocks uint32) ([]byte, bool) {
+	msf := cdb[1]&scsiReadTOCMSF != 0
+	address := func(lba uint32) []byte {
+		if !msf {
+			return binary.BigEndian.AppendUint32(nil, lba)
+		}
+
+		// the MSF address starts after the 2 seconds pregap
+		frames := lba + 150
+
+		return []byte{0, byte(frames / (75 * 60)), byte(frames / 75 % 60), byte(frames % 75)}
+	}
+
+	// the format is in the control byte of the older drives
+	format := cdb[2] & 0x0f
+	if format == 0 {
+		format = cdb[9] >> 6
+	}
+
+	switch format {
+	case 0:
+	case scsiReadTOCFormatSession:
+		return append([]byte{0, 10, 1, 1, 0, 0x14, 1, 0}, address(0)...), true
+	default:
+		return nil, false
+	}
+
+	data := []byte{0, 18, 1, 1}
+	data = append(append(data, 0, 0x14, 1, 0), address(0)...)
+	data = append(append(data, 0, 0x14, scsiReadTOCLeadOut, 0), address(blocks)...)
+
+	return data, true
+}
@@ -0,0 +1,436 @@
+package intelamt
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const redirectionService = `<h:AMT_RedirectionService xmlns:h="` + uriRedirectionService + `">
+<h:AccessLog>0</h:AccessLog><h:CreationClassName>AMT_RedirectionService</h:CreationClassName>
+<h:ElementName>Intel(r) AMT Redirection Service</h:ElementName><h:EnabledState>32768</h:EnabledState>
+<h:ListenerEnabled>false</h:ListenerEnabled><h:Name>Intel(r) AMT Redirection Service</h:Name>
+<h:SystemCreationClassName>CIM_ComputerSystem</h:SystemCreationClassName><h:SystemName>Intel(r) AMT</h:SystemName>
+</h:AMT_RedirectionService>`
+
+// fakeRedirection is an AMT redirection service accepting IDE-R sessions with
+// a read buffer of two CD blocks.
+type fakeRedirection struct {
+	listener net.Listener
+	sessions chan net.Conn
+}
+
+func newFakeRedirection(t *testing.T) *fakeRedirection {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { listener.Close() })
+
+	f := &fakeRedirection{listener: listener, sessions: make(chan net.Conn, 1)}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			if err := f.handshake(conn); err != nil {
+				conn.Close()
+				continue
+			}
+
+			f.sessions <- conn
+		}
+	}()
+
+	return f
+}
+
+// handshake starts and authenticates the session, then opens IDE-R.
+func (f *fakeRedirection) handshake(conn net.Conn) error {
+	start := make([]byte, 8)
+	if _, err := io.ReadFull(conn, start); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(start, []byte{redirectionStartSession, 0, 0, 0, 'I', 'D', 'E', 'R'}) {
+		return fmt.Errorf("start session = %x", start)
+	}
+
+	if _, err := conn.Write([]byte{redirectionStartSessionReply, redirectionStatusSuccess, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}); err != nil {
+		return err
+	}
+
+	if _, err := fakeAuth(conn, 0, []byte{0x01, redirectionAuthDigest, redirectionAuthDigestQOP}); err != nil {
+		return err
+	}
+
+	if _, err := fakeAuth(conn, 1, lengthPrefixed(testRealm, testNonce, "auth")); err != nil {
+		return err
+	}
+
+	// the digest response of user, realm, nonce, uri, cnonce, nc, response and qop
+	request, err := readAuth(conn)
+	if err != nil {
+		return err
+	}
+
+	fields, err := parseLengthPrefixed(request)
+	if err != nil || len(fields) != 8 {
+		return fmt.Errorf("digest response = %q", fields)
+	}
+
+	ha1 := md5Hex(testUser + ":" + testRealm + ":" + testPass)
+	ha2 := md5Hex("POST:" + redirectionAuthURI)
+
+	if fields[0] != testUser || fields[6] != md5Hex(ha1+":"+testNonce+":"+fields[5]+":"+fields[4]+":auth:"+ha2) {
+		_, _ = conn.Write([]byte{redirectionAuthenticateReply, 1, 0, 0, redirectionAuthDigestQOP, 0, 0, 0, 0})
+		return errors.New("invalid digest")
+	}
+
+	if _, err := conn.Write([]byte{redirectionAuthenticateReply, redirectionStatusSuccess, 0, 0, redirectionAuthDigestQOP, 0, 0, 0, 0}); err != nil {
+		return err
+	}
+
+	if reply, err := readIDERReply(conn); err != nil || reply.cmd != iderOpenSession {
+		return fmt.Errorf("open session = %+v, %v", reply, err)
+	}
+
+	open := make([]byte, 30)
+	open[0] = iderOpenSessionReply
+	binary.LittleEndian.PutUint16(open[16:], 2*2048)
+
+	if _, err := conn.Write(open); err != nil {
+		return err
+	}
+
+	for _, featureType := range []byte{iderFeaturesQuery, iderFeaturesSet} {
+		if reply, err := readIDERReply(conn); err != nil || reply.cmd != iderDisableEnableFeatures || reply.payload[0] != featureType {
+			return fmt.Errorf("features = %+v, %v", reply, err)
+		}
+
+		if _, err := conn.Write([]byte{iderDisableEnableFeaturesReply, 0, 0, 0, 0, 0, 0, 0, featureType, 1, 0, 0, 0}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fakeAuth reads an authentication request and answers with the status and
+// data.
+func fakeAuth(conn net.Conn, status byte, data []byte) ([]byte, error) {
+	request, err := readAuth(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := []byte{redirectionAuthenticateReply, status, 0, 0, redirectionAuthDigestQOP, 0, 0, 0, 0}
+	binary.LittleEndian.PutUint32(reply[5:], uint32(len(data)))
+
+	_, err = conn.Write(append(reply, data...))
+
+	return request, err
+}
+
+func readAuth(conn net.Conn) ([]byte, error) {
+	header := make([]byte, 9)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, binary.LittleEndian.Uint32(header[5:]))
+	_, err := io.ReadFull(conn, data)
+
+	return data, err
+}
+
+type iderReply struct {
+	cmd        byte
+	attributes byte
+	payload    []byte
+}
+
+// readIDERReply reads a message of the session, skipping the heartbeats.
+func readIDERReply(conn net.Conn) (iderReply, error) {
+	for {
+		header := make([]byte, iderHeaderLength)
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return iderReply{}, err
+		}
+
+		reply := iderReply{cmd: header[0], attributes: header[3]}
+
+		var err error
+
+		switch reply.cmd {
+		case iderHeartbeat:
+			continue
+		case iderOpenSession:
+			reply.payload, err = readMessage(conn, nil, 10)
+		case iderDisableEnableFeatures:
+			if reply.payload, err = readMessage(conn, nil, 1); err == nil && reply.payload[0] == iderFeaturesSet {
+				reply.payload, err = readMessage(conn, reply.payload, 5)
+			}
+		case iderCommandEndResponse:
+			reply.payload, err = readMessage(conn, nil, 15)
+		case iderDataToHost:
+			if reply.payload, err = readMessage(conn, nil, 20); err == nil {
+				reply.payload, err = readMessage(conn, reply.payload, 20+int(binary.LittleEndian.Uint16(reply.payload[1:])))
+			}
+		}
+
+		return reply, err
+	}
+}
+
+// exec writes the ATAPI command to the drive and returns the data and the
+// sense of its end.
+func exec(t *testing.T, conn net.Conn, drive iderDrive, cdb ...byte) ([]byte, scsiSense) {
+	t.Helper()
+
+	msg := make([]byte, 28)
+	msg[0] = iderCommandWritten
+	msg[14] = drive.device
+	copy(msg[16:], cdb)
+
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if _, err := conn.Write(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	var data []byte
+
+	for {
+		reply, err := readIDERReply(conn)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		switch reply.cmd {
+		case iderDataToHost:
+			data = append(data, reply.payload[20:]...)
+			if reply.attributes&iderAttributeCompleted != 0 {
+				return data, senseNone
+			}
+		case iderCommandEndResponse:
+			if reply.attributes&iderAttributeCompleted == 0 || reply.payload[10] != drive.device {
+				t.Fatalf("command end = %+v", reply)
+			}
+
+			if reply.payload[7] == 0x50 {
+				return data, senseNone
+			}
+
+			return data, scsiSense{reply.payload[12], reply.payload[13], reply.payload[14]}
+		default:
+			t.Fatalf("unexpected reply %+v", reply)
+		}
+	}
+}
+
+// newIDERConn returns a connection with the fake WS-Management endpoint and
+// redirection service, and the URL of a CD image of 5 blocks.
+func newIDERConn(t *testing.T) (*fakeAMT, *fakeRedirection, *Conn, []byte, string) {
+	t.Helper()
+
+	f, conn := newFakeAMT(t, map[string][]string{
+		uriBootSettingData:    {bootSettingData},
+		uriRedirectionService: {redirectionService},
+	})
+
+	redirection := newFakeRedirection(t)
+	conn.redirection = redirectionConfig{address: redirection.listener.Addr().String(), user: testUser, pass: testPass}
+
+	iso := make([]byte, 5*2048)
+	for i := range iso {
+		iso[i] = byte(i % 251)
+	}
+
+	images := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/boot.iso" {
+			http.NotFound(w, r)
+			return
+		}
+
+		http.ServeContent(w, r, "boot.iso", time.Time{}, bytes.NewReader(iso))
+	}))
+	t.Cleanup(images.Close)
+
+	conn.httpClient = images.Client()
+
+	return f, redirection, conn, iso, images.URL + "/boot.iso"
+}
+
+// Requirement: the image is attached to the IDE-R CD drive after enabling the
+// redirection service, the ATAPI commands of the host are answered from it and
+// the cdrom boot device boots from the drive.
+func TestSetVirtualMedia(t *testing.T) {
+	f, redirection, conn, iso, url := newIDERConn(t)
+	ctx := context.Background()
+
+	ok, err := conn.SetVirtualMedia(ctx, "CD", url)
+	if err != nil || !ok {
+		t.Fatalf("SetVirtualMedia() = %v, %v", ok, err)
+	}
+
+	state := f.request(uriRedirectionService + "/RequestStateChange")
+	if state == nil || !strings.Contains(state.Body, "<h:RequestedState>32769</h:RequestedState>") {
+		t.Fatalf("RequestStateChange = %+v", state)
+	}
+
+	listener := f.request(actionPut)
+	if listener == nil || !strings.Contains(listener.Body, "<h:ListenerEnabled>true</h:ListenerEnabled>") || strings.Contains(listener.Body, "AccessLog") {
+		t.Fatalf("Put of AMT_RedirectionService = %+v", listener)
+	}
+
+	session := <-redirection.sessions
+
+	// the host commands in order, TEST UNIT READY reports the medium change first
+	commands := []struct {
+		name  string
+		drive iderDrive
+		cdb   []byte
+		data  []byte
+		sense scsiSense
+	}{
+		{name: "medium changed", drive: iderCDROM, cdb: []byte{scsiTestUnitReady}, sense: senseMediumChanged},
+		{name: "ready", drive: iderCDROM, cdb: []byte{scsiTestUnitReady}},
+		{name: "no floppy", drive: iderFloppy, cdb: []byte{scsiTestUnitReady}, sense: senseNoMedium},
+		{name: "request sense", drive: iderFloppy, cdb: []byte{scsiRequestSense, 0, 0, 0, 14, 0}, data: []byte{0x70, 0, 0x02, 0, 0, 0, 0, 10, 0, 0, 0, 0, 0x3a, 0}},
+		{name: "capacity", drive: iderCDROM, cdb: []byte{scsiReadCapacity}, data: []byte{0, 0, 0, 4, 0, 0, 8, 0}},
+		{name: "read in two chunks", drive: iderCDROM, cdb: []byte{scsiRead10, 0, 0, 0, 0, 1, 0, 0, 3, 0}, data: iso[2048 : 4*2048]},
+		{name: "read out of range", drive: iderCDROM, cdb: []byte{scsiRead10, 0, 0, 0, 0, 4, 0, 0, 2, 0}, sense: senseLBAOutOfRange},
+		{name: "write protected", drive: iderCDROM, cdb: []byte{scsiWrite10, 0, 0, 0, 0, 0, 0, 0, 1, 0}, sense: senseWriteProtected},
+		{name: "unsupported command", drive: iderCDROM, cdb: []byte{0xbd}, sense: senseInvalidCommand},
+		{name: "session TOC", drive: iderCDROM, cdb: []byte{scsiReadTOC, 0, scsiReadTOCFormatSession, 0, 0, 0, 0, 0, 12, 0}, data: []byte{0, 10, 1, 1, 0, 0x14, 1, 0, 0, 0, 0, 0}},
+		{name: "inquiry allocation", drive: iderCDROM, cdb: []byte{scsiInquiry, 0, 0, 0, 2, 0}, data: []byte{scsiPeripheralCDROM, scsiRemovableMedium}},
+		{name: "floppy inquiry", drive: iderFloppy, cdb: []byte{scsiInquiry, 0, 0, 0, 1, 0}, data: []byte{scsiPeripheralDirectBlock}},
+	}
+
+	for _, command := range commands {
+		data, sense := exec(t, session, command.drive, command.cdb...)
+		if !bytes.Equal(data, command.data) || sense != command.sense {
+			t.Fatalf("%s: data = %x, sense = %+v, want %x, %+v", command.name, data, sense, command.data, command.sense)
+		}
+	}
+
+	ok, err = conn.BootDeviceSet(ctx, "cdrom", false, false)
+	if err != nil || !ok {
+		t.Fatalf("BootDeviceSet() = %v, %v", ok, err)
+	}
+
+	f.mu.Lock()
+	put := f.requests[len(f.requests)-3]
+	f.mu.Unlock()
+
+	if put.ResourceURI != uriBootSettingData || !strings.Contains(put.Body, "<h:UseIDER>true</h:UseIDER>") || !strings.Contains(put.Body, "<h:IDERBootDevice>1</h:IDERBootDevice>") {
+		t.Fatalf("Put of AMT_BootSettingData = %+v", put)
+	}
+
+	if _, err := conn.BootDeviceSet(ctx, "floppy", false, false); err == nil {
+		t.Fatal("BootDeviceSet(floppy) without a floppy image succeeded")
+	}
+
+	if err := conn.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if reply, err := readIDERReply(session); err != nil || reply.cmd != iderCloseSession {
+		t.Fatalf("close = %+v, %v", reply, err)
+	}
+
+	if _, err := readIDERReply(session); !errors.Is(err, io.EOF) {
+		t.Fatalf("session not closed: %v", err)
+	}
+}
+
+// Requirement: ejecting the last image ends the session and the IDE-R boot
+// devices are rejected again.
+func TestSetVirtualMediaEject(t *testing.T) {
+	_, redirection, conn, _, url := newIDERConn(t)
+	ctx := context.Background()
+
+	if ok, err := conn.SetVirtualMedia(ctx, "CD", url); err != nil || !ok {
+		t.Fatalf("SetVirtualMedia() = %v, %v", ok, err)
+	}
+
+	session := <-redirection.sessions
+
+	if ok, err := conn.SetVirtualMedia(ctx, "CD", ""); err != nil || !ok {
+		t.Fatalf("SetVirtualMedia() eject = %v, %v", ok, err)
+	}
+
+	if reply, err := readIDERReply(session); err != nil || reply.cmd != iderCloseSession {
+		t.Fatalf("close = %+v, %v", reply, err)
+	}
+
+	if _, err := conn.BootDeviceSet(ctx, "cdrom", false, false); err == nil {
+		t.Fatal("BootDeviceSet(cdrom) after the eject succeeded")
+	}
+}
+
+// Requirement: invalid virtual media are rejected before a session is opened.
+func TestSetVirtualMediaInvalid(t *testing.T) {
+	tests := map[string]struct {
+		kind string
+		pass string
+		path string
+	}{
+		"unsupported kind":     {kind: "USBStick", path: "/boot.iso"},
+		"image not found":      {kind: "CD", path: "/missing.iso"},
+		"odd floppy size":      {kind: "Floppy", path: "/boot.iso"},
+		"authentication error": {kind: "CD", path: "/boot.iso", pass: "wrong"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, conn, _, url := newIDERConn(t)
+			url = strings.TrimSuffix(url, "/boot.iso") + tt.path
+
+			if tt.pass != "" {
+				conn.redirection.pass = tt.pass
+			}
+
+			if tt.kind == "Floppy" {
+				conn.httpClient = &http.Client{Transport: truncate{conn.httpClient.Transport}}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if ok, err := conn.SetVirtualMedia(ctx, tt.kind, url); err == nil || ok {
+				t.Fatalf("SetVirtualMedia() = %v, %v, want an error", ok, err)
+			}
+		})
+	}
+}
+
+// truncate reports an image size of 1000 bytes.
+type truncate struct {
+	transport http.RoundTripper
+}
+
+func (tr truncate) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := tr.transport.RoundTrip(r)
+	if err == nil {
+		resp.Header.Set("Content-Range", "bytes 0-0/1000")
+	}
+
+	return resp, err
+}
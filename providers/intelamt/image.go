@@ -0,0 +1,114 @@
+package intelamt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// imageReadAhead is the minimum size of an image range request, the IDE-R
+// reads are small and mostly sequential.
+const imageReadAhead = 1 << 20
+
+// image is a virtual media image.
+type image interface {
+	// ReadAt reads len(p) bytes from the offset.
+	ReadAt(ctx context.Context, p []byte, off int64) error
+	Size() int64
+}
+
+// remoteImage is an image read from an HTTP server with range requests.
+type remoteImage struct {
+	url        string
+	size       int64
+	httpClient *http.Client
+
+	mu           sync.Mutex
+	window       []byte
+	windowOffset int64
+}
+
+// openRemoteImage returns the image at the URL, the server has to support range
+// requests.
+func openRemoteImage(ctx context.Context, httpClient *http.Client, url string) (*remoteImage, error) {
+	body, header, err := getRange(ctx, httpClient, url, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	body.Close()
+
+	// Content-Range is "bytes 0-0/<size>"
+	_, total, _ := strings.Cut(header.Get("Content-Range"), "/")
+
+	size, err := strconv.ParseInt(total, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("media image %s: unknown size in Content-Range %q", url, header.Get("Content-Range"))
+	}
+
+	return &remoteImage{url: url, size: size, httpClient: httpClient}, nil
+}
+
+// Size returns the size of the image in bytes.
+func (r *remoteImage) Size() int64 {
+	return r.size
+}
+
+// ReadAt reads len(p) bytes from the offset, through a window of at least
+// imageReadAhead bytes.
+func (r *remoteImage) ReadAt(ctx context.Context, p []byte, off int64) error {
+	if off < 0 || off+int64(len(p)) > r.size {
+		return fmt.Errorf("media image %s: read of %d bytes at %d out of range", r.url, len(p), off)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if off < r.windowOffset || off+int64(len(p)) > r.windowOffset+int64(len(r.window)) {
+		end := min(off+max(int64(len(p)), imageReadAhead), r.size) - 1
+
+		body, _, err := getRange(ctx, r.httpClient, r.url, off, end)
+		if err != nil {
+			return err
+		}
+
+		defer body.Close()
+
+		window := make([]byte, end-off+1)
+		if _, err := io.ReadFull(body, window); err != nil {
+			return fmt.Errorf("media image %s: %w", r.url, err)
+		}
+
+		r.window, r.windowOffset = window, off
+	}
+
+	copy(p, r.window[off-r.windowOffset:])
+
+	return nil
+}
+
+// getRange requests the bytes from start to end, inclusive.
+func getRange(ctx context.Context, httpClient *http.Client, url string, start, end int64) (io.ReadCloser, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode != http.StatusPartialContent {
+		resp.Body.Close()
+		return nil, nil, fmt.Errorf("media image %s: unexpected status %s to a range request", url, resp.Status)
+	}
+
+	return resp.Body, resp.Header, nil
+}
@@ -3,13 +3,20 @@ package intelamt
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/go-logr/logr"
 	"github.com/jacobweinstock/iamt"
 	"github.com/jacobweinstock/registrar"
 
+	"github.com/bmc-toolbox/bmclib/v2/internal/httpclient"
 	"github.com/bmc-toolbox/bmclib/v2/providers"
 )
 
@@ -25,6 +32,8 @@ var Features = registrar.Features{
 	providers.FeaturePowerSet,
 	providers.FeaturePowerState,
 	providers.FeatureBootDeviceSet,
+	providers.FeatureInventoryRead,
+	providers.FeatureVirtualMedia,
 }
 
 // iamtClient interface allows us to mock the client for testing
@@ -40,7 +49,15 @@ type iamtClient interface {
 
 // Conn is a connection to a BMC via Intel AMT
 type Conn struct {
-	client iamtClient
+	client      iamtClient
+	wsman       *wsman
+	redirection redirectionConfig
+	httpClient  *http.Client
+	log         logr.Logger
+
+	// iderMu guards ider, the IDE-R session serving the virtual media.
+	iderMu sync.Mutex
+	ider   *iderSession
 }
 
 // Option for setting optional Client values
@@ -60,6 +77,14 @@ func WithHostScheme(hostScheme string) Option {
 	}
 }
 
+// WithRedirectionPort sets the port of the redirection service serving the
+// virtual media, 16994 or 16995 for the https host scheme by default.
+func WithRedirectionPort(port uint32) Option {
+	return func(c *Config) {
+		c.RedirectionPort = port
+	}
+}
+
 // WithLogger sets the logger used by the provider.
 func WithLogger(logger logr.Logger) Option {
 	return func(c *Config) {
@@ -67,13 +92,26 @@ func WithLogger(logger logr.Logger) Option {
 	}
 }
 
+// WithHTTPClient sets the HTTP client used for the WS-Management requests of
+// the boot device overrides and the inventory, and to read the virtual media
+// images.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Config) {
+		c.HTTPClient = httpClient
+	}
+}
+
 // Config holds the configuration for an Intel AMT connection.
 type Config struct {
 	// HostScheme should be either "http" or "https".
 	HostScheme string
 	// Port is the port number to connect to.
-	Port   uint32
-	Logger logr.Logger
+	Port uint32
+	// RedirectionPort is the port of the redirection service, 0 for the
+	// default of the host scheme.
+	RedirectionPort uint32
+	Logger          logr.Logger
+	HTTPClient      *http.Client
 }
 
 // New creates a new AMT connection
@@ -82,6 +120,7 @@ func New(host, user, pass string, opts ...Option) *Conn {
 		HostScheme: "http",
 		Port:       16992,
 		Logger:     logr.Discard(),
+		HTTPClient: httpclient.Build(),
 	}
 	for _, opt := range opts {
 		opt(defaultClient)
@@ -92,8 +131,32 @@ func New(host, user, pass string, opts ...Option) *Conn {
 		iamt.WithPort(defaultClient.Port),
 		iamt.WithScheme(defaultClient.HostScheme),
 	}
+	endpoint := fmt.Sprintf("%s://%s/wsman", defaultClient.HostScheme, net.JoinHostPort(host, strconv.FormatUint(uint64(defaultClient.Port), 10)))
+
+	redirection := redirectionConfig{user: user, pass: pass}
+
+	port := uint32(redirectionPort)
+	if defaultClient.HostScheme == "https" {
+		port = redirectionTLSPort
+		redirection.tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+
+		if transport, ok := defaultClient.HTTPClient.Transport.(*http.Transport); ok && transport.TLSClientConfig != nil {
+			redirection.tlsConfig = transport.TLSClientConfig.Clone()
+		}
+	}
+
+	if defaultClient.RedirectionPort != 0 {
+		port = defaultClient.RedirectionPort
+	}
+
+	redirection.address = net.JoinHostPort(host, strconv.FormatUint(uint64(port), 10))
+
 	return &Conn{
-		client: iamt.NewClient(host, user, pass, iopts...),
+		client:      iamt.NewClient(host, user, pass, iopts...),
+		wsman:       newWSMan(endpoint, user, pass, defaultClient.HTTPClient),
+		redirection: redirection,
+		httpClient:  defaultClient.HTTPClient,
+		log:         defaultClient.Logger,
 	}
 }
 
@@ -107,8 +170,15 @@ func (c *Conn) Open(ctx context.Context) (err error) {
 	return c.client.Open(ctx)
 }
 
-// Close a connection to a BMC
+// Close a connection to a BMC, ejecting the virtual media.
 func (c *Conn) Close(ctx context.Context) (err error) {
+	c.iderMu.Lock()
+	if c.ider != nil {
+		c.ider.close()
+		c.ider = nil
+	}
+	c.iderMu.Unlock()
+
 	return c.client.Close(ctx)
 }
 
@@ -125,18 +195,94 @@ func (c *Conn) Compatible(ctx context.Context) bool {
 	return true
 }
 
-// BootDeviceSet sets the next boot device with options
+// BootDeviceSet sets the device of the next boot: pxe, disk, bios (setup),
+// cdrom or floppy. cdrom and floppy boot from the IDE redirection (IDE-R) drive
+// of the image attached by SetVirtualMedia. AMT boot overrides only apply to the
+// next boot, setPersistent and efiBoot are ignored.
 func (c *Conn) BootDeviceSet(ctx context.Context, bootDevice string, setPersistent, efiBoot bool) (ok bool, err error) {
-	if !strings.EqualFold(bootDevice, "pxe") {
-		return false, errors.New("only pxe boot device is supported for AMT provider")
+	device := strings.ToLower(bootDevice)
+
+	if device == "pxe" {
+		if err := c.client.SetPXE(ctx); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	override, ok := bootOverrides[device]
+	if !ok {
+		return false, fmt.Errorf("unsupported boot device for AMT provider: %s", bootDevice)
+	}
+
+	if override.ider != nil && !c.iderAttached(*override.ider) {
+		return false, fmt.Errorf("boot device %s needs a %s image, attach one with SetVirtualMedia", bootDevice, override.ider.kind)
+	}
+
+	if err := c.setBootOverride(ctx, override); err != nil {
+		return false, fmt.Errorf("failed to set boot device %s: %w", bootDevice, err)
+	}
+
+	return true, nil
+}
+
+// SetVirtualMedia attaches the image at mediaURL to the IDE-R drive of the kind,
+// "CD" or "Floppy", an empty mediaURL ejects it. The images are read from their
+// server with HTTP range requests and served by a redirection session until
+// they are ejected or the connection is closed. The IDE-R sessions and the
+// listener of the redirection service are enabled when needed, a device in
+// client control mode may also require the user consent.
+func (c *Conn) SetVirtualMedia(ctx context.Context, kind string, mediaURL string) (ok bool, err error) {
+	drive, ok := iderDrives[kind]
+	if !ok {
+		return false, fmt.Errorf("unsupported virtual media kind for AMT provider: %s", kind)
 	}
-	if err := c.client.SetPXE(ctx); err != nil {
+
+	c.iderMu.Lock()
+	defer c.iderMu.Unlock()
+
+	if mediaURL == "" {
+		if c.ider != nil && c.ider.detach(drive) == 0 {
+			c.ider.close()
+			c.ider = nil
+		}
+
+		return true, nil
+	}
+
+	media, err := openRemoteImage(ctx, c.httpClient, mediaURL)
+	if err != nil {
+		return false, err
+	}
+
+	if media.Size() == 0 || media.Size()%drive.blockSize != 0 {
+		return false, fmt.Errorf("media image %s: size %d is not a multiple of the %d bytes %s block", mediaURL, media.Size(), drive.blockSize, kind)
+	}
+
+	if c.ider != nil && !c.ider.closed() {
+		c.ider.attach(drive, media)
+		return true, nil
+	}
+
+	if err := c.enableRedirection(ctx); err != nil {
+		return false, fmt.Errorf("failed to enable IDE-R: %w", err)
+	}
+
+	if c.ider, err = startIDER(ctx, c.redirection, c.log, drive, media); err != nil {
 		return false, err
 	}
 
 	return true, nil
 }
 
+// iderAttached returns whether the drive holds an image.
+func (c *Conn) iderAttached(drive iderDrive) bool {
+	c.iderMu.Lock()
+	defer c.iderMu.Unlock()
+
+	return c.ider != nil && c.ider.attached(drive)
+}
+
 // PowerStateGet gets the power state of a BMC machine
 func (c *Conn) PowerStateGet(ctx context.Context) (state string, err error) {
 	on, err := c.client.IsPoweredOn(ctx)
@@ -73,17 +73,19 @@ func TestBootDeviceSet(t *testing.T) {
 		device   string
 	}{
 		"success":                   {want: true, device: "pxe"},
-		"invalid boot device":       {want: false, err: errors.New("only pxe boot device is supported for AMT provider"), device: "invalid"},
-		"failed to set boot device": {want: false, failCall: true, err: errors.New(""), device: "pxe"},
+		"hdd":                       {want: true, device: "disk"},
+		"bios":                      {want: true, device: "bios"},
+		"cdrom":                     {want: false, err: errors.New("boot device cdrom needs a CD image, attach one with SetVirtualMedia"), device: "cdrom"},
+		"invalid boot device":       {want: false, err: errors.New("unsupported boot device for AMT provider: invalid"), device: "invalid"},
+		"failed to set boot device": {want: false, failCall: true, err: errors.New("set pxe failed"), device: "pxe"},
 	}
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			m := &mock{}
+			_, conn := newFakeAMT(t, map[string][]string{uriBootSettingData: {bootSettingData}})
 			if tt.failCall {
-				m = &mock{errSetPXE: tt.err}
+				conn.client = &mock{errSetPXE: tt.err}
 			}
-			conn := &Conn{client: m}
 			ctx := context.Background()
 			if err := conn.Open(ctx); err != nil {
 				t.Fatal(err)
@@ -93,6 +95,14 @@ func TestBootDeviceSet(t *testing.T) {
 			if err != nil && tt.err == nil {
 				t.Fatalf("expected nil error, got: %v", err)
 			}
+			if tt.err != nil {
+				if err == nil {
+					t.Fatalf("expected error %q, got nil", tt.err)
+				}
+				if diff := cmp.Diff(err.Error(), tt.err.Error()); diff != "" {
+					t.Fatal(diff)
+				}
+			}
 			if diff := cmp.Diff(got, tt.want); diff != "" {
 				t.Fatal(diff)
 			}
@@ -227,9 +237,7 @@ func TestNew(t *testing.T) {
 	want := &Conn{client: wantClient}
 	got := New("localhost", "admin", "pass")
 	t.Log(got == nil)
-	c := Conn{}
-	l := logr.Logger{}
-	if diff := cmp.Diff(got, want, cmpopts.IgnoreUnexported(c, l)); diff != "" {
+	if diff := cmp.Diff(got, want, cmpopts.IgnoreUnexported(Conn{}, logr.Logger{})); diff != "" {
 		t.Fatal(diff)
 	}
 }
@@ -0,0 +1,241 @@
+package intelamt
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bmc-toolbox/common"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+const (
+	uriChassis              = "http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_Chassis"
+	uriProcessor            = "http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_Processor"
+	uriPhysicalMemory       = "http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_PhysicalMemory"
+	uriEthernetPortSettings = "http://intel.com/wbem/wscim/1/amt-schema/1/AMT_EthernetPortSettings"
+)
+
+var _ bmc.InventoryGetter = (*Conn)(nil)
+
+// healthStates are the CIM HealthState values.
+var healthStates = map[string]string{
+	"5":  "OK",
+	"10": "Warning",
+	"15": "Warning",
+	"20": "Critical",
+	"25": "Critical",
+	"30": "Critical",
+}
+
+// enabledStates are the CIM EnabledState values.
+var enabledStates = map[string]string{
+	"2": "Enabled",
+	"3": "Disabled",
+	"6": "Enabled but Offline",
+	"9": "Quiesce",
+}
+
+// memoryTypes are the CIM_PhysicalMemory MemoryType values of the memory
+// generations AMT platforms ship with.
+var memoryTypes = map[string]string{
+	"20": "DDR",
+	"21": "DDR2",
+	"24": "DDR3",
+	"26": "DDR4",
+	"29": "LPDDR3",
+	"30": "LPDDR4",
+	"34": "DDR5",
+	"35": "LPDDR5",
+}
+
+// memoryFormFactors are the CIM_PhysicalMemory FormFactor values of memory
+// modules.
+var memoryFormFactors = map[string]string{
+	"8":  "DIMM",
+	"12": "SODIMM",
+}
+
+// Inventory returns the chassis, processors, memory modules and network ports
+// reported by the AMT WS-Management CIM_Chassis, CIM_Processor,
+// CIM_PhysicalMemory and AMT_EthernetPortSettings classes.
+//
+// Implements bmc.InventoryGetter.
+func (c *Conn) Inventory(ctx context.Context) (device *common.Device, err error) {
+	d := common.NewDevice()
+	device = &d
+
+	chassis, err := c.wsman.enumerate(ctx, uriChassis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CIM_Chassis: %w", err)
+	}
+
+	for i := range chassis {
+		enclosure := chassisEnclosure(&chassis[i])
+		if i == 0 {
+			device.Vendor = enclosure.Vendor
+			device.Model = enclosure.Model
+			device.Serial = enclosure.Serial
+		}
+
+		device.Enclosures = append(device.Enclosures, enclosure)
+	}
+
+	processors, err := c.wsman.enumerate(ctx, uriProcessor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CIM_Processor: %w", err)
+	}
+
+	for i := range processors {
+		device.CPUs = append(device.CPUs, processorCPU(&processors[i]))
+	}
+
+	memory, err := c.wsman.enumerate(ctx, uriPhysicalMemory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CIM_PhysicalMemory: %w", err)
+	}
+
+	for i := range memory {
+		device.Memory = append(device.Memory, physicalMemory(&memory[i]))
+	}
+
+	ports, err := c.wsman.enumerate(ctx, uriEthernetPortSettings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read AMT_EthernetPortSettings: %w", err)
+	}
+
+	for i := range ports {
+		if nic := ethernetPortNIC(&ports[i]); nic != nil {
+			device.NICs = append(device.NICs, nic)
+		}
+	}
+
+	return device, nil
+}
+
+func chassisEnclosure(n *node) *common.Enclosure {
+	enclosure := &common.Enclosure{
+		ID: n.value("Tag"),
+		Common: common.Common{
+			Description: n.value("ElementName"),
+			Vendor:      common.FormatVendorName(n.value("Manufacturer")),
+			Model:       n.value("Model"),
+			Serial:      n.value("SerialNumber"),
+		},
+	}
+
+	setMetadata(&enclosure.Common, "version", n.value("Version"))
+	setMetadata(&enclosure.Common, "chassis_package_type", n.value("ChassisPackageType"))
+
+	return enclosure
+}
+
+func processorCPU(n *node) *common.CPU {
+	cpu := &common.CPU{
+		ID:           n.value("DeviceID"),
+		ClockSpeedHz: megahertz(n.value("MaxClockSpeed")),
+		Common: common.Common{
+			Description: n.value("ElementName"),
+			Status:      status(n),
+		},
+	}
+
+	setMetadata(&cpu.Common, "family", n.value("Family"))
+	setMetadata(&cpu.Common, "stepping", n.value("Stepping"))
+	setMetadata(&cpu.Common, "current_clock_speed_mhz", n.value("CurrentClockSpeed"))
+	setMetadata(&cpu.Common, "external_bus_clock_speed_mhz", n.value("ExternalBusClockSpeed"))
+
+	return cpu
+}
+
+func physicalMemory(n *node) *common.Memory {
+	size, _ := strconv.ParseInt(n.value("Capacity"), 10, 64)
+
+	clockSpeed := megahertz(n.value("ConfiguredMemoryClockSpeed"))
+	if clockSpeed == 0 {
+		clockSpeed = megahertz(n.value("MaxMemorySpeed"))
+	}
+
+	memory := &common.Memory{
+		ID:           n.value("Tag"),
+		Slot:         n.value("BankLabel"),
+		Type:         memoryTypes[n.value("MemoryType")],
+		SizeBytes:    size,
+		FormFactor:   memoryFormFactors[n.value("FormFactor")],
+		PartNumber:   n.value("PartNumber"),
+		ClockSpeedHz: clockSpeed,
+		Common: common.Common{
+			Description: n.value("ElementName"),
+			Vendor:      common.FormatVendorName(n.value("Manufacturer")),
+			Serial:      n.value("SerialNumber"),
+		},
+	}
+
+	setMetadata(&memory.Common, "memory_type", n.value("MemoryType"))
+
+	return memory
+}
+
+// ethernetPortNIC returns the NIC of the AMT wired or wireless port settings,
+// nil when the port has no MAC address.
+func ethernetPortNIC(n *node) *common.NIC {
+	mac := strings.ToLower(strings.ReplaceAll(n.value("MACAddress"), "-", ":"))
+	if mac == "" {
+		return nil
+	}
+
+	linkStatus := "Down"
+	if n.value("LinkIsUp") == "true" {
+		linkStatus = "Up"
+	}
+
+	port := &common.NICPort{
+		ID:         n.value("InstanceID"),
+		MacAddress: mac,
+		LinkStatus: linkStatus,
+	}
+
+	setMetadata(&port.Common, "dhcp_enabled", n.value("DHCPEnabled"))
+	setMetadata(&port.Common, "ip_address", n.value("IPAddress"))
+	setMetadata(&port.Common, "subnet_mask", n.value("SubnetMask"))
+	setMetadata(&port.Common, "default_gateway", n.value("DefaultGateway"))
+	setMetadata(&port.Common, "shared_mac", n.value("SharedMAC"))
+
+	return &common.NIC{
+		ID:       n.value("InstanceID"),
+		Common:   common.Common{Description: n.value("ElementName")},
+		NICPorts: []*common.NICPort{port},
+	}
+}
+
+// status returns the health and state of a CIM managed element.
+func status(n *node) *common.Status {
+	health, state := healthStates[n.value("HealthState")], enabledStates[n.value("EnabledState")]
+	if health == "" && state == "" {
+		return nil
+	}
+
+	return &common.Status{Health: health, State: state}
+}
+
+// megahertz returns the clock speed in Hz of a value in MHz.
+func megahertz(value string) int64 {
+	mhz, _ := strconv.ParseInt(value, 10, 64)
+
+	return mhz * 1_000_000
+}
+
+// setMetadata sets a non empty metadata value on the component.
+func setMetadata(component *common.Common, key, value string) {
+	if value == "" {
+		return
+	}
+
+	if component.Metadata == nil {
+		component.Metadata = map[string]string{}
+	}
+
+	component.Metadata[key] = value
+}
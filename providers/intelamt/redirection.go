@@ -0,0 +1,303 @@
+package intelamt
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// AMT redirection protocol, the protocol of the IDE-R and SOL sessions, see the
+// Intel AMT SDK.
+const (
+	redirectionPort    = 16994
+	redirectionTLSPort = 16995
+
+	redirectionStartSession      = 0x10
+	redirectionStartSessionReply = 0x11
+	redirectionAuthenticate      = 0x13
+	redirectionAuthenticateReply = 0x14
+
+	redirectionStatusSuccess = 0x00
+
+	// redirection authentication types, digest is without and digest with qop
+	// is with the quality of protection "auth".
+	redirectionAuthQuery     = 0x00
+	redirectionAuthDigest    = 0x03
+	redirectionAuthDigestQOP = 0x04
+
+	redirectionAuthURI = "/RedirectionService"
+
+	uriRedirectionService = "http://intel.com/wbem/wscim/1/amt-schema/1/AMT_RedirectionService"
+
+	// redirectionServiceIDER is the IDE-R bit of the AMT_RedirectionService
+	// EnabledState, 32768 plus 1 for IDE-R and 2 for SOL.
+	redirectionServiceDisabled = 32768
+	redirectionServiceIDER     = 1
+	redirectionServiceSOL      = 2
+)
+
+// redirectionServiceReadOnly are the AMT_RedirectionService properties rejected
+// by a Put.
+var redirectionServiceReadOnly = map[string]bool{
+	"AccessLog": true,
+}
+
+// redirectionConfig is the address of the AMT redirection service and the
+// credentials of its sessions.
+type redirectionConfig struct {
+	address string
+	// tlsConfig is nil for the plain TCP port.
+	tlsConfig *tls.Config
+	user      string
+	pass      string
+}
+
+// enableRedirection enables the IDE-R sessions and the listener of the
+// AMT_RedirectionService, both are disabled by default.
+func (c *Conn) enableRedirection(ctx context.Context) error {
+	service, err := c.wsman.get(ctx, uriRedirectionService)
+	if err != nil {
+		return err
+	}
+
+	state, err := strconv.Atoi(service.value("EnabledState"))
+	if err != nil {
+		return fmt.Errorf("AMT_RedirectionService EnabledState %q: %w", service.value("EnabledState"), err)
+	}
+
+	if state&redirectionServiceIDER == 0 {
+		requested := redirectionServiceDisabled | state&redirectionServiceSOL | redirectionServiceIDER
+
+		err := c.wsman.invoke(ctx, uriRedirectionService, "RequestStateChange",
+			fmt.Sprintf("<h:RequestedState>%d</h:RequestedState>", requested))
+		if err != nil {
+			return err
+		}
+
+		if service, err = c.wsman.get(ctx, uriRedirectionService); err != nil {
+			return err
+		}
+	}
+
+	if service.value("ListenerEnabled") == "true" {
+		return nil
+	}
+
+	values := map[string]string{"ListenerEnabled": "true"}
+
+	return c.wsman.put(ctx, uriRedirectionService, instance(uriRedirectionService, service, values, redirectionServiceReadOnly))
+}
+
+// dialRedirection connects to the redirection service, starts a session of the
+// protocol and authenticates it. The context bounds the handshake only.
+func dialRedirection(ctx context.Context, config redirectionConfig, protocol string) (net.Conn, error) {
+	var (
+		conn net.Conn
+		err  error
+	)
+
+	if config.tlsConfig != nil {
+		dialer := &tls.Dialer{Config: config.tlsConfig}
+		conn, err = dialer.DialContext(ctx, "tcp", config.address)
+	} else {
+		dialer := &net.Dialer{}
+		conn, err = dialer.DialContext(ctx, "tcp", config.address)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("redirection: %w", err)
+	}
+
+	if err := startRedirection(ctx, conn, config, protocol); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("redirection: %w", err)
+	}
+
+	return conn, nil
+}
+
+// startRedirection starts and authenticates the session, the connection
+// deadline follows the context during the handshake.
+func startRedirection(ctx context.Context, conn net.Conn, config redirectionConfig, protocol string) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+
+	defer stop()
+
+	err := handshakeRedirection(conn, config, protocol)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return conn.SetDeadline(time.Time{})
+}
+
+func handshakeRedirection(conn net.Conn, config redirectionConfig, protocol string) error {
+	if _, err := conn.Write(append([]byte{redirectionStartSession, 0, 0, 0}, protocol...)); err != nil {
+		return err
+	}
+
+	reply := make([]byte, 13)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return err
+	}
+
+	if reply[0] != redirectionStartSessionReply {
+		return fmt.Errorf("unexpected reply 0x%02x to the %s session start", reply[0], protocol)
+	}
+
+	if reply[1] != redirectionStatusSuccess {
+		return fmt.Errorf("%s session refused, status %d", protocol, reply[1])
+	}
+
+	// the reply ends with OEM defined data
+	if _, err := io.CopyN(io.Discard, conn, int64(reply[12])); err != nil {
+		return err
+	}
+
+	return authenticateRedirection(conn, config.user, config.pass)
+}
+
+// authenticateRedirection authenticates the session with the digest
+// authentication, the only one offered without TLS.
+func authenticateRedirection(rw io.ReadWriter, user, pass string) error {
+	_, _, offered, err := redirectionAuth(rw, redirectionAuthQuery, nil)
+	if err != nil {
+		return err
+	}
+
+	var authType byte
+
+	switch {
+	case slices.Contains(offered, redirectionAuthDigestQOP):
+		authType = redirectionAuthDigestQOP
+	case slices.Contains(offered, redirectionAuthDigest):
+		authType = redirectionAuthDigest
+	default:
+		return fmt.Errorf("no supported authentication offered: %v", offered)
+	}
+
+	status, _, challenge, err := redirectionAuth(rw, authType, lengthPrefixed(user, "", "", redirectionAuthURI, "", "", "", ""))
+	if err != nil {
+		return err
+	}
+
+	if status == redirectionStatusSuccess {
+		return nil
+	}
+
+	fields, err := parseLengthPrefixed(challenge)
+	if err != nil || len(fields) < 2 {
+		return errors.New("invalid authentication challenge")
+	}
+
+	realm, nonce := fields[0], fields[1]
+
+	cnonce := make([]byte, 16)
+	if _, err := rand.Read(cnonce); err != nil {
+		return err
+	}
+
+	const nc = "00000001"
+
+	response := lengthPrefixed(user, realm, nonce, redirectionAuthURI, hex.EncodeToString(cnonce), nc)
+
+	ha1 := md5Hex(user + ":" + realm + ":" + pass)
+	ha2 := md5Hex("POST:" + redirectionAuthURI)
+
+	if authType == redirectionAuthDigestQOP {
+		qop := "auth"
+		response = append(response, lengthPrefixed(md5Hex(ha1+":"+nonce+":"+nc+":"+hex.EncodeToString(cnonce)+":"+qop+":"+ha2), qop)...)
+	} else {
+		response = append(response, lengthPrefixed(md5Hex(ha1+":"+nonce+":"+ha2))...)
+	}
+
+	status, _, _, err = redirectionAuth(rw, authType, response)
+	if err != nil {
+		return err
+	}
+
+	if status != redirectionStatusSuccess {
+		return errors.New("authentication failed")
+	}
+
+	return nil
+}
+
+// redirectionAuth sends an authentication request and returns the status,
+// authentication type and data of the reply.
+func redirectionAuth(rw io.ReadWriter, authType byte, data []byte) (status, replyType byte, replyData []byte, err error) {
+	request := make([]byte, 9, 9+len(data))
+	request[0] = redirectionAuthenticate
+	request[4] = authType
+	binary.LittleEndian.PutUint32(request[5:], uint32(len(data)))
+
+	if _, err := rw.Write(append(request, data...)); err != nil {
+		return 0, 0, nil, err
+	}
+
+	reply := make([]byte, 9)
+	if _, err := io.ReadFull(rw, reply); err != nil {
+		return 0, 0, nil, err
+	}
+
+	if reply[0] != redirectionAuthenticateReply {
+		return 0, 0, nil, fmt.Errorf("unexpected reply 0x%02x to the authentication", reply[0])
+	}
+
+	replyData = make([]byte, binary.LittleEndian.Uint32(reply[5:]))
+	if _, err := io.ReadFull(rw, replyData); err != nil {
+		return 0, 0, nil, err
+	}
+
+	return reply[1], reply[4], replyData, nil
+}
+
+// lengthPrefixed encodes the fields of an authentication request, each
+// preceded by its length.
+func lengthPrefixed(fields ...string) []byte {
+	var b bytes.Buffer
+
+	for _, field := range fields {
+		b.WriteByte(byte(len(field)))
+		b.WriteString(field)
+	}
+
+	return b.Bytes()
+}
+
+// parseLengthPrefixed decodes the fields of an authentication reply.
+func parseLengthPrefixed(data []byte) ([]string, error) {
+	var fields []string
+
+	for len(data) > 0 {
+		n := int(data[0])
+		if len(data) < 1+n {
+			return nil, io.ErrUnexpectedEOF
+		}
+
+		fields = append(fields, string(data[1:1+n]))
+		data = data[1+n:]
+	}
+
+	return fields, nil
+}
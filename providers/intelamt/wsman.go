@@ -0,0 +1,363 @@
+package intelamt
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec // Intel AMT only supports MD5 HTTP digest authentication
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// WS-Management namespaces and actions, see DSP0226.
+const (
+	nsSOAP       = "http://www.w3.org/2003/05/soap-envelope"
+	nsAddressing = "http://schemas.xmlsoap.org/ws/2004/08/addressing"
+	nsWSMan      = "http://schemas.dmtf.org/wbem/wsman/1/wsman.xsd"
+	nsEnumerate  = "http://schemas.xmlsoap.org/ws/2004/09/enumeration"
+
+	actionGet       = "http://schemas.xmlsoap.org/ws/2004/09/transfer/Get"
+	actionPut       = "http://schemas.xmlsoap.org/ws/2004/09/transfer/Put"
+	actionEnumerate = nsEnumerate + "/Enumerate"
+	actionPull      = nsEnumerate + "/Pull"
+
+	anonymous = nsAddressing + "/role/anonymous"
+)
+
+// node is an XML element of a WS-Management response.
+type node struct {
+	XMLName xml.Name
+	Content string `xml:",chardata"`
+	Nodes   []node `xml:",any"`
+}
+
+// find returns the first descendant element with the local name, depth first.
+func (n *node) find(local string) *node {
+	for i := range n.Nodes {
+		if n.Nodes[i].XMLName.Local == local {
+			return &n.Nodes[i]
+		}
+
+		if found := n.Nodes[i].find(local); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// value returns the trimmed content of the child element with the local name.
+func (n *node) value(local string) string {
+	for i := range n.Nodes {
+		if n.Nodes[i].XMLName.Local == local {
+			return strings.TrimSpace(n.Nodes[i].Content)
+		}
+	}
+
+	return ""
+}
+
+// selector is a WS-Management selector of a resource instance.
+type selector struct {
+	Name  string
+	Value string
+}
+
+// wsman is a WS-Management client of the AMT /wsman endpoint with HTTP digest
+// authentication.
+type wsman struct {
+	endpoint   string
+	user       string
+	pass       string
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	challenge map[string]string
+	nc        uint32
+	messageID uint32
+}
+
+func newWSMan(endpoint, user, pass string, httpClient *http.Client) *wsman {
+	return &wsman{endpoint: endpoint, user: user, pass: pass, httpClient: httpClient}
+}
+
+// get returns the instance of the resource.
+func (w *wsman) get(ctx context.Context, resourceURI string, selectors ...selector) (*node, error) {
+	body, err := w.send(ctx, actionGet, resourceURI, selectors, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body.Nodes) == 0 {
+		return nil, fmt.Errorf("empty response to get %s", resourceURI)
+	}
+
+	return &body.Nodes[0], nil
+}
+
+// put replaces the instance of the resource with the XML encoded instance.
+func (w *wsman) put(ctx context.Context, resourceURI, instance string, selectors ...selector) error {
+	_, err := w.send(ctx, actionPut, resourceURI, selectors, instance)
+
+	return err
+}
+
+// invoke invokes the method of the resource with the XML encoded parameters,
+// a non zero ReturnValue is an error.
+func (w *wsman) invoke(ctx context.Context, resourceURI, method, parameters string, selectors ...selector) error {
+	input := fmt.Sprintf(`<h:%s_INPUT xmlns:h="%s">%s</h:%s_INPUT>`, method, resourceURI, parameters, method)
+
+	body, err := w.send(ctx, resourceURI+"/"+method, resourceURI, selectors, input)
+	if err != nil {
+		return err
+	}
+
+	returnValue := body.find("ReturnValue")
+	if returnValue == nil {
+		return fmt.Errorf("%s: no ReturnValue in the response", method)
+	}
+
+	if value := strings.TrimSpace(returnValue.Content); value != "0" {
+		return fmt.Errorf("%s: ReturnValue %s", method, value)
+	}
+
+	return nil
+}
+
+// enumerate returns all the instances of the resource.
+func (w *wsman) enumerate(ctx context.Context, resourceURI string) ([]node, error) {
+	body, err := w.send(ctx, actionEnumerate, resourceURI, nil, `<Enumerate xmlns="`+nsEnumerate+`"/>`)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []node
+
+	for {
+		enumerationContext := body.find("EnumerationContext")
+		if enumerationContext == nil {
+			return nil, fmt.Errorf("enumerate %s: no EnumerationContext in the response", resourceURI)
+		}
+
+		pull := fmt.Sprintf(`<Pull xmlns="%s"><EnumerationContext>%s</EnumerationContext><MaxElements>100</MaxElements></Pull>`,
+			nsEnumerate, escape(strings.TrimSpace(enumerationContext.Content)))
+
+		body, err = w.send(ctx, actionPull, resourceURI, nil, pull)
+		if err != nil {
+			return nil, err
+		}
+
+		if found := body.find("Items"); found != nil {
+			items = append(items, found.Nodes...)
+		}
+
+		if body.find("EndOfSequence") != nil {
+			return items, nil
+		}
+	}
+}
+
+// send posts the request and returns the SOAP Body of the response.
+func (w *wsman) send(ctx context.Context, action, resourceURI string, selectors []selector, body string) (*node, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.messageID++
+
+	var selectorSet string
+	if len(selectors) > 0 {
+		selectorSet = "<w:SelectorSet>"
+		for _, s := range selectors {
+			selectorSet += fmt.Sprintf(`<w:Selector Name="%s">%s</w:Selector>`, escape(s.Name), escape(s.Value))
+		}
+		selectorSet += "</w:SelectorSet>"
+	}
+
+	envelope := fmt.Sprintf(`<?xml version="1.0" encoding="utf-8"?>`+
+		`<Envelope xmlns="%s" xmlns:a="%s" xmlns:w="%s"><Header>`+
+		`<a:Action>%s</a:Action><a:To>/wsman</a:To><w:ResourceURI>%s</w:ResourceURI>`+
+		`<a:MessageID>%d</a:MessageID><a:ReplyTo><a:Address>%s</a:Address></a:ReplyTo>`+
+		`<w:OperationTimeout>PT60S</w:OperationTimeout>%s</Header><Body>%s</Body></Envelope>`,
+		nsSOAP, nsAddressing, nsWSMan, action, resourceURI, w.messageID, anonymous, selectorSet, body)
+
+	resp, err := w.post(ctx, []byte(envelope))
+	if err != nil {
+		return nil, err
+	}
+
+	// a stale nonce, or the first request, is answered with a new challenge.
+	if resp.StatusCode == http.StatusUnauthorized {
+		w.challenge = parseChallenge(resp.Header.Get("WWW-Authenticate"))
+		w.nc = 0
+		resp.Body.Close()
+
+		if w.challenge == nil {
+			return nil, errors.New("wsman: digest authentication not offered")
+		}
+
+		if resp, err = w.post(ctx, []byte(envelope)); err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("wsman: %w", err)
+	}
+
+	var response struct {
+		Body node `xml:"Body"`
+	}
+
+	if err := xml.Unmarshal(data, &response); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("wsman: invalid response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if fault := response.Body.find("Text"); fault != nil {
+			return nil, fmt.Errorf("wsman: %s: %s", resp.Status, strings.TrimSpace(fault.Content))
+		}
+
+		return nil, fmt.Errorf("wsman: %s", resp.Status)
+	}
+
+	return &response.Body, nil
+}
+
+func (w *wsman) post(ctx context.Context, envelope []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.endpoint, bytes.NewReader(envelope))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/soap+xml; charset=utf-8")
+
+	if w.challenge != nil {
+		req.Header.Set("Authorization", w.authorization(req.URL))
+	}
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("wsman: %w", err)
+	}
+
+	return resp, nil
+}
+
+// authorization returns the digest Authorization header of a request, see
+// RFC 2617.
+func (w *wsman) authorization(u *url.URL) string {
+	w.nc++
+
+	cnonceBytes := make([]byte, 8)
+	_, _ = rand.Read(cnonceBytes)
+	cnonce := hex.EncodeToString(cnonceBytes)
+	nc := fmt.Sprintf("%08x", w.nc)
+
+	realm, nonce, qop := w.challenge["realm"], w.challenge["nonce"], w.challenge["qop"]
+	ha1 := md5Hex(w.user + ":" + realm + ":" + w.pass)
+	ha2 := md5Hex(http.MethodPost + ":" + u.RequestURI())
+
+	header := fmt.Sprintf(`Digest username="%s", realm="%s", nonce="%s", uri="%s"`, w.user, realm, nonce, u.RequestURI())
+
+	if qop == "" {
+		header += fmt.Sprintf(`, response="%s"`, md5Hex(ha1+":"+nonce+":"+ha2))
+	} else {
+		// AMT offers qop="auth" only.
+		header += fmt.Sprintf(`, qop=auth, nc=%s, cnonce="%s", response="%s"`, nc, cnonce, md5Hex(ha1+":"+nonce+":"+nc+":"+cnonce+":auth:"+ha2))
+	}
+
+	if opaque, ok := w.challenge["opaque"]; ok {
+		header += fmt.Sprintf(`, opaque="%s"`, opaque)
+	}
+
+	return header
+}
+
+// parseChallenge returns the parameters of a Digest WWW-Authenticate header,
+// nil for another scheme.
+func parseChallenge(header string) map[string]string {
+	scheme, params, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(scheme, "Digest") {
+		return nil
+	}
+
+	challenge := map[string]string{}
+
+	for params != "" {
+		var name, value string
+
+		name, params, _ = strings.Cut(params, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		params = strings.TrimSpace(params)
+
+		if strings.HasPrefix(params, `"`) {
+			value, params, _ = strings.Cut(params[1:], `"`)
+			_, params, _ = strings.Cut(params, ",")
+		} else {
+			value, params, _ = strings.Cut(params, ",")
+		}
+
+		challenge[name] = strings.TrimSpace(value)
+	}
+
+	return challenge
+}
+
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s)) //nolint:gosec // see the import
+	return hex.EncodeToString(sum[:])
+}
+
+// escape returns s escaped for XML content and attribute values.
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+
+	return b.String()
+}
+
+// instance returns the XML encoded instance of the resource for a put, the
+// properties of the current instance with the values replaced and the read only
+// properties left out.
+func instance(resourceURI string, current *node, values map[string]string, readOnly map[string]bool) string {
+	class := current.XMLName.Local
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, `<h:%s xmlns:h="%s">`, class, resourceURI)
+
+	for _, property := range current.Nodes {
+		name := property.XMLName.Local
+		if readOnly[name] {
+			continue
+		}
+
+		value, ok := values[name]
+		if !ok {
+			value = escape(strings.TrimSpace(property.Content))
+		}
+
+		fmt.Fprintf(&b, "<h:%s>%s</h:%s>", name, value, name)
+	}
+
+	fmt.Fprintf(&b, "</h:%s>", class)
+
+	return b.String()
+}
+
+// endpointReference returns the XML of an endpoint reference parameter to the
+// resource instance with the InstanceID.
+func endpointReference(parameter, resourceURI, instanceID string) string {
+	return fmt.Sprintf(`<h:%s><a:Address>%s</a:Address><a:ReferenceParameters>`+
+		`<w:ResourceURI>%s</w:ResourceURI><w:SelectorSet><w:Selector Name="InstanceID">%s</w:Selector></w:SelectorSet>`+
+		`</a:ReferenceParameters></h:%s>`, parameter, nsAddressing, resourceURI, escape(instanceID), parameter)
+}
@@ -0,0 +1,281 @@
+package intelamt
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	testUser  = "admin"
+	testPass  = "P@ssw0rd"
+	testRealm = "Digest:0123456789ABCDEF"
+	testNonce = "5f2e8f7a0b3c"
+)
+
+// fakeAMT is a WS-Management endpoint with digest authentication answering
+// with the instances of its resources.
+type fakeAMT struct {
+	t         *testing.T
+	instances map[string][]string
+
+	mu       sync.Mutex
+	requests []fakeRequest
+}
+
+type fakeRequest struct {
+	Action      string
+	ResourceURI string
+	Body        string
+}
+
+func newFakeAMT(t *testing.T, instances map[string][]string) (*fakeAMT, *Conn) {
+	t.Helper()
+
+	f := &fakeAMT{t: t, instances: instances}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+
+	return f, &Conn{client: &mock{}, wsman: newWSMan(server.URL+"/wsman", testUser, testPass, server.Client())}
+}
+
+func (f *fakeAMT) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	challenge := parseChallenge(r.Header.Get("Authorization"))
+	if challenge == nil || !f.validDigest(challenge) {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Digest realm="%s", nonce="%s", stale="false", qop="auth"`, testRealm, testNonce))
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	var envelope struct {
+		Action      string `xml:"Header>Action"`
+		ResourceURI string `xml:"Header>ResourceURI"`
+		Body        struct {
+			Inner string `xml:",innerxml"`
+		} `xml:"Body"`
+	}
+
+	if err := xml.NewDecoder(r.Body).Decode(&envelope); err != nil {
+		f.t.Errorf("invalid request: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	f.mu.Lock()
+	f.requests = append(f.requests, fakeRequest{Action: envelope.Action, ResourceURI: envelope.ResourceURI, Body: envelope.Body.Inner})
+	f.mu.Unlock()
+
+	var body string
+
+	switch envelope.Action {
+	case actionGet:
+		body = f.instances[envelope.ResourceURI][0]
+	case actionPut:
+		body = envelope.Body.Inner
+	case actionEnumerate:
+		body = `<g:EnumerateResponse xmlns:g="` + nsEnumerate + `"><g:EnumerationContext>ctx-1</g:EnumerationContext></g:EnumerateResponse>`
+	case actionPull:
+		body = `<g:PullResponse xmlns:g="` + nsEnumerate + `"><g:Items>` + strings.Join(f.instances[envelope.ResourceURI], "") +
+			`</g:Items><g:EndOfSequence/></g:PullResponse>`
+	default:
+		method := envelope.Action[strings.LastIndex(envelope.Action, "/")+1:]
+		body = fmt.Sprintf(`<h:%s_OUTPUT xmlns:h="%s"><h:ReturnValue>0</h:ReturnValue></h:%s_OUTPUT>`, method, envelope.ResourceURI, method)
+	}
+
+	w.Header().Set("Content-Type", "application/soap+xml; charset=utf-8")
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><a:Envelope xmlns:a="%s"><a:Header/><a:Body>%s</a:Body></a:Envelope>`, nsSOAP, body)
+}
+
+func (f *fakeAMT) validDigest(params map[string]string) bool {
+	if params["username"] != testUser || params["nonce"] != testNonce {
+		return false
+	}
+
+	ha1 := md5Hex(testUser + ":" + testRealm + ":" + testPass)
+	ha2 := md5Hex("POST:" + params["uri"])
+
+	return params["response"] == md5Hex(ha1+":"+testNonce+":"+params["nc"]+":"+params["cnonce"]+":auth:"+ha2)
+}
+
+func (f *fakeAMT) request(action string) *fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := range f.requests {
+		if f.requests[i].Action == action {
+			return &f.requests[i]
+		}
+	}
+
+	return nil
+}
+
+const bootSettingData = `<h:AMT_BootSettingData xmlns:h="` + uriBootSettingData + `">
+<h:BIOSLastStatus>2</h:BIOSLastStatus><h:BIOSLastStatus>0</h:BIOSLastStatus>
+<h:BIOSPause>false</h:BIOSPause><h:BIOSSetup>false</h:BIOSSetup>
+<h:BootMediaIndex>0</h:BootMediaIndex><h:ConfigurationDataReset>false</h:ConfigurationDataReset>
+<h:ElementName>Intel(r) AMT Boot Configuration Settings</h:ElementName>
+<h:FirmwareVerbosity>0</h:FirmwareVerbosity><h:ForcedProgressEvents>false</h:ForcedProgressEvents>
+<h:IDERBootDevice>0</h:IDERBootDevice><h:InstanceID>Intel(r) AMT:BootSettingData 0</h:InstanceID>
+<h:LockKeyboard>false</h:LockKeyboard><h:OptionsCleared>true</h:OptionsCleared>
+<h:UseIDER>false</h:UseIDER><h:UseSOL>false</h:UseSOL><h:UseSafeMode>false</h:UseSafeMode>
+</h:AMT_BootSettingData>`
+
+// Requirement: disk and bios boot devices put the boot settings
+// without their read only properties, change the boot order and set the boot
+// configuration for the next boot.
+func TestBootDeviceSetWSMan(t *testing.T) {
+	tests := map[string]struct {
+		device      string
+		settings    []string
+		bootOrder   string
+		noBootOrder bool
+	}{
+		"disk": {
+			device:    "disk",
+			settings:  []string{"<h:UseIDER>false</h:UseIDER>", "<h:IDERBootDevice>0</h:IDERBootDevice>", "<h:BIOSSetup>false</h:BIOSSetup>"},
+			bootOrder: "Intel(r) AMT: Force Hard-drive Boot",
+		},
+		"bios": {
+			device:      "bios",
+			settings:    []string{"<h:BIOSSetup>true</h:BIOSSetup>", "<h:UseIDER>false</h:UseIDER>"},
+			noBootOrder: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, conn := newFakeAMT(t, map[string][]string{uriBootSettingData: {bootSettingData}})
+
+			ok, err := conn.BootDeviceSet(context.Background(), tt.device, false, false)
+			if err != nil || !ok {
+				t.Fatalf("BootDeviceSet() = %v, %v", ok, err)
+			}
+
+			put := f.request(actionPut)
+			if put == nil {
+				t.Fatal("no Put of AMT_BootSettingData")
+			}
+
+			for _, want := range append(tt.settings, "<h:UseSOL>false</h:UseSOL>") {
+				if !strings.Contains(put.Body, want) {
+					t.Fatalf("Put body %s does not contain %s", put.Body, want)
+				}
+			}
+
+			if strings.Contains(put.Body, "BIOSLastStatus") || strings.Contains(put.Body, "OptionsCleared") {
+				t.Fatalf("Put body %s contains read only properties", put.Body)
+			}
+
+			order := f.request(uriBootConfigSetting + "/ChangeBootOrder")
+			if order == nil {
+				t.Fatal("no ChangeBootOrder")
+			}
+
+			if tt.noBootOrder == strings.Contains(order.Body, "Source") || !strings.Contains(order.Body, tt.bootOrder) {
+				t.Fatalf("ChangeBootOrder body %s, want source %q", order.Body, tt.bootOrder)
+			}
+
+			role := f.request(uriBootService + "/SetBootConfigRole")
+			if role == nil || !strings.Contains(role.Body, "<h:Role>1</h:Role>") || !strings.Contains(role.Body, bootConfigSetting) {
+				t.Fatalf("SetBootConfigRole = %+v", role)
+			}
+		})
+	}
+}
+
+// Requirement: an unknown boot device and the IDE-R boot devices without an
+// attached image are rejected without a request.
+func TestBootDeviceSetUnsupported(t *testing.T) {
+	for _, device := range []string{"usb", "cdrom", "Floppy"} {
+		t.Run(device, func(t *testing.T) {
+			f, conn := newFakeAMT(t, nil)
+
+			if ok, err := conn.BootDeviceSet(context.Background(), device, false, false); err == nil || ok {
+				t.Fatalf("BootDeviceSet() = %v, %v, want an error", ok, err)
+			}
+
+			if len(f.requests) != 0 {
+				t.Fatalf("requests = %+v", f.requests)
+			}
+		})
+	}
+}
+
+// Requirement: the inventory is read from the chassis, processor, physical
+// memory and ethernet port settings classes.
+func TestInventory(t *testing.T) {
+	_, conn := newFakeAMT(t, map[string][]string{
+		uriChassis: {`<h:CIM_Chassis xmlns:h="` + uriChassis + `"><h:ChassisPackageType>35</h:ChassisPackageType>
+<h:ElementName>Managed System Chassis</h:ElementName><h:Manufacturer>Intel Corporation</h:Manufacturer>
+<h:Model>NUC11TNHi5</h:Model><h:SerialNumber>G6TN12345678</h:SerialNumber><h:Tag>CIM_Chassis</h:Tag>
+<h:Version>M11904-403</h:Version></h:CIM_Chassis>`},
+		uriProcessor: {`<h:CIM_Processor xmlns:h="` + uriProcessor + `"><h:CPUStatus>1</h:CPUStatus>
+<h:CurrentClockSpeed>2400</h:CurrentClockSpeed><h:DeviceID>CPU 0</h:DeviceID><h:ElementName>Managed System CPU</h:ElementName>
+<h:EnabledState>2</h:EnabledState><h:Family>205</h:Family><h:HealthState>5</h:HealthState>
+<h:MaxClockSpeed>8300</h:MaxClockSpeed><h:Stepping>1</h:Stepping></h:CIM_Processor>`},
+		uriPhysicalMemory: {
+			`<h:CIM_PhysicalMemory xmlns:h="` + uriPhysicalMemory + `"><h:BankLabel>BANK 0</h:BankLabel>
+<h:Capacity>17179869184</h:Capacity><h:ConfiguredMemoryClockSpeed>3200</h:ConfiguredMemoryClockSpeed>
+<h:ElementName>Managed System Memory Chip</h:ElementName><h:FormFactor>12</h:FormFactor>
+<h:Manufacturer>Samsung</h:Manufacturer><h:MemoryType>26</h:MemoryType><h:PartNumber>M471A2K43EB1-CWE</h:PartNumber>
+<h:SerialNumber>12345678</h:SerialNumber><h:Tag>9876543210</h:Tag></h:CIM_PhysicalMemory>`,
+		},
+		uriEthernetPortSettings: {
+			`<h:AMT_EthernetPortSettings xmlns:h="` + uriEthernetPortSettings + `"><h:DHCPEnabled>true</h:DHCPEnabled>
+<h:ElementName>Intel(r) AMT Ethernet Port Settings</h:ElementName><h:IPAddress>10.0.0.21</h:IPAddress>
+<h:InstanceID>Intel(r) AMT Ethernet Port Settings 0</h:InstanceID><h:LinkIsUp>true</h:LinkIsUp>
+<h:MACAddress>88-ae-dd-01-02-03</h:MACAddress><h:SharedMAC>true</h:SharedMAC></h:AMT_EthernetPortSettings>`,
+			`<h:AMT_EthernetPortSettings xmlns:h="` + uriEthernetPortSettings + `">
+<h:InstanceID>Intel(r) AMT Ethernet Port Settings 1</h:InstanceID><h:LinkIsUp>false</h:LinkIsUp>
+<h:MACAddress>00-00-00-00-00-00</h:MACAddress></h:AMT_EthernetPortSettings>`,
+			`<h:AMT_EthernetPortSettings xmlns:h="` + uriEthernetPortSettings + `">
+<h:InstanceID>Intel(r) AMT Ethernet Port Settings 2</h:InstanceID></h:AMT_EthernetPortSettings>`,
+		},
+	})
+
+	device, err := conn.Inventory(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if device.Model != "NUC11TNHi5" || device.Serial != "G6TN12345678" || device.Vendor == "" || len(device.Enclosures) != 1 {
+		t.Fatalf("device = %+v", device.Common)
+	}
+
+	if len(device.CPUs) != 1 {
+		t.Fatalf("CPUs = %+v", device.CPUs)
+	}
+
+	if cpu := device.CPUs[0]; cpu.ID != "CPU 0" || cpu.ClockSpeedHz != 8_300_000_000 || cpu.Status == nil || cpu.Status.Health != "OK" {
+		t.Fatalf("CPU = %+v", cpu)
+	}
+
+	if len(device.Memory) != 1 {
+		t.Fatalf("Memory = %+v", device.Memory)
+	}
+
+	if m := device.Memory[0]; m.Slot != "BANK 0" || m.SizeBytes != 17179869184 || m.Type != "DDR4" || m.FormFactor != "SODIMM" ||
+		m.ClockSpeedHz != 3_200_000_000 || m.PartNumber != "M471A2K43EB1-CWE" {
+		t.Fatalf("Memory = %+v", m)
+	}
+
+	if len(device.NICs) != 2 {
+		t.Fatalf("NICs = %+v", device.NICs)
+	}
+
+	if port := device.NICs[0].NICPorts[0]; port.MacAddress != "88:ae:dd:01:02:03" || port.LinkStatus != "Up" || port.Metadata["ip_address"] != "10.0.0.21" {
+		t.Fatalf("NIC port = %+v", port)
+	}
+
+	if port := device.NICs[1].NICPorts[0]; port.LinkStatus != "Down" {
+		t.Fatalf("NIC port = %+v", port)
+	}
+}
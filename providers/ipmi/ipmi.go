@@ -7,10 +7,12 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/jacobweinstock/registrar"
 
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
 	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
 	"github.com/bmc-toolbox/bmclib/v2/internal/goipmi"
 	"github.com/bmc-toolbox/bmclib/v2/providers"
@@ -33,7 +35,17 @@ var Features = registrar.Features{
 	providers.FeatureClearSystemEventLog,
 	providers.FeatureGetSystemEventLog,
 	providers.FeatureGetSystemEventLogRaw,
+	providers.FeatureGetSystemEventLogEntries,
 	providers.FeatureDeactivateSOL,
+	providers.FeaturePowerConsumption,
+	providers.FeatureSetPowerLimit,
+	providers.FeatureSensorsRead,
+	providers.FeatureSetIdentifyLED,
+	providers.FeatureGetIdentifyLED,
+	providers.FeatureGetBMCNetwork,
+	providers.FeatureSetBMCNetwork,
+	providers.FeatureGetBMCTime,
+	providers.FeatureSetBMCTime,
 }
 
 // Conn for IPMI connection details
@@ -222,6 +234,66 @@ func (c *Conn) GetSystemEventLog(ctx context.Context) (entries [][]string, err e
 	return c.ipmi.GetSystemEventLog(ctx)
 }
 
+// GetSystemEventLogEntries returns the BMC System Event Log (SEL) as typed entries.
+func (c *Conn) GetSystemEventLogEntries(ctx context.Context) (entries []bmc.SystemEventLogEntry, err error) {
+	return c.ipmi.GetSystemEventLogEntries(ctx)
+}
+
+// PowerConsumption returns the DCMI power reading and power limit
+func (c *Conn) PowerConsumption(ctx context.Context) (consumption *bmc.PowerConsumption, err error) {
+	return c.ipmi.PowerConsumption(ctx)
+}
+
+// SetPowerLimit sets the DCMI power limit
+func (c *Conn) SetPowerLimit(ctx context.Context, limit bmc.PowerLimit) (err error) {
+	return c.ipmi.SetPowerLimit(ctx, limit)
+}
+
+// Sensors returns the sensor readings from the BMC Sensor Data Repository (SDR).
+func (c *Conn) Sensors(ctx context.Context) (sensors []bmc.SensorReading, err error) {
+	return c.ipmi.Sensors(ctx)
+}
+
+// SetIdentifyLED turns the chassis identify LED on or off
+func (c *Conn) SetIdentifyLED(ctx context.Context, on bool, duration time.Duration) (err error) {
+	return c.ipmi.SetIdentifyLED(ctx, on, duration)
+}
+
+// GetIdentifyLED returns the chassis identify LED state
+func (c *Conn) GetIdentifyLED(ctx context.Context) (on bool, err error) {
+	return c.ipmi.GetIdentifyLED(ctx)
+}
+
+// GetBMCNetwork returns the IPv4 and VLAN configuration of the BMC LAN channel
+func (c *Conn) GetBMCNetwork(ctx context.Context) (config *bmc.BMCNetworkConfig, err error) {
+	return c.ipmi.GetBMCNetwork(ctx)
+}
+
+// SetBMCNetwork applies the IPv4 and VLAN configuration to the BMC LAN channel
+func (c *Conn) SetBMCNetwork(ctx context.Context, config bmc.BMCNetworkConfig) (err error) {
+	return c.ipmi.SetBMCNetwork(ctx, config)
+}
+
+// GetBMCTime returns the SEL clock
+func (c *Conn) GetBMCTime(ctx context.Context) (bmcTime *bmc.BMCTime, err error) {
+	return c.ipmi.GetBMCTime(ctx)
+}
+
+// SetBMCTime sets the SEL clock
+func (c *Conn) SetBMCTime(ctx context.Context, t time.Time) (err error) {
+	return c.ipmi.SetBMCTime(ctx, t)
+}
+
+// SetNTPServers is not supported over IPMI
+func (c *Conn) SetNTPServers(ctx context.Context, servers []string) (err error) {
+	return c.ipmi.SetNTPServers(ctx, servers)
+}
+
+// KeepSessionAlive refreshes the IPMI session, an expired session is re-established
+func (c *Conn) KeepSessionAlive(ctx context.Context) (err error) {
+	return c.ipmi.KeepSessionAlive(ctx)
+}
+
 // GetSystemEventLogRaw returns the raw BMC System Event Log (SEL).
 func (c *Conn) GetSystemEventLogRaw(ctx context.Context) (eventlog string, err error) {
 	return c.ipmi.GetSystemEventLogRaw(ctx)
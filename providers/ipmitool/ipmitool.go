@@ -5,10 +5,12 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/jacobweinstock/registrar"
 
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
 	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
 	"github.com/bmc-toolbox/bmclib/v2/internal/ipmi"
 	"github.com/bmc-toolbox/bmclib/v2/providers"
@@ -31,7 +33,14 @@ var Features = registrar.Features{
 	providers.FeatureClearSystemEventLog,
 	providers.FeatureGetSystemEventLog,
 	providers.FeatureGetSystemEventLogRaw,
+	providers.FeatureGetSystemEventLogEntries,
 	providers.FeatureDeactivateSOL,
+	providers.FeaturePowerConsumption,
+	providers.FeatureSetPowerLimit,
+	providers.FeatureSetIdentifyLED,
+	providers.FeatureGetIdentifyLED,
+	providers.FeatureGetBMCNetwork,
+	providers.FeatureSetBMCNetwork,
 }
 
 // Conn for Ipmitool connection details
@@ -207,6 +216,41 @@ func (c *Conn) GetSystemEventLog(ctx context.Context) (entries [][]string, err e
 	return c.ipmitool.GetSystemEventLog(ctx)
 }
 
+// GetSystemEventLogEntries returns the BMC System Event Log (SEL) as typed entries.
+func (c *Conn) GetSystemEventLogEntries(ctx context.Context) (entries []bmc.SystemEventLogEntry, err error) {
+	return c.ipmitool.GetSystemEventLogEntries(ctx)
+}
+
+// PowerConsumption returns the DCMI power reading and power limit
+func (c *Conn) PowerConsumption(ctx context.Context) (consumption *bmc.PowerConsumption, err error) {
+	return c.ipmitool.PowerConsumption(ctx)
+}
+
+// SetPowerLimit sets the DCMI power limit
+func (c *Conn) SetPowerLimit(ctx context.Context, limit bmc.PowerLimit) (err error) {
+	return c.ipmitool.SetPowerLimit(ctx, limit)
+}
+
+// SetIdentifyLED turns the chassis identify LED on or off
+func (c *Conn) SetIdentifyLED(ctx context.Context, on bool, duration time.Duration) (err error) {
+	return c.ipmitool.SetIdentifyLED(ctx, on, duration)
+}
+
+// GetIdentifyLED returns the chassis identify LED state
+func (c *Conn) GetIdentifyLED(ctx context.Context) (on bool, err error) {
+	return c.ipmitool.GetIdentifyLED(ctx)
+}
+
+// GetBMCNetwork returns the IPv4 and VLAN configuration of the BMC LAN channel
+func (c *Conn) GetBMCNetwork(ctx context.Context) (config *bmc.BMCNetworkConfig, err error) {
+	return c.ipmitool.GetBMCNetwork(ctx)
+}
+
+// SetBMCNetwork applies the IPv4 and VLAN configuration to the BMC LAN channel
+func (c *Conn) SetBMCNetwork(ctx context.Context, config bmc.BMCNetworkConfig) (err error) {
+	return c.ipmitool.SetBMCNetwork(ctx, config)
+}
+
 // GetSystemEventLogRaw returns the raw BMC System Event Log (SEL).
 func (c *Conn) GetSystemEventLogRaw(ctx context.Context) (eventlog string, err error) {
 	return c.ipmitool.GetSystemEventLogRaw(ctx)
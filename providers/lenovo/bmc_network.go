@@ -0,0 +1,24 @@
+package lenovo
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// compile-time assertion that the provider implements the interface.
+var _ bmc.BMCNetworkConfigurator = (*Conn)(nil)
+
+// GetBMCNetwork returns the XCC management network configuration.
+//
+// Implements bmc.BMCNetworkConfigurator.
+func (c *Conn) GetBMCNetwork(ctx context.Context) (config *bmc.BMCNetworkConfig, err error) {
+	return c.redfishwrapper.GetBMCNetwork(ctx)
+}
+
+// SetBMCNetwork applies the configuration to the XCC management interface.
+//
+// Implements bmc.BMCNetworkConfigurator.
+func (c *Conn) SetBMCNetwork(ctx context.Context, config bmc.BMCNetworkConfig) (err error) {
+	return c.redfishwrapper.SetBMCNetwork(ctx, config)
+}
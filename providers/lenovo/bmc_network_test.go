@@ -0,0 +1,42 @@
+package lenovo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// Requirement: BMC management network read.
+func TestGetBMCNetwork(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	config, err := c.GetBMCNetwork(context.Background())
+	if err != nil {
+		t.Fatalf("GetBMCNetwork: %v", err)
+	}
+	if config.InterfaceID != "eth0" || config.Hostname != "XCC-SR650" {
+		t.Errorf("GetBMCNetwork = %+v, want the eth0 interface", config)
+	}
+	if config.IPv4 == nil || config.IPv4.Address != "10.0.0.50" || config.IPv4.Gateway != "10.0.0.1" {
+		t.Errorf("IPv4 = %+v, want 10.0.0.50 via 10.0.0.1", config.IPv4)
+	}
+	// the link local IPv6 address is not part of the configuration.
+	if len(config.IPv6) != 0 {
+		t.Errorf("IPv6 = %+v, want none", config.IPv6)
+	}
+}
+
+// Requirement: BMC management network set.
+func TestSetBMCNetwork(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	if err := c.SetBMCNetwork(context.Background(), bmc.BMCNetworkConfig{DHCPv4: true}); err != nil {
+		t.Fatalf("SetBMCNetwork: %v", err)
+	}
+	if !ts.didPatchBMCEthernet() {
+		t.Fatal("expected the eth0 interface to be PATCHed")
+	}
+}
@@ -0,0 +1,132 @@
+package lenovo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// compile-time assertions that the provider implements the interfaces.
+var (
+	_ bmc.BootOrderGetter = (*Conn)(nil)
+	_ bmc.BootOrderSetter = (*Conn)(nil)
+)
+
+// oemBootOrderPath is the XCC OEM boot order resource, relative to the ComputerSystem.
+const oemBootOrderPath = "/Oem/Lenovo/BootSettings/BootOrder.BootOrder"
+
+// oemBootOrder is the XCC OEM boot order resource, the boot order entries are
+// boot device names (e.g. "Network", "Hard Disk 0") rather than UEFI boot
+// option references.
+type oemBootOrder struct {
+	BootOrderCurrent   []string `json:"BootOrderCurrent"`
+	BootOrderNext      []string `json:"BootOrderNext"`
+	BootOrderSupported []string `json:"BootOrderSupported"`
+}
+
+// GetBootOrder returns the persistent boot order.
+//
+// XCC firmware that implements the standard Boot.BootOrder is read through the
+// shared wrapper. Earlier XCC firmware only exposes the boot order through the
+// OEM BootSettings resource; its entries are device names, so the name doubles
+// as the boot option reference, and the pending (next) order is returned when
+// one is staged.
+//
+// Implements bmc.BootOrderGetter.
+func (c *Conn) GetBootOrder(ctx context.Context) (order []bmc.BootOption, err error) {
+	sys, err := c.redfishwrapper.System()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sys.Boot.BootOrder) > 0 {
+		return c.redfishwrapper.GetBootOrder(ctx)
+	}
+
+	var doc oemBootOrder
+	if err := c.getJSON(sys.ODataID+oemBootOrderPath, &doc); err != nil {
+		return nil, err
+	}
+
+	names := doc.BootOrderNext
+	if len(names) == 0 {
+		names = doc.BootOrderCurrent
+	}
+
+	order = make([]bmc.BootOption, 0, len(names))
+	for _, name := range names {
+		order = append(order, bmc.BootOption{Reference: name, DisplayName: name, Enabled: true})
+	}
+
+	return order, nil
+}
+
+// SetBootOrder sets the persistent boot order, staged for the next host reset.
+//
+// XCC quirk: XCC rejects boot order changes on the ComputerSystem itself and
+// only accepts them on its @Redfish.Settings resource (/Systems/1/Pending), so
+// the boot order is written there directly instead of letting the shared
+// wrapper fail over after a rejected PATCH. On XCC firmware without the
+// standard Boot.BootOrder the OEM BootOrderNext is written instead, which
+// accepts any of the BootOrderSupported device names.
+//
+// Implements bmc.BootOrderSetter.
+func (c *Conn) SetBootOrder(ctx context.Context, order []string) (err error) {
+	sys, err := c.redfishwrapper.System()
+	if err != nil {
+		return err
+	}
+
+	if len(sys.Boot.BootOrder) > 0 {
+		return c.redfishwrapper.SetPendingBootOrder(ctx, order)
+	}
+
+	target := sys.ODataID + oemBootOrderPath
+
+	var doc oemBootOrder
+	if err := c.getJSON(target, &doc); err != nil {
+		return err
+	}
+
+	next, err := oemNextBootOrder(doc, order)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{"BootOrderNext": next}
+
+	return checkResponse(c.redfishwrapper.PatchWithHeaders(ctx, target, payload, nil)) //nolint:bodyclose // checkResponse closes the response body
+}
+
+// oemNextBootOrder returns the OEM BootOrderNext for the requested order. The
+// requested devices must be listed in BootOrderSupported, the devices in the
+// current order that were not requested follow in their existing order.
+func oemNextBootOrder(doc oemBootOrder, order []string) ([]string, error) {
+	supported := make(map[string]bool, len(doc.BootOrderSupported))
+	for _, name := range doc.BootOrderSupported {
+		supported[name] = true
+	}
+
+	requested := make(map[string]bool, len(order))
+	for _, name := range order {
+		if !supported[name] {
+			return nil, fmt.Errorf("boot device %q is not in the XCC supported boot order %v", name, doc.BootOrderSupported)
+		}
+		requested[name] = true
+	}
+
+	current := doc.BootOrderNext
+	if len(current) == 0 {
+		current = doc.BootOrderCurrent
+	}
+
+	next := append(make([]string, 0, len(current)+len(order)), order...)
+	for _, name := range current {
+		if !requested[name] {
+			next = append(next, name)
+		}
+	}
+
+	return next, nil
+}
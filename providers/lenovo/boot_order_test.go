@@ -0,0 +1,85 @@
+package lenovo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// Requirement: Persistent boot order read.
+func TestGetBootOrder(t *testing.T) {
+	t.Run("standard boot order", func(t *testing.T) {
+		ts := newTestServer(t, testServerOpts{systemFixture: "system.bootorder.json"})
+		c := ts.openedClient(t)
+
+		order, err := c.GetBootOrder(context.Background())
+		if err != nil {
+			t.Fatalf("GetBootOrder: %v", err)
+		}
+		if len(order) != 3 {
+			t.Fatalf("len(order) = %d, want 3", len(order))
+		}
+		if got := order[2].DisplayName; got != "Slot 4 Port 1: UEFI PXE IPv4 Intel(R) Ethernet Controller X710" {
+			t.Errorf("order[2].DisplayName = %q, want the Boot0003 boot option name", got)
+		}
+	})
+
+	t.Run("OEM boot order on earlier XCC firmware", func(t *testing.T) {
+		ts := newTestServer(t, testServerOpts{})
+		c := ts.openedClient(t)
+
+		order, err := c.GetBootOrder(context.Background())
+		if err != nil {
+			t.Fatalf("GetBootOrder: %v", err)
+		}
+		if len(order) != 3 || order[0].Reference != "CD/DVD Rom" || !order[0].Enabled {
+			t.Fatalf("GetBootOrder = %+v, want the OEM BootOrderNext devices", order)
+		}
+	})
+}
+
+// Requirement: Persistent boot order set.
+func TestSetBootOrder(t *testing.T) {
+	t.Run("standard boot order is written to the settings resource", func(t *testing.T) {
+		ts := newTestServer(t, testServerOpts{systemFixture: "system.bootorder.json"})
+		c := ts.openedClient(t)
+
+		if err := c.SetBootOrder(context.Background(), []string{"Boot0003"}); err != nil {
+			t.Fatalf("SetBootOrder: %v", err)
+		}
+		// XCC rejects boot order changes on the ComputerSystem itself.
+		if ts.didPatchSystem() {
+			t.Fatal("the ComputerSystem must not be PATCHed")
+		}
+
+		body := ts.bootOrderPatch("/redfish/v1/Systems/1/Pending")
+		want := map[string]any{"Boot": map[string]any{"BootOrder": []any{"Boot0003", "Boot0001", "Boot0002"}}}
+		if !reflect.DeepEqual(body, want) {
+			t.Fatalf("Pending PATCH body = %v, want %v", body, want)
+		}
+	})
+
+	t.Run("OEM boot order on earlier XCC firmware", func(t *testing.T) {
+		ts := newTestServer(t, testServerOpts{})
+		c := ts.openedClient(t)
+
+		if err := c.SetBootOrder(context.Background(), []string{"Network", "USB Storage"}); err != nil {
+			t.Fatalf("SetBootOrder: %v", err)
+		}
+
+		body := ts.bootOrderPatch("/redfish/v1/Systems/1/Oem/Lenovo/BootSettings/BootOrder.BootOrder")
+		want := map[string]any{"BootOrderNext": []any{"Network", "USB Storage", "CD/DVD Rom", "Hard Disk"}}
+		if !reflect.DeepEqual(body, want) {
+			t.Fatalf("OEM PATCH body = %v, want %v", body, want)
+		}
+	})
+
+	t.Run("unsupported OEM boot device errors", func(t *testing.T) {
+		ts := newTestServer(t, testServerOpts{})
+		c := ts.openedClient(t)
+
+		if err := c.SetBootOrder(context.Background(), []string{"Floppy"}); err == nil {
+			t.Fatal("expected an error for a boot device XCC does not support")
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package lenovo
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// compile-time assertion that the provider implements the interface.
+var _ bmc.CertificateManager = (*Conn)(nil)
+
+// GenerateCSR has the XCC generate a key pair and returns the certificate signing request.
+//
+// Implements bmc.CertificateManager.
+func (c *Conn) GenerateCSR(ctx context.Context, request bmc.CSRRequest) (csr string, err error) {
+	return c.redfishwrapper.GenerateCSR(ctx, request)
+}
+
+// ReplaceCertificate installs the certificate in place of the given XCC certificate, or in place
+// of the XCC HTTPS certificate when certificateID is empty.
+//
+// Implements bmc.CertificateManager.
+func (c *Conn) ReplaceCertificate(ctx context.Context, certificateID, certificate string) (err error) {
+	return c.redfishwrapper.ReplaceCertificate(ctx, certificateID, certificate)
+}
+
+// ListCertificates returns the XCC certificates.
+//
+// Implements bmc.CertificateManager.
+func (c *Conn) ListCertificates(ctx context.Context) (certificates []bmc.Certificate, err error) {
+	return c.redfishwrapper.ListCertificates(ctx)
+}
@@ -0,0 +1,48 @@
+package lenovo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// Requirement: BMC certificate signing request generation.
+func TestGenerateCSR(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	csr, err := c.GenerateCSR(context.Background(), bmc.CSRRequest{CommonName: "xcc-7z60.example.com"})
+	if err != nil {
+		t.Fatalf("GenerateCSR: %v", err)
+	}
+	if !strings.HasPrefix(csr, "-----BEGIN CERTIFICATE REQUEST-----") {
+		t.Errorf("GenerateCSR = %q, want a PEM encoded CSR", csr)
+	}
+	if !ts.didGenerateCSR() {
+		t.Fatal("expected the GenerateCSR action to be posted")
+	}
+}
+
+// Requirement: BMC certificate replacement and listing.
+func TestCertificates(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	certificates, err := c.ListCertificates(context.Background())
+	if err != nil {
+		t.Fatalf("ListCertificates: %v", err)
+	}
+	if len(certificates) != 1 || certificates[0].Subject != "XCC-7Z60-SN" || certificates[0].ValidNotAfter.Year() != 2033 {
+		t.Fatalf("ListCertificates = %+v, want the XCC HTTPS certificate", certificates)
+	}
+
+	// an empty certificate ID replaces the HTTPS certificate.
+	if err := c.ReplaceCertificate(context.Background(), "", "-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n"); err != nil {
+		t.Fatalf("ReplaceCertificate: %v", err)
+	}
+	if !ts.didReplaceCertificate() {
+		t.Fatal("expected the ReplaceCertificate action to be posted")
+	}
+}
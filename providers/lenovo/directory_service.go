@@ -0,0 +1,59 @@
+package lenovo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+)
+
+// compile-time assertion that the provider implements the interface.
+var _ bmc.DirectoryServiceConfigurator = (*Conn)(nil)
+
+// errUnknownDirectoryRole is returned when a directory role mapping names a role
+// the XCC does not define.
+var errUnknownDirectoryRole = errors.New("unknown XCC role")
+
+// xccDirectoryServiceProperty is the AccountService property holding the XCC
+// directory service. XCC has a single LDAP client that also serves Active
+// Directory domain controllers, it does not expose an ActiveDirectory property.
+const xccDirectoryServiceProperty = string(bmc.DirectoryServiceLDAP)
+
+// GetDirectoryService returns the XCC LDAP client configuration, reported with
+// the requested directory service type.
+//
+// Implements bmc.DirectoryServiceConfigurator.
+func (c *Conn) GetDirectoryService(ctx context.Context, serviceType bmc.DirectoryServiceType) (config *bmc.DirectoryServiceConfig, err error) {
+	return c.redfishwrapper.DirectoryServiceFromProperty(ctx, xccDirectoryServiceProperty, serviceType)
+}
+
+// SetDirectoryService configures the XCC LDAP client, Active Directory
+// configurations are written to the same LDAP client.
+//
+// XCC role mappings may name custom roles, the mapped roles are checked against
+// the XCC roles so that an unknown role fails before anything is changed.
+//
+// Implements bmc.DirectoryServiceConfigurator.
+func (c *Conn) SetDirectoryService(ctx context.Context, config bmc.DirectoryServiceConfig) (err error) {
+	if len(config.RoleMappings) > 0 {
+		roles, err := c.Roles(ctx)
+		if err != nil {
+			return fmt.Errorf("querying XCC roles: %w", err)
+		}
+
+		known := make(map[string]bool, len(roles))
+		for _, role := range roles {
+			known[role.ID] = true
+		}
+
+		for _, mapping := range config.RoleMappings {
+			if !known[mapping.LocalRole] {
+				return fmt.Errorf("%w: %s", errUnknownDirectoryRole, mapping.LocalRole)
+			}
+		}
+	}
+
+	return c.redfishwrapper.PatchDirectoryService(ctx, xccDirectoryServiceProperty, redfishwrapper.DirectoryServicePayload(config))
+}
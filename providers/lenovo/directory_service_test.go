@@ -0,0 +1,67 @@
+package lenovo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// Requirement: Active Directory configuration read from the XCC LDAP client.
+func TestGetDirectoryService(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	config, err := c.GetDirectoryService(context.Background(), bmc.DirectoryServiceActiveDirectory)
+	if err != nil {
+		t.Fatalf("GetDirectoryService: %v", err)
+	}
+	if config.Type != bmc.DirectoryServiceActiveDirectory || !config.Enabled {
+		t.Errorf("Type = %s Enabled = %v, want enabled ActiveDirectory", config.Type, config.Enabled)
+	}
+	if !reflect.DeepEqual(config.ServiceAddresses, []string{"dc1.corp.example.com:3268"}) {
+		t.Errorf("ServiceAddresses = %v", config.ServiceAddresses)
+	}
+	want := []bmc.DirectoryRoleMapping{{RemoteGroup: "xcc-admins", LocalRole: "Administrator"}}
+	if !reflect.DeepEqual(config.RoleMappings, want) {
+		t.Errorf("RoleMappings = %v, want %v", config.RoleMappings, want)
+	}
+}
+
+// Requirement: directory service configuration set through the XCC LDAP client.
+func TestSetDirectoryService(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	config := bmc.DirectoryServiceConfig{
+		Type:         bmc.DirectoryServiceActiveDirectory,
+		Enabled:      true,
+		RoleMappings: []bmc.DirectoryRoleMapping{{RemoteGroup: "xcc-operators", LocalRole: "Operator"}},
+	}
+	if err := c.SetDirectoryService(context.Background(), config); err != nil {
+		t.Fatalf("SetDirectoryService: %v", err)
+	}
+	if _, ok := ts.accountServicePatchBody()["LDAP"]; !ok {
+		t.Fatalf("expected the LDAP property to be PATCHed, got %v", ts.accountServicePatchBody())
+	}
+}
+
+// Requirement: role mappings to roles the XCC does not define are rejected.
+func TestSetDirectoryServiceUnknownRole(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	config := bmc.DirectoryServiceConfig{
+		Type:         bmc.DirectoryServiceLDAP,
+		RoleMappings: []bmc.DirectoryRoleMapping{{RemoteGroup: "auditors", LocalRole: "Auditor"}},
+	}
+	err := c.SetDirectoryService(context.Background(), config)
+	if !errors.Is(err, errUnknownDirectoryRole) {
+		t.Fatalf("expected errUnknownDirectoryRole, got %v", err)
+	}
+	if ts.accountServicePatchBody() != nil {
+		t.Fatal("expected no AccountService PATCH")
+	}
+}
@@ -0,0 +1,28 @@
+package lenovo
+
+import (
+	"context"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// compile-time assertions that the provider implements the interfaces.
+var (
+	_ bmc.IndicatorLEDSetter = (*Conn)(nil)
+	_ bmc.IndicatorLEDGetter = (*Conn)(nil)
+)
+
+// SetIdentifyLED turns the chassis identify LED on or off.
+//
+// Implements bmc.IndicatorLEDSetter.
+func (c *Conn) SetIdentifyLED(ctx context.Context, on bool, duration time.Duration) (err error) {
+	return c.redfishwrapper.SetIdentifyLED(ctx, on, duration)
+}
+
+// GetIdentifyLED returns the chassis identify LED state.
+//
+// Implements bmc.IndicatorLEDGetter.
+func (c *Conn) GetIdentifyLED(ctx context.Context) (on bool, err error) {
+	return c.redfishwrapper.GetIdentifyLED(ctx)
+}
@@ -101,9 +101,35 @@ var Features = registrar.Features{
 	// logs-sel
 	providers.FeatureGetSystemEventLog,
 	providers.FeatureGetSystemEventLogRaw,
+	providers.FeatureGetSystemEventLogEntries,
 	providers.FeatureClearSystemEventLog,
 	// bmc-management
 	providers.FeatureBmcReset,
+	// sensors
+	providers.FeatureSensorsRead,
+	// power-metering
+	providers.FeaturePowerConsumption,
+	providers.FeatureSetPowerLimit,
+	// identify LED
+	providers.FeatureSetIdentifyLED,
+	providers.FeatureGetIdentifyLED,
+	// boot order
+	providers.FeatureGetBootOrder,
+	providers.FeatureSetBootOrder,
+	// BMC network
+	providers.FeatureGetBMCNetwork,
+	providers.FeatureSetBMCNetwork,
+	// certificates
+	providers.FeatureGenerateCSR,
+	providers.FeatureReplaceCertificate,
+	providers.FeatureListCertificates,
+	// BMC time
+	providers.FeatureGetBMCTime,
+	providers.FeatureSetBMCTime,
+	providers.FeatureSetNTPServers,
+	// directory service
+	providers.FeatureGetDirectoryService,
+	providers.FeatureSetDirectoryService,
 }
 
 // Conn is a connection to a Lenovo XCC BMC.
@@ -256,6 +282,14 @@ func (c *Conn) Close(ctx context.Context) error {
 	return c.redfishwrapper.Close(ctx)
 }
 
+// KeepSessionAlive refreshes the XCC session so it does not reach the XCC
+// session inactivity timeout, an expired session is re-established.
+//
+// Implements bmc.SessionKeeper.
+func (c *Conn) KeepSessionAlive(ctx context.Context) error {
+	return c.redfishwrapper.KeepSessionAlive(ctx)
+}
+
 // Compatible reports whether this provider can manage the device.
 //
 // The device is compatible only when a session can be established, the Redfish
@@ -3,6 +3,11 @@ package lenovo
 import (
 	"context"
 	"fmt"
+
+	"github.com/stmcginnis/gofish/schemas"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
 )
 
 // XCC log-service ids. XCC exposes several log services beyond the IPMI SEL;
@@ -25,6 +30,37 @@ const (
 // XCC-specific provider method (the additional log types are not modeled by a
 // bmc.Feature interface).
 func (c *Conn) EventLog(ctx context.Context, logServiceID string) ([][]string, error) {
+	lentries, err := c.logServiceEntries(ctx, logServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(lentries))
+	for _, e := range lentries {
+		rows = append(rows, []string{e.ID, e.Created, string(e.Severity), e.Message})
+	}
+
+	return rows, nil
+}
+
+// EventLogEntries is the typed counterpart of [Conn.EventLog], it returns the
+// entries of the given XCC log service as bmc.SystemEventLogEntry values.
+func (c *Conn) EventLogEntries(ctx context.Context, logServiceID string) ([]bmc.SystemEventLogEntry, error) {
+	lentries, err := c.logServiceEntries(ctx, logServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]bmc.SystemEventLogEntry, 0, len(lentries))
+	for _, e := range lentries {
+		entries = append(entries, redfishwrapper.SystemEventLogEntryFromLogEntry(e))
+	}
+
+	return entries, nil
+}
+
+// logServiceEntries returns the raw entries of the Manager log service with the given id.
+func (c *Conn) logServiceEntries(ctx context.Context, logServiceID string) ([]*schemas.LogEntry, error) {
 	managers, err := c.redfishwrapper.Managers(ctx)
 	if err != nil {
 		return nil, err
@@ -46,12 +82,7 @@ func (c *Conn) EventLog(ctx context.Context, logServiceID string) ([][]string, e
 				return nil, fmt.Errorf("reading entries of log service %q: %w", logServiceID, err)
 			}
 
-			rows := make([][]string, 0, len(lentries))
-			for _, e := range lentries {
-				rows = append(rows, []string{e.ID, e.Created, string(e.Severity), e.Message})
-			}
-
-			return rows, nil
+			return lentries, nil
 		}
 	}
 
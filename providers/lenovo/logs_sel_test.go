@@ -4,6 +4,8 @@ import (
 	"context"
 	"strings"
 	"testing"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
 )
 
 // Requirement: Read the System Event Log.
@@ -24,6 +26,29 @@ func TestGetSystemEventLog(t *testing.T) {
 	}
 }
 
+// Requirement: Read the System Event Log as typed entries.
+func TestGetSystemEventLogEntries(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	entries, err := c.GetSystemEventLogEntries(context.Background())
+	if err != nil {
+		t.Fatalf("GetSystemEventLogEntries: %v", err)
+	}
+	if len(entries) == 0 {
+		t.Fatal("expected at least one SEL entry")
+	}
+	if entries[0].RecordID == "" {
+		t.Error("expected the entry to carry its record id")
+	}
+	if entries[0].Timestamp.IsZero() {
+		t.Error("expected the entry timestamp to be parsed")
+	}
+	if len(entries[0].Raw) == 0 {
+		t.Error("expected the raw entry JSON to be retained")
+	}
+}
+
 // Requirement: Read the raw System Event Log.
 func TestGetSystemEventLogRaw(t *testing.T) {
 	ts := newTestServer(t, testServerOpts{})
@@ -68,6 +93,26 @@ func TestEventLogAudit(t *testing.T) {
 	}
 }
 
+// Requirement: Additional XCC log types are available as typed entries.
+func TestEventLogEntriesAudit(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	entries, err := c.EventLogEntries(context.Background(), LogServiceAudit)
+	if err != nil {
+		t.Fatalf("EventLogEntries(AuditLog): %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d audit entries, want 1", len(entries))
+	}
+	if entries[0].Severity == bmc.SystemEventLogSeverityUnknown {
+		t.Errorf("unexpected audit severity: %q", entries[0].Severity)
+	}
+	if !strings.Contains(entries[0].Message, "logged in") {
+		t.Errorf("unexpected audit message: %q", entries[0].Message)
+	}
+}
+
 // Requirement: Unknown/absent service errors clearly.
 func TestEventLogUnknown(t *testing.T) {
 	ts := newTestServer(t, testServerOpts{})
@@ -118,6 +118,11 @@ type testServer struct {
 	certRenewed  bool
 	// snmpPatched records a PATCH of the OEM SNMP resource.
 	snmpPatched bool
+	// accountServicePatch records the decoded body of an AccountService PATCH.
+	accountServicePatch map[string]any
+	// bootOrderPatches records the decoded bodies of boot order PATCHes by path,
+	// so tests can assert which resource (system, settings or OEM) was written.
+	bootOrderPatches map[string]map[string]any
 }
 
 // fixtureBytes reads a fixture file from the fixtures dir.
@@ -161,65 +166,69 @@ func newTestServer(t *testing.T, opts testServerOpts) *testServer {
 
 	// path -> fixture file for plain GETs.
 	routes := map[string]string{
-		"/redfish/v1/":                                                "serviceroot.json",
-		"/redfish/v1/Systems":                                         "systems.json",
-		"/redfish/v1/Systems/1":                                       opts.systemFixture,
-		"/redfish/v1/Systems/1/Bios/Pending":                          "bios.pending.json",
-		"/redfish/v1/Systems/1/Bios":                                  "bios.json",
-		"/redfish/v1/Systems/1/SecureBoot":                            "secureboot.json",
-		"/redfish/v1/Chassis":                                         "chassis.json",
-		"/redfish/v1/Chassis/1":                                       "chassis.1.json",
-		"/redfish/v1/Chassis/1/Power":                                 "power.json",
-		"/redfish/v1/Chassis/1/Thermal":                               "thermal.json",
-		"/redfish/v1/Systems/1/Storage":                               "storage.json",
-		"/redfish/v1/Systems/1/Storage/RAID_Slot1":                    "storage.raid.json",
-		"/redfish/v1/Systems/1/Storage/RAID_Slot1/Volumes/1":          "volume.1.json",
-		"/redfish/v1/TaskService":                                     "taskservice.json",
-		"/redfish/v1/TaskService/Tasks":                               "tasks.json",
-		"/redfish/v1/TaskService/Tasks/1":                             "task.1.json",
-		"/redfish/v1/Managers":                                        "managers.json",
-		"/redfish/v1/Managers/1":                                      "manager.1.json",
-		"/redfish/v1/Managers/1/VirtualMedia":                         "managers.1.virtualmedia.json",
-		"/redfish/v1/AccountService":                                  "accountservice.json",
-		"/redfish/v1/AccountService/Accounts/1":                       "account.1.json",
-		"/redfish/v1/AccountService/Accounts/2":                       "account.2.json",
-		"/redfish/v1/AccountService/Roles/Administrator":              "role.administrator.json",
-		"/redfish/v1/AccountService/Roles/Operator":                   "role.operator.json",
-		"/redfish/v1/Managers/1/LogServices":                          "managers.1.logservices.json",
-		"/redfish/v1/Managers/1/LogServices/Sel":                      "ls.sel.json",
-		"/redfish/v1/Managers/1/LogServices/AuditLog":                 "ls.audit.json",
-		"/redfish/v1/Managers/1/LogServices/Sel/Entries":              "ls.sel.entries.json",
-		"/redfish/v1/Managers/1/LogServices/Sel/Entries/1":            "ls.sel.entry.1.json",
-		"/redfish/v1/Managers/1/LogServices/AuditLog/Entries":         "ls.audit.entries.json",
-		"/redfish/v1/Managers/1/LogServices/AuditLog/Entries/1":       "ls.audit.entry.1.json",
-		"/redfish/v1/Chassis/1/LogServices":                           "chassis.1.logservices.json",
-		"/redfish/v1/Chassis/1/LogServices/Sel":                       "chassis.ls.sel.json",
-		"/redfish/v1/LicenseService/Licenses":                         "licenses.json",
-		"/redfish/v1/LicenseService/Licenses/XCC_Advanced":            "license.xcc_advanced.json",
-		"/redfish/v1/Managers/1/Oem/Lenovo/SecureKeyLifecycleService": "sklm.json",
-		"/redfish/v1/Managers/1/EthernetInterfaces":                   "manager.ethernetinterfaces.json",
-		"/redfish/v1/Managers/1/EthernetInterfaces/eth0":              "manager.eth0.json",
-		"/redfish/v1/Managers/1/HostInterfaces":                       "manager.hostinterfaces.json",
-		"/redfish/v1/Managers/1/HostInterfaces/1":                     "manager.hostinterface.1.json",
-		"/redfish/v1/Managers/1/SerialInterfaces":                     "manager.serialinterfaces.json",
-		"/redfish/v1/Managers/1/SerialInterfaces/1":                   "manager.serial.1.json",
-		"/redfish/v1/Managers/1/NetworkProtocol":                      "networkprotocol.json",
-		"/redfish/v1/Systems/1/EthernetInterfaces":                    "system.ethernetinterfaces.json",
-		"/redfish/v1/Systems/1/EthernetInterfaces/NIC.1":              "system.eth.nic1.json",
-		"/redfish/v1/EventService":                                    "eventservice.json",
-		"/redfish/v1/EventService/Subscriptions/1":                    "subscription.1.json",
-		"/redfish/v1/TelemetryService":                                "telemetryservice.json",
-		"/redfish/v1/TelemetryService/MetricReports":                  "metricreports.json",
-		"/redfish/v1/TelemetryService/MetricReports/PowerMetrics":     "metricreport.power.json",
-		"/redfish/v1/TelemetryService/MetricReportDefinitions":        "metricreportdefinitions.json",
-		"/redfish/v1/TelemetryService/MetricDefinitions":              "metricdefinitions.json",
-		"/redfish/v1/JobService":                                      "jobservice.json",
-		"/redfish/v1/JobService/Jobs":                                 "jobs.json",
-		"/redfish/v1/JobService/Jobs/Restart":                         "job.restart.json",
-		"/redfish/v1/CertificateService":                              "certificateservice.json",
-		"/redfish/v1/CertificateService/CertificateLocations":         "certificatelocations.json",
-		"/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates/1": "certificate.1.json",
-		"/redfish/v1/Managers/1/NetworkProtocol/Oem/Lenovo/SNMP":      "snmp.json",
+		"/redfish/v1/":                                                      "serviceroot.json",
+		"/redfish/v1/Systems":                                               "systems.json",
+		"/redfish/v1/Systems/1":                                             opts.systemFixture,
+		"/redfish/v1/Systems/1/Bios/Pending":                                "bios.pending.json",
+		"/redfish/v1/Systems/1/Bios":                                        "bios.json",
+		"/redfish/v1/Systems/1/SecureBoot":                                  "secureboot.json",
+		"/redfish/v1/Chassis":                                               "chassis.json",
+		"/redfish/v1/Chassis/1":                                             "chassis.1.json",
+		"/redfish/v1/Chassis/1/Power":                                       "power.json",
+		"/redfish/v1/Chassis/1/Thermal":                                     "thermal.json",
+		"/redfish/v1/Systems/1/Storage":                                     "storage.json",
+		"/redfish/v1/Systems/1/Storage/RAID_Slot1":                          "storage.raid.json",
+		"/redfish/v1/Systems/1/Storage/RAID_Slot1/Volumes/1":                "volume.1.json",
+		"/redfish/v1/TaskService":                                           "taskservice.json",
+		"/redfish/v1/TaskService/Tasks":                                     "tasks.json",
+		"/redfish/v1/TaskService/Tasks/1":                                   "task.1.json",
+		"/redfish/v1/Managers":                                              "managers.json",
+		"/redfish/v1/Managers/1":                                            "manager.1.json",
+		"/redfish/v1/Managers/1/VirtualMedia":                               "managers.1.virtualmedia.json",
+		"/redfish/v1/AccountService":                                        "accountservice.json",
+		"/redfish/v1/AccountService/Accounts/1":                             "account.1.json",
+		"/redfish/v1/AccountService/Accounts/2":                             "account.2.json",
+		"/redfish/v1/AccountService/Roles/Administrator":                    "role.administrator.json",
+		"/redfish/v1/AccountService/Roles/Operator":                         "role.operator.json",
+		"/redfish/v1/Managers/1/LogServices":                                "managers.1.logservices.json",
+		"/redfish/v1/Managers/1/LogServices/Sel":                            "ls.sel.json",
+		"/redfish/v1/Managers/1/LogServices/AuditLog":                       "ls.audit.json",
+		"/redfish/v1/Managers/1/LogServices/Sel/Entries":                    "ls.sel.entries.json",
+		"/redfish/v1/Managers/1/LogServices/Sel/Entries/1":                  "ls.sel.entry.1.json",
+		"/redfish/v1/Managers/1/LogServices/AuditLog/Entries":               "ls.audit.entries.json",
+		"/redfish/v1/Managers/1/LogServices/AuditLog/Entries/1":             "ls.audit.entry.1.json",
+		"/redfish/v1/Chassis/1/LogServices":                                 "chassis.1.logservices.json",
+		"/redfish/v1/Chassis/1/LogServices/Sel":                             "chassis.ls.sel.json",
+		"/redfish/v1/LicenseService/Licenses":                               "licenses.json",
+		"/redfish/v1/LicenseService/Licenses/XCC_Advanced":                  "license.xcc_advanced.json",
+		"/redfish/v1/Managers/1/Oem/Lenovo/SecureKeyLifecycleService":       "sklm.json",
+		"/redfish/v1/Managers/1/EthernetInterfaces":                         "manager.ethernetinterfaces.json",
+		"/redfish/v1/Managers/1/EthernetInterfaces/eth0":                    "manager.eth0.json",
+		"/redfish/v1/Managers/1/HostInterfaces":                             "manager.hostinterfaces.json",
+		"/redfish/v1/Managers/1/HostInterfaces/1":                           "manager.hostinterface.1.json",
+		"/redfish/v1/Managers/1/SerialInterfaces":                           "manager.serialinterfaces.json",
+		"/redfish/v1/Managers/1/SerialInterfaces/1":                         "manager.serial.1.json",
+		"/redfish/v1/Managers/1/NetworkProtocol":                            "networkprotocol.json",
+		"/redfish/v1/Systems/1/EthernetInterfaces":                          "system.ethernetinterfaces.json",
+		"/redfish/v1/Systems/1/EthernetInterfaces/NIC.1":                    "system.eth.nic1.json",
+		"/redfish/v1/EventService":                                          "eventservice.json",
+		"/redfish/v1/EventService/Subscriptions/1":                          "subscription.1.json",
+		"/redfish/v1/TelemetryService":                                      "telemetryservice.json",
+		"/redfish/v1/TelemetryService/MetricReports":                        "metricreports.json",
+		"/redfish/v1/TelemetryService/MetricReports/PowerMetrics":           "metricreport.power.json",
+		"/redfish/v1/TelemetryService/MetricReportDefinitions":              "metricreportdefinitions.json",
+		"/redfish/v1/TelemetryService/MetricDefinitions":                    "metricdefinitions.json",
+		"/redfish/v1/JobService":                                            "jobservice.json",
+		"/redfish/v1/JobService/Jobs":                                       "jobs.json",
+		"/redfish/v1/JobService/Jobs/Restart":                               "job.restart.json",
+		"/redfish/v1/CertificateService":                                    "certificateservice.json",
+		"/redfish/v1/CertificateService/CertificateLocations":               "certificatelocations.json",
+		"/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates/1":       "certificate.1.json",
+		"/redfish/v1/Managers/1/NetworkProtocol/Oem/Lenovo/SNMP":            "snmp.json",
+		"/redfish/v1/Systems/1/Pending":                                     "system.pending.json",
+		"/redfish/v1/Systems/1/BootOptions":                                 "bootoptions.json",
+		"/redfish/v1/Systems/1/BootOptions/Boot0003":                        "bootoption.boot0003.json",
+		"/redfish/v1/Systems/1/Oem/Lenovo/BootSettings/BootOrder.BootOrder": "bootorder.oem.json",
 	}
 
 	if opts.licenseServiceNotFound {
@@ -579,6 +588,13 @@ func newTestServer(t *testing.T, opts testServerOpts) *testServer {
 				}
 			case "/redfish/v1/AccountService/Accounts/1", "/redfish/v1/AccountService/Accounts/2":
 				ts.accountPatched = true
+			case "/redfish/v1/AccountService":
+				if b, err := io.ReadAll(r.Body); err == nil {
+					var body map[string]any
+					if json.Unmarshal(b, &body) == nil {
+						ts.accountServicePatch = body
+					}
+				}
 			case "/redfish/v1/LicenseService/Licenses":
 				ts.licenseInstalled = true
 			case "/redfish/v1/LicenseService/Licenses/XCC_Advanced":
@@ -601,6 +617,16 @@ func newTestServer(t *testing.T, opts testServerOpts) *testServer {
 				ts.jobScheduleUpdated = true
 			case "/redfish/v1/Managers/1/NetworkProtocol/Oem/Lenovo/SNMP":
 				ts.snmpPatched = true
+			case "/redfish/v1/Systems/1/Pending", "/redfish/v1/Systems/1/Oem/Lenovo/BootSettings/BootOrder.BootOrder":
+				if b, err := io.ReadAll(r.Body); err == nil {
+					var body map[string]any
+					if json.Unmarshal(b, &body) == nil {
+						if ts.bootOrderPatches == nil {
+							ts.bootOrderPatches = map[string]map[string]any{}
+						}
+						ts.bootOrderPatches[r.URL.Path] = body
+					}
+				}
 			}
 			ts.mu.Unlock()
 			w.WriteHeader(http.StatusNoContent)
@@ -793,3 +819,39 @@ func (ts *testServer) didFactoryReset() bool {
 	defer ts.mu.Unlock()
 	return ts.factoryReset
 }
+
+func (ts *testServer) bootOrderPatch(path string) map[string]any {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.bootOrderPatches[path]
+}
+
+func (ts *testServer) didPatchBMCEthernet() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.bmcEthPatched
+}
+
+func (ts *testServer) didGenerateCSR() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.csrGenerated
+}
+
+func (ts *testServer) didReplaceCertificate() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.certReplaced
+}
+
+func (ts *testServer) didPatchNetworkProtocol() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.netProtoPatched
+}
+
+func (ts *testServer) accountServicePatchBody() map[string]any {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.accountServicePatch
+}
@@ -0,0 +1,33 @@
+package lenovo
+
+import (
+	"context"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// compile-time assertion that the provider implements the interface.
+var _ bmc.ManagerTimeConfigurator = (*Conn)(nil)
+
+// GetBMCTime returns the XCC clock and its NTP configuration.
+//
+// Implements bmc.ManagerTimeConfigurator.
+func (c *Conn) GetBMCTime(ctx context.Context) (bmcTime *bmc.BMCTime, err error) {
+	return c.redfishwrapper.GetBMCTime(ctx)
+}
+
+// SetBMCTime sets the XCC clock, the time zone is set through the OEM Manager properties with
+// UpdateManager.
+//
+// Implements bmc.ManagerTimeConfigurator.
+func (c *Conn) SetBMCTime(ctx context.Context, t time.Time) (err error) {
+	return c.redfishwrapper.SetBMCTime(ctx, t)
+}
+
+// SetNTPServers sets the XCC NTP servers.
+//
+// Implements bmc.ManagerTimeConfigurator.
+func (c *Conn) SetNTPServers(ctx context.Context, servers []string) (err error) {
+	return c.redfishwrapper.SetNTPServers(ctx, servers)
+}
@@ -0,0 +1,38 @@
+package lenovo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Requirement: BMC clock and NTP configuration read.
+func TestGetBMCTime(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	bmcTime, err := c.GetBMCTime(context.Background())
+	if err != nil {
+		t.Fatalf("GetBMCTime: %v", err)
+	}
+	if want := time.Date(2024, 5, 1, 12, 5, 0, 0, time.UTC); !bmcTime.Time.Equal(want) {
+		t.Errorf("Time = %v, want %v", bmcTime.Time, want)
+	}
+	if !bmcTime.NTPEnabled || !reflect.DeepEqual(bmcTime.NTPServers, []string{"pool.ntp.org"}) {
+		t.Errorf("NTP = %v %v, want enabled with pool.ntp.org", bmcTime.NTPEnabled, bmcTime.NTPServers)
+	}
+}
+
+// Requirement: BMC NTP configuration set.
+func TestSetNTPServers(t *testing.T) {
+	ts := newTestServer(t, testServerOpts{})
+	c := ts.openedClient(t)
+
+	if err := c.SetNTPServers(context.Background(), []string{"10.0.0.5"}); err != nil {
+		t.Fatalf("SetNTPServers: %v", err)
+	}
+	if !ts.didPatchNetworkProtocol() {
+		t.Fatal("expected the NetworkProtocol to be PATCHed")
+	}
+}
@@ -0,0 +1,27 @@
+package lenovo
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// compile-time assertions that the provider implements the interfaces.
+var (
+	_ bmc.PowerMeter   = (*Conn)(nil)
+	_ bmc.PowerLimiter = (*Conn)(nil)
+)
+
+// PowerConsumption returns the host power consumption and the configured power cap.
+//
+// Implements bmc.PowerMeter.
+func (c *Conn) PowerConsumption(ctx context.Context) (consumption *bmc.PowerConsumption, err error) {
+	return c.redfishwrapper.PowerConsumption(ctx)
+}
+
+// SetPowerLimit sets or removes the host power cap.
+//
+// Implements bmc.PowerLimiter.
+func (c *Conn) SetPowerLimit(ctx context.Context, limit bmc.PowerLimit) (err error) {
+	return c.redfishwrapper.SetPowerLimit(ctx, limit)
+}
@@ -6,8 +6,11 @@ import (
 	"github.com/bmc-toolbox/bmclib/v2/bmc"
 )
 
-// compile-time assertion that the provider implements the interface.
-var _ bmc.SystemEventLog = (*Conn)(nil)
+// compile-time assertions that the provider implements the interfaces.
+var (
+	_ bmc.SystemEventLog              = (*Conn)(nil)
+	_ bmc.SystemEventLogEntriesGetter = (*Conn)(nil)
+)
 
 // GetSystemEventLog returns the System Event Log entries as rows of
 // [id, created, description, message], aggregated from the BMC log services.
@@ -17,6 +20,14 @@ func (c *Conn) GetSystemEventLog(ctx context.Context) (entries [][]string, err e
 	return c.redfishwrapper.GetSystemEventLog(ctx)
 }
 
+// GetSystemEventLogEntries returns the System Event Log as typed entries,
+// aggregated from the BMC log services.
+//
+// Implements bmc.SystemEventLogEntriesGetter.
+func (c *Conn) GetSystemEventLogEntries(ctx context.Context) (entries []bmc.SystemEventLogEntry, err error) {
+	return c.redfishwrapper.GetSystemEventLogEntries(ctx)
+}
+
 // GetSystemEventLogRaw returns the raw JSON of the SEL log entries.
 //
 // Implements bmc.SystemEventLog.
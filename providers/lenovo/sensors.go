@@ -0,0 +1,18 @@
+package lenovo
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// compile-time assertion that the provider implements the interface.
+var _ bmc.SensorsGetter = (*Conn)(nil)
+
+// Sensors returns the XCC sensor readings, from the Chassis Sensors collection
+// or the Thermal and Power resources on older firmware.
+//
+// Implements bmc.SensorsGetter.
+func (c *Conn) Sensors(ctx context.Context) (sensors []bmc.SensorReading, err error) {
+	return c.redfishwrapper.Sensors(ctx)
+}
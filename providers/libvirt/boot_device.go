@@ -0,0 +1,87 @@
+package libvirt
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+var _ bmc.BootDeviceSetter = (*Config)(nil)
+
+// bootDevices maps the boot devices to the <os><boot dev=""/> devices.
+var bootDevices = map[bmc.BootDeviceType]string{
+	bmc.BootDeviceTypePXE:    "network",
+	bmc.BootDeviceTypeDisk:   "hd",
+	bmc.BootDeviceTypeCDROM:  "cdrom",
+	bmc.BootDeviceTypeFloppy: "fd",
+}
+
+var (
+	// bootElement matches the <boot dev=""/> elements of <os> and the
+	// <boot order=""/> elements of the devices.
+	bootElement = regexp.MustCompile(`\s*<boot\s+(?:dev|order)=['"][^'"]*['"][^>]*/>`)
+	// osEnd matches the end of the <os> element and the indentation before it.
+	osEnd = regexp.MustCompile(`(\n?[ \t]*)</os>`)
+)
+
+// BootDeviceSet sets the device the domain boots from, by rewriting the boot
+// order of the domain definition.
+//
+// The boot device is the only <os><boot dev=""/> element of the definition,
+// the per device boot order is removed since libvirt rejects a definition with
+// both. libvirt has no one time boot override, the boot order is persistent
+// whether setPersistent is set or not and applies from the next start of the
+// domain. The firmware is part of the domain definition, efiBoot is ignored.
+func (p *Config) BootDeviceSet(ctx context.Context, bootDevice string, setPersistent, efiBoot bool) (ok bool, err error) {
+	if err := p.opened(); err != nil {
+		return false, err
+	}
+
+	dev, ok := bootDevices[bmc.BootDeviceType(bootDevice)]
+	if !ok {
+		return false, fmt.Errorf("unsupported boot device: %s", bootDevice)
+	}
+
+	xml, err := p.domainXML(ctx, true)
+	if err != nil {
+		return false, fmt.Errorf("failed to get libvirt domain definition: %w", err)
+	}
+
+	xml, err = setBootDevice(xml, dev)
+	if err != nil {
+		return false, err
+	}
+
+	if err := p.defineXML(ctx, xml); err != nil {
+		return false, fmt.Errorf("failed to define libvirt domain: %w", err)
+	}
+
+	if !setPersistent {
+		p.Logger.V(1).Info("libvirt boot device set persistently, a one time boot override is not supported", "bootDevice", bootDevice)
+	}
+
+	return true, nil
+}
+
+// setBootDevice returns the domain XML with the boot device as the only boot
+// element.
+func setBootDevice(xml, dev string) (string, error) {
+	xml = bootElement.ReplaceAllString(xml, "")
+
+	loc := osEnd.FindStringSubmatchIndex(xml)
+	if loc == nil {
+		return "", fmt.Errorf("libvirt domain definition has no <os> element")
+	}
+
+	boot := "<boot dev='" + dev + "'/>"
+
+	// indent the element one level deeper than </os>.
+	if indent := xml[loc[2]:loc[3]]; strings.HasPrefix(indent, "\n") {
+		boot = indent + "  " + boot
+	}
+
+	return xml[:loc[0]] + boot + xml[loc[0]:], nil
+}
@@ -0,0 +1,148 @@
+package libvirt
+
+import (
+	"context"
+)
+
+// Domain states, see virDomainState.
+const (
+	stateNoState     = 0
+	stateRunning     = 1
+	stateBlocked     = 2
+	statePaused      = 3
+	stateShutdown    = 4
+	stateShutoff     = 5
+	stateCrashed     = 6
+	statePMSuspended = 7
+)
+
+// Domain XML flags, see virDomainXMLFlags.
+const (
+	xmlSecure   = 1
+	xmlInactive = 2
+)
+
+// Device modification flags, see virDomainDeviceModifyFlags.
+const (
+	affectLive   = 1
+	affectConfig = 2
+	modifyForce  = 4
+)
+
+// connectOpen opens the connection URI on the daemon.
+func (p *Config) connectOpen(ctx context.Context) error {
+	e := &encoder{}
+	e.optionalString(&p.URI)
+	e.uint32(0)
+
+	_, err := p.rpc.call(ctx, procConnectOpen, e.bytes())
+
+	return err
+}
+
+// lookupDomain returns the domain with the name.
+func (p *Config) lookupDomain(ctx context.Context, name string) (*domain, error) {
+	e := &encoder{}
+	e.string(name)
+
+	d, err := p.rpc.call(ctx, procDomainLookupByName, e.bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	dom := d.domain()
+
+	return dom, d.err
+}
+
+// domainCall invokes a domain procedure that takes the domain and optional
+// flags, and returns no value.
+func (p *Config) domainCall(ctx context.Context, proc procedure, flags ...uint32) error {
+	e := &encoder{}
+	e.domain(p.dom)
+
+	for _, f := range flags {
+		e.uint32(f)
+	}
+
+	_, err := p.rpc.call(ctx, proc, e.bytes())
+
+	return err
+}
+
+// domainState returns the state of the domain, read from virDomainGetInfo.
+func (p *Config) domainState(ctx context.Context) (int32, error) {
+	e := &encoder{}
+	e.domain(p.dom)
+
+	d, err := p.rpc.call(ctx, procDomainGetInfo, e.bytes())
+	if err != nil {
+		return stateNoState, err
+	}
+
+	// the state is the first member of remote_domain_get_info_ret, followed
+	// by the memory, vCPU count and CPU time.
+	state := d.int32()
+
+	return state, d.err
+}
+
+// domainXML returns the XML of the domain, inactive returns the persistent
+// definition instead of the running configuration.
+func (p *Config) domainXML(ctx context.Context, inactive bool) (string, error) {
+	flags := uint32(xmlSecure)
+	if inactive {
+		flags |= xmlInactive
+	}
+
+	e := &encoder{}
+	e.domain(p.dom)
+	e.uint32(flags)
+
+	d, err := p.rpc.call(ctx, procDomainGetXMLDesc, e.bytes())
+	if err != nil {
+		return "", err
+	}
+
+	xml := d.string()
+
+	return xml, d.err
+}
+
+// defineXML redefines the persistent definition of the domain.
+func (p *Config) defineXML(ctx context.Context, xml string) error {
+	e := &encoder{}
+	e.string(xml)
+
+	d, err := p.rpc.call(ctx, procDomainDefineXML, e.bytes())
+	if err != nil {
+		return err
+	}
+
+	d.domain()
+
+	return d.err
+}
+
+// updateDevice updates a device of the domain with the device XML.
+func (p *Config) updateDevice(ctx context.Context, xml string, flags uint32) error {
+	e := &encoder{}
+	e.domain(p.dom)
+	e.string(xml)
+	e.uint32(flags)
+
+	_, err := p.rpc.call(ctx, procDomainUpdateDeviceFlags, e.bytes())
+
+	return err
+}
+
+// running returns true for the domain states of a domain with a running QEMU
+// process.
+func running(state int32) bool {
+	switch state {
+	case stateRunning, stateBlocked, statePaused, stateShutdown, statePMSuspended:
+		return true
+	}
+
+	return false
+}
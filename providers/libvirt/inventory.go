@@ -0,0 +1,221 @@
+package libvirt
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bmc-toolbox/common"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+var _ bmc.InventoryGetter = (*Config)(nil)
+
+// domainDefinition is the subset of the domain XML the provider reads.
+type domainDefinition struct {
+	Type   string `xml:"type,attr"`
+	Name   string `xml:"name"`
+	UUID   string `xml:"uuid"`
+	Memory struct {
+		Value uint64 `xml:",chardata"`
+		Unit  string `xml:"unit,attr"`
+	} `xml:"memory"`
+	VCPU int `xml:"vcpu"`
+	CPU  struct {
+		Mode  string `xml:"mode,attr"`
+		Model struct {
+			Value string `xml:",chardata"`
+		} `xml:"model"`
+		Topology struct {
+			Sockets int `xml:"sockets,attr"`
+			Cores   int `xml:"cores,attr"`
+			Threads int `xml:"threads,attr"`
+		} `xml:"topology"`
+	} `xml:"cpu"`
+	OS struct {
+		Type struct {
+			Arch    string `xml:"arch,attr"`
+			Machine string `xml:"machine,attr"`
+		} `xml:"type"`
+		Loader struct {
+			Value string `xml:",chardata"`
+		} `xml:"loader"`
+		Firmware string `xml:"firmware,attr"`
+	} `xml:"os"`
+	Devices struct {
+		Emulator   string            `xml:"emulator"`
+		Disks      []domainDisk      `xml:"disk"`
+		Interfaces []domainInterface `xml:"interface"`
+	} `xml:"devices"`
+}
+
+// domainDisk is a <disk> device of the domain XML.
+type domainDisk struct {
+	Type   string `xml:"type,attr"`
+	Device string `xml:"device,attr"`
+	Driver struct {
+		Type string `xml:"type,attr"`
+	} `xml:"driver"`
+	Source struct {
+		File     string `xml:"file,attr"`
+		Dev      string `xml:"dev,attr"`
+		Protocol string `xml:"protocol,attr"`
+		Name     string `xml:"name,attr"`
+	} `xml:"source"`
+	Target struct {
+		Dev string `xml:"dev,attr"`
+		Bus string `xml:"bus,attr"`
+	} `xml:"target"`
+	Serial string `xml:"serial"`
+}
+
+// source returns the image file, block device or network volume of the disk.
+func (d *domainDisk) source() string {
+	switch {
+	case d.Source.File != "":
+		return d.Source.File
+	case d.Source.Dev != "":
+		return d.Source.Dev
+	}
+
+	return d.Source.Name
+}
+
+// domainInterface is an <interface> device of the domain XML.
+type domainInterface struct {
+	Type string `xml:"type,attr"`
+	MAC  struct {
+		Address string `xml:"address,attr"`
+	} `xml:"mac"`
+	Model struct {
+		Type string `xml:"type,attr"`
+	} `xml:"model"`
+	Target struct {
+		Dev string `xml:"dev,attr"`
+	} `xml:"target"`
+}
+
+// memoryUnits maps the libvirt memory units to their size in bytes.
+var memoryUnits = map[string]uint64{
+	"b": 1, "bytes": 1,
+	"kb": 1000, "k": 1 << 10, "kib": 1 << 10,
+	"mb": 1000 * 1000, "m": 1 << 20, "mib": 1 << 20,
+	"gb": 1000 * 1000 * 1000, "g": 1 << 30, "gib": 1 << 30,
+	"tb": 1000 * 1000 * 1000 * 1000, "t": 1 << 40, "tib": 1 << 40,
+}
+
+// Inventory returns the inventory of the domain, read from the domain XML.
+//
+// The UUID of the domain is the serial and the machine type its model, the
+// vCPUs are reported as a single CPU, the memory as a single module, and the
+// disks and network interfaces of the domain as drives and NICs.
+func (p *Config) Inventory(ctx context.Context) (device *common.Device, err error) {
+	if err := p.opened(); err != nil {
+		return nil, err
+	}
+
+	desc, err := p.domainXML(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get libvirt domain XML: %w", err)
+	}
+
+	def := &domainDefinition{}
+	if err := xml.Unmarshal([]byte(desc), def); err != nil {
+		return nil, fmt.Errorf("failed to parse libvirt domain XML: %w", err)
+	}
+
+	d := common.NewDevice()
+	device = &d
+
+	device.Vendor = "libvirt"
+	device.Model = def.OS.Type.Machine
+	device.ProductName = def.Name
+	device.Serial = def.UUID
+	setMetadata(&device.Common, "domain_type", def.Type)
+	setMetadata(&device.Common, "emulator", def.Devices.Emulator)
+	setMetadata(&device.Common, "firmware", def.OS.Firmware)
+	setMetadata(&device.Common, "loader", def.OS.Loader.Value)
+
+	cpuModel := def.CPU.Model.Value
+	if cpuModel == "" {
+		cpuModel = def.CPU.Mode
+	}
+
+	device.CPUs = append(device.CPUs, &common.CPU{
+		Common:       common.Common{Model: cpuModel, Description: "vCPU"},
+		ID:           "0",
+		Architecture: def.OS.Type.Arch,
+		Cores:        def.VCPU,
+		Threads:      def.VCPU,
+	})
+
+	if size := memoryBytes(def.Memory.Value, def.Memory.Unit); size > 0 {
+		device.Memory = append(device.Memory, &common.Memory{
+			Common:    common.Common{Description: "virtual memory"},
+			ID:        "0",
+			SizeBytes: int64(size), //nolint:gosec // domain memory is far below the int64 range
+		})
+	}
+
+	for _, disk := range def.Devices.Disks {
+		if disk.Device != "disk" {
+			continue
+		}
+
+		drive := &common.Drive{
+			Common: common.Common{
+				Description: disk.source(),
+				Serial:      disk.Serial,
+				LogicalName: disk.Target.Dev,
+			},
+			ID:       disk.Target.Dev,
+			Protocol: strings.ToUpper(disk.Target.Bus),
+		}
+		setMetadata(&drive.Common, "format", disk.Driver.Type)
+
+		device.Drives = append(device.Drives, drive)
+	}
+
+	for i, iface := range def.Devices.Interfaces {
+		id := iface.Target.Dev
+		if id == "" {
+			id = "net" + strconv.Itoa(i)
+		}
+
+		device.NICs = append(device.NICs, &common.NIC{
+			Common: common.Common{Model: iface.Model.Type, Description: iface.Type},
+			ID:     id,
+			NICPorts: []*common.NICPort{
+				{ID: id, MacAddress: iface.MAC.Address},
+			},
+		})
+	}
+
+	return device, nil
+}
+
+// memoryBytes returns the size in bytes of a libvirt memory value, KiB is the
+// default unit.
+func memoryBytes(value uint64, unit string) uint64 {
+	if unit == "" {
+		unit = "KiB"
+	}
+
+	return value * memoryUnits[strings.ToLower(unit)]
+}
+
+// setMetadata sets a non empty metadata value on the component.
+func setMetadata(component *common.Common, key, value string) {
+	if value == "" {
+		return
+	}
+
+	if component.Metadata == nil {
+		component.Metadata = map[string]string{}
+	}
+
+	component.Metadata[key] = value
+}
@@ -0,0 +1,151 @@
+// Package libvirt implements a bmclib provider for libvirt virtual machines.
+//
+// The provider talks to the libvirt daemon over its RPC socket, so a VM can be
+// driven through the bmclib.Client API like a server with a BMC, without a
+// virtualbmc or sushy emulator in front of it:
+//
+//   - power.go starts, stops and resets the domain.
+//
+//   - boot_device.go rewrites the boot order of the domain definition.
+//
+//   - virtual_media.go changes the ISO of the CD-ROM of the domain.
+//
+//   - inventory.go reads the inventory from the domain XML.
+//
+//   - screenshot.go captures the console of the domain.
+package libvirt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/go-logr/logr"
+	"github.com/jacobweinstock/registrar"
+
+	"github.com/bmc-toolbox/bmclib/v2/providers"
+)
+
+const (
+	// ProviderName for the libvirt implementation.
+	ProviderName = "libvirt"
+	// ProviderProtocol for the libvirt implementation.
+	ProviderProtocol = "libvirt"
+
+	// defaultSocket is the read-write socket of the system libvirt daemon.
+	defaultSocket = "/var/run/libvirt/libvirt-sock"
+	// defaultURI is the connection URI of the system QEMU driver.
+	defaultURI = "qemu:///system"
+)
+
+// Features implemented by the libvirt provider.
+var Features = registrar.Features{
+	providers.FeaturePowerSet,
+	providers.FeaturePowerState,
+	providers.FeatureBootDeviceSet,
+	providers.FeatureVirtualMedia,
+	providers.FeatureInventoryRead,
+	providers.FeatureScreenshot,
+}
+
+// errNotOpen is returned when the provider is used before Open.
+var errNotOpen = errors.New("libvirt connection not open")
+
+// Config holds the configuration for the libvirt provider.
+type Config struct {
+	// Network is the network of Address, "unix" (the default) or "tcp" for a
+	// daemon listening on TCP (libvirtd --listen, port 16509).
+	Network string
+	// Address is the socket path or the host:port of the daemon, defaults to
+	// the system daemon socket.
+	Address string
+	// URI is the connection URI the daemon opens, defaults to "qemu:///system".
+	URI string
+	// Domain is the name of the domain (VM).
+	Domain string
+	Logger logr.Logger
+
+	rpc *rpc
+	dom *domain
+}
+
+// New returns a new Config containing all the defaults for the libvirt provider.
+func New(address, domainName string) *Config {
+	if address == "" {
+		address = defaultSocket
+	}
+
+	return &Config{
+		Network: "unix",
+		Address: address,
+		URI:     defaultURI,
+		Domain:  domainName,
+		Logger:  logr.Discard(),
+	}
+}
+
+// Name returns the name of this libvirt provider.
+// Implements bmc.Provider interface
+func (p *Config) Name() string {
+	return ProviderName
+}
+
+// Open connects to the libvirt daemon, opens the URI and looks up the domain.
+func (p *Config) Open(ctx context.Context) error {
+	if p.Domain == "" {
+		return errors.New("libvirt domain not configured")
+	}
+
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, p.Network, p.Address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to libvirt: %w", err)
+	}
+
+	p.rpc = &rpc{conn: conn}
+
+	if err := p.connectOpen(ctx); err != nil {
+		p.closeConn()
+		return fmt.Errorf("failed to open libvirt connection %s: %w", p.URI, err)
+	}
+
+	dom, err := p.lookupDomain(ctx, p.Domain)
+	if err != nil {
+		_ = p.Close(ctx)
+		return fmt.Errorf("failed to look up libvirt domain %s: %w", p.Domain, err)
+	}
+
+	p.dom = dom
+
+	return nil
+}
+
+// Close closes the libvirt connection.
+func (p *Config) Close(ctx context.Context) (err error) {
+	if p.rpc == nil {
+		return nil
+	}
+
+	_, err = p.rpc.call(ctx, procConnectClose, nil)
+	p.closeConn()
+
+	return err
+}
+
+// closeConn closes the socket of the connection.
+func (p *Config) closeConn() {
+	_ = p.rpc.conn.Close()
+	p.rpc = nil
+	p.dom = nil
+}
+
+// opened returns errNotOpen when the provider is not open.
+func (p *Config) opened() error {
+	if p.rpc == nil || p.dom == nil {
+		return errNotOpen
+	}
+
+	return nil
+}
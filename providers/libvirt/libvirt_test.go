@@ -0,0 +1,432 @@
+package libvirt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image/png"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+const domainXML = `<domain type='kvm'>
+  <name>vm1</name>
+  <uuid>5e0c3b6e-8f4e-4a8b-9c1d-2f3e4a5b6c7d</uuid>
+  <memory unit='KiB'>4194304</memory>
+  <vcpu placement='static'>2</vcpu>
+  <os>
+    <type arch='x86_64' machine='pc-q35-8.2'>hvm</type>
+    <boot dev='hd'/>
+  </os>
+  <devices>
+    <emulator>/usr/bin/qemu-system-x86_64</emulator>
+    <disk type='file' device='disk'>
+      <driver name='qemu' type='qcow2'/>
+      <source file='/var/lib/libvirt/images/vm1.qcow2'/>
+      <target dev='vda' bus='virtio'/>
+    </disk>
+    <disk type='file' device='cdrom'>
+      <driver name='qemu' type='raw'/>
+      <target dev='sda' bus='sata'/>
+      <readonly/>
+    </disk>
+    <interface type='network'>
+      <mac address='52:54:00:12:34:56'/>
+      <source network='default'/>
+      <model type='virtio'/>
+    </interface>
+  </devices>
+</domain>
+`
+
+// fakeDaemon is a libvirt daemon stand-in serving the remote protocol on a
+// unix socket, it knows the domain "vm1".
+type fakeDaemon struct {
+	socket string
+
+	mu    sync.Mutex
+	state int32
+	xml   string
+	// calls are the procedures called, in order.
+	calls []procedure
+	// devices are the device XMLs of the device updates.
+	devices []string
+	flags   []uint32
+}
+
+func newFakeDaemon(t *testing.T, state int32) *fakeDaemon {
+	t.Helper()
+
+	f := &fakeDaemon{
+		socket: filepath.Join(t.TempDir(), "libvirt-sock"),
+		state:  state,
+		xml:    domainXML,
+	}
+
+	l, err := net.Listen("unix", f.socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go f.serve(conn)
+		}
+	}()
+
+	return f
+}
+
+func (f *fakeDaemon) serve(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		h, body, err := readMessage(conn)
+		if err != nil {
+			return
+		}
+
+		f.mu.Lock()
+		f.calls = append(f.calls, h.Procedure)
+		reply, stream, errMessage := f.handle(h.Procedure, &decoder{r: bytes.NewReader(body)})
+		f.mu.Unlock()
+
+		if errMessage != "" {
+			e := &encoder{}
+			e.int32(42)
+			e.int32(10)
+			e.optionalString(&errMessage)
+			writeMessage(conn, h, messageReply, statusError, e.bytes())
+
+			continue
+		}
+
+		writeMessage(conn, h, messageReply, statusOK, reply)
+
+		if stream != nil {
+			writeMessage(conn, h, messageStream, statusContinue, stream)
+			writeMessage(conn, h, messageStream, statusOK, nil)
+		}
+	}
+}
+
+// handle applies the call and returns the encoded reply, the data of a stream
+// or the message of an error.
+func (f *fakeDaemon) handle(proc procedure, d *decoder) (reply, stream []byte, errMessage string) {
+	e := &encoder{}
+	vm := &domain{Name: "vm1", UUID: [16]byte{1, 2, 3}, ID: 1}
+
+	switch proc {
+	case procConnectOpen, procConnectClose, procDomainReset:
+	case procDomainLookupByName:
+		if name := d.string(); name != "vm1" {
+			return nil, nil, "Domain not found: no domain with matching name '" + name + "'"
+		}
+
+		e.domain(vm)
+	case procDomainGetInfo:
+		e.int32(f.state)
+		e.buf.Write(make([]byte, 8+8+4+8))
+	case procDomainCreate:
+		f.state = stateRunning
+	case procDomainDestroy, procDomainShutdown:
+		f.state = stateShutoff
+	case procDomainGetXMLDesc:
+		e.string(f.xml)
+	case procDomainDefineXML:
+		f.xml = d.string()
+		e.domain(vm)
+	case procDomainUpdateDeviceFlags:
+		d.domain()
+		f.devices = append(f.devices, d.string())
+		f.flags = append(f.flags, d.uint32())
+	case procDomainScreenshot:
+		mime := "image/x-portable-pixmap"
+		e.optionalString(&mime)
+
+		// a 2x1 image of a red and a blue pixel.
+		stream = []byte("P6\n2 1\n255\n\xff\x00\x00\x00\x00\xff")
+	default:
+		return nil, nil, "unsupported procedure"
+	}
+
+	return e.bytes(), stream, ""
+}
+
+func writeMessage(conn net.Conn, h *header, typ messageType, status messageStatus, body []byte) {
+	e := &encoder{}
+	e.uint32(headerSize + 4 + uint32(len(body)))
+	e.uint32(h.Program)
+	e.uint32(h.Version)
+	e.uint32(uint32(h.Procedure))
+	e.uint32(uint32(typ))
+	e.uint32(h.Serial)
+	e.uint32(uint32(status))
+	e.buf.Write(body)
+
+	_, _ = conn.Write(e.bytes())
+}
+
+func (f *fakeDaemon) called() []procedure {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]procedure(nil), f.calls...)
+}
+
+// openedProvider returns a provider opened on the daemon, the calls of Open
+// are not recorded.
+func openedProvider(t *testing.T, f *fakeDaemon) *Config {
+	t.Helper()
+
+	p := New(f.socket, "vm1")
+	if err := p.Open(context.Background()); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	t.Cleanup(func() { _ = p.Close(context.Background()) })
+
+	f.mu.Lock()
+	f.calls = nil
+	f.mu.Unlock()
+
+	return p
+}
+
+// Requirement: Open fails for a domain the daemon does not know.
+func TestOpenUnknownDomain(t *testing.T) {
+	f := newFakeDaemon(t, stateShutoff)
+
+	p := New(f.socket, "vm2")
+	err := p.Open(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "no domain with matching name") {
+		t.Fatalf("Open = %v, want a domain not found error", err)
+	}
+
+	if _, err := p.PowerStateGet(context.Background()); err == nil {
+		t.Fatal("PowerStateGet on an unopened provider succeeded")
+	}
+}
+
+// Requirement: power states map to the domain lifecycle procedures, a stopped
+// domain is started by a reset.
+func TestPower(t *testing.T) {
+	tests := []struct {
+		name  string
+		state int32
+		power string
+		calls []procedure
+		want  string
+	}{
+		{"on", stateShutoff, "on", []procedure{procDomainGetInfo, procDomainCreate}, "on"},
+		{"on running", stateRunning, "on", []procedure{procDomainGetInfo}, "on"},
+		{"off", stateRunning, "off", []procedure{procDomainGetInfo, procDomainDestroy}, "off"},
+		{"soft", stateRunning, "soft", []procedure{procDomainGetInfo, procDomainShutdown}, "off"},
+		{"reset", stateRunning, "reset", []procedure{procDomainGetInfo, procDomainReset}, "on"},
+		{"reset stopped", stateShutoff, "reset", []procedure{procDomainGetInfo, procDomainCreate}, "on"},
+		{"cycle", stateRunning, "cycle", []procedure{procDomainGetInfo, procDomainDestroy, procDomainCreate}, "on"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFakeDaemon(t, tc.state)
+			p := openedProvider(t, f)
+
+			ok, err := p.PowerSet(context.Background(), tc.power)
+			if err != nil || !ok {
+				t.Fatalf("PowerSet(%s) = %v, %v", tc.power, ok, err)
+			}
+
+			if calls := f.called(); !equalCalls(calls, tc.calls) {
+				t.Fatalf("calls = %v, want %v", calls, tc.calls)
+			}
+
+			state, err := p.PowerStateGet(context.Background())
+			if err != nil {
+				t.Fatalf("PowerStateGet: %v", err)
+			}
+
+			if state != tc.want {
+				t.Fatalf("PowerStateGet = %q, want %q", state, tc.want)
+			}
+		})
+	}
+
+	p := openedProvider(t, newFakeDaemon(t, stateRunning))
+	if _, err := p.PowerSet(context.Background(), "hibernate"); err == nil {
+		t.Fatal("PowerSet(hibernate) succeeded")
+	}
+}
+
+func equalCalls(a, b []procedure) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Requirement: the boot device replaces the boot order of the domain
+// definition.
+func TestBootDeviceSet(t *testing.T) {
+	f := newFakeDaemon(t, stateShutoff)
+	p := openedProvider(t, f)
+
+	ok, err := p.BootDeviceSet(context.Background(), "pxe", true, false)
+	if err != nil || !ok {
+		t.Fatalf("BootDeviceSet = %v, %v", ok, err)
+	}
+
+	want := "    <type arch='x86_64' machine='pc-q35-8.2'>hvm</type>\n    <boot dev='network'/>\n  </os>"
+	if !strings.Contains(f.xml, want) || strings.Contains(f.xml, "<boot dev='hd'/>") {
+		t.Fatalf("defined XML = %s", f.xml)
+	}
+
+	if _, err := p.BootDeviceSet(context.Background(), "usb", true, false); err == nil {
+		t.Fatal("BootDeviceSet(usb) succeeded")
+	}
+}
+
+// Requirement: setBootDevice drops the per device boot order and fails on a
+// definition without an <os> element.
+func TestSetBootDevice(t *testing.T) {
+	xml := "<domain><os><type>hvm</type></os><devices><disk><boot order='1'/></disk></devices></domain>"
+
+	got, err := setBootDevice(xml, "cdrom")
+	if err != nil {
+		t.Fatalf("setBootDevice: %v", err)
+	}
+
+	want := "<domain><os><type>hvm</type><boot dev='cdrom'/></os><devices><disk></disk></devices></domain>"
+	if got != want {
+		t.Fatalf("setBootDevice = %s, want %s", got, want)
+	}
+
+	if _, err := setBootDevice("<domain></domain>", "hd"); err == nil {
+		t.Fatal("setBootDevice without <os> succeeded")
+	}
+}
+
+// Requirement: virtual media is inserted into, and ejected from, the CD-ROM
+// drive of the domain, live on a running domain.
+func TestSetVirtualMedia(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    int32
+		mediaURL string
+		source   string
+		flags    uint32
+	}{
+		{"file", stateShutoff, "/var/lib/libvirt/images/boot.iso", `<disk type="file" device="cdrom"><driver name="qemu" type="raw"></driver><source file="/var/lib/libvirt/images/boot.iso"></source>`, affectConfig | modifyForce},
+		{"http", stateRunning, "http://10.0.0.1:8080/boot.iso", `<disk type="network" device="cdrom"><driver name="qemu" type="raw"></driver><source protocol="http" name="/boot.iso"><host name="10.0.0.1" port="8080"></host></source>`, affectLive | affectConfig | modifyForce},
+		{"eject", stateRunning, "", `<disk type="file" device="cdrom"><driver name="qemu" type="raw"></driver><target`, affectLive | affectConfig | modifyForce},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFakeDaemon(t, tc.state)
+			p := openedProvider(t, f)
+
+			ok, err := p.SetVirtualMedia(context.Background(), "CD", tc.mediaURL)
+			if err != nil || !ok {
+				t.Fatalf("SetVirtualMedia = %v, %v", ok, err)
+			}
+
+			if len(f.devices) != 1 || !strings.HasPrefix(f.devices[0], tc.source) || !strings.Contains(f.devices[0], `<target dev="sda" bus="sata">`) {
+				t.Fatalf("device XML = %v", f.devices)
+			}
+
+			if f.flags[0] != tc.flags {
+				t.Fatalf("flags = %d, want %d", f.flags[0], tc.flags)
+			}
+		})
+	}
+
+	p := openedProvider(t, newFakeDaemon(t, stateShutoff))
+	if _, err := p.SetVirtualMedia(context.Background(), "Floppy", "/tmp/floppy.img"); err == nil {
+		t.Fatal("SetVirtualMedia without a floppy drive succeeded")
+	}
+
+	if _, err := p.SetVirtualMedia(context.Background(), "CD", "nfs://10.0.0.1/boot.iso"); err == nil {
+		t.Fatal("SetVirtualMedia with an nfs URL succeeded")
+	}
+}
+
+// Requirement: the inventory is read from the domain XML.
+func TestInventory(t *testing.T) {
+	p := openedProvider(t, newFakeDaemon(t, stateRunning))
+
+	device, err := p.Inventory(context.Background())
+	if err != nil {
+		t.Fatalf("Inventory: %v", err)
+	}
+
+	if device.Serial != "5e0c3b6e-8f4e-4a8b-9c1d-2f3e4a5b6c7d" || device.Model != "pc-q35-8.2" {
+		t.Fatalf("device = %+v", device.Common)
+	}
+
+	if len(device.CPUs) != 1 || device.CPUs[0].Threads != 2 {
+		t.Fatalf("CPUs = %+v", device.CPUs)
+	}
+
+	if len(device.Memory) != 1 || device.Memory[0].SizeBytes != 4<<30 {
+		t.Fatalf("Memory = %+v", device.Memory)
+	}
+
+	if len(device.Drives) != 1 || device.Drives[0].Description != "/var/lib/libvirt/images/vm1.qcow2" {
+		t.Fatalf("Drives = %+v", device.Drives)
+	}
+
+	if len(device.NICs) != 1 || len(device.NICs[0].NICPorts) != 1 || device.NICs[0].NICPorts[0].MacAddress != "52:54:00:12:34:56" {
+		t.Fatalf("NICs = %+v", device.NICs)
+	}
+}
+
+// Requirement: the PPM screenshot of QEMU is returned as a PNG.
+func TestScreenshot(t *testing.T) {
+	p := openedProvider(t, newFakeDaemon(t, stateRunning))
+
+	img, fileType, err := p.Screenshot(context.Background())
+	if err != nil {
+		t.Fatalf("Screenshot: %v", err)
+	}
+
+	if fileType != "png" {
+		t.Fatalf("file type = %q, want png", fileType)
+	}
+
+	decoded, err := png.Decode(bytes.NewReader(img))
+	if err != nil {
+		t.Fatalf("png.Decode: %v", err)
+	}
+
+	if r, _, b, _ := decoded.At(0, 0).RGBA(); r != 0xffff || b != 0 {
+		t.Fatalf("pixel (0, 0) = %v, want red", decoded.At(0, 0))
+	}
+
+	if r, _, b, _ := decoded.At(1, 0).RGBA(); r != 0 || b != 0xffff {
+		t.Fatalf("pixel (1, 0) = %v, want blue", decoded.At(1, 0))
+	}
+
+	if _, _, err := New("", "vm1").Screenshot(context.Background()); !errors.Is(err, bmclibErrs.ErrScreenshot) {
+		t.Fatalf("Screenshot on an unopened provider = %v, want ErrScreenshot", err)
+	}
+}
@@ -0,0 +1,89 @@
+package libvirt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// compile-time assertions that the provider implements the interfaces.
+var (
+	_ bmc.PowerStateGetter = (*Config)(nil)
+	_ bmc.PowerSetter      = (*Config)(nil)
+)
+
+// PowerStateGet returns "on" for a running (or paused) domain and "off"
+// otherwise.
+func (p *Config) PowerStateGet(ctx context.Context) (state string, err error) {
+	if err := p.opened(); err != nil {
+		return "", err
+	}
+
+	s, err := p.domainState(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get libvirt domain state: %w", err)
+	}
+
+	if running(s) {
+		return "on", nil
+	}
+
+	return "off", nil
+}
+
+// PowerSet sets the power state of the domain.
+//
+// "on" starts the domain, "off" destroys (powers off) it, "soft" requests an
+// ACPI shutdown of the guest, "reset" resets the running domain and "cycle"
+// destroys and starts it. A stopped domain is started by "reset" and "cycle".
+func (p *Config) PowerSet(ctx context.Context, state string) (ok bool, err error) {
+	if err := p.opened(); err != nil {
+		return false, err
+	}
+
+	current, err := p.domainState(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get libvirt domain state: %w", err)
+	}
+
+	on := running(current)
+
+	switch strings.ToLower(state) {
+	case "on":
+		if !on {
+			err = p.domainCall(ctx, procDomainCreate)
+		}
+	case "off":
+		if on {
+			err = p.domainCall(ctx, procDomainDestroy)
+		}
+	case "soft":
+		if on {
+			err = p.domainCall(ctx, procDomainShutdown)
+		}
+	case "reset":
+		if on {
+			err = p.domainCall(ctx, procDomainReset, 0)
+		} else {
+			err = p.domainCall(ctx, procDomainCreate)
+		}
+	case "cycle":
+		if on {
+			if err := p.domainCall(ctx, procDomainDestroy); err != nil {
+				return false, fmt.Errorf("failed to power off libvirt domain: %w", err)
+			}
+		}
+
+		err = p.domainCall(ctx, procDomainCreate)
+	default:
+		return false, fmt.Errorf("invalid power state: %s", state)
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to set libvirt domain power state %s: %w", state, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,376 @@
+package libvirt
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+)
+
+// The libvirt remote protocol, see src/remote/remote_protocol.x and
+// src/rpc/virnetprotocol.x of libvirt. A message is a length prefixed XDR
+// encoded header followed by the XDR encoded arguments or return value of the
+// procedure.
+const (
+	remoteProgram         = 0x20008086
+	remoteProtocolVersion = 1
+
+	// maxMessageSize is the largest message the daemon sends, a stream
+	// message carries up to 256KiB of data.
+	maxMessageSize = 32 << 20
+
+	// headerSize is the size of the message header, after the length.
+	headerSize = 24
+)
+
+// procedure is a remote protocol procedure number.
+type procedure uint32
+
+const (
+	procConnectOpen             procedure = 1
+	procConnectClose            procedure = 2
+	procDomainCreate            procedure = 9
+	procDomainDefineXML         procedure = 11
+	procDomainDestroy           procedure = 12
+	procDomainGetXMLDesc        procedure = 14
+	procDomainGetInfo           procedure = 16
+	procDomainLookupByName      procedure = 23
+	procDomainShutdown          procedure = 33
+	procDomainUpdateDeviceFlags procedure = 145
+	procDomainScreenshot        procedure = 211
+	procDomainReset             procedure = 245
+)
+
+// messageType is the type of a remote protocol message.
+type messageType uint32
+
+const (
+	messageCall   messageType = 0
+	messageReply  messageType = 1
+	messageStream messageType = 3
+)
+
+// messageStatus is the status of a remote protocol message.
+type messageStatus uint32
+
+const (
+	statusOK       messageStatus = 0
+	statusError    messageStatus = 1
+	statusContinue messageStatus = 2
+)
+
+// header is the remote protocol message header.
+type header struct {
+	Program   uint32
+	Version   uint32
+	Procedure procedure
+	Type      messageType
+	Serial    uint32
+	Status    messageStatus
+}
+
+// domain is a remote_nonnull_domain, the reference to a domain the daemon
+// returns on lookup and expects as the argument of the domain procedures.
+type domain struct {
+	Name string
+	UUID [16]byte
+	ID   int32
+}
+
+// rpcError is a remote_error returned by the daemon.
+type rpcError struct {
+	Code    int32
+	Domain  int32
+	Message string
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("libvirt error %d: %s", e.Code, e.Message)
+}
+
+// rpc is a client of the libvirt remote protocol, it serializes the calls on
+// the connection.
+type rpc struct {
+	mu     sync.Mutex
+	conn   net.Conn
+	serial uint32
+}
+
+// call invokes the procedure with the XDR encoded arguments and returns the
+// decoder of the reply.
+func (r *rpc) call(ctx context.Context, proc procedure, args []byte) (*decoder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deadline(ctx)
+
+	serial, err := r.send(proc, args)
+	if err != nil {
+		return nil, err
+	}
+
+	h, body, err := r.receive(serial)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.Status == statusError {
+		return nil, decodeError(body)
+	}
+
+	return &decoder{r: bytes.NewReader(body)}, nil
+}
+
+// callStream invokes a procedure that returns a download stream, it returns
+// the decoder of the reply and the data of the stream.
+func (r *rpc) callStream(ctx context.Context, proc procedure, args []byte) (*decoder, []byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deadline(ctx)
+
+	serial, err := r.send(proc, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	h, body, err := r.receive(serial)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if h.Status == statusError {
+		return nil, nil, decodeError(body)
+	}
+
+	var data bytes.Buffer
+
+	for {
+		h, chunk, err := r.receive(serial)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if h.Type != messageStream {
+			return nil, nil, fmt.Errorf("unexpected message type %d in stream", h.Type)
+		}
+
+		switch h.Status {
+		case statusContinue:
+			data.Write(chunk)
+		case statusOK:
+			return &decoder{r: bytes.NewReader(body)}, data.Bytes(), nil
+		default:
+			return nil, nil, decodeError(chunk)
+		}
+	}
+}
+
+// deadline sets the deadline of the context on the connection, a context
+// without a deadline clears it.
+func (r *rpc) deadline(ctx context.Context) {
+	deadline, _ := ctx.Deadline()
+	_ = r.conn.SetDeadline(deadline)
+}
+
+// send writes a call message and returns its serial.
+func (r *rpc) send(proc procedure, args []byte) (uint32, error) {
+	r.serial++
+
+	e := &encoder{}
+	e.uint32(headerSize + 4 + uint32(len(args)))
+	e.uint32(remoteProgram)
+	e.uint32(remoteProtocolVersion)
+	e.uint32(uint32(proc))
+	e.uint32(uint32(messageCall))
+	e.uint32(r.serial)
+	e.uint32(uint32(statusOK))
+	e.buf.Write(args)
+
+	if _, err := r.conn.Write(e.bytes()); err != nil {
+		return 0, fmt.Errorf("libvirt call %d: %w", proc, err)
+	}
+
+	return r.serial, nil
+}
+
+// receive reads the next message of the call with the serial, messages of
+// other programs, e.g. keepalives, are skipped.
+func (r *rpc) receive(serial uint32) (*header, []byte, error) {
+	for {
+		h, body, err := readMessage(r.conn)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if h.Program != remoteProgram || h.Serial != serial {
+			continue
+		}
+
+		return h, body, nil
+	}
+}
+
+// readMessage reads a message and returns its header and body.
+func readMessage(rd io.Reader) (*header, []byte, error) {
+	var size uint32
+	if err := binary.Read(rd, binary.BigEndian, &size); err != nil {
+		return nil, nil, fmt.Errorf("libvirt read: %w", err)
+	}
+
+	if size < headerSize+4 || size > maxMessageSize {
+		return nil, nil, fmt.Errorf("libvirt read: invalid message size %d", size)
+	}
+
+	buf := make([]byte, size-4)
+	if _, err := io.ReadFull(rd, buf); err != nil {
+		return nil, nil, fmt.Errorf("libvirt read: %w", err)
+	}
+
+	h := &header{}
+	if err := binary.Read(bytes.NewReader(buf[:headerSize]), binary.BigEndian, h); err != nil {
+		return nil, nil, fmt.Errorf("libvirt read: %w", err)
+	}
+
+	return h, buf[headerSize:], nil
+}
+
+// decodeError decodes the leading code, domain and message fields of a
+// remote_error.
+func decodeError(body []byte) error {
+	d := &decoder{r: bytes.NewReader(body)}
+
+	e := &rpcError{
+		Code:   d.int32(),
+		Domain: d.int32(),
+	}
+
+	if message, ok := d.optionalString(); ok {
+		e.Message = message
+	}
+
+	if d.err != nil {
+		return fmt.Errorf("libvirt error: %w", d.err)
+	}
+
+	return e
+}
+
+// encoder encodes XDR values.
+type encoder struct {
+	buf bytes.Buffer
+}
+
+func (e *encoder) uint32(v uint32) {
+	_ = binary.Write(&e.buf, binary.BigEndian, v)
+}
+
+func (e *encoder) int32(v int32) {
+	_ = binary.Write(&e.buf, binary.BigEndian, v)
+}
+
+func (e *encoder) string(s string) {
+	e.uint32(uint32(len(s)))
+	e.buf.WriteString(s)
+	e.buf.Write(make([]byte, padding(len(s))))
+}
+
+// optionalString encodes a remote_string, a pointer to a string.
+func (e *encoder) optionalString(s *string) {
+	if s == nil {
+		e.uint32(0)
+		return
+	}
+
+	e.uint32(1)
+	e.string(*s)
+}
+
+func (e *encoder) domain(dom *domain) {
+	e.string(dom.Name)
+	e.buf.Write(dom.UUID[:])
+	e.int32(dom.ID)
+}
+
+func (e *encoder) bytes() []byte {
+	return e.buf.Bytes()
+}
+
+// decoder decodes XDR values, the first error is kept in err and the values
+// decoded after it are zero.
+type decoder struct {
+	r   *bytes.Reader
+	err error
+}
+
+func (d *decoder) read(v any) {
+	if d.err != nil {
+		return
+	}
+
+	d.err = binary.Read(d.r, binary.BigEndian, v)
+}
+
+func (d *decoder) uint32() uint32 {
+	var v uint32
+	d.read(&v)
+
+	return v
+}
+
+func (d *decoder) int32() int32 {
+	var v int32
+	d.read(&v)
+
+	return v
+}
+
+func (d *decoder) uint64() uint64 {
+	var v uint64
+	d.read(&v)
+
+	return v
+}
+
+func (d *decoder) string() string {
+	n := d.uint32()
+	if d.err != nil {
+		return ""
+	}
+
+	if int64(n) > int64(d.r.Len()) {
+		d.err = errors.New("xdr string exceeds the message")
+		return ""
+	}
+
+	buf := make([]byte, int(n)+padding(int(n)))
+	d.read(buf)
+
+	return string(buf[:n])
+}
+
+// optionalString decodes a remote_string, ok is false for a null string.
+func (d *decoder) optionalString() (s string, ok bool) {
+	if d.uint32() == 0 {
+		return "", false
+	}
+
+	return d.string(), d.err == nil
+}
+
+func (d *decoder) domain() *domain {
+	dom := &domain{Name: d.string()}
+	d.read(&dom.UUID)
+	dom.ID = d.int32()
+
+	return dom
+}
+
+// padding returns the number of bytes that pad n bytes to a multiple of 4.
+func padding(n int) int {
+	return (4 - n%4) % 4
+}
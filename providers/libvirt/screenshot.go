@@ -0,0 +1,99 @@
+package libvirt
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+var _ bmc.ScreenshotGetter = (*Config)(nil)
+
+// Screenshot returns a PNG image of the first screen of the domain console.
+//
+// QEMU returns the screenshot as a binary PPM, it is converted to PNG. An image
+// of another type is returned as is, with the file type of its MIME type.
+func (p *Config) Screenshot(ctx context.Context) (img []byte, fileType string, err error) {
+	if err := p.opened(); err != nil {
+		return nil, "", errors.Wrap(bmclibErrs.ErrScreenshot, err.Error())
+	}
+
+	e := &encoder{}
+	e.domain(p.dom)
+	e.uint32(0) // screen
+	e.uint32(0) // flags
+
+	d, data, err := p.rpc.callStream(ctx, procDomainScreenshot, e.bytes())
+	if err != nil {
+		return nil, "", errors.Wrap(bmclibErrs.ErrScreenshot, err.Error())
+	}
+
+	mime, _ := d.optionalString()
+	if d.err != nil {
+		return nil, "", errors.Wrap(bmclibErrs.ErrScreenshot, d.err.Error())
+	}
+
+	switch mime {
+	case "image/x-portable-pixmap", "image/x-portable-anymap":
+		img, err = ppmToPNG(data)
+		if err != nil {
+			return nil, "", errors.Wrap(bmclibErrs.ErrScreenshot, err.Error())
+		}
+
+		return img, "png", nil
+	case "image/png":
+		return data, "png", nil
+	}
+
+	_, fileType, _ = strings.Cut(mime, "/")
+
+	return data, strings.TrimPrefix(fileType, "x-"), nil
+}
+
+// ppmToPNG converts a binary (P6) PPM image with 8 bit samples to PNG.
+func ppmToPNG(data []byte) ([]byte, error) {
+	r := bufio.NewReader(bytes.NewReader(data))
+
+	var magic string
+
+	var width, height, maxValue int
+	if _, err := fmt.Fscan(r, &magic, &width, &height, &maxValue); err != nil {
+		return nil, fmt.Errorf("invalid PPM header: %w", err)
+	}
+
+	if magic != "P6" || maxValue <= 0 || maxValue > 255 {
+		return nil, fmt.Errorf("unsupported PPM image %s with maximum value %d", magic, maxValue)
+	}
+
+	// a single whitespace separates the header from the samples.
+	if _, err := r.ReadByte(); err != nil {
+		return nil, fmt.Errorf("invalid PPM header: %w", err)
+	}
+
+	pixels := make([]byte, width*height*3)
+	if _, err := io.ReadFull(r, pixels); err != nil {
+		return nil, fmt.Errorf("truncated PPM image: %w", err)
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i := 0; i < width*height; i++ {
+		rgba.Set(i%width, i/width, color.RGBA{R: pixels[i*3], G: pixels[i*3+1], B: pixels[i*3+2], A: 0xff})
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, rgba); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
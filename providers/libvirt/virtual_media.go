@@ -0,0 +1,164 @@
+package libvirt
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+var _ bmc.VirtualMediaSetter = (*Config)(nil)
+
+// mediaDevices maps the virtual media kinds to the disk device of the domain.
+var mediaDevices = map[string]string{
+	"cd":     "cdrom",
+	"dvd":    "cdrom",
+	"floppy": "floppy",
+}
+
+// mediaDisk is the <disk> device XML the media is changed with.
+type mediaDisk struct {
+	XMLName xml.Name     `xml:"disk"`
+	Type    string       `xml:"type,attr"`
+	Device  string       `xml:"device,attr"`
+	Driver  mediaDriver  `xml:"driver"`
+	Source  *mediaSource `xml:"source,omitempty"`
+	Target  mediaTarget  `xml:"target"`
+	// ReadOnly is the <readonly/> element.
+	ReadOnly *struct{} `xml:"readonly,omitempty"`
+}
+
+type mediaDriver struct {
+	Name string `xml:"name,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type mediaSource struct {
+	File     string     `xml:"file,attr,omitempty"`
+	Protocol string     `xml:"protocol,attr,omitempty"`
+	Name     string     `xml:"name,attr,omitempty"`
+	Host     *mediaHost `xml:"host,omitempty"`
+}
+
+type mediaHost struct {
+	Name string `xml:"name,attr"`
+	Port string `xml:"port,attr,omitempty"`
+}
+
+type mediaTarget struct {
+	Dev string `xml:"dev,attr"`
+	Bus string `xml:"bus,attr,omitempty"`
+}
+
+// SetVirtualMedia inserts the image into the CD-ROM (kind "CD" or "DVD") or
+// floppy drive of the domain, an empty mediaURL ejects the media.
+//
+// mediaURL is a path on the hypervisor host, a file:// URL, or an http(s)://
+// URL the hypervisor reads the image from. The domain must have a drive of the
+// kind, the media of a running domain is changed live and in its definition.
+func (p *Config) SetVirtualMedia(ctx context.Context, kind, mediaURL string) (ok bool, err error) {
+	if err := p.opened(); err != nil {
+		return false, err
+	}
+
+	device, ok := mediaDevices[strings.ToLower(kind)]
+	if !ok {
+		return false, fmt.Errorf("unsupported virtual media kind: %s", kind)
+	}
+
+	desc, err := p.domainXML(ctx, false)
+	if err != nil {
+		return false, fmt.Errorf("failed to get libvirt domain XML: %w", err)
+	}
+
+	def := &domainDefinition{}
+	if err := xml.Unmarshal([]byte(desc), def); err != nil {
+		return false, fmt.Errorf("failed to parse libvirt domain XML: %w", err)
+	}
+
+	var target *mediaTarget
+
+	for _, disk := range def.Devices.Disks {
+		if disk.Device == device {
+			target = &mediaTarget{Dev: disk.Target.Dev, Bus: disk.Target.Bus}
+			break
+		}
+	}
+
+	if target == nil {
+		return false, fmt.Errorf("libvirt domain has no %s drive", device)
+	}
+
+	disk, err := newMediaDisk(device, *target, mediaURL)
+	if err != nil {
+		return false, err
+	}
+
+	diskXML, err := xml.Marshal(disk)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal libvirt disk XML: %w", err)
+	}
+
+	state, err := p.domainState(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get libvirt domain state: %w", err)
+	}
+
+	flags := uint32(affectConfig | modifyForce)
+	if running(state) {
+		flags |= affectLive
+	}
+
+	if err := p.updateDevice(ctx, string(diskXML), flags); err != nil {
+		return false, fmt.Errorf("failed to change libvirt %s media: %w", device, err)
+	}
+
+	return true, nil
+}
+
+// newMediaDisk returns the disk XML of the drive with the media of mediaURL,
+// without a source for an empty mediaURL.
+func newMediaDisk(device string, target mediaTarget, mediaURL string) (*mediaDisk, error) {
+	disk := &mediaDisk{
+		Type:     "file",
+		Device:   device,
+		Driver:   mediaDriver{Name: "qemu", Type: "raw"},
+		Target:   target,
+		ReadOnly: &struct{}{},
+	}
+
+	if mediaURL == "" {
+		return disk, nil
+	}
+
+	u, err := url.Parse(mediaURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid virtual media URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case "":
+		disk.Source = &mediaSource{File: mediaURL}
+	case "file":
+		disk.Source = &mediaSource{File: u.Path}
+	case "http", "https":
+		name := u.EscapedPath()
+		if u.RawQuery != "" {
+			name += "?" + u.RawQuery
+		}
+
+		disk.Type = "network"
+		disk.Source = &mediaSource{
+			Protocol: u.Scheme,
+			Name:     name,
+			Host:     &mediaHost{Name: u.Hostname(), Port: u.Port()},
+		}
+	default:
+		return nil, fmt.Errorf("unsupported virtual media URL scheme: %s", u.Scheme)
+	}
+
+	return disk, nil
+}
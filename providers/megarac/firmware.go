@@ -1,4 +1,4 @@
-package asrockrack
+package megarac
 
 import (
 	"context"
@@ -24,8 +24,8 @@ const (
 )
 
 // FirmwareInstallSteps returns the ordered steps required to install firmware for the given component.
-func (a *ASRockRack) FirmwareInstallSteps(ctx context.Context, component string) ([]constants.FirmwareInstallStep, error) {
-	if err := a.supported(ctx); err != nil {
+func (c *Conn) FirmwareInstallSteps(ctx context.Context, component string) ([]constants.FirmwareInstallStep, error) {
+	if err := c.supported(ctx); err != nil {
 		return nil, bmclibErrs.NewErrUnsupportedHardware(err.Error())
 	}
 
@@ -43,18 +43,18 @@ func (a *ASRockRack) FirmwareInstallSteps(ctx context.Context, component string)
 }
 
 // FirmwareUpload uploads the firmware image for the given component to the BMC.
-func (a *ASRockRack) FirmwareUpload(ctx context.Context, component string, file *os.File) (taskID string, err error) {
+func (c *Conn) FirmwareUpload(ctx context.Context, component string, file *os.File) (taskID string, err error) {
 	switch strings.ToUpper(component) {
 	case common.SlugBIOS:
-		return "", a.firmwareUploadBIOS(ctx, file)
+		return "", c.firmwareUploadBIOS(ctx, file)
 	case common.SlugBMC:
-		return "", a.firmwareUploadBMC(ctx, file)
+		return "", c.firmwareUploadBMC(ctx, file)
 	}
 
 	return "", errors.Wrap(bmclibErrs.ErrFirmwareUpload, "component unsupported: "+component)
 }
 
-func (a *ASRockRack) firmwareUploadBMC(ctx context.Context, file *os.File) error {
+func (c *Conn) firmwareUploadBMC(ctx context.Context, file *os.File) error {
 	//	// expect atleast 5 minutes left in the deadline to proceed with the upload
 	d, _ := ctx.Deadline()
 	if time.Until(d) < 5*time.Minute {
@@ -62,8 +62,8 @@ func (a *ASRockRack) firmwareUploadBMC(ctx context.Context, file *os.File) error
 	}
 
 	// Beware: this locks some capabilities, e.g. the access to fruAttributes
-	a.log.V(2).WithValues("step", "1/4").Info("set device to flash mode, takes a minute...")
-	err := a.setFlashMode(ctx)
+	c.log.V(2).WithValues("step", "1/4").Info("set device to flash mode, takes a minute...")
+	err := c.setFlashMode(ctx)
 	if err != nil {
 		return errors.Wrap(
 			bmclibErrs.ErrFirmwareUpload,
@@ -71,17 +71,13 @@ func (a *ASRockRack) firmwareUploadBMC(ctx context.Context, file *os.File) error
 		)
 	}
 
-	var fwEndpoint string
-	switch a.deviceModel {
-	// E3C256D4ID-NL calls a different endpoint for firmware upload
-	case "E3C256D4ID-NL":
-		fwEndpoint = "api/maintenance/firmware/firmware"
-	default:
-		fwEndpoint = "api/maintenance/firmware"
+	fwEndpoint := c.endpoints().BMCFirmwareUpload
+	if fwEndpoint == "" {
+		fwEndpoint = bmcFirmwareUpload
 	}
 
-	a.log.V(2).WithValues("step", "2/4").Info("upload BMC firmware image to " + fwEndpoint)
-	err = a.uploadFirmware(ctx, fwEndpoint, file)
+	c.log.V(2).WithValues("step", "2/4").Info("upload BMC firmware image to " + fwEndpoint)
+	err = c.uploadFirmware(ctx, fwEndpoint, file)
 	if err != nil {
 		return errors.Wrap(
 			bmclibErrs.ErrFirmwareUpload,
@@ -89,8 +85,8 @@ func (a *ASRockRack) firmwareUploadBMC(ctx context.Context, file *os.File) error
 		)
 	}
 
-	a.log.V(2).WithValues("step", "3/4").Info("verify uploaded BMC firmware")
-	err = a.verifyUploadedFirmware(ctx)
+	c.log.V(2).WithValues("step", "3/4").Info("verify uploaded BMC firmware")
+	err = c.verifyUploadedFirmware(ctx)
 	if err != nil {
 		return errors.Wrap(
 			bmclibErrs.ErrFirmwareUpload,
@@ -101,9 +97,14 @@ func (a *ASRockRack) firmwareUploadBMC(ctx context.Context, file *os.File) error
 	return nil
 }
 
-func (a *ASRockRack) firmwareUploadBIOS(ctx context.Context, file *os.File) error {
-	a.log.V(2).WithValues("step", "1/3").Info("upload BIOS firmware image")
-	err := a.uploadFirmware(ctx, "api/asrr/maintenance/BIOS/firmware", file)
+func (c *Conn) firmwareUploadBIOS(ctx context.Context, file *os.File) error {
+	endpoint := c.endpoints().BIOSFirmwareUpload
+	if endpoint == "" {
+		return errors.Wrap(bmclibErrs.ErrFirmwareUpload, c.unsupported("BIOS install").Error())
+	}
+
+	c.log.V(2).WithValues("step", "1/3").Info("upload BIOS firmware image")
+	err := c.uploadFirmware(ctx, endpoint, file)
 	if err != nil {
 		return errors.Wrap(
 			bmclibErrs.ErrFirmwareUpload,
@@ -111,8 +112,8 @@ func (a *ASRockRack) firmwareUploadBIOS(ctx context.Context, file *os.File) erro
 		)
 	}
 
-	a.log.V(2).WithValues("step", "2/3").Info("set BIOS preserve flash configuration")
-	err = a.biosUpgradeConfiguration(ctx)
+	c.log.V(2).WithValues("step", "2/3").Info("set BIOS preserve flash configuration")
+	err = c.biosUpgradeConfiguration(ctx)
 	if err != nil {
 		return errors.Wrap(
 			bmclibErrs.ErrFirmwareUpload,
@@ -121,8 +122,8 @@ func (a *ASRockRack) firmwareUploadBIOS(ctx context.Context, file *os.File) erro
 	}
 
 	// 3. run upgrade
-	a.log.V(2).WithValues("step", "3/3").Info("proceed with BIOS firmware install")
-	err = a.upgradeBIOS(ctx)
+	c.log.V(2).WithValues("step", "3/3").Info("proceed with BIOS firmware install")
+	err = c.upgradeBIOS(ctx)
 	if err != nil {
 		return errors.Wrap(
 			bmclibErrs.ErrFirmwareUpload,
@@ -134,22 +135,22 @@ func (a *ASRockRack) firmwareUploadBIOS(ctx context.Context, file *os.File) erro
 }
 
 // FirmwareInstallUploaded initiates the install of a previously uploaded firmware image for the given component.
-func (a *ASRockRack) FirmwareInstallUploaded(ctx context.Context, component, uploadTaskID string) (installTaskID string, err error) {
+func (c *Conn) FirmwareInstallUploaded(ctx context.Context, component, uploadTaskID string) (installTaskID string, err error) {
 	switch strings.ToUpper(component) {
 	case common.SlugBIOS:
-		return "", a.firmwareInstallUploadedBIOS(ctx)
+		return "", c.firmwareInstallUploadedBIOS(ctx)
 	case common.SlugBMC:
-		return "", a.firmwareInstallUploadedBMC(ctx)
+		return "", c.firmwareInstallUploadedBMC(ctx)
 	}
 
 	return "", errors.Wrap(bmclibErrs.ErrFirmwareInstall, "component unsupported: "+component)
 }
 
 // firmwareInstallUploadedBIOS uploads and installs firmware for the BMC component
-func (a *ASRockRack) firmwareInstallUploadedBIOS(ctx context.Context) error {
+func (c *Conn) firmwareInstallUploadedBIOS(ctx context.Context) error {
 	// 4. Run the upgrade - preserving current config
-	a.log.V(2).WithValues("step", "install").Info("proceed with BIOS firmware install, preserve current configuration")
-	err := a.upgradeBIOS(ctx)
+	c.log.V(2).WithValues("step", "install").Info("proceed with BIOS firmware install, preserve current configuration")
+	err := c.upgradeBIOS(ctx)
 	if err != nil {
 		return errors.Wrap(
 			bmclibErrs.ErrFirmwareInstallUploaded,
@@ -161,10 +162,10 @@ func (a *ASRockRack) firmwareInstallUploadedBIOS(ctx context.Context) error {
 }
 
 // firmwareInstallUploadedBMC uploads and installs firmware for the BMC component
-func (a *ASRockRack) firmwareInstallUploadedBMC(ctx context.Context) error {
+func (c *Conn) firmwareInstallUploadedBMC(ctx context.Context) error {
 	// 4. Run the upgrade - preserving current config
-	a.log.V(2).WithValues("step", "install").Info("proceed with BMC firmware install, preserve current configuration")
-	err := a.upgradeBMC(ctx)
+	c.log.V(2).WithValues("step", "install").Info("proceed with BMC firmware install, preserve current configuration")
+	err := c.upgradeBMC(ctx)
 	if err != nil {
 		return errors.Wrap(
 			bmclibErrs.ErrFirmwareInstallUploaded,
@@ -176,23 +177,26 @@ func (a *ASRockRack) firmwareInstallUploadedBMC(ctx context.Context) error {
 }
 
 // FirmwareTaskStatus returns the status of a firmware related task queued on the BMC.
-func (a *ASRockRack) FirmwareTaskStatus(ctx context.Context, kind constants.FirmwareInstallStep, component, taskID, installVersion string) (state constants.TaskState, status string, err error) {
+func (c *Conn) FirmwareTaskStatus(ctx context.Context, kind constants.FirmwareInstallStep, component, taskID, installVersion string) (state constants.TaskState, status string, err error) {
 	component = strings.ToUpper(component)
 	switch component {
 	case common.SlugBIOS, common.SlugBMC:
-		return a.firmwareUpdateStatus(ctx, component, installVersion)
+		return c.firmwareUpdateStatus(ctx, component, installVersion)
 	default:
 		return "", "", errors.Wrap(bmclibErrs.ErrFirmwareInstallStatus, "component unsupported: "+component)
 	}
 }
 
 // firmwareUpdateBIOSStatus returns the BIOS firmware install status
-func (a *ASRockRack) firmwareUpdateStatus(ctx context.Context, component, installVersion string) (state constants.TaskState, status string, err error) {
+func (c *Conn) firmwareUpdateStatus(ctx context.Context, component, installVersion string) (state constants.TaskState, status string, err error) {
 	var endpoint string
 	component = strings.ToUpper(component)
 	switch component {
 	case common.SlugBIOS:
-		endpoint = "api/asrr/maintenance/BIOS/flash-progress"
+		endpoint = c.endpoints().BIOSFlashProgress
+		if endpoint == "" {
+			return "", "", errors.Wrap(bmclibErrs.ErrFirmwareInstallStatus, c.unsupported("BIOS install").Error())
+		}
 	case common.SlugBMC:
 		endpoint = "api/maintenance/firmware/flash-progress"
 	default:
@@ -202,9 +206,9 @@ func (a *ASRockRack) firmwareUpdateStatus(ctx context.Context, component, instal
 	// 1. query the flash progress endpoint
 	//
 	// once an update completes/fails this endpoint will return 500
-	progress, err := a.flashProgress(ctx, endpoint)
+	progress, err := c.flashProgress(ctx, endpoint)
 	if err != nil {
-		a.log.V(3).Error(err, "bmc query for install progress returned error: ")
+		c.log.V(3).Error(err, "bmc query for install progress returned error: ")
 	}
 
 	if progress != nil {
@@ -218,16 +222,21 @@ func (a *ASRockRack) firmwareUpdateStatus(ctx context.Context, component, instal
 		case 2:
 			return constants.Complete, status, nil
 		default:
-			a.log.V(3).WithValues("state", progress.State).Info("warn", "bmc returned unknown flash progress state")
+			c.log.V(3).WithValues("state", progress.State).Info("warn", "bmc returned unknown flash progress state")
 		}
 	}
 
 	// 2. query the firmware info endpoint to determine the update status
 	//
-	// at this point the flash-progress endpoint isn't returning useful information
+	// at this point the flash-progress endpoint isn't returning useful information,
+	// builds without a firmware info endpoint leave the status unknown
+	if c.endpoints().FirmwareInfo == "" {
+		return constants.Unknown, status, nil
+	}
+
 	var installStatus int
 
-	installStatus, err = a.versionInstalled(ctx, component, installVersion)
+	installStatus, err = c.versionInstalled(ctx, component, installVersion)
 	if err != nil {
 		return "", "", errors.Wrap(bmclibErrs.ErrFirmwareInstallStatus, err.Error())
 	}
@@ -237,7 +246,7 @@ func (a *ASRockRack) firmwareUpdateStatus(ctx context.Context, component, instal
 		if progress == nil {
 			// TODO: we should pass the force parameter to firmwareUpdateStatus,
 			// so that we can know if we expect a version change or not
-			a.log.V(3).Info("Nil progress + no version change -> unknown")
+			c.log.V(3).Info("Nil progress + no version change -> unknown")
 			return constants.Unknown, status, nil
 		}
 
@@ -256,16 +265,16 @@ func (a *ASRockRack) firmwareUpdateStatus(ctx context.Context, component, instal
 // - 0 indicates the given version parameter matches the version installed
 // - 1 indicates the given version parameter does not match the version installed
 // - 2 the version parameter returned from the BMC is empty (which means the BMC needs a reset)
-func (a *ASRockRack) versionInstalled(ctx context.Context, component, version string) (status int, err error) {
+func (c *Conn) versionInstalled(ctx context.Context, component, version string) (status int, err error) {
 	component = strings.ToUpper(component)
 	if !internal.StringInSlice(component, []string{common.SlugBIOS, common.SlugBMC}) {
 		return versionStrError, errors.Wrap(bmclibErrs.ErrFirmwareInstall, "component unsupported: "+component)
 	}
 
-	fwInfo, err := a.firmwareInfo(ctx)
+	fwInfo, err := c.firmwareInfo(ctx)
 	if err != nil {
 		err = errors.Wrap(err, "error querying for firmware info: ")
-		a.log.V(3).Info("warn", err.Error())
+		c.log.V(3).Info("warn", err.Error())
 		return versionStrError, err
 	}
 
@@ -1,4 +1,4 @@
-package asrockrack
+package megarac
 
 import (
 	"bytes"
@@ -136,8 +136,8 @@ var knownPOSTCodes = map[int]string{
 	178: constants.POSTStateUEFI,
 }
 
-func (a *ASRockRack) listUsers(ctx context.Context) ([]*UserAccount, error) {
-	resp, statusCode, err := a.queryHTTPS(ctx, "api/settings/users", "GET", nil, nil, 0)
+func (c *Conn) listUsers(ctx context.Context) ([]*UserAccount, error) {
+	resp, statusCode, err := c.queryHTTPS(ctx, "api/settings/users", "GET", nil, nil, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -156,7 +156,7 @@ func (a *ASRockRack) listUsers(ctx context.Context) ([]*UserAccount, error) {
 	return accounts, nil
 }
 
-func (a *ASRockRack) createUpdateUser(ctx context.Context, account *UserAccount) error {
+func (c *Conn) createUpdateUser(ctx context.Context, account *UserAccount) error {
 	endpoint := "api/settings/users/" + fmt.Sprintf("%d", account.ID)
 
 	payload, err := json.Marshal(account)
@@ -165,7 +165,7 @@ func (a *ASRockRack) createUpdateUser(ctx context.Context, account *UserAccount)
 	}
 
 	headers := map[string]string{"Content-Type": "application/json"}
-	_, statusCode, err := a.queryHTTPS(ctx, endpoint, "PUT", bytes.NewReader(payload), headers, 0)
+	_, statusCode, err := c.queryHTTPS(ctx, endpoint, "PUT", bytes.NewReader(payload), headers, 0)
 	if err != nil {
 		return err
 	}
@@ -182,14 +182,13 @@ func (a *ASRockRack) createUpdateUser(ctx context.Context, account *UserAccount)
 // with the BMC set in flash mode, no new logins are accepted
 // and only a few endpoints can be queried with the existing session
 // one of the few being the install progress/flash status endpoint.
-func (a *ASRockRack) setFlashMode(ctx context.Context) error {
+func (c *Conn) setFlashMode(ctx context.Context) error {
 	device := common.NewDevice()
 	device.Metadata = map[string]string{}
-	_ = a.fruAttributes(ctx, &device)
+	_ = c.fruAttributes(ctx, &device)
 
 	pConfig := &preserveConfig{}
-	// preserve config is needed by e3c256d4i
-	if device.Model == E3C256D4ID_NL {
+	if c.profile.preserveConfigOnFlashMode(device.Model) {
 		pConfig = &preserveConfig{PreserveConfig: 1}
 	}
 
@@ -199,7 +198,7 @@ func (a *ASRockRack) setFlashMode(ctx context.Context) error {
 	}
 
 	headers := map[string]string{"Content-Type": "application/json"}
-	_, statusCode, err := a.queryHTTPS(ctx, "api/maintenance/flash", "PUT", bytes.NewReader(payload), headers, 0)
+	_, statusCode, err := c.queryHTTPS(ctx, "api/maintenance/flash", "PUT", bytes.NewReader(payload), headers, 0)
 	if err != nil {
 		return err
 	}
@@ -208,7 +207,7 @@ func (a *ASRockRack) setFlashMode(ctx context.Context) error {
 		return fmt.Errorf("non 200 response: %d", statusCode)
 	}
 
-	a.resetRequired = true
+	c.resetRequired = true
 
 	return nil
 }
@@ -222,7 +221,7 @@ func multipartSize(fieldname, filename string) int64 {
 }
 
 // 2 Upload the firmware file
-func (a *ASRockRack) uploadFirmware(ctx context.Context, endpoint string, file *os.File) error {
+func (c *Conn) uploadFirmware(ctx context.Context, endpoint string, file *os.File) error {
 	var size int64
 	finfo, err := file.Stat()
 	if err != nil {
@@ -272,7 +271,7 @@ func (a *ASRockRack) uploadFirmware(ctx context.Context, endpoint string, file *
 	}
 
 	// POST payload
-	_, statusCode, err := a.queryHTTPS(ctx, endpoint, "POST", pipeReader, headers, contentLength)
+	_, statusCode, err := c.queryHTTPS(ctx, endpoint, "POST", pipeReader, headers, contentLength)
 	if err != nil {
 		return err
 	}
@@ -285,8 +284,8 @@ func (a *ASRockRack) uploadFirmware(ctx context.Context, endpoint string, file *
 }
 
 // 3. Verify uploaded firmware file - to be invoked after uploadFirmware()
-func (a *ASRockRack) verifyUploadedFirmware(ctx context.Context) error {
-	_, statusCode, err := a.queryHTTPS(ctx, "api/maintenance/firmware/verification", "GET", nil, nil, 0)
+func (c *Conn) verifyUploadedFirmware(ctx context.Context) error {
+	_, statusCode, err := c.queryHTTPS(ctx, "api/maintenance/firmware/verification", "GET", nil, nil, 0)
 	if err != nil {
 		return err
 	}
@@ -299,7 +298,7 @@ func (a *ASRockRack) verifyUploadedFirmware(ctx context.Context) error {
 }
 
 // 4. Start firmware flashing process - to be invoked after verifyUploadedFirmware
-func (a *ASRockRack) upgradeBMC(ctx context.Context) error {
+func (c *Conn) upgradeBMC(ctx context.Context) error {
 	endpoint := "api/maintenance/firmware/upgrade"
 
 	// preserve all configuration during upgrade, full flash
@@ -310,7 +309,7 @@ func (a *ASRockRack) upgradeBMC(ctx context.Context) error {
 	}
 
 	headers := map[string]string{"Content-Type": "application/json"}
-	_, statusCode, err := a.queryHTTPS(ctx, endpoint, "PUT", bytes.NewReader(payload), headers, 0)
+	_, statusCode, err := c.queryHTTPS(ctx, endpoint, "PUT", bytes.NewReader(payload), headers, 0)
 	if err != nil {
 		return err
 	}
@@ -323,8 +322,8 @@ func (a *ASRockRack) upgradeBMC(ctx context.Context) error {
 }
 
 // 5. firmware flash progress
-func (a *ASRockRack) flashProgress(ctx context.Context, endpoint string) (*upgradeProgress, error) {
-	resp, statusCode, err := a.queryHTTPS(ctx, endpoint, "GET", nil, nil, 0)
+func (c *Conn) flashProgress(ctx context.Context, endpoint string) (*upgradeProgress, error) {
+	resp, statusCode, err := c.queryHTTPS(ctx, endpoint, "GET", nil, nil, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -343,8 +342,13 @@ func (a *ASRockRack) flashProgress(ctx context.Context, endpoint string) (*upgra
 }
 
 // Query firmware information from the BMC
-func (a *ASRockRack) firmwareInfo(ctx context.Context) (*firmwareInfo, error) {
-	resp, statusCode, err := a.queryHTTPS(ctx, "api/asrr/fw-info", "GET", nil, nil, 0)
+func (c *Conn) firmwareInfo(ctx context.Context) (*firmwareInfo, error) {
+	endpoint := c.endpoints().FirmwareInfo
+	if endpoint == "" {
+		return nil, c.unsupported("firmware info")
+	}
+
+	resp, statusCode, err := c.queryHTTPS(ctx, endpoint, "GET", nil, nil, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -363,8 +367,13 @@ func (a *ASRockRack) firmwareInfo(ctx context.Context) (*firmwareInfo, error) {
 }
 
 // Query BIOS/UEFI POST code information from the BMC
-func (a *ASRockRack) postCodeInfo(ctx context.Context) (*biosPOSTCode, error) {
-	resp, statusCode, err := a.queryHTTPS(ctx, "/api/asrr/getbioscode", "GET", nil, nil, 0)
+func (c *Conn) postCodeInfo(ctx context.Context) (*biosPOSTCode, error) {
+	endpoint := c.endpoints().PostCode
+	if endpoint == "" {
+		return nil, c.unsupported("POST code")
+	}
+
+	resp, statusCode, err := c.queryHTTPS(ctx, endpoint, "GET", nil, nil, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -383,8 +392,8 @@ func (a *ASRockRack) postCodeInfo(ctx context.Context) (*biosPOSTCode, error) {
 }
 
 // Query the inventory info endpoint
-func (a *ASRockRack) inventoryInfoE3C246D41D(ctx context.Context) ([]*component, error) {
-	resp, statusCode, err := a.queryHTTPS(ctx, "api/asrr/inventory_info", "GET", nil, nil, 0)
+func (c *Conn) inventoryInfo(ctx context.Context, endpoint string) ([]*component, error) {
+	resp, statusCode, err := c.queryHTTPS(ctx, endpoint, "GET", nil, nil, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -403,8 +412,8 @@ func (a *ASRockRack) inventoryInfoE3C246D41D(ctx context.Context) ([]*component,
 }
 
 // Query the fru info endpoint
-func (a *ASRockRack) fruInfo(ctx context.Context) ([]*fru, error) {
-	resp, statusCode, err := a.queryHTTPS(ctx, "api/fru", "GET", nil, nil, 0)
+func (c *Conn) fruInfo(ctx context.Context) ([]*fru, error) {
+	resp, statusCode, err := c.queryHTTPS(ctx, "api/fru", "GET", nil, nil, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -449,8 +458,8 @@ func (a *ASRockRack) fruInfo(ctx context.Context) ([]*fru, error) {
 }
 
 // Query the sensors  endpoint
-func (a *ASRockRack) sensors(ctx context.Context) ([]*sensor, error) {
-	resp, statusCode, err := a.queryHTTPS(ctx, "api/sensors", "GET", nil, nil, 0)
+func (c *Conn) sensors(ctx context.Context) ([]*sensor, error) {
+	resp, statusCode, err := c.queryHTTPS(ctx, "api/sensors", "GET", nil, nil, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -470,8 +479,11 @@ func (a *ASRockRack) sensors(ctx context.Context) ([]*sensor, error) {
 
 // Set the BIOS upgrade configuration
 //   - preserve current configuration
-func (a *ASRockRack) biosUpgradeConfiguration(ctx context.Context) error {
-	endpoint := "api/asrr/maintenance/BIOS/configuration"
+func (c *Conn) biosUpgradeConfiguration(ctx context.Context) error {
+	endpoint := c.endpoints().BIOSConfiguration
+	if endpoint == "" {
+		return c.unsupported("BIOS install")
+	}
 
 	// Preserve existing configuration?
 	p := biosUpdateAction{Action: 2}
@@ -481,7 +493,7 @@ func (a *ASRockRack) biosUpgradeConfiguration(ctx context.Context) error {
 	}
 
 	headers := map[string]string{"Content-Type": "application/json"}
-	resp, statusCode, err := a.queryHTTPS(ctx, endpoint, "POST", bytes.NewReader(payload), headers, 0)
+	resp, statusCode, err := c.queryHTTPS(ctx, endpoint, "POST", bytes.NewReader(payload), headers, 0)
 	if err != nil {
 		return err
 	}
@@ -495,8 +507,11 @@ func (a *ASRockRack) biosUpgradeConfiguration(ctx context.Context) error {
 }
 
 // Run BIOS upgrade
-func (a *ASRockRack) upgradeBIOS(ctx context.Context) error {
-	endpoint := "api/asrr/maintenance/BIOS/upgrade"
+func (c *Conn) upgradeBIOS(ctx context.Context) error {
+	endpoint := c.endpoints().BIOSUpgrade
+	if endpoint == "" {
+		return c.unsupported("BIOS install")
+	}
 
 	// Run upgrade
 	p := biosUpdateAction{Action: 3}
@@ -506,7 +521,7 @@ func (a *ASRockRack) upgradeBIOS(ctx context.Context) error {
 	}
 
 	headers := map[string]string{"Content-Type": "application/json"}
-	resp, statusCode, err := a.queryHTTPS(ctx, endpoint, "POST", bytes.NewReader(payload), headers, 0)
+	resp, statusCode, err := c.queryHTTPS(ctx, endpoint, "POST", bytes.NewReader(payload), headers, 0)
 	if err != nil {
 		return err
 	}
@@ -520,8 +535,8 @@ func (a *ASRockRack) upgradeBIOS(ctx context.Context) error {
 }
 
 // Returns the chassis status object which includes the power state
-func (a *ASRockRack) chassisStatusInfo(ctx context.Context) (*chassisStatus, error) {
-	resp, statusCode, err := a.queryHTTPS(ctx, "/api/chassis-status", "GET", nil, nil, 0)
+func (c *Conn) chassisStatusInfo(ctx context.Context) (*chassisStatus, error) {
+	resp, statusCode, err := c.queryHTTPS(ctx, "/api/chassis-status", "GET", nil, nil, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -540,20 +555,20 @@ func (a *ASRockRack) chassisStatusInfo(ctx context.Context) (*chassisStatus, err
 }
 
 // Aquires a session id cookie and a csrf token
-func (a *ASRockRack) httpsLogin(ctx context.Context) error {
+func (c *Conn) httpsLogin(ctx context.Context) error {
 	urlEndpoint := "api/session"
 
 	// login payload
 	payload := []byte(
 		fmt.Sprintf("username=%s&password=%s&certlogin=0",
-			a.username,
-			a.password,
+			c.username,
+			c.password,
 		),
 	)
 
 	headers := map[string]string{"Content-Type": "application/x-www-form-urlencoded"}
 
-	resp, statusCode, err := a.queryHTTPS(ctx, urlEndpoint, "POST", bytes.NewReader(payload), headers, 0)
+	resp, statusCode, err := c.queryHTTPS(ctx, urlEndpoint, "POST", bytes.NewReader(payload), headers, 0)
 	if err != nil {
 		return fmt.Errorf("logging in: %w", err)
 	}
@@ -563,17 +578,30 @@ func (a *ASRockRack) httpsLogin(ctx context.Context) error {
 	}
 
 	// Unmarshal login session
-	err = json.Unmarshal(resp, a.loginSession)
+	session := &loginSession{}
+	err = json.Unmarshal(resp, session)
 	if err != nil {
 		return fmt.Errorf("unmarshalling response payload: %w", err)
 	}
 
+	c.sessionMu.Lock()
+	c.loginSession = session
+	c.sessionMu.Unlock()
+
 	return nil
 }
 
+// csrfToken returns the CSRF token of the current session.
+func (c *Conn) csrfToken() string {
+	c.sessionMu.RLock()
+	defer c.sessionMu.RUnlock()
+
+	return c.loginSession.CSRFToken
+}
+
 // Close ends the BMC session
-func (a *ASRockRack) httpsLogout(ctx context.Context) error {
-	_, statusCode, err := a.queryHTTPS(ctx, "api/session", "DELETE", nil, nil, 0)
+func (c *Conn) httpsLogout(ctx context.Context) error {
+	_, statusCode, err := c.queryHTTPS(ctx, "api/session", "DELETE", nil, nil, 0)
 	if err != nil {
 		return fmt.Errorf("logging out: %w", err)
 	}
@@ -588,17 +616,46 @@ func (a *ASRockRack) httpsLogout(ctx context.Context) error {
 // queryHTTPS run the HTTPS query passing in the required headers
 // the / suffix should be excluded from the URLendpoint
 // returns - response body, http status code, error if any
-func (a *ASRockRack) queryHTTPS(ctx context.Context, endpoint, method string, payload io.Reader, headers map[string]string, contentLength int64) (responseBody []byte, statusCode int, err error) {
+//
+// When the BMC rejects the session as expired, a new session is opened and the query is retried once,
+// queries with a payload are only retried when the payload can be rewound.
+func (c *Conn) queryHTTPS(ctx context.Context, endpoint, method string, payload io.Reader, headers map[string]string, contentLength int64) (responseBody []byte, statusCode int, err error) {
+	responseBody, statusCode, err = c.doQueryHTTPS(ctx, endpoint, method, payload, headers, contentLength)
+	if err != nil || statusCode != http.StatusUnauthorized || endpoint == "api/session" {
+		return responseBody, statusCode, err
+	}
+
+	if payload != nil {
+		seeker, ok := payload.(io.Seeker)
+		if !ok {
+			return responseBody, statusCode, nil
+		}
+
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return responseBody, statusCode, nil
+		}
+	}
+
+	if err := c.httpsLogin(ctx); err != nil {
+		c.log.V(1).Info("session renewal failed", "error", err.Error())
+		return responseBody, statusCode, nil
+	}
+
+	return c.doQueryHTTPS(ctx, endpoint, method, payload, headers, contentLength)
+}
+
+// doQueryHTTPS runs a single HTTPS query, see queryHTTPS.
+func (c *Conn) doQueryHTTPS(ctx context.Context, endpoint, method string, payload io.Reader, headers map[string]string, contentLength int64) (responseBody []byte, statusCode int, err error) {
 	var req *http.Request
 
-	URL := fmt.Sprintf("https://%s/%s", a.ip, endpoint)
+	URL := fmt.Sprintf("https://%s/%s", c.ip, endpoint)
 	req, err = http.NewRequestWithContext(ctx, method, URL, payload)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// add headers
-	req.Header.Add("X-CSRFTOKEN", a.loginSession.CSRFToken)
+	req.Header.Add("X-CSRFTOKEN", c.csrfToken())
 	for k, v := range headers {
 		req.Header.Add(k, v)
 	}
@@ -613,10 +670,10 @@ func (a *ASRockRack) queryHTTPS(ctx context.Context, endpoint, method string, pa
 	// debug dump request
 	if os.Getenv(constants.EnvEnableDebug) == "true" {
 		reqDump, _ := httputil.DumpRequestOut(req, true)
-		a.log.V(3).Info("trace", "url", URL, "requestDump", string(reqDump))
+		c.log.V(3).Info("trace", "url", URL, "requestDump", string(reqDump))
 	}
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return responseBody, 0, err
 	}
@@ -624,7 +681,7 @@ func (a *ASRockRack) queryHTTPS(ctx context.Context, endpoint, method string, pa
 	// debug dump response
 	if os.Getenv(constants.EnvEnableDebug) == "true" {
 		respDump, _ := httputil.DumpResponse(resp, true)
-		a.log.V(3).Info("trace", "responseDump", string(respDump))
+		c.log.V(3).Info("trace", "responseDump", string(respDump))
 	}
 
 	responseBody, err = io.ReadAll(resp.Body)
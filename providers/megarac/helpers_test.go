@@ -1,4 +1,4 @@
-package asrockrack
+package megarac
 
 import (
 	"context"
@@ -38,7 +38,7 @@ func TestInventoryInfo(t *testing.T) {
 		t.Errorf("login: %s", err.Error())
 	}
 
-	inventory, err := aClient.inventoryInfoE3C246D41D(context.TODO())
+	inventory, err := aClient.inventoryInfo(context.TODO(), "api/asrr/inventory_info")
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -1,4 +1,4 @@
-package asrockrack
+package megarac
 
 import (
 	"context"
@@ -7,7 +7,7 @@ import (
 )
 
 // Inventory returns hardware and firmware inventory
-func (a *ASRockRack) Inventory(ctx context.Context) (device *common.Device, err error) {
+func (c *Conn) Inventory(ctx context.Context) (device *common.Device, err error) {
 	// initialize device to be populated with inventory
 	newDevice := common.NewDevice()
 	device = &newDevice
@@ -16,13 +16,17 @@ func (a *ASRockRack) Inventory(ctx context.Context) (device *common.Device, err
 	device.Metadata = map[string]string{}
 
 	// populate device BMC, BIOS component attributes
-	err = a.fruAttributes(ctx, device)
+	err = c.fruAttributes(ctx, device)
 	if err != nil {
 		return nil, err
 	}
 
+	if c.deviceModel == "" {
+		c.identify(device)
+	}
+
 	// populate device System components attributes
-	err = a.systemAttributes(ctx, device)
+	err = c.systemAttributes(ctx, device)
 	if err != nil {
 		return nil, err
 	}
@@ -31,17 +35,17 @@ func (a *ASRockRack) Inventory(ctx context.Context) (device *common.Device, err
 	//
 	// sensor data collection can fail for a myriad of reasons
 	// we log the error and keep going
-	err = a.systemHealth(ctx, device)
+	err = c.systemHealth(ctx, device)
 	if err != nil {
-		a.log.V(2).Error(err, "sensor data collection error", "deviceModel", a.deviceModel)
+		c.log.V(2).Error(err, "sensor data collection error", "deviceModel", c.deviceModel)
 	}
 
 	return device, nil
 }
 
 // systemHealth collects system health information based on the sensors data
-func (a *ASRockRack) systemHealth(ctx context.Context, device *common.Device) error {
-	sensors, err := a.sensors(ctx)
+func (c *Conn) systemHealth(ctx context.Context, device *common.Device) error {
+	sensors, err := c.sensors(ctx)
 	if err != nil {
 		return err
 	}
@@ -70,14 +74,14 @@ func (a *ASRockRack) systemHealth(ctx context.Context, device *common.Device) er
 	}
 
 	// we don't want to fail inventory collection hence ignore POST code collection error
-	device.Status.PostCodeStatus, device.Status.PostCode, _ = a.PostCode(ctx)
+	device.Status.PostCodeStatus, device.Status.PostCode, _ = c.PostCode(ctx)
 
 	return nil
 }
 
 // fruAttributes collects chassis information
-func (a *ASRockRack) fruAttributes(ctx context.Context, device *common.Device) error {
-	components, err := a.fruInfo(ctx)
+func (c *Conn) fruAttributes(ctx context.Context, device *common.Device) error {
+	components, err := c.fruInfo(ctx)
 	if err != nil {
 		return err
 	}
@@ -108,8 +112,13 @@ func (a *ASRockRack) fruAttributes(ctx context.Context, device *common.Device) e
 }
 
 // systemAttributes collects system component attributes
-func (a *ASRockRack) systemAttributes(ctx context.Context, device *common.Device) error {
-	fwInfo, err := a.firmwareInfo(ctx)
+func (c *Conn) systemAttributes(ctx context.Context, device *common.Device) error {
+	endpoints := c.profile.endpoints(device.Model)
+	if endpoints.FirmwareInfo == "" {
+		return nil
+	}
+
+	fwInfo, err := c.firmwareInfo(ctx)
 	if err != nil {
 		return err
 	}
@@ -142,17 +151,16 @@ func (a *ASRockRack) systemAttributes(ctx context.Context, device *common.Device
 
 	device.Metadata["node_id"] = fwInfo.NodeID
 
-	switch device.Model {
-	case E3C246D4ID_NL, E3C246D4I_NL:
-		return a.componentAttributesE3C246(ctx, fwInfo, device)
-	default:
+	if endpoints.Inventory == "" {
 		return nil
 	}
+
+	return c.componentAttributes(ctx, endpoints.Inventory, fwInfo, device)
 }
 
-func (a *ASRockRack) componentAttributesE3C246(ctx context.Context, fwInfo *firmwareInfo, device *common.Device) error {
+func (c *Conn) componentAttributes(ctx context.Context, endpoint string, fwInfo *firmwareInfo, device *common.Device) error {
 	// TODO: implement newer device inventory
-	components, err := a.inventoryInfoE3C246D41D(ctx)
+	components, err := c.inventoryInfo(ctx, endpoint)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,250 @@
+// Package megarac implements a bmclib provider for BMCs running the AMI
+// MegaRAC SP-X web API.
+//
+// ASRock Rack, Gigabyte, Tyan and several ODM boards ship the same MegaRAC
+// SP-X stack with their own OEM endpoints. The endpoints of a board are
+// selected by a Profile, detected from the FRU board manufacturer on Open or
+// set with WithProfile.
+package megarac
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/bmc-toolbox/common"
+	"github.com/go-logr/logr"
+	"github.com/jacobweinstock/registrar"
+	"github.com/pkg/errors"
+
+	"github.com/bmc-toolbox/bmclib/v2/constants"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+	"github.com/bmc-toolbox/bmclib/v2/internal/httpclient"
+	"github.com/bmc-toolbox/bmclib/v2/providers"
+)
+
+const (
+	// ProviderName for the provider implementation
+	ProviderName = "megarac"
+	// ProviderProtocol for the provider implementation
+	ProviderProtocol = "vendorapi"
+)
+
+// Features implemented by megarac https
+var Features = registrar.Features{
+	providers.FeaturePostCodeRead,
+	providers.FeatureBmcReset,
+	providers.FeatureUserCreate,
+	providers.FeatureUserUpdate,
+	providers.FeatureFirmwareUpload,
+	providers.FeatureFirmwareInstallUploaded,
+	providers.FeatureFirmwareTaskStatus,
+	providers.FeatureFirmwareInstallSteps,
+	providers.FeatureInventoryRead,
+	providers.FeaturePowerSet,
+	providers.FeaturePowerState,
+	providers.FeatureSensorsRead,
+}
+
+// Conn holds the status and properties of a connection to a MegaRAC SP-X BMC
+type Conn struct {
+	// sessionMu guards loginSession, the session keepalive re-establishes the session while queries run.
+	sessionMu            sync.RWMutex
+	loginSession         *loginSession
+	httpClient           *http.Client
+	log                  logr.Logger
+	ip                   string
+	username             string
+	password             string
+	deviceModel          string
+	profile              *Profile
+	profileDetected      bool
+	httpClientSetupFuncs []func(*http.Client)
+	resetRequired        bool
+	skipLogout           bool
+}
+
+// Config holds the optional configuration for a MegaRAC connection.
+type Config struct {
+	Port string
+}
+
+// Option is a type that can configure a *Conn
+type Option func(*Conn)
+
+// WithSecureTLS enforces trusted TLS connections, with an optional CA certificate pool.
+// Using this option with an nil pool uses the system CAs.
+func WithSecureTLS(rootCAs *x509.CertPool) Option {
+	return func(c *Conn) {
+		c.httpClientSetupFuncs = append(c.httpClientSetupFuncs, httpclient.SecureTLSOption(rootCAs))
+	}
+}
+
+// WithHTTPClient sets an HTTP client on the Conn
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Conn) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithProfile sets the OEM profile of the BMC, skipping the detection of the
+// profile from the FRU board manufacturer.
+func WithProfile(profile Profile) Option { //nolint:gocritic // functional options take their config by value by convention
+	return func(c *Conn) {
+		c.profile = &profile
+	}
+}
+
+// New returns a new Conn instance ready to be used
+func New(ip, username, password string, log logr.Logger, opts ...Option) *Conn {
+	c := &Conn{
+		ip:           ip,
+		username:     username,
+		password:     password,
+		log:          log,
+		loginSession: &loginSession{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.httpClient == nil {
+		c.httpClient = httpclient.Build(c.httpClientSetupFuncs...)
+	} else {
+		for _, setupFunc := range c.httpClientSetupFuncs {
+			setupFunc(c.httpClient)
+		}
+	}
+	return c
+}
+
+// Name returns the name of this provider.
+func (c *Conn) Name() string {
+	return ProviderName
+}
+
+// Profile returns the OEM profile of the BMC, the profile is detected on Open
+// unless set with WithProfile.
+func (c *Conn) Profile() (profile Profile, ok bool) {
+	if c.profile == nil {
+		return Profile{}, false
+	}
+
+	return *c.profile, true
+}
+
+// Open a connection to a BMC, implements the Opener interface
+func (c *Conn) Open(ctx context.Context) (err error) {
+	if err := c.httpsLogin(ctx); err != nil {
+		return err
+	}
+
+	if err := c.supported(ctx); err != nil {
+		// the session is not kept open on a board the provider does not manage
+		_ = c.httpsLogout(ctx)
+
+		return err
+	}
+
+	return nil
+}
+
+// supported identifies the board and returns an error when the profile of the
+// board does not support its model.
+func (c *Conn) supported(ctx context.Context) error {
+	if c.deviceModel == "" {
+		device := common.NewDevice()
+		device.Metadata = map[string]string{}
+
+		err := c.fruAttributes(ctx, &device)
+		if err != nil {
+			return errors.Wrap(err, "failed to identify device model")
+		}
+
+		if device.Model == "" {
+			return errors.New("failed to identify device model - empty model attribute")
+		}
+
+		c.identify(&device)
+	}
+
+	// the provider dedicated to a detected family opens its own session to the
+	// BMC, the board is not managed twice.
+	if c.profileDetected && c.profile.Provider != "" {
+		return fmt.Errorf("%s boards are managed by the %s provider", c.profile.Family, c.profile.Provider)
+	}
+
+	if c.profile.supportsModel(c.deviceModel) {
+		return nil
+	}
+
+	return fmt.Errorf("device model not supported: %s", c.deviceModel)
+}
+
+// identify sets the board model and, unless set with WithProfile, the profile
+// detected from the board manufacturer of the device.
+func (c *Conn) identify(device *common.Device) {
+	c.deviceModel = device.Model
+
+	if c.profile == nil {
+		profile := DetectProfile(device.Vendor)
+		c.profile = &profile
+		c.profileDetected = true
+
+		c.log.V(2).Info("detected MegaRAC profile", "family", profile.Family, "manufacturer", device.Vendor, "model", device.Model)
+	}
+}
+
+// endpoints returns the OEM endpoints of the board.
+func (c *Conn) endpoints() Endpoints {
+	return c.profile.endpoints(c.deviceModel)
+}
+
+// unsupported returns the error for a capability the OEM build of the BMC does
+// not expose.
+func (c *Conn) unsupported(capability string) error {
+	family := "unidentified"
+	if c.profile != nil {
+		family = c.profile.Family
+	}
+
+	return errors.Wrap(bmclibErrs.ErrNotImplemented, capability+" is not supported by the "+family+" MegaRAC build")
+}
+
+// Close a connection to a BMC, implements the Closer interface
+func (c *Conn) Close(ctx context.Context) (err error) {
+	if c.skipLogout {
+		return nil
+	}
+
+	return c.httpsLogout(ctx)
+}
+
+// KeepSessionAlive refreshes the BMC web session, an expired session is re-established by queryHTTPS.
+func (c *Conn) KeepSessionAlive(ctx context.Context) (err error) {
+	_, err = c.chassisStatusInfo(ctx)
+
+	return err
+}
+
+// CheckCredentials verify whether the credentials are valid or not
+func (c *Conn) CheckCredentials(ctx context.Context) (err error) {
+	return c.httpsLogin(ctx)
+}
+
+// PostCode returns the BIOS/UEFI post code status and value.
+func (c *Conn) PostCode(ctx context.Context) (status string, code int, err error) {
+	postInfo, err := c.postCodeInfo(ctx)
+	if err != nil {
+		return status, code, err
+	}
+
+	code = postInfo.PostData
+	status, exists := knownPOSTCodes[code]
+	if !exists {
+		status = constants.POSTCodeUnknown
+	}
+
+	return status, code, nil
+}
@@ -0,0 +1,284 @@
+package megarac
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+func TestHttpLogin(t *testing.T) {
+	err := aClient.httpsLogin(context.TODO())
+	if err != nil {
+		t.Errorf("login: %s", err.Error())
+	}
+
+	assert.Equal(t, "l5L29IP7", aClient.loginSession.CSRFToken)
+}
+
+func TestClose(t *testing.T) {
+	err := aClient.httpsLogin(context.TODO())
+	if err != nil {
+		t.Errorf("login setup: %s", err.Error())
+	}
+
+	err = aClient.httpsLogout(context.TODO())
+	if err != nil {
+		t.Errorf("logout: %s", err.Error())
+	}
+}
+
+func TestFirwmwareUpdateBMC(t *testing.T) {
+	err := aClient.httpsLogin(context.TODO())
+	if err != nil {
+		t.Errorf("login: %s", err.Error())
+	}
+
+	upgradeFile := "/tmp/dummy-E3C246D4I-NL_L0.01.00.ima"
+	_, err = os.Create(upgradeFile)
+	if err != nil {
+		t.Errorf("create file: %s", err.Error())
+	}
+
+	fh, err := os.Open(upgradeFile)
+	if err != nil {
+		t.Errorf("file open: %s", err.Error())
+	}
+
+	defer fh.Close()
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute*15)
+	defer cancel()
+
+	err = aClient.firmwareUploadBMC(ctx, fh)
+	if err != nil {
+		t.Errorf("upload: %s", err.Error())
+	}
+}
+
+func TestKeepSessionAlive(t *testing.T) {
+	var mu sync.Mutex
+	logins := 0
+	expired := false
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/session", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		logins++
+		expired = false
+		_, _ = w.Write(loginResponse)
+	})
+	handler.HandleFunc("/api/chassis-status", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if expired {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write(chassisStatusResponse)
+	})
+
+	srv := httptest.NewTLSServer(handler)
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+	client := New(u.Host, "foo", "bar", logr.Discard())
+
+	if err := client.httpsLogin(context.TODO()); err != nil {
+		t.Fatalf("login: %s", err.Error())
+	}
+
+	if err := client.KeepSessionAlive(context.TODO()); err != nil {
+		t.Fatalf("keepalive: %s", err.Error())
+	}
+
+	assert.Equal(t, 1, logins)
+
+	// an expired session is renewed and the query retried
+	mu.Lock()
+	expired = true
+	mu.Unlock()
+
+	if err := client.KeepSessionAlive(context.TODO()); err != nil {
+		t.Fatalf("keepalive with an expired session: %s", err.Error())
+	}
+
+	assert.Equal(t, 2, logins)
+}
+
+// Requirement: the session keepalive may re-establish the session while queries run, run with -race.
+func TestKeepSessionAliveConcurrentQueries(t *testing.T) {
+	var mu sync.Mutex
+	logins, queries := 0, 0
+	expired := false
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/session", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		logins++
+		expired = false
+		_, _ = w.Write(loginResponse)
+	})
+	handler.HandleFunc("/api/chassis-status", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		// the BMC drops the session every few queries
+		queries++
+		if expired || queries%5 == 0 {
+			expired = true
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write(chassisStatusResponse)
+	})
+
+	srv := httptest.NewTLSServer(handler)
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+	client := New(u.Host, "foo", "bar", logr.Discard())
+
+	if err := client.httpsLogin(context.TODO()); err != nil {
+		t.Fatalf("login: %s", err.Error())
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				_ = client.KeepSessionAlive(context.TODO())
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				_, _ = client.PowerStateGet(context.TODO())
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if logins < 2 {
+		t.Fatalf("expected the session to be re-established, logins: %d", logins)
+	}
+}
+
+func TestOpenDetectsProfile(t *testing.T) {
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/session", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(loginResponse)
+	})
+	handler.HandleFunc("/api/fru", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(gigabyteFRUResponse)
+	})
+
+	srv := httptest.NewTLSServer(handler)
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+	client := New(u.Host, "foo", "bar", logr.Discard())
+
+	if err := client.Open(context.TODO()); err != nil {
+		t.Fatalf("open: %s", err.Error())
+	}
+
+	profile, ok := client.Profile()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "gigabyte", profile.Family)
+	assert.Equal(t, "MZ32-AR0-00", client.deviceModel)
+
+	// the ASRockRack OEM endpoints are not queried on a Gigabyte board
+	_, _, err := client.PostCode(context.TODO())
+	assert.Equal(t, true, errors.Is(err, bmclibErrs.ErrNotImplemented))
+
+	steps, err := client.FirmwareInstallSteps(context.TODO(), "bmc")
+	if err != nil {
+		t.Fatalf("install steps: %s", err.Error())
+	}
+
+	assert.Equal(t, 5, len(steps))
+}
+
+func TestOpenUnsupportedModel(t *testing.T) {
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/session", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(loginResponse)
+	})
+	handler.HandleFunc("/api/fru", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(gigabyteFRUResponse)
+	})
+
+	srv := httptest.NewTLSServer(handler)
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+
+	// the ASRockRack profile limits the provider to the listed ASRockRack models
+	client := New(u.Host, "foo", "bar", logr.Discard(), WithProfile(ProfileASRockRack))
+	if err := client.Open(context.TODO()); err == nil {
+		t.Fatal("expected an error opening an unsupported board model")
+	}
+}
+
+func TestOpenDedicatedProviderProfile(t *testing.T) {
+	var logouts int
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/session", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			logouts++
+		}
+
+		_, _ = w.Write(loginResponse)
+	})
+	handler.HandleFunc("/api/fru", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(fruinfoResponse)
+	})
+
+	srv := httptest.NewTLSServer(handler)
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+
+	// ASRockRack boards are left to the asrockrack provider
+	client := New(u.Host, "foo", "bar", logr.Discard())
+	err := client.Open(context.TODO())
+	if err == nil {
+		t.Fatal("expected an error opening an ASRockRack board")
+	}
+
+	assert.Equal(t, "asrockrack boards are managed by the asrockrack provider", err.Error())
+	assert.Equal(t, 1, logouts)
+
+	// the asrockrack provider sets the profile and manages the board
+	client = New(u.Host, "foo", "bar", logr.Discard(), WithProfile(ProfileASRockRack))
+	if err := client.Open(context.TODO()); err != nil {
+		t.Fatalf("open: %s", err.Error())
+	}
+}
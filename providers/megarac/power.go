@@ -1,4 +1,4 @@
-package asrockrack
+package megarac
 
 import (
 	"bytes"
@@ -18,16 +18,16 @@ type power struct {
 }
 
 // PowerStateGet gets the power state of a machine
-func (a *ASRockRack) PowerStateGet(ctx context.Context) (state string, err error) {
-	info, err := a.chassisStatusInfo(ctx)
+func (c *Conn) PowerStateGet(ctx context.Context) (state string, err error) {
+	info, err := c.chassisStatusInfo(ctx)
 	if err != nil {
 		if strings.Contains(err.Error(), "401") {
 			// during a BMC update, only the flash-progress endpoint can be queried
 			// and so we cannot determine server power status
 			// we don't return an error here because we don't want the bmclib client to retry another provider.
-			progress, err := a.flashProgress(ctx, "/api/maintenance/firmware/flash-progress")
+			progress, err := c.flashProgress(ctx, "/api/maintenance/firmware/flash-progress")
 			if err == nil && progress.Action != "" {
-				a.log.V(2).WithValues(
+				c.log.V(2).WithValues(
 					"action", progress.Action,
 					"progress", progress.Progress,
 					"state", progress.State,
@@ -62,24 +62,24 @@ func (a *ASRockRack) PowerStateGet(ctx context.Context) (state string, err error
 }
 
 // PowerSet sets the hardware power state of a machine
-func (a *ASRockRack) PowerSet(ctx context.Context, state string) (ok bool, err error) {
+func (c *Conn) PowerSet(ctx context.Context, state string) (ok bool, err error) {
 	switch strings.ToLower(state) {
 	case "on":
-		return a.powerAction(ctx, 1)
+		return c.powerAction(ctx, 1)
 	case "off":
-		return a.powerAction(ctx, 0)
+		return c.powerAction(ctx, 0)
 	case "soft":
-		return a.powerAction(ctx, 5)
+		return c.powerAction(ctx, 5)
 	case "reset":
-		return a.powerAction(ctx, 3)
+		return c.powerAction(ctx, 3)
 	case "cycle":
-		return a.powerAction(ctx, 2)
+		return c.powerAction(ctx, 2)
 	default:
 		return false, errors.New("requested power state unknown: " + state)
 	}
 }
 
-func (a *ASRockRack) powerAction(ctx context.Context, action int) (ok bool, err error) {
+func (c *Conn) powerAction(ctx context.Context, action int) (ok bool, err error) {
 	endpoint := "/api/actions/power"
 
 	p := power{Command: action}
@@ -89,7 +89,7 @@ func (a *ASRockRack) powerAction(ctx context.Context, action int) (ok bool, err
 	}
 
 	headers := map[string]string{"Content-Type": "application/json"}
-	_, statusCode, err := a.queryHTTPS(
+	_, statusCode, err := c.queryHTTPS(
 		ctx,
 		endpoint,
 		"POST",
@@ -111,9 +111,9 @@ func (a *ASRockRack) powerAction(ctx context.Context, action int) (ok bool, err
 	return true, nil
 }
 
-// BmcReset will reset the BMC - ASRR BMCs only support a cold reset.
-func (a *ASRockRack) BmcReset(ctx context.Context, resetType string) (ok bool, err error) {
-	err = a.resetBMC(ctx)
+// BmcReset will reset the BMC - MegaRAC BMCs only support a cold reset.
+func (c *Conn) BmcReset(ctx context.Context, resetType string) (ok bool, err error) {
+	err = c.resetBMC(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -122,10 +122,10 @@ func (a *ASRockRack) BmcReset(ctx context.Context, resetType string) (ok bool, e
 }
 
 // 4. reset BMC - performs a cold reset
-func (a *ASRockRack) resetBMC(ctx context.Context) error {
+func (c *Conn) resetBMC(ctx context.Context) error {
 	endpoint := "api/maintenance/reset"
 
-	_, statusCode, err := a.queryHTTPS(ctx, endpoint, "POST", nil, nil, 0)
+	_, statusCode, err := c.queryHTTPS(ctx, endpoint, "POST", nil, nil, 0)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,196 @@
+package megarac
+
+import (
+	"strings"
+)
+
+// Endpoints are the web API endpoints that differ between the OEM builds of
+// MegaRAC SP-X. An empty endpoint marks a capability the build does not expose.
+type Endpoints struct {
+	// FirmwareInfo returns the installed BMC, BIOS, ME and CPLD firmware versions.
+	FirmwareInfo string
+	// PostCode returns the current BIOS POST code.
+	PostCode string
+	// Inventory returns the CPU, memory and drive inventory.
+	Inventory string
+	// BMCFirmwareUpload accepts the multipart BMC firmware image.
+	BMCFirmwareUpload string
+	// BIOSFirmwareUpload accepts the multipart BIOS firmware image.
+	BIOSFirmwareUpload string
+	// BIOSConfiguration sets whether the BIOS configuration is preserved by the BIOS install.
+	BIOSConfiguration string
+	// BIOSUpgrade starts the BIOS install.
+	BIOSUpgrade string
+	// BIOSFlashProgress returns the progress of the BIOS install.
+	BIOSFlashProgress string
+}
+
+// Profile is the configuration of an OEM build of MegaRAC SP-X.
+type Profile struct {
+	// Family names the OEM build, e.g. "asrockrack".
+	Family string
+	// Manufacturers are the FRU board manufacturer names the family is detected by,
+	// a board manufacturer matches when it starts with one of the names.
+	Manufacturers []string
+	// Models limits the profile to the listed board models, all models are
+	// supported when empty.
+	Models []string
+	// Endpoints are the OEM endpoints of the family.
+	Endpoints Endpoints
+	// ModelEndpoints override the Endpoints of the family for a board model,
+	// only the endpoints set are overridden.
+	ModelEndpoints map[string]Endpoints
+	// PreserveConfigOnFlashMode lists the board models that require the BMC
+	// configuration to be preserved when the BMC is set to flash mode.
+	PreserveConfigOnFlashMode []string
+	// Provider names the bmclib provider dedicated to the family, a board the
+	// profile is detected for is left to that provider.
+	Provider string
+}
+
+// bmcFirmwareUpload is the BMC firmware upload endpoint of the stock AMI build.
+const bmcFirmwareUpload = "api/maintenance/firmware"
+
+var (
+	// ProfileAMI is the stock AMI MegaRAC SP-X build, it is used for boards
+	// no other profile is detected for.
+	ProfileAMI = Profile{
+		Family:        "ami",
+		Manufacturers: []string{"American Megatrends", "AMI"},
+		Endpoints: Endpoints{
+			BMCFirmwareUpload: bmcFirmwareUpload,
+		},
+	}
+
+	// ProfileASRockRack is the ASRock Rack build, firmware versions, POST
+	// codes, the inventory and the BIOS install are exposed under api/asrr.
+	ProfileASRockRack = Profile{
+		Family:        "asrockrack",
+		Manufacturers: []string{"ASRockRack", "ASRock Rack"},
+		Models:        []string{"E3C256D4ID-NL", "E3C246D4ID-NL", "E3C246D4I-NL"},
+		Endpoints: Endpoints{
+			FirmwareInfo:       "api/asrr/fw-info",
+			PostCode:           "api/asrr/getbioscode",
+			BMCFirmwareUpload:  bmcFirmwareUpload,
+			BIOSFirmwareUpload: "api/asrr/maintenance/BIOS/firmware",
+			BIOSConfiguration:  "api/asrr/maintenance/BIOS/configuration",
+			BIOSUpgrade:        "api/asrr/maintenance/BIOS/upgrade",
+			BIOSFlashProgress:  "api/asrr/maintenance/BIOS/flash-progress",
+		},
+		ModelEndpoints: map[string]Endpoints{
+			// E3C256D4ID-NL calls a different endpoint for firmware upload
+			"E3C256D4ID-NL": {BMCFirmwareUpload: "api/maintenance/firmware/firmware"},
+			"E3C246D4ID-NL": {Inventory: "api/asrr/inventory_info"},
+			"E3C246D4I-NL":  {Inventory: "api/asrr/inventory_info"},
+		},
+		PreserveConfigOnFlashMode: []string{"E3C256D4ID-NL"},
+		Provider:                  "asrockrack",
+	}
+
+	// ProfileGigabyte is the Gigabyte (Giga Computing) build.
+	ProfileGigabyte = Profile{
+		Family:        "gigabyte",
+		Manufacturers: []string{"GIGABYTE", "Giga Computing"},
+		Endpoints: Endpoints{
+			BMCFirmwareUpload: bmcFirmwareUpload,
+		},
+	}
+
+	// ProfileTyan is the Tyan (MiTAC) build.
+	ProfileTyan = Profile{
+		Family:        "tyan",
+		Manufacturers: []string{"TYAN", "MiTAC"},
+		Endpoints: Endpoints{
+			BMCFirmwareUpload: bmcFirmwareUpload,
+		},
+	}
+)
+
+// profiles are the profiles DetectProfile chooses from.
+var profiles = []Profile{ProfileASRockRack, ProfileGigabyte, ProfileTyan, ProfileAMI}
+
+// DetectProfile returns the profile of the FRU board manufacturer, ProfileAMI
+// is returned for an unknown manufacturer.
+func DetectProfile(manufacturer string) Profile {
+	manufacturer = strings.ToLower(strings.TrimSpace(manufacturer))
+
+	for _, profile := range profiles {
+		for _, name := range profile.Manufacturers {
+			if manufacturer != "" && strings.HasPrefix(manufacturer, strings.ToLower(name)) {
+				return profile
+			}
+		}
+	}
+
+	return ProfileAMI
+}
+
+// endpoints returns the endpoints of the family with the overrides of the
+// board model applied, an unset profile has no endpoints.
+func (p *Profile) endpoints(model string) Endpoints {
+	if p == nil {
+		return Endpoints{}
+	}
+
+	e := p.Endpoints
+
+	for m, o := range p.ModelEndpoints {
+		if !strings.EqualFold(m, model) {
+			continue
+		}
+
+		override(&e.FirmwareInfo, o.FirmwareInfo)
+		override(&e.PostCode, o.PostCode)
+		override(&e.Inventory, o.Inventory)
+		override(&e.BMCFirmwareUpload, o.BMCFirmwareUpload)
+		override(&e.BIOSFirmwareUpload, o.BIOSFirmwareUpload)
+		override(&e.BIOSConfiguration, o.BIOSConfiguration)
+		override(&e.BIOSUpgrade, o.BIOSUpgrade)
+		override(&e.BIOSFlashProgress, o.BIOSFlashProgress)
+	}
+
+	return e
+}
+
+// override sets the endpoint to the override when the override is set.
+func override(endpoint *string, o string) {
+	if o != "" {
+		*endpoint = o
+	}
+}
+
+// supportsModel returns true when the profile supports the board model, an
+// unset profile supports no model.
+func (p *Profile) supportsModel(model string) bool {
+	if p == nil {
+		return false
+	}
+
+	if len(p.Models) == 0 {
+		return true
+	}
+
+	for _, m := range p.Models {
+		if strings.EqualFold(m, model) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// preserveConfigOnFlashMode returns true when the board model requires the BMC
+// configuration to be preserved when the BMC is set to flash mode.
+func (p *Profile) preserveConfigOnFlashMode(model string) bool {
+	if p == nil {
+		return false
+	}
+
+	for _, m := range p.PreserveConfigOnFlashMode {
+		if strings.EqualFold(m, model) {
+			return true
+		}
+	}
+
+	return false
+}
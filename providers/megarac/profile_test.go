@@ -0,0 +1,60 @@
+package megarac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectProfile(t *testing.T) {
+	testCases := []struct {
+		manufacturer string
+		expected     string
+	}{
+		{"ASRockRack", "asrockrack"},
+		{"ASRock Rack Incorporation", "asrockrack"},
+		{"GIGABYTE", "gigabyte"},
+		{"Giga Computing Technology Co., Ltd.", "gigabyte"},
+		{"TYAN", "tyan"},
+		{"MiTAC Computing Technology Corp.", "tyan"},
+		{"American Megatrends International, LLC.", "ami"},
+		{"Acme ODM", "ami"},
+		{"", "ami"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.manufacturer, func(t *testing.T) {
+			assert.Equal(t, tc.expected, DetectProfile(tc.manufacturer).Family)
+		})
+	}
+}
+
+func TestProfileEndpoints(t *testing.T) {
+	profile := ProfileASRockRack
+
+	// model overrides are merged over the family endpoints
+	endpoints := profile.endpoints("E3C256D4ID-NL")
+	assert.Equal(t, "api/maintenance/firmware/firmware", endpoints.BMCFirmwareUpload)
+	assert.Equal(t, "api/asrr/fw-info", endpoints.FirmwareInfo)
+	assert.Empty(t, endpoints.Inventory)
+
+	endpoints = profile.endpoints("e3c246d4i-nl")
+	assert.Equal(t, "api/maintenance/firmware", endpoints.BMCFirmwareUpload)
+	assert.Equal(t, "api/asrr/inventory_info", endpoints.Inventory)
+
+	var unset *Profile
+	assert.Equal(t, Endpoints{}, unset.endpoints("E3C246D4I-NL"))
+}
+
+func TestProfileSupportsModel(t *testing.T) {
+	asrr := ProfileASRockRack
+	assert.True(t, asrr.supportsModel("E3C246D4I-NL"))
+	assert.False(t, asrr.supportsModel("ROMED8-2T"))
+
+	// profiles without a model list support every model
+	gigabyte := ProfileGigabyte
+	assert.True(t, gigabyte.supportsModel("MZ32-AR0-00"))
+
+	var unset *Profile
+	assert.False(t, unset.supportsModel("E3C246D4I-NL"))
+}
@@ -0,0 +1,134 @@
+package megarac
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+const (
+	// sensorStateNormal is the sensor_state of a threshold sensor reading within its thresholds.
+	sensorStateNormal = 1
+	// sensorNotAccessible is the accessible value of a sensor without a reading, e.g. an unpopulated temperature probe.
+	sensorNotAccessible = 0xd5
+	// sensorUnitDiscrete is the unit reported for discrete sensors.
+	sensorUnitDiscrete = "unknown"
+)
+
+// Sensors returns the BMC sensor readings
+func (c *Conn) Sensors(ctx context.Context) (readings []bmc.SensorReading, err error) {
+	sensors, err := c.sensors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range sensors {
+		readings = append(readings, toSensorReading(s))
+	}
+
+	return readings, nil
+}
+
+// toSensorReading converts a sensor from the sensors endpoint into a bmc.SensorReading.
+//
+// The BMC reports unset thresholds as 0, these are left nil.
+func toSensorReading(s *sensor) bmc.SensorReading {
+	reading := bmc.SensorReading{
+		ID:     strconv.Itoa(s.ID),
+		Name:   s.Name,
+		Type:   sensorType(s.Type),
+		Units:  sensorUnits(s.Unit),
+		Health: bmc.SensorHealthUnknown,
+	}
+
+	if s.Accessible == sensorNotAccessible {
+		reading.State = "Absent"
+		return reading
+	}
+
+	reading.State = "Enabled"
+
+	// discrete sensors report a bitmask of asserted states, no analog reading
+	if s.Unit == sensorUnitDiscrete {
+		reading.Health = bmc.SensorHealthOK
+		if s.SensorState != 0 {
+			reading.Health = bmc.SensorHealthCritical
+		}
+
+		return reading
+	}
+
+	value := s.Reading
+	reading.Reading = &value
+	reading.Thresholds = bmc.SensorThresholds{
+		LowerNonCritical: nonZero(s.LowerNonCriticalThreshold),
+		LowerCritical:    nonZero(s.LowerCriticalThreshold),
+		LowerFatal:       nonZero(s.LowerNonRecoverableThreshold),
+		UpperNonCritical: nonZero(s.HigherNonCriticalThreshold),
+		UpperCritical:    nonZero(s.HigherCriticalThreshold),
+		UpperFatal:       nonZero(s.HigherNonRecoverableThreshold),
+	}
+
+	reading.Health = bmc.SensorHealthOK
+	if s.SensorState != sensorStateNormal {
+		reading.Health = bmc.SensorHealthWarning
+		if exceedsCritical(value, reading.Thresholds) {
+			reading.Health = bmc.SensorHealthCritical
+		}
+	}
+
+	return reading
+}
+
+func sensorType(t string) bmc.SensorType {
+	switch t {
+	case "temperature":
+		return bmc.SensorTypeTemperature
+	case "fan":
+		return bmc.SensorTypeFan
+	case "voltage":
+		return bmc.SensorTypeVoltage
+	case "current":
+		return bmc.SensorTypeCurrent
+	case "power_supply":
+		return bmc.SensorTypePowerSupply
+	default:
+		return bmc.SensorTypeOther
+	}
+}
+
+func sensorUnits(unit string) string {
+	switch unit {
+	case "°C":
+		return "Cel"
+	case sensorUnitDiscrete:
+		return ""
+	default:
+		return unit
+	}
+}
+
+func exceedsCritical(value float64, t bmc.SensorThresholds) bool {
+	for _, lower := range []*float64{t.LowerCritical, t.LowerFatal} {
+		if lower != nil && value <= *lower {
+			return true
+		}
+	}
+
+	for _, upper := range []*float64{t.UpperCritical, t.UpperFatal} {
+		if upper != nil && value >= *upper {
+			return true
+		}
+	}
+
+	return false
+}
+
+func nonZero(v float64) *float64 {
+	if v == 0 {
+		return nil
+	}
+
+	return &v
+}
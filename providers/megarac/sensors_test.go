@@ -0,0 +1,82 @@
+package megarac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+func TestSensors(t *testing.T) {
+	readings, err := aClient.Sensors(context.TODO())
+	require.NoError(t, err)
+	require.Len(t, readings, 27)
+
+	vsb := readings[0]
+	assert.Equal(t, "1", vsb.ID)
+	assert.Equal(t, "3VSB", vsb.Name)
+	assert.Equal(t, bmc.SensorTypeVoltage, vsb.Type)
+	assert.Equal(t, "V", vsb.Units)
+	assert.Equal(t, bmc.SensorHealthOK, vsb.Health)
+	require.NotNil(t, vsb.Reading)
+	assert.Equal(t, 3.36, *vsb.Reading)
+	require.NotNil(t, vsb.Thresholds.LowerCritical)
+	assert.Equal(t, 2.97, *vsb.Thresholds.LowerCritical)
+	assert.Nil(t, vsb.Thresholds.LowerNonCritical)
+
+	cpuTemp := readings[14]
+	assert.Equal(t, "CPU Temp", cpuTemp.Name)
+	assert.Equal(t, bmc.SensorTypeTemperature, cpuTemp.Type)
+	assert.Equal(t, "Cel", cpuTemp.Units)
+
+	// TR1 Temp has no probe attached
+	absent := readings[13]
+	assert.Equal(t, "TR1 Temp", absent.Name)
+	assert.Nil(t, absent.Reading)
+	assert.Equal(t, "Absent", absent.State)
+	assert.Equal(t, bmc.SensorHealthUnknown, absent.Health)
+
+	caterr := readings[26]
+	assert.Equal(t, "CPU_CATERR", caterr.Name)
+	assert.Equal(t, bmc.SensorTypeOther, caterr.Type)
+	assert.Nil(t, caterr.Reading)
+	assert.Equal(t, bmc.SensorHealthOK, caterr.Health)
+}
+
+func TestToSensorReadingHealth(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sensor   *sensor
+		expected bmc.SensorHealth
+	}{
+		{
+			name:     "within thresholds",
+			sensor:   &sensor{Type: "temperature", Unit: "°C", Reading: 40, SensorState: 1, HigherNonCriticalThreshold: 80, HigherCriticalThreshold: 90},
+			expected: bmc.SensorHealthOK,
+		},
+		{
+			name:     "above non critical",
+			sensor:   &sensor{Type: "temperature", Unit: "°C", Reading: 85, SensorState: 8, HigherNonCriticalThreshold: 80, HigherCriticalThreshold: 90},
+			expected: bmc.SensorHealthWarning,
+		},
+		{
+			name:     "above critical",
+			sensor:   &sensor{Type: "temperature", Unit: "°C", Reading: 95, SensorState: 16, HigherNonCriticalThreshold: 80, HigherCriticalThreshold: 90},
+			expected: bmc.SensorHealthCritical,
+		},
+		{
+			name:     "discrete asserted",
+			sensor:   &sensor{Type: "processor", Unit: "unknown", SensorState: 1},
+			expected: bmc.SensorHealthCritical,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, toSensorReading(tc.sensor).Health)
+		})
+	}
+}
@@ -1,4 +1,4 @@
-package asrockrack
+package megarac
 
 import (
 	"context"
@@ -14,7 +14,7 @@ import (
 // TODO: standardize these across Redfish, IPMI, Vendor GUI
 var validRoles = []string{"Administrator", "Operator", "User"}
 
-// UserAccount is a ASRR BMC user account struct
+// UserAccount is a MegaRAC BMC user account struct
 type UserAccount struct {
 	SSHKey                       string `json:"ssh_key"`
 	PasswordSize                 string `json:"password_size"`
@@ -44,13 +44,13 @@ type UserAccount struct {
 }
 
 // UserRead returns a list of enabled user accounts
-func (a *ASRockRack) UserRead(ctx context.Context) (users []map[string]string, err error) {
-	err = a.Open(ctx)
+func (c *Conn) UserRead(ctx context.Context) (users []map[string]string, err error) {
+	err = c.Open(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	accounts, err := a.listUsers(ctx)
+	accounts, err := c.listUsers(ctx)
 	if err != nil {
 		return nil, errors.Wrap(bmclibErrs.ErrRetrievingUserAccounts, err.Error())
 	}
@@ -71,7 +71,7 @@ func (a *ASRockRack) UserRead(ctx context.Context) (users []map[string]string, e
 }
 
 // UserCreate adds a new user account
-func (a *ASRockRack) UserCreate(ctx context.Context, user, pass, role string) (ok bool, err error) {
+func (c *Conn) UserCreate(ctx context.Context, user, pass, role string) (ok bool, err error) {
 	if !internal.StringInSlice(role, validRoles) {
 		return false, bmclibErrs.ErrInvalidUserRole
 	}
@@ -81,14 +81,14 @@ func (a *ASRockRack) UserCreate(ctx context.Context, user, pass, role string) (o
 	}
 
 	// fetch current list of accounts
-	accounts, err := a.listUsers(ctx)
+	accounts, err := c.listUsers(ctx)
 	if err != nil {
 		return false, errors.Wrap(bmclibErrs.ErrRetrievingUserAccounts, err.Error())
 	}
 
 	// identify account slot not in use
 	for _, account := range accounts {
-		// ASRR BMCs have a reserved slot 1 for a disabled Anonymous, no idea why.
+		// MegaRAC BMCs have a reserved slot 1 for a disabled Anonymous, no idea why.
 		if account.ID == 1 {
 			continue
 		}
@@ -100,7 +100,7 @@ func (a *ASRockRack) UserCreate(ctx context.Context, user, pass, role string) (o
 
 		if account.Access == 0 && account.Name == "" {
 			newAccount := newUserAccount(account.ID, user, pass, strings.ToLower(role))
-			err := a.createUpdateUser(ctx, newAccount)
+			err := c.createUpdateUser(ctx, newAccount)
 			if err != nil {
 				return false, err
 			}
@@ -114,7 +114,7 @@ func (a *ASRockRack) UserCreate(ctx context.Context, user, pass, role string) (o
 //
 
 // UserUpdate updates a user password and role
-func (a *ASRockRack) UserUpdate(ctx context.Context, user, pass, role string) (ok bool, err error) {
+func (c *Conn) UserUpdate(ctx context.Context, user, pass, role string) (ok bool, err error) {
 	if !internal.StringInSlice(role, validRoles) {
 		return false, bmclibErrs.ErrInvalidUserRole
 	}
@@ -123,7 +123,7 @@ func (a *ASRockRack) UserUpdate(ctx context.Context, user, pass, role string) (o
 		return false, bmclibErrs.ErrUserParamsRequired
 	}
 
-	accounts, err := a.listUsers(ctx)
+	accounts, err := c.listUsers(ctx)
 	if err != nil {
 		return false, errors.Wrap(bmclibErrs.ErrRetrievingUserAccounts, err.Error())
 	}
@@ -146,7 +146,7 @@ func (a *ASRockRack) UserUpdate(ctx context.Context, user, pass, role string) (o
 				user.CreationTime = 6000 // doesn't mean anything.
 			}
 
-			err := a.createUpdateUser(ctx, user)
+			err := c.createUpdateUser(ctx, user)
 			if err != nil {
 				return false, errors.Wrap(bmclibErrs.ErrUserAccountUpdate, err.Error())
 			}
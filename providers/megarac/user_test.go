@@ -1,4 +1,4 @@
-package asrockrack
+package megarac
 
 import (
 	"context"
@@ -0,0 +1,98 @@
+package nvidia
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/constants"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+)
+
+const (
+	// ComponentHGX is the firmware component of an HGX firmware bundle, the
+	// bundle carries the GPU, NVSwitch, FPGA, ERoT and HMC firmware.
+	ComponentHGX = "HGX"
+
+	// hgxBundleFirmwareID is the firmware inventory member the HMC accepts an
+	// HGX firmware bundle for.
+	hgxBundleFirmwareID = "HGX_0"
+)
+
+// compile-time assertions that the provider implements the firmware interfaces.
+var (
+	_ bmc.FirmwareInstallProvider    = (*Conn)(nil)
+	_ bmc.FirmwareTaskVerifier       = (*Conn)(nil)
+	_ bmc.FirmwareInstallStepsGetter = (*Conn)(nil)
+)
+
+// FirmwareInstallSteps returns the ordered steps the provider performs for an
+// HGX firmware bundle install. The multipart push uploads and initiates the
+// install in one step, followed by polling the update task.
+//
+// Implements bmc.FirmwareInstallStepsGetter.
+func (c *Conn) FirmwareInstallSteps(ctx context.Context, component string) ([]constants.FirmwareInstallStep, error) {
+	if !strings.EqualFold(component, ComponentHGX) {
+		return nil, errors.Wrap(bmclibErrs.ErrFirmwareInstall, "unsupported component: "+component)
+	}
+
+	return []constants.FirmwareInstallStep{
+		constants.FirmwareInstallStepUploadInitiateInstall,
+		constants.FirmwareInstallStepInstallStatus,
+	}, nil
+}
+
+// FirmwareInstallUploadAndInitiate uploads an HGX firmware bundle through the
+// host BMC UpdateService multipart push and returns the update task id. The
+// bundle is targeted at the HGX_0 firmware inventory member when the host BMC
+// lists it, otherwise the HMC updates the components the bundle carries.
+//
+// The HMC stages the firmware, the new firmware is activated by the next power
+// cycle of the host. The upload of a bundle can take a while, ctx is expected
+// to carry a deadline that covers the upload.
+//
+// Implements bmc.FirmwareInstallProvider.
+func (c *Conn) FirmwareInstallUploadAndInitiate(ctx context.Context, component string, file *os.File) (taskID string, err error) {
+	if !strings.EqualFold(component, ComponentHGX) {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareInstall, "unsupported component: "+component)
+	}
+
+	targets, err := c.hgxBundleTargets()
+	if err != nil {
+		return "", errors.Wrap(bmclibErrs.ErrFirmwareInstall, err.Error())
+	}
+
+	params := &redfishwrapper.RedfishUpdateServiceParameters{
+		Targets:            targets,
+		OperationApplyTime: constants.Immediate,
+	}
+
+	return c.redfishwrapper.FirmwareUpload(ctx, file, params)
+}
+
+// FirmwareTaskStatus returns the state and status of a firmware update task.
+//
+// Implements bmc.FirmwareTaskVerifier.
+func (c *Conn) FirmwareTaskStatus(ctx context.Context, kind constants.FirmwareInstallStep, component, taskID, installVersion string) (state constants.TaskState, status string, err error) {
+	return c.redfishwrapper.TaskStatus(ctx, taskID)
+}
+
+// hgxBundleTargets returns the update targets of an HGX firmware bundle.
+func (c *Conn) hgxBundleTargets() ([]string, error) {
+	members, err := c.redfishwrapper.CollectionMembers(firmwareInventoryURI)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		if memberID(member) == hgxBundleFirmwareID {
+			return []string{member}, nil
+		}
+	}
+
+	return []string{}, nil
+}
@@ -0,0 +1,95 @@
+package nvidia
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/bmc-toolbox/bmclib/v2/constants"
+)
+
+// Requirement: an HGX bundle is uploaded and initiated in one step, other
+// components are not supported.
+func TestFirmwareInstallSteps(t *testing.T) {
+	c := New("127.0.0.1", "u", "p", logr.Discard())
+
+	steps, err := c.FirmwareInstallSteps(context.Background(), "hgx")
+	if err != nil {
+		t.Fatalf("FirmwareInstallSteps(hgx): %v", err)
+	}
+	if len(steps) != 2 || steps[0] != constants.FirmwareInstallStepUploadInitiateInstall {
+		t.Fatalf("FirmwareInstallSteps(hgx) = %v", steps)
+	}
+
+	if _, err := c.FirmwareInstallSteps(context.Background(), "bios"); err == nil {
+		t.Fatal("expected an error for an unsupported component")
+	}
+}
+
+// Requirement: an HGX bundle is pushed targeted at the HGX_0 firmware
+// inventory member when it is listed, untargeted otherwise.
+func TestFirmwareInstallUploadAndInitiate(t *testing.T) {
+	tests := []struct {
+		name    string
+		listed  bool
+		targets []any
+	}{
+		{"HGX_0 listed", true, []any{"/redfish/v1/UpdateService/FirmwareInventory/HGX_0"}},
+		{"HGX_0 not listed", false, []any{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := newTestServer(t)
+			c := ts.openedClient(t)
+
+			if !tc.listed {
+				ts.serve("/redfish/v1/UpdateService/FirmwareInventory", "tasks.json")
+			}
+
+			path := filepath.Join(t.TempDir(), "nvfw_HGX_H100x8.fwpkg")
+			if err := os.WriteFile(path, []byte("bundle"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			file, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			taskID, err := c.FirmwareInstallUploadAndInitiate(ctx, ComponentHGX, file)
+			if err != nil {
+				t.Fatalf("FirmwareInstallUploadAndInitiate: %v", err)
+			}
+			if taskID != "1" {
+				t.Fatalf("task id = %q, want %q", taskID, "1")
+			}
+
+			targets, _ := ts.uploadParameters()["Targets"].([]any)
+			if len(targets) != len(tc.targets) || (len(targets) == 1 && targets[0] != tc.targets[0]) {
+				t.Fatalf("UpdateParameters = %v", ts.uploadParameters())
+			}
+		})
+	}
+}
+
+// Requirement: the update task is polled through the TaskService.
+func TestFirmwareTaskStatus(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	state, _, err := c.FirmwareTaskStatus(context.Background(), constants.FirmwareInstallStepInstallStatus, ComponentHGX, "1", "")
+	if err != nil {
+		t.Fatalf("FirmwareTaskStatus: %v", err)
+	}
+	if state != constants.Running {
+		t.Fatalf("state = %q, want %q", state, constants.Running)
+	}
+}
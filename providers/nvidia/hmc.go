@@ -0,0 +1,159 @@
+package nvidia
+
+import (
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+)
+
+const (
+	// hgxPrefix is the Id prefix of the HMC resources the host BMC aggregates.
+	hgxPrefix = "HGX_"
+
+	// chassisURI is the Chassis collection of the host BMC, it includes the
+	// HMC Chassis.
+	chassisURI = "/redfish/v1/Chassis"
+	// firmwareInventoryURI is the firmware inventory of the host BMC, it
+	// includes the HMC firmware inventory members.
+	firmwareInventoryURI = "/redfish/v1/UpdateService/FirmwareInventory"
+
+	// hmcFirmwareID is the firmware inventory member of the HMC firmware.
+	hmcFirmwareID = "HGX_FW_BMC_0"
+)
+
+// errNoHMC is returned when the host BMC does not aggregate an HGX HMC.
+var errNoHMC = errors.New("no HGX HMC resources found")
+
+// hmcComponent is the kind of an HMC Chassis.
+type hmcComponent int
+
+const (
+	hmcUnknown hmcComponent = iota
+	hmcBaseboard
+	hmcGPU
+	hmcNVSwitch
+	hmcFPGA
+)
+
+// hmcComponentPrefixes maps the HMC Chassis Id prefixes to the component kind.
+// The ERoT (External Root of Trust) and other HMC Chassis are not inventoried.
+var hmcComponentPrefixes = []struct {
+	prefix    string
+	component hmcComponent
+}{
+	{"HGX_GPU_", hmcGPU},
+	{"HGX_NVSwitch_", hmcNVSwitch},
+	{"HGX_FPGA_", hmcFPGA},
+	{"HGX_Chassis_", hmcBaseboard},
+	{"HGX_Baseboard_", hmcBaseboard},
+}
+
+// hmcChassis is the subset of an HMC Chassis resource the provider reads.
+type hmcChassis struct {
+	ID           string `json:"Id"`
+	Name         string `json:"Name"`
+	Manufacturer string `json:"Manufacturer"`
+	Model        string `json:"Model"`
+	PartNumber   string `json:"PartNumber"`
+	SerialNumber string `json:"SerialNumber"`
+	Status       status `json:"Status"`
+}
+
+// status is the Redfish status shape of the HMC resources.
+type status struct {
+	Health string `json:"Health"`
+	State  string `json:"State"`
+}
+
+// component returns the kind of the HMC Chassis.
+func (h *hmcChassis) component() hmcComponent {
+	for _, p := range hmcComponentPrefixes {
+		if strings.HasPrefix(h.ID, p.prefix) {
+			return p.component
+		}
+	}
+
+	return hmcUnknown
+}
+
+// firmwareID returns the firmware inventory member of the HMC Chassis, the HMC
+// names it after the Chassis Id, e.g. HGX_FW_GPU_SXM_1 for HGX_GPU_SXM_1. The
+// baseboard has no firmware of its own, it reports the HMC firmware.
+func (h *hmcChassis) firmwareID() string {
+	if h.component() == hmcBaseboard {
+		return hmcFirmwareID
+	}
+
+	return hgxPrefix + "FW_" + strings.TrimPrefix(h.ID, hgxPrefix)
+}
+
+// hmcChassis returns the HMC Chassis aggregated by the host BMC, errNoHMC is
+// returned when there are none.
+func (c *Conn) hmcChassis() ([]*hmcChassis, error) {
+	members, err := c.redfishwrapper.CollectionMembers(chassisURI)
+	if err != nil {
+		return nil, err
+	}
+
+	chassis := []*hmcChassis{}
+
+	for _, member := range members {
+		if !strings.HasPrefix(memberID(member), hgxPrefix) {
+			continue
+		}
+
+		ch := &hmcChassis{}
+		if err := c.redfishwrapper.GetJSON(member, ch); err != nil {
+			return nil, err
+		}
+
+		chassis = append(chassis, ch)
+	}
+
+	if len(chassis) == 0 {
+		return nil, errNoHMC
+	}
+
+	return chassis, nil
+}
+
+// hmcFirmwareVersions returns the versions of the HMC firmware inventory
+// members, keyed by the member Id.
+func (c *Conn) hmcFirmwareVersions() (map[string]string, error) {
+	members, err := c.redfishwrapper.CollectionMembers(firmwareInventoryURI)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := map[string]string{}
+
+	for _, member := range members {
+		if !strings.HasPrefix(memberID(member), hgxPrefix) {
+			continue
+		}
+
+		var fw struct {
+			ID      string `json:"Id"`
+			Version string `json:"Version"`
+		}
+
+		if err := c.redfishwrapper.GetJSON(member, &fw); err != nil {
+			if redfishwrapper.IsNotFound(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		versions[fw.ID] = fw.Version
+	}
+
+	return versions, nil
+}
+
+// memberID returns the Id of a collection member from its link.
+func memberID(member string) string {
+	return path.Base(strings.TrimRight(member, "/"))
+}
@@ -0,0 +1,120 @@
+package nvidia
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/common"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+var _ bmc.InventoryGetter = (*Conn)(nil)
+
+// Inventory collects the hardware and firmware inventory of the host system
+// through the host BMC Redfish resources, and adds the HGX baseboard
+// components read from the HMC resources:
+//
+//   - the GPUs are added to GPUs.
+//
+//   - the NVSwitches are added to NICs, as OEM components described as
+//     "NVSwitch", common has no component type for fabric switches.
+//
+//   - the baseboard FPGA is added to CPLDs.
+//
+//   - the HGX baseboard is added to Enclosures, its firmware is the HMC
+//     firmware.
+//
+// When the connection's failInventoryOnError is false (the default, set via
+// [WithFailInventoryOnError]), a failure reading the HMC resources does not
+// abort the whole inventory — the provider returns what it could collect. When
+// true, the first error is returned.
+//
+// Implements bmc.InventoryGetter.
+func (c *Conn) Inventory(ctx context.Context) (device *common.Device, err error) {
+	device, err = c.redfishwrapper.Inventory(ctx, c.failInventoryOnError)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.hmcInventory(device); err != nil {
+		if c.failInventoryOnError {
+			return nil, err
+		}
+
+		c.Log.V(2).WithValues("provider", c.Name()).Info("HGX inventory incomplete", "error", err.Error())
+	}
+
+	return device, nil
+}
+
+// hmcInventory adds the HMC Chassis components to the device.
+func (c *Conn) hmcInventory(device *common.Device) error {
+	chassis, err := c.hmcChassis()
+	if err != nil {
+		return err
+	}
+
+	versions, err := c.hmcFirmwareVersions()
+	if err != nil {
+		return err
+	}
+
+	for _, ch := range chassis {
+		component := ch.attributes(versions)
+
+		switch ch.component() {
+		case hmcGPU:
+			device.GPUs = append(device.GPUs, &common.GPU{Common: component})
+		case hmcNVSwitch:
+			component.Oem = true
+			component.Description = "NVSwitch"
+			device.NICs = append(device.NICs, &common.NIC{Common: component, ID: ch.ID})
+		case hmcFPGA:
+			device.CPLDs = append(device.CPLDs, &common.CPLD{Common: component})
+		case hmcBaseboard:
+			device.Enclosures = append(device.Enclosures, &common.Enclosure{
+				Common:   component,
+				ID:       ch.ID,
+				Firmware: component.Firmware,
+			})
+		case hmcUnknown:
+		}
+	}
+
+	return nil
+}
+
+// attributes returns the common component attributes of the HMC Chassis, with the
+// firmware version read from the firmware inventory member of the Chassis.
+func (h *hmcChassis) attributes(versions map[string]string) common.Common {
+	component := common.Common{
+		Description: h.Name,
+		Vendor:      common.FormatVendorName(h.Manufacturer),
+		Model:       h.Model,
+		Serial:      h.SerialNumber,
+		ProductName: h.Model,
+		Status:      &common.Status{Health: h.Status.Health, State: h.Status.State},
+	}
+
+	setMetadata(&component, "id", h.ID)
+	setMetadata(&component, "part_number", h.PartNumber)
+
+	if version, ok := versions[h.firmwareID()]; ok && version != "" {
+		component.Firmware = &common.Firmware{Installed: version, SoftwareID: h.firmwareID()}
+	}
+
+	return component
+}
+
+// setMetadata sets a non empty metadata value on the component.
+func setMetadata(component *common.Common, key, value string) {
+	if value == "" {
+		return
+	}
+
+	if component.Metadata == nil {
+		component.Metadata = map[string]string{}
+	}
+
+	component.Metadata[key] = value
+}
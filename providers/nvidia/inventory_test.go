@@ -0,0 +1,104 @@
+package nvidia
+
+import (
+	"context"
+	"testing"
+)
+
+// Requirement: the inventory includes the host components and the GPUs,
+// NVSwitches, FPGA and baseboard of the HMC, the ERoT is not inventoried.
+func TestInventory(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	device, err := c.Inventory(context.Background())
+	if err != nil {
+		t.Fatalf("Inventory: %v", err)
+	}
+
+	if device.Model != "DGXH100" || device.Serial != "1660222000123" {
+		t.Fatalf("device = %q %q, want the host System", device.Model, device.Serial)
+	}
+
+	if device.BMC == nil || device.BMC.Firmware == nil || device.BMC.Firmware.Installed != "24.01.05" {
+		t.Fatalf("BMC = %+v", device.BMC)
+	}
+
+	if len(device.GPUs) != 2 {
+		t.Fatalf("GPUs = %d, want 2", len(device.GPUs))
+	}
+	gpu := device.GPUs[0]
+	if gpu.Model != "NVIDIA H100 80GB HBM3" || gpu.Serial != "1654022001001" || gpu.Metadata["part_number"] != "2330-885-A1" {
+		t.Fatalf("GPU = %+v", gpu.Common)
+	}
+	if gpu.Firmware == nil || gpu.Firmware.Installed != "96.00.5E.00.01" {
+		t.Fatalf("GPU firmware = %+v", gpu.Firmware)
+	}
+	if device.GPUs[1].Status == nil || device.GPUs[1].Status.Health != "Warning" {
+		t.Fatalf("GPU status = %+v", device.GPUs[1].Status)
+	}
+
+	if len(device.NICs) != 1 || device.NICs[0].Description != "NVSwitch" || !device.NICs[0].Oem {
+		t.Fatalf("NICs = %+v", device.NICs)
+	}
+	if device.NICs[0].Firmware == nil || device.NICs[0].Firmware.Installed != "96.10.3F.00.01" {
+		t.Fatalf("NVSwitch firmware = %+v", device.NICs[0].Firmware)
+	}
+
+	var fpga bool
+	for _, cpld := range device.CPLDs {
+		if cpld.Metadata["id"] != "HGX_FPGA_0" {
+			continue
+		}
+
+		fpga = true
+		if cpld.Firmware == nil || cpld.Firmware.Installed != "1.10" {
+			t.Fatalf("FPGA firmware = %+v", cpld.Firmware)
+		}
+	}
+	if !fpga {
+		t.Fatalf("CPLDs = %+v, want the HGX FPGA", device.CPLDs)
+	}
+
+	var baseboard bool
+	for _, enclosure := range device.Enclosures {
+		if enclosure.ID != "HGX_Chassis_0" {
+			continue
+		}
+
+		baseboard = true
+		if enclosure.Firmware == nil || enclosure.Firmware.Installed != "HGX-22.10-1-rc80" {
+			t.Fatalf("baseboard firmware = %+v", enclosure.Firmware)
+		}
+	}
+	if !baseboard {
+		t.Fatalf("Enclosures = %+v, want the HGX baseboard", device.Enclosures)
+	}
+}
+
+// Requirement: a failure reading the HMC resources fails the inventory only
+// when failInventoryOnError is set.
+func TestInventoryHMCError(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	ts.serve("/redfish/v1/Chassis/HGX_GPU_SXM_1", "")
+
+	device, err := c.Inventory(context.Background())
+	if err != nil {
+		t.Fatalf("Inventory: %v", err)
+	}
+	if len(device.GPUs) != 0 {
+		t.Fatalf("GPUs = %d, want the HMC components to be skipped", len(device.GPUs))
+	}
+
+	c = ts.client(t, WithFailInventoryOnError(true))
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer c.Close(context.Background())
+
+	if _, err := c.Inventory(context.Background()); err == nil {
+		t.Fatal("expected an error with failInventoryOnError set")
+	}
+}
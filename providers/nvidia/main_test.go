@@ -0,0 +1,234 @@
+package nvidia
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+const fixturesDir = "./fixtures/v1"
+
+// testServer is an httptest-backed mock of the host BMC of an HGX system, its
+// Redfish tree includes the aggregated HMC resources. It serves recorded JSON
+// fixtures and emulates Redfish session create/delete and the UpdateService
+// multipart push so the provider can be exercised entirely offline.
+type testServer struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	sessionCreated bool
+	sessionDeleted bool
+	// lastResetType records the ResetType posted to ComputerSystem.Reset.
+	lastResetType string
+	// updateParameters records the UpdateParameters part of the multipart push.
+	updateParameters map[string]any
+	// routes maps the paths served from fixtures to the fixture file.
+	routes map[string]string
+}
+
+// newTestServer builds and starts a TLS mock host BMC server.
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	ts := &testServer{}
+
+	// path -> fixture file for plain GETs.
+	ts.routes = map[string]string{
+		"/redfish/v1/":                                                  "serviceroot.json",
+		"/redfish/v1/Systems":                                           "systems.json",
+		"/redfish/v1/Systems/Self":                                      "system.self.json",
+		"/redfish/v1/Systems/HGX_Baseboard_0":                           "system.hgx_baseboard_0.json",
+		"/redfish/v1/Chassis":                                           "chassis.json",
+		"/redfish/v1/Chassis/Self":                                      "chassis.self.json",
+		"/redfish/v1/Chassis/HGX_Chassis_0":                             "chassis.hgx_chassis_0.json",
+		"/redfish/v1/Chassis/HGX_GPU_SXM_1":                             "chassis.hgx_gpu_sxm_1.json",
+		"/redfish/v1/Chassis/HGX_GPU_SXM_2":                             "chassis.hgx_gpu_sxm_2.json",
+		"/redfish/v1/Chassis/HGX_NVSwitch_0":                            "chassis.hgx_nvswitch_0.json",
+		"/redfish/v1/Chassis/HGX_FPGA_0":                                "chassis.hgx_fpga_0.json",
+		"/redfish/v1/Chassis/HGX_ERoT_GPU_SXM_1":                        "chassis.hgx_erot_gpu_sxm_1.json",
+		"/redfish/v1/Managers":                                          "managers.json",
+		"/redfish/v1/Managers/Self":                                     "manager.self.json",
+		"/redfish/v1/Managers/HGX_BMC_0":                                "manager.hgx_bmc_0.json",
+		"/redfish/v1/UpdateService":                                     "updateservice.json",
+		"/redfish/v1/UpdateService/FirmwareInventory":                   "firmwareinventory.json",
+		"/redfish/v1/UpdateService/FirmwareInventory/BMC":               "firmwareinventory.bmc.json",
+		"/redfish/v1/UpdateService/FirmwareInventory/HGX_0":             "firmwareinventory.hgx_0.json",
+		"/redfish/v1/UpdateService/FirmwareInventory/HGX_FW_BMC_0":      "firmwareinventory.hgx_fw_bmc_0.json",
+		"/redfish/v1/UpdateService/FirmwareInventory/HGX_FW_GPU_SXM_1":  "firmwareinventory.hgx_fw_gpu_sxm_1.json",
+		"/redfish/v1/UpdateService/FirmwareInventory/HGX_FW_GPU_SXM_2":  "firmwareinventory.hgx_fw_gpu_sxm_2.json",
+		"/redfish/v1/UpdateService/FirmwareInventory/HGX_FW_NVSwitch_0": "firmwareinventory.hgx_fw_nvswitch_0.json",
+		"/redfish/v1/UpdateService/FirmwareInventory/HGX_FW_FPGA_0":     "firmwareinventory.hgx_fw_fpga_0.json",
+		"/redfish/v1/TaskService":                                       "taskservice.json",
+		"/redfish/v1/TaskService/Tasks":                                 "tasks.json",
+		"/redfish/v1/TaskService/Tasks/1":                               "task.1.json",
+	}
+
+	mux := http.NewServeMux()
+
+	// ComputerSystem.Reset action of the host System — records the requested ResetType.
+	mux.HandleFunc("/redfish/v1/Systems/Self/Actions/ComputerSystem.Reset", func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			ResetType string `json:"ResetType"`
+		}
+		if body, err := io.ReadAll(r.Body); err == nil {
+			_ = json.Unmarshal(body, &payload)
+		}
+		ts.mu.Lock()
+		ts.lastResetType = payload.ResetType
+		ts.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// UpdateService multipart push: records the UpdateParameters and returns
+	// the update task Location.
+	mux.HandleFunc("/redfish/v1/UpdateService/upload", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var params map[string]any
+		if err := json.Unmarshal([]byte(r.FormValue("UpdateParameters")), &params); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		ts.mu.Lock()
+		ts.updateParameters = params
+		ts.mu.Unlock()
+		w.Header().Set("Location", "/redfish/v1/TaskService/Tasks/1")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	// Session create: returns an X-Auth-Token and the session Location.
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		ts.mu.Lock()
+		ts.sessionCreated = true
+		ts.mu.Unlock()
+
+		w.Header().Set("X-Auth-Token", "test-token")
+		w.Header().Set("Location", "/redfish/v1/SessionService/Sessions/1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"@odata.id":"/redfish/v1/SessionService/Sessions/1","Id":"1","Name":"Session"}`))
+	})
+
+	// A created session is deleted here on Close.
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			ts.mu.Lock()
+			ts.sessionDeleted = true
+			ts.mu.Unlock()
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Catch-all for the rest of the Redfish tree, GETs are served from fixtures.
+	mux.HandleFunc("/redfish/v1/", func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		file, ok := ts.routes[r.URL.Path]
+		ts.mu.Unlock()
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		body, err := os.ReadFile(filepath.Join(fixturesDir, file))
+		if err != nil {
+			t.Errorf("failed to read fixture %q for %s: %v", file, r.URL.Path, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	})
+
+	ts.Server = httptest.NewTLSServer(mux)
+
+	return ts
+}
+
+// client returns a *Conn pointed at the mock server. Extra options are appended
+// after the mandatory port option.
+func (ts *testServer) client(t *testing.T, opts ...Option) *Conn {
+	t.Helper()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("parse mock url: %v", err)
+	}
+	opts = append([]Option{WithPort(u.Port())}, opts...)
+	return New(u.Hostname(), "user", "pass", logr.Discard(), opts...)
+}
+
+// openedClient returns a *Conn with an established session and registers Close
+// + server shutdown for cleanup.
+func (ts *testServer) openedClient(t *testing.T, opts ...Option) *Conn {
+	t.Helper()
+	c := ts.client(t, opts...)
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = c.Close(context.Background())
+		ts.Close()
+	})
+	return c
+}
+
+// serve replaces the fixture served for the path, an empty file removes the
+// route so the path returns 404.
+func (ts *testServer) serve(path, file string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if file == "" {
+		delete(ts.routes, path)
+		return
+	}
+
+	ts.routes[path] = file
+}
+
+func (ts *testServer) didCreateSession() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.sessionCreated
+}
+
+func (ts *testServer) didDeleteSession() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.sessionDeleted
+}
+
+func (ts *testServer) resetType() string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.lastResetType
+}
+
+func (ts *testServer) uploadParameters() map[string]any {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.updateParameters
+}
@@ -0,0 +1,221 @@
+// Package nvidia implements a bmclib provider for NVIDIA HGX and DGX systems.
+//
+// The GPU baseboard of an HGX system is managed by the HGX Management
+// Controller (HMC). The host BMC aggregates the HMC Redfish resources into its
+// own service, the HMC Chassis, Systems, Managers and firmware inventory
+// members are named with an "HGX_" prefix. This provider is built on top of the
+// shared gofish-backed [redfishwrapper.Client], it manages the host through the
+// host BMC resources and the GPU baseboard through the HMC resources:
+//
+//   - hmc.go discovers the HMC resources behind the host BMC.
+//
+//   - inventory.go adds the GPUs, NVSwitches, FPGA and baseboard of the HMC
+//     to the host inventory.
+//
+//   - firmware.go installs HGX firmware bundles through the host BMC
+//     UpdateService and tracks the update task.
+package nvidia
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/jacobweinstock/registrar"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/internal/httpclient"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+	"github.com/bmc-toolbox/bmclib/v2/providers"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+const (
+	// ProviderName is the registered name of this provider.
+	ProviderName = "nvidia"
+	// ProviderProtocol is the transport/protocol this provider speaks.
+	ProviderProtocol = "redfish"
+
+	// systemsOdataIDPrefix is the prefix of the System Odata ID, the host
+	// System Id differs between the HGX system vendors, e.g.
+	// /redfish/v1/Systems/DGX.
+	systemsOdataIDPrefix = "/redfish/v1/Systems/"
+)
+
+// Features is the set of bmclib features this provider implements.
+var Features = registrar.Features{
+	// power
+	providers.FeaturePowerState,
+	providers.FeaturePowerSet,
+	// inventory
+	providers.FeatureInventoryRead,
+	// UpdateService firmware
+	providers.FeatureFirmwareUploadInitiateInstall,
+	providers.FeatureFirmwareTaskStatus,
+	providers.FeatureFirmwareInstallSteps,
+}
+
+// Conn is a connection to the host BMC of an NVIDIA HGX system.
+type Conn struct {
+	redfishwrapper *redfishwrapper.Client
+	// failInventoryOnError has Inventory fail on the first error reading the
+	// HMC resources.
+	failInventoryOnError bool
+	Log                  logr.Logger
+}
+
+// Config is the configuration of a host BMC [Conn], it is built by [New]
+// from the given options.
+type Config struct {
+	// HTTPClient sends the host BMC requests, a client with the bmclib defaults
+	// is built when nil.
+	HTTPClient *http.Client
+	// Port is the TCP port the host BMC Redfish service listens on. Defaults to "443".
+	Port string
+	// VersionsNotCompatible are the Redfish versions of the host BMC firmware
+	// the provider is not to be used with.
+	VersionsNotCompatible []string
+	// RootCAs verifies the host BMC certificate against the pool, the
+	// certificate is not verified when nil.
+	RootCAs *x509.CertPool
+	// UseBasicAuth selects HTTP Basic authentication instead of Redfish session
+	// login.
+	UseBasicAuth bool
+	// FailInventoryOnError has Inventory fail on the first error reading a
+	// host or HMC component, by default the host inventory is returned without
+	// the GPU baseboard components the HMC failed to report.
+	FailInventoryOnError bool
+}
+
+// Option sets a setting of the host BMC [Config].
+type Option func(*Config)
+
+// WithHTTPClient sets the HTTP client for the host BMC requests.
+func WithHTTPClient(c *http.Client) Option {
+	return func(cfg *Config) { cfg.HTTPClient = c }
+}
+
+// WithPort sets the host BMC Redfish service port (default "443").
+func WithPort(port string) Option {
+	return func(cfg *Config) { cfg.Port = port }
+}
+
+// WithVersionsNotCompatible excludes the host BMC firmware reporting one of
+// the Redfish versions.
+func WithVersionsNotCompatible(versions []string) Option {
+	return func(cfg *Config) { cfg.VersionsNotCompatible = versions }
+}
+
+// WithRootCAs has the host BMC certificate verified against the pool.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(cfg *Config) { cfg.RootCAs = pool }
+}
+
+// WithUseBasicAuth authenticates the requests with HTTP Basic auth instead of
+// a Redfish session, the HMC resources are read through the same
+// authentication.
+func WithUseBasicAuth(use bool) Option {
+	return func(cfg *Config) { cfg.UseBasicAuth = use }
+}
+
+// WithFailInventoryOnError has Inventory fail on the first error reading the
+// HMC resources.
+func WithFailInventoryOnError(fail bool) Option {
+	return func(cfg *Config) { cfg.FailInventoryOnError = fail }
+}
+
+// New returns a [Conn] for the given host BMC. The connection is not opened
+// until [Conn.Open] is called.
+func New(host, user, pass string, log logr.Logger, opts ...Option) *Conn {
+	cfg := &Config{
+		HTTPClient:            httpclient.Build(),
+		Port:                  "443",
+		VersionsNotCompatible: []string{},
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	rfOpts := []redfishwrapper.Option{
+		redfishwrapper.WithHTTPClient(cfg.HTTPClient),
+		redfishwrapper.WithVersionsNotCompatible(cfg.VersionsNotCompatible),
+		redfishwrapper.WithBasicAuthEnabled(cfg.UseBasicAuth),
+		redfishwrapper.WithSystemsOdataIDPrefix(systemsOdataIDPrefix),
+		redfishwrapper.WithIgnoredIDPrefix(hgxPrefix),
+	}
+
+	if cfg.RootCAs != nil {
+		rfOpts = append(rfOpts, redfishwrapper.WithSecureTLS(cfg.RootCAs))
+	}
+
+	return &Conn{
+		Log:                  log,
+		failInventoryOnError: cfg.FailInventoryOnError,
+		redfishwrapper:       redfishwrapper.NewClient(host, cfg.Port, user, pass, rfOpts...),
+	}
+}
+
+// Name returns the provider name ("nvidia").
+func (c *Conn) Name() string {
+	return ProviderName
+}
+
+// Open opens a Redfish session, or sets up Basic auth when [WithUseBasicAuth]
+// was given.
+func (c *Conn) Open(ctx context.Context) error {
+	return c.redfishwrapper.Open(ctx)
+}
+
+// Close releases the Redfish session.
+func (c *Conn) Close(ctx context.Context) error {
+	return c.redfishwrapper.Close(ctx)
+}
+
+// KeepSessionAlive refreshes the host BMC session, an expired session is re-established.
+//
+// Implements bmc.SessionKeeper.
+func (c *Conn) KeepSessionAlive(ctx context.Context) error {
+	return c.redfishwrapper.KeepSessionAlive(ctx)
+}
+
+// ProbeCapabilities probes the host BMC for the features it supports.
+//
+// Implements bmc.CapabilityProber.
+func (c *Conn) ProbeCapabilities(ctx context.Context) (*bmc.ProbedCapabilities, error) {
+	return c.redfishwrapper.ProbeCapabilities(ctx)
+}
+
+// Compatible reports whether the BMC is the host BMC of an NVIDIA HGX system.
+//
+// A host BMC session is opened for the check, the BMC is compatible when its
+// Redfish version is not excluded with [WithVersionsNotCompatible] and it
+// aggregates the Chassis of an HGX HMC. HGX systems are built by several
+// vendors, the system manufacturer is not checked.
+func (c *Conn) Compatible(ctx context.Context) bool {
+	if err := c.Open(ctx); err != nil {
+		c.Log.V(2).WithValues("provider", c.Name()).
+			Info(bmclibErrs.ErrCompatibilityCheck.Error(), "error", err.Error())
+
+		return false
+	}
+	defer func() { _ = c.Close(ctx) }()
+
+	if !c.redfishwrapper.VersionCompatible() {
+		c.Log.V(2).WithValues("provider", c.Name()).
+			Info(bmclibErrs.ErrCompatibilityCheck.Error(), "reason", "incompatible redfish version")
+
+		return false
+	}
+
+	if _, err := c.hmcChassis(); err != nil {
+		c.Log.V(2).WithValues("provider", c.Name()).
+			Info(bmclibErrs.ErrCompatibilityCheck.Error(), "error", err.Error())
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,96 @@
+package nvidia
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// Requirement: Provider identity and registration.
+func TestName(t *testing.T) {
+	if ProviderName != "nvidia" {
+		t.Fatalf("ProviderName = %q, want %q", ProviderName, "nvidia")
+	}
+	c := New("127.0.0.1", "u", "p", logr.Discard())
+	if got := c.Name(); got != ProviderName {
+		t.Fatalf("Name() = %q, want %q", got, ProviderName)
+	}
+}
+
+// Requirement: Connection lifecycle — Open creates a session, Close deletes it.
+func TestOpenClose(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.Close()
+
+	c := ts.client(t)
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if !ts.didCreateSession() {
+		t.Fatal("expected a session to be created on Open")
+	}
+
+	if err := c.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !ts.didDeleteSession() {
+		t.Fatal("expected the session to be deleted on Close")
+	}
+}
+
+// Requirement: Compatible identifies host BMCs aggregating an HGX HMC and
+// honors excluded versions.
+func TestCompatible(t *testing.T) {
+	t.Run("HGX system is compatible", func(t *testing.T) {
+		ts := newTestServer(t)
+		defer ts.Close()
+
+		if !ts.client(t).Compatible(context.Background()) {
+			t.Fatal("expected the HGX system to be compatible")
+		}
+	})
+
+	t.Run("system without an HMC is not compatible", func(t *testing.T) {
+		ts := newTestServer(t)
+		defer ts.Close()
+
+		ts.serve("/redfish/v1/Chassis", "chassis.self.json")
+		if ts.client(t).Compatible(context.Background()) {
+			t.Fatal("expected a system without HGX chassis to be incompatible")
+		}
+	})
+
+	t.Run("excluded redfish version is not compatible", func(t *testing.T) {
+		ts := newTestServer(t)
+		defer ts.Close()
+
+		c := ts.client(t, WithVersionsNotCompatible([]string{"1.11.1"}))
+		if c.Compatible(context.Background()) {
+			t.Fatal("expected the excluded redfish version to be incompatible")
+		}
+	})
+}
+
+// Requirement: Power control targets the host System, not the aggregated HGX
+// baseboard System.
+func TestPower(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	state, err := c.PowerStateGet(context.Background())
+	if err != nil {
+		t.Fatalf("PowerStateGet: %v", err)
+	}
+	if state != "On" {
+		t.Fatalf("PowerStateGet = %q, want %q", state, "On")
+	}
+
+	ok, err := c.PowerSet(context.Background(), "soft")
+	if err != nil || !ok {
+		t.Fatalf("PowerSet(soft) = (%v, %v), want (true, nil)", ok, err)
+	}
+	if rt := ts.resetType(); rt != "GracefulShutdown" {
+		t.Fatalf("reset type = %q, want %q", rt, "GracefulShutdown")
+	}
+}
@@ -0,0 +1,29 @@
+package nvidia
+
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// compile-time assertions that the provider implements the interfaces.
+var (
+	_ bmc.PowerStateGetter = (*Conn)(nil)
+	_ bmc.PowerSetter      = (*Conn)(nil)
+)
+
+// PowerStateGet returns the power state of the host system, the HGX baseboard
+// System aggregated from the HMC is not considered.
+//
+// Implements bmc.PowerStateGetter.
+func (c *Conn) PowerStateGet(ctx context.Context) (state string, err error) {
+	return c.redfishwrapper.SystemPowerStatus(ctx)
+}
+
+// PowerSet sets the host system power state through the ComputerSystem.Reset
+// action, the GPU baseboard follows the host power state.
+//
+// Implements bmc.PowerSetter.
+func (c *Conn) PowerSet(ctx context.Context, state string) (ok bool, err error) {
+	return c.redfishwrapper.PowerSet(ctx, state)
+}
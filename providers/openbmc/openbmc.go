@@ -8,12 +8,14 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/bmc-toolbox/common"
 	"github.com/go-logr/logr"
 	"github.com/jacobweinstock/registrar"
 	"github.com/pkg/errors"
 
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
 	"github.com/bmc-toolbox/bmclib/v2/internal/httpclient"
 	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
 	"github.com/bmc-toolbox/bmclib/v2/providers"
@@ -36,6 +38,23 @@ var (
 		providers.FeatureFirmwareUploadInitiateInstall,
 		providers.FeatureFirmwareTaskStatus,
 		providers.FeatureInventoryRead,
+		providers.FeatureSensorsRead,
+		providers.FeaturePowerConsumption,
+		providers.FeatureSetPowerLimit,
+		providers.FeatureSetIdentifyLED,
+		providers.FeatureGetIdentifyLED,
+		providers.FeatureGetBootOrder,
+		providers.FeatureSetBootOrder,
+		providers.FeatureGetBMCNetwork,
+		providers.FeatureSetBMCNetwork,
+		providers.FeatureGenerateCSR,
+		providers.FeatureReplaceCertificate,
+		providers.FeatureListCertificates,
+		providers.FeatureGetBMCTime,
+		providers.FeatureSetBMCTime,
+		providers.FeatureSetNTPServers,
+		providers.FeatureGetDirectoryService,
+		providers.FeatureSetDirectoryService,
 	}
 
 	errNotOpenBMCDevice = errors.New("not an OpenBMC device")
@@ -192,6 +211,101 @@ func (c *Conn) BmcReset(ctx context.Context, resetType string) (ok bool, err err
 	return c.redfishwrapper.BMCReset(ctx, resetType)
 }
 
+// Sensors returns the host sensor readings
+func (c *Conn) Sensors(ctx context.Context) (sensors []bmc.SensorReading, err error) {
+	return c.redfishwrapper.Sensors(ctx)
+}
+
+// PowerConsumption returns the host power consumption
+func (c *Conn) PowerConsumption(ctx context.Context) (consumption *bmc.PowerConsumption, err error) {
+	return c.redfishwrapper.PowerConsumption(ctx)
+}
+
+// SetPowerLimit sets the host power limit
+func (c *Conn) SetPowerLimit(ctx context.Context, limit bmc.PowerLimit) (err error) {
+	return c.redfishwrapper.SetPowerLimit(ctx, limit)
+}
+
+// SetIdentifyLED turns the chassis identify LED on or off
+func (c *Conn) SetIdentifyLED(ctx context.Context, on bool, duration time.Duration) (err error) {
+	return c.redfishwrapper.SetIdentifyLED(ctx, on, duration)
+}
+
+// GetIdentifyLED returns the chassis identify LED state
+func (c *Conn) GetIdentifyLED(ctx context.Context) (on bool, err error) {
+	return c.redfishwrapper.GetIdentifyLED(ctx)
+}
+
+// GetBootOrder returns the persistent boot order
+func (c *Conn) GetBootOrder(ctx context.Context) (order []bmc.BootOption, err error) {
+	return c.redfishwrapper.GetBootOrder(ctx)
+}
+
+// SetBootOrder sets the persistent boot order
+func (c *Conn) SetBootOrder(ctx context.Context, order []string) (err error) {
+	return c.redfishwrapper.SetBootOrder(ctx, order)
+}
+
+// GetBMCNetwork returns the BMC management network configuration
+func (c *Conn) GetBMCNetwork(ctx context.Context) (config *bmc.BMCNetworkConfig, err error) {
+	return c.redfishwrapper.GetBMCNetwork(ctx)
+}
+
+// SetBMCNetwork applies the configuration to the BMC management interface
+func (c *Conn) SetBMCNetwork(ctx context.Context, config bmc.BMCNetworkConfig) (err error) {
+	return c.redfishwrapper.SetBMCNetwork(ctx, config)
+}
+
+// GenerateCSR has the BMC generate a key pair and returns the certificate signing request
+func (c *Conn) GenerateCSR(ctx context.Context, request bmc.CSRRequest) (csr string, err error) {
+	return c.redfishwrapper.GenerateCSR(ctx, request)
+}
+
+// ReplaceCertificate installs the certificate in place of the given BMC certificate
+func (c *Conn) ReplaceCertificate(ctx context.Context, certificateID, certificate string) (err error) {
+	return c.redfishwrapper.ReplaceCertificate(ctx, certificateID, certificate)
+}
+
+// ListCertificates returns the BMC certificates
+func (c *Conn) ListCertificates(ctx context.Context) (certificates []bmc.Certificate, err error) {
+	return c.redfishwrapper.ListCertificates(ctx)
+}
+
+// GetBMCTime returns the BMC clock and its NTP configuration
+func (c *Conn) GetBMCTime(ctx context.Context) (bmcTime *bmc.BMCTime, err error) {
+	return c.redfishwrapper.GetBMCTime(ctx)
+}
+
+// SetBMCTime sets the BMC clock
+func (c *Conn) SetBMCTime(ctx context.Context, t time.Time) (err error) {
+	return c.redfishwrapper.SetBMCTime(ctx, t)
+}
+
+// SetNTPServers sets the BMC NTP servers
+func (c *Conn) SetNTPServers(ctx context.Context, servers []string) (err error) {
+	return c.redfishwrapper.SetNTPServers(ctx, servers)
+}
+
+// GetDirectoryService returns the BMC LDAP or Active Directory configuration
+func (c *Conn) GetDirectoryService(ctx context.Context, serviceType bmc.DirectoryServiceType) (config *bmc.DirectoryServiceConfig, err error) {
+	return c.redfishwrapper.GetDirectoryService(ctx, serviceType)
+}
+
+// SetDirectoryService sets the BMC LDAP or Active Directory configuration
+func (c *Conn) SetDirectoryService(ctx context.Context, config bmc.DirectoryServiceConfig) (err error) {
+	return c.redfishwrapper.SetDirectoryService(ctx, config)
+}
+
+// KeepSessionAlive refreshes the redfish session, an expired session is re-established
+func (c *Conn) KeepSessionAlive(ctx context.Context) (err error) {
+	return c.redfishwrapper.KeepSessionAlive(ctx)
+}
+
+// ProbeCapabilities probes the BMC for the features it supports, like virtual media which requires a license on some BMCs
+func (c *Conn) ProbeCapabilities(ctx context.Context) (*bmc.ProbedCapabilities, error) {
+	return c.redfishwrapper.ProbeCapabilities(ctx)
+}
+
 // SendNMI tells the BMC to issue an NMI to the device
 func (c *Conn) SendNMI(ctx context.Context) error {
 	return c.redfishwrapper.SendNMI(ctx)
@@ -47,6 +47,42 @@ const (
 	FeatureGetSystemEventLog registrar.Feature = "getsystemeventlog"
 	// FeatureGetSystemEventLogRaw means an implementation that returns the BMC System Event Log (SEL) in raw format
 	FeatureGetSystemEventLogRaw registrar.Feature = "getsystemeventlograw"
+	// FeatureGetSystemEventLogEntries means an implementation that returns the BMC System Event Log (SEL) as typed entries
+	FeatureGetSystemEventLogEntries registrar.Feature = "getsystemeventlogentries"
+	// FeatureSensorsRead means an implementation that returns the host sensor readings
+	FeatureSensorsRead registrar.Feature = "sensorsread"
+	// FeaturePowerConsumption means an implementation that returns the host power consumption
+	FeaturePowerConsumption registrar.Feature = "powerconsumption"
+	// FeatureSetPowerLimit means an implementation that sets the host power limit
+	FeatureSetPowerLimit registrar.Feature = "setpowerlimit"
+	// FeatureSetIdentifyLED means an implementation that turns the chassis identify LED on or off
+	FeatureSetIdentifyLED registrar.Feature = "setidentifyled"
+	// FeatureGetIdentifyLED means an implementation that returns the chassis identify LED state
+	FeatureGetIdentifyLED registrar.Feature = "getidentifyled"
+	// FeatureGetBootOrder means an implementation that returns the persistent boot order
+	FeatureGetBootOrder registrar.Feature = "getbootorder"
+	// FeatureSetBootOrder means an implementation that sets the persistent boot order
+	FeatureSetBootOrder registrar.Feature = "setbootorder"
+	// FeatureGetBMCNetwork means an implementation that returns the BMC management network configuration
+	FeatureGetBMCNetwork registrar.Feature = "getbmcnetwork"
+	// FeatureSetBMCNetwork means an implementation that sets the BMC management network configuration
+	FeatureSetBMCNetwork registrar.Feature = "setbmcnetwork"
+	// FeatureGenerateCSR means an implementation that generates a certificate signing request on the BMC
+	FeatureGenerateCSR registrar.Feature = "generatecsr"
+	// FeatureReplaceCertificate means an implementation that replaces a BMC certificate
+	FeatureReplaceCertificate registrar.Feature = "replacecertificate"
+	// FeatureListCertificates means an implementation that lists the BMC certificates
+	FeatureListCertificates registrar.Feature = "listcertificates"
+	// FeatureGetBMCTime means an implementation that returns the BMC clock
+	FeatureGetBMCTime registrar.Feature = "getbmctime"
+	// FeatureSetBMCTime means an implementation that sets the BMC clock
+	FeatureSetBMCTime registrar.Feature = "setbmctime"
+	// FeatureSetNTPServers means an implementation that sets the BMC NTP servers
+	FeatureSetNTPServers registrar.Feature = "setntpservers"
+	// FeatureGetDirectoryService means an implementation that returns the BMC LDAP or Active Directory configuration
+	FeatureGetDirectoryService registrar.Feature = "getdirectoryservice"
+	// FeatureSetDirectoryService means an implementation that sets the BMC LDAP or Active Directory configuration
+	FeatureSetDirectoryService registrar.Feature = "setdirectoryservice"
 	// FeatureFirmwareInstallSteps means an implementation returns the steps part of the firmware update process.
 	FeatureFirmwareInstallSteps registrar.Feature = "firmwareinstallsteps"
 
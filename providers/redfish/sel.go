@@ -1,6 +1,10 @@
 package redfish
 
-import "context"
+import (
+	"context"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
 
 // ClearSystemEventLog clears the System Event Log (SEL).
 func (c *Conn) ClearSystemEventLog(ctx context.Context) (err error) {
@@ -12,6 +16,11 @@ func (c *Conn) GetSystemEventLog(ctx context.Context) (entries [][]string, err e
 	return c.redfishwrapper.GetSystemEventLog(ctx)
 }
 
+// GetSystemEventLogEntries returns the System Event Log (SEL) as typed entries.
+func (c *Conn) GetSystemEventLogEntries(ctx context.Context) (entries []bmc.SystemEventLogEntry, err error) {
+	return c.redfishwrapper.GetSystemEventLogEntries(ctx)
+}
+
 // GetSystemEventLogRaw returns the raw System Event Log (SEL) content.
 func (c *Conn) GetSystemEventLogRaw(ctx context.Context) (eventlog string, err error) {
 	return c.redfishwrapper.GetSystemEventLogRaw(ctx)
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
 )
 
 // Write tests for GetSystemEventLog
@@ -18,6 +20,22 @@ func Test_GetSystemEventLog(t *testing.T) {
 	assert.Equal(t, 2, len(entries))
 }
 
+func Test_GetSystemEventLogEntries(t *testing.T) {
+	entries, err := mockClient.GetSystemEventLogEntries(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "1", entries[0].RecordID)
+	assert.Equal(t, bmc.SystemEventLogSeverityOK, entries[0].Severity)
+	assert.Equal(t, bmc.SystemEventLogEventDirectionDeasserted, entries[0].EventDirection)
+	assert.Equal(t, "Sensor 999", entries[0].SensorName)
+	assert.Equal(t, "OEM software event.", entries[0].Message)
+	assert.Equal(t, 2023, entries[0].Timestamp.Year())
+	assert.NotEmpty(t, entries[0].Raw)
+}
+
 // Write tests for GetSystemEventLogRaw
 func Test_GetSystemEventLogRaw(t *testing.T) {
 	eventlog, err := mockClient.GetSystemEventLogRaw(context.Background())
@@ -0,0 +1,280 @@
+// Package redfishpdu implements a bmclib provider for intelligent rack PDUs
+// that speak the Redfish PowerEquipment model (e.g. Raritan, ServerTech,
+// Vertiv).
+//
+// The provider controls the power of a machine without a working BMC by
+// switching the PDU outlets its power supplies are plugged into. A machine is
+// mapped to one or more outlets, a dual PSU server is mapped to the outlet of
+// each PSU, on the same or on different PDUs.
+package redfishpdu
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/jacobweinstock/registrar"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/internal/httpclient"
+	"github.com/bmc-toolbox/bmclib/v2/internal/pdu"
+	"github.com/bmc-toolbox/bmclib/v2/providers"
+)
+
+const (
+	// ProviderName for the Redfish PDU implementation.
+	ProviderName = "redfishpdu"
+	// ProviderProtocol for the Redfish PDU implementation.
+	ProviderProtocol = "redfish"
+
+	// defaultRackPDU is the RackPDU Id of single PDU services.
+	defaultRackPDU = "1"
+	// defaultCycleDelaySeconds is the time the outlets are kept off by a power cycle.
+	defaultCycleDelaySeconds = 5
+
+	// Redfish outlet power states.
+	outletOn  = "On"
+	outletOff = "Off"
+)
+
+// Features implemented by the Redfish PDU provider.
+var Features = registrar.Features{
+	providers.FeaturePowerSet,
+	providers.FeaturePowerState,
+}
+
+// compile-time assertions that the provider implements the interfaces.
+var (
+	_ bmc.PowerStateGetter = (*Config)(nil)
+	_ bmc.PowerSetter      = (*Config)(nil)
+)
+
+// Outlet identifies a PDU outlet a power supply of the machine is plugged into.
+type Outlet struct {
+	// Host is the address of the PDU, the provider host is used when empty.
+	Host string
+	// RackPDU is the Id of the RackPDU resource, "1" is used when empty.
+	RackPDU string
+	// ID is the Id of the Outlet resource, e.g. "OUTLET3" or "A12".
+	ID string
+}
+
+// Config holds the configuration for the Redfish PDU provider.
+type Config struct {
+	// Host is the address of the PDU, with an optional scheme and port,
+	// https is used when no scheme is given.
+	Host     string
+	Username string
+	Password string
+	// Outlets are the outlets of the machine, they are switched off and on in
+	// the order listed.
+	Outlets []Outlet
+	// CycleDelaySeconds is the time the outlets are kept off by a power cycle,
+	// defaults to 5 seconds.
+	CycleDelaySeconds uint32
+	HTTPClient        *http.Client
+	Logger            logr.Logger
+}
+
+// outlet is the subset of a Redfish Outlet resource the provider reads.
+type outlet struct {
+	ID         string `json:"Id"`
+	PowerState string `json:"PowerState"`
+	Actions    struct {
+		PowerControl struct {
+			Target string `json:"target"`
+		} `json:"#Outlet.PowerControl"`
+	} `json:"Actions"`
+}
+
+// New returns a new Config containing all the defaults for the Redfish PDU provider.
+func New(host, username, password string) *Config {
+	return &Config{
+		Host:              host,
+		Username:          username,
+		Password:          password,
+		CycleDelaySeconds: defaultCycleDelaySeconds,
+		HTTPClient:        httpclient.Build(),
+		Logger:            logr.Discard(),
+	}
+}
+
+// Name returns the name of this Redfish PDU provider.
+// Implements bmc.Provider interface
+func (p *Config) Name() string {
+	return ProviderName
+}
+
+// Open validates the outlets mapped to the machine exist on the PDUs.
+func (p *Config) Open(ctx context.Context) error {
+	if len(p.Outlets) == 0 {
+		return errors.New("no PDU outlets configured")
+	}
+
+	for _, o := range p.Outlets {
+		if _, err := p.outlet(ctx, o); err != nil {
+			return fmt.Errorf("failed to get PDU outlet %s: %w", o.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Close a connection to the PDUs, the provider holds no session.
+func (p *Config) Close(_ context.Context) (err error) {
+	return nil
+}
+
+// PowerStateGet reads the PowerState of the Outlet resources of the machine,
+// it returns "on" when any of them is on.
+func (p *Config) PowerStateGet(ctx context.Context) (state string, err error) {
+	outletsOn := make([]bool, 0, len(p.Outlets))
+
+	for _, o := range p.Outlets {
+		res, err := p.outlet(ctx, o)
+		if err != nil {
+			return "unknown", fmt.Errorf("failed to get PDU outlet %s: %w", o.ID, err)
+		}
+
+		outletsOn = append(outletsOn, strings.EqualFold(res.PowerState, outletOn))
+	}
+
+	return pdu.PowerState(outletsOn), nil
+}
+
+// PowerSet sets the power state of the machine with the Outlet.PowerControl
+// action of its outlets, see pdu.PowerSet for the power states.
+func (p *Config) PowerSet(ctx context.Context, state string) (ok bool, err error) {
+	return pdu.PowerSet(ctx, p.Logger, p.switchOutlets, time.Duration(p.CycleDelaySeconds)*time.Second, state)
+}
+
+// switchOutlets POSTs the power state to the PowerControl action of the outlets
+// in the order configured.
+func (p *Config) switchOutlets(ctx context.Context, on bool) error {
+	powerState := outletOff
+	if on {
+		powerState = outletOn
+	}
+
+	for _, o := range p.Outlets {
+		res, err := p.outlet(ctx, o)
+		if err != nil {
+			return fmt.Errorf("failed to get PDU outlet %s: %w", o.ID, err)
+		}
+
+		target := res.Actions.PowerControl.Target
+		if target == "" {
+			target = p.outletURI(o) + "/Actions/Outlet.PowerControl"
+		}
+
+		if err := p.post(ctx, o, target, map[string]string{"PowerState": powerState}); err != nil {
+			return fmt.Errorf("failed to set PDU outlet %s %s: %w", o.ID, powerState, err)
+		}
+
+		p.Logger.V(1).Info("set PDU outlet power state", "outlet", o.ID, "powerState", powerState)
+	}
+
+	return nil
+}
+
+// outlet GETs the Outlet resource.
+func (p *Config) outlet(ctx context.Context, o Outlet) (*outlet, error) {
+	req, err := p.request(ctx, http.MethodGet, o, p.outletURI(o), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	res := &outlet{}
+	if err := json.Unmarshal(body, res); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return res, nil
+}
+
+// post POSTs the payload to a Redfish action target of the PDU of the outlet.
+func (p *Config) post(ctx context.Context, o Outlet, target string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	req, err := p.request(ctx, http.MethodPost, o, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// request returns a Basic authenticated request for the Redfish path on the
+// PDU of the outlet.
+func (p *Config) request(ctx context.Context, method string, o Outlet, path string, body io.Reader) (*http.Request, error) {
+	host := o.Host
+	if host == "" {
+		host = p.Host
+	}
+
+	if !strings.HasPrefix(host, "https://") && !strings.HasPrefix(host, "http://") {
+		host = "https://" + host
+	}
+
+	u, err := url.JoinPath(host, path)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.SetBasicAuth(p.Username, p.Password)
+	req.Header.Set("Accept", "application/json")
+
+	return req, nil
+}
+
+// outletURI returns the Redfish URI of the Outlet resource.
+func (p *Config) outletURI(o Outlet) string {
+	pdu := o.RackPDU
+	if pdu == "" {
+		pdu = defaultRackPDU
+	}
+
+	return "/redfish/v1/PowerEquipment/RackPDUs/" + url.PathEscape(pdu) + "/Outlets/" + url.PathEscape(o.ID)
+}
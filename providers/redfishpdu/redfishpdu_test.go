@@ -0,0 +1,199 @@
+package redfishpdu
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// pduServer is an httptest-backed mock of a Redfish rack PDU, it serves the
+// Outlets of RackPDU 1 and applies the Outlet.PowerControl actions.
+type pduServer struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// states maps the outlet Ids to their power state.
+	states map[string]string
+}
+
+// newPDUServer builds and starts a mock PDU with the outlets in the given power
+// states, the actions posted are appended to actions as "<outlet> <PowerState>".
+func newPDUServer(t *testing.T, states map[string]string, actions *[]string, mu *sync.Mutex) *pduServer {
+	t.Helper()
+
+	s := &pduServer{states: states}
+	const outlets = "/redfish/v1/PowerEquipment/RackPDUs/1/Outlets/"
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "admin" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		id, action, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, outlets), "/")
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		state, ok := s.states[id]
+		if !strings.HasPrefix(r.URL.Path, outlets) || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Method == http.MethodPost && action == "Actions/Outlet.PowerControl" {
+			var payload struct {
+				PowerState string `json:"PowerState"`
+			}
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &payload)
+
+			s.states[id] = payload.PowerState
+
+			mu.Lock()
+			*actions = append(*actions, id+" "+payload.PowerState)
+			mu.Unlock()
+
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"@odata.id":  outlets + id,
+			"Id":         id,
+			"PowerState": state,
+			"Actions": map[string]any{
+				"#Outlet.PowerControl": map[string]string{"target": outlets + id + "/Actions/Outlet.PowerControl"},
+			},
+		})
+	}))
+
+	return s
+}
+
+// provider returns a provider for the outlets, with no cycle delay.
+func provider(s *pduServer, outlets ...Outlet) *Config {
+	p := New(s.URL, "admin", "secret")
+	p.Outlets = outlets
+	p.CycleDelaySeconds = 0
+
+	return p
+}
+
+// Requirement: Provider identity and registration.
+func TestName(t *testing.T) {
+	if got := New("127.0.0.1", "u", "p").Name(); got != ProviderName {
+		t.Fatalf("Name() = %q, want %q", got, ProviderName)
+	}
+}
+
+// Requirement: Open validates the mapped outlets exist.
+func TestOpen(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		actions []string
+	)
+	s := newPDUServer(t, map[string]string{"A1": "On"}, &actions, &mu)
+	defer s.Close()
+
+	if err := provider(s, Outlet{ID: "A1"}).Open(context.Background()); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	if err := provider(s, Outlet{ID: "A9"}).Open(context.Background()); err == nil {
+		t.Fatal("expected an error for an unknown outlet")
+	}
+
+	if err := provider(s).Open(context.Background()); err == nil {
+		t.Fatal("expected an error without outlets")
+	}
+}
+
+// Requirement: the machine is on while any of its outlets is on.
+func TestPowerStateGet(t *testing.T) {
+	tests := []struct {
+		states map[string]string
+		want   string
+	}{
+		{map[string]string{"A1": "On", "A2": "On"}, "on"},
+		{map[string]string{"A1": "Off", "A2": "On"}, "on"},
+		{map[string]string{"A1": "Off", "A2": "Off"}, "off"},
+	}
+
+	for _, tc := range tests {
+		var (
+			mu      sync.Mutex
+			actions []string
+		)
+		s := newPDUServer(t, tc.states, &actions, &mu)
+
+		state, err := provider(s, Outlet{ID: "A1"}, Outlet{ID: "A2"}).PowerStateGet(context.Background())
+		s.Close()
+
+		if err != nil {
+			t.Fatalf("PowerStateGet: %v", err)
+		}
+		if state != tc.want {
+			t.Fatalf("PowerStateGet(%v) = %q, want %q", tc.states, state, tc.want)
+		}
+	}
+}
+
+// Requirement: the outlets are switched in the configured order, a cycle
+// switches all of them off before switching them on, across PDUs.
+func TestPowerSet(t *testing.T) {
+	tests := []struct {
+		state string
+		want  []string
+	}{
+		{"off", []string{"A1 Off", "B1 Off"}},
+		{"on", []string{"A1 On", "B1 On"}},
+		{"cycle", []string{"A1 Off", "B1 Off", "A1 On", "B1 On"}},
+		{"reset", []string{"A1 Off", "B1 Off", "A1 On", "B1 On"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.state, func(t *testing.T) {
+			var (
+				mu      sync.Mutex
+				actions []string
+			)
+			a := newPDUServer(t, map[string]string{"A1": "On"}, &actions, &mu)
+			defer a.Close()
+			b := newPDUServer(t, map[string]string{"B1": "On"}, &actions, &mu)
+			defer b.Close()
+
+			p := provider(a, Outlet{ID: "A1"}, Outlet{Host: b.URL, ID: "B1"})
+			ok, err := p.PowerSet(context.Background(), tc.state)
+			if err != nil || !ok {
+				t.Fatalf("PowerSet(%s) = (%v, %v), want (true, nil)", tc.state, ok, err)
+			}
+
+			if strings.Join(actions, ",") != strings.Join(tc.want, ",") {
+				t.Fatalf("actions = %v, want %v", actions, tc.want)
+			}
+		})
+	}
+}
+
+// Requirement: a PDU can not shut down the machine gracefully.
+func TestPowerSetSoft(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		actions []string
+	)
+	s := newPDUServer(t, map[string]string{"A1": "On"}, &actions, &mu)
+	defer s.Close()
+
+	if ok, err := provider(s, Outlet{ID: "A1"}).PowerSet(context.Background(), "soft"); err == nil || ok {
+		t.Fatalf("PowerSet(soft) = (%v, %v), want an error", ok, err)
+	}
+	if len(actions) != 0 {
+		t.Fatalf("actions = %v, want none", actions)
+	}
+}
@@ -0,0 +1,67 @@
+package snmppdu
+
+// Command is an SNMP SET of an integer value on an outlet control OID.
+type Command struct {
+	// OID is the control OID of the outlet table, the outlet index is appended.
+	OID string
+	// Value is the integer value set.
+	Value int
+}
+
+// Profile is the outlet MIB of a PDU family.
+type Profile struct {
+	// Name names the MIB, e.g. "apc".
+	Name string
+	// StateOID is the outlet state OID of the outlet table, the outlet index
+	// is appended.
+	StateOID string
+	// OnState is the StateOID value of an outlet that is on, any other value
+	// is off.
+	OnState int
+	// On switches an outlet on.
+	On Command
+	// Off switches an outlet off.
+	Off Command
+}
+
+var (
+	// ProfileAPC is the APC PowerNet-MIB rPDU outlet table of the AP78xx and
+	// AP79xx switched rack PDUs, the outlet index is the outlet number, e.g.
+	// "3".
+	ProfileAPC = Profile{
+		Name: "apc",
+		// rPDUOutletStatusOutletState: outletStatusOn(1), outletStatusOff(2)
+		StateOID: ".1.3.6.1.4.1.318.1.1.12.3.5.1.1.4",
+		OnState:  1,
+		// rPDUOutletControlOutletCommand: immediateOn(1), immediateOff(2)
+		On:  Command{OID: ".1.3.6.1.4.1.318.1.1.12.3.3.1.1.4", Value: 1},
+		Off: Command{OID: ".1.3.6.1.4.1.318.1.1.12.3.3.1.1.4", Value: 2},
+	}
+
+	// ProfileEaton is the EATON-EPDU-MIB outlet control table of the Eaton
+	// ePDUs, the outlet index is the daisy chain unit and the outlet number,
+	// e.g. "0.3" for outlet 3 of the first unit.
+	ProfileEaton = Profile{
+		Name: "eaton",
+		// outletControlStatus: off(0), on(1), pendingOff(2), pendingOn(3)
+		StateOID: ".1.3.6.1.4.1.534.6.6.7.6.6.1.2",
+		OnState:  1,
+		// outletControlOnCmd and outletControlOffCmd, the value is the delay
+		// in seconds before the command is executed.
+		On:  Command{OID: ".1.3.6.1.4.1.534.6.6.7.6.6.1.4", Value: 0},
+		Off: Command{OID: ".1.3.6.1.4.1.534.6.6.7.6.6.1.3", Value: 0},
+	}
+
+	// ProfileServerTech is the Sentry3-MIB outlet table of the ServerTech
+	// Sentry switched PDUs, the outlet index is the tower, the infeed and the
+	// outlet number, e.g. "1.1.3" for outlet 3 of infeed A of the master tower.
+	ProfileServerTech = Profile{
+		Name: "servertech",
+		// outletStatus: off(0), on(1), offWait(2), onWait(3), ...
+		StateOID: ".1.3.6.1.4.1.1718.3.2.3.1.5",
+		OnState:  1,
+		// outletControlAction: on(1), off(2)
+		On:  Command{OID: ".1.3.6.1.4.1.1718.3.2.3.1.11", Value: 1},
+		Off: Command{OID: ".1.3.6.1.4.1.1718.3.2.3.1.11", Value: 2},
+	}
+)
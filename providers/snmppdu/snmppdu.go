@@ -0,0 +1,315 @@
+// Package snmppdu implements a bmclib provider for switched rack PDUs that are
+// controlled through SNMP outlet MIBs.
+//
+// The provider controls the power of a machine by switching the PDU outlets
+// its power supplies are plugged into, it is registered after the BMC
+// providers as a power fallback for machines with an unresponsive BMC. The
+// outlet MIB of the PDU is selected by a Profile, profiles are provided for the
+// APC PowerNet-MIB, Eaton EATON-EPDU-MIB and ServerTech Sentry3-MIB.
+package snmppdu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gosnmp/gosnmp"
+	"github.com/jacobweinstock/registrar"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/internal/pdu"
+	"github.com/bmc-toolbox/bmclib/v2/providers"
+)
+
+const (
+	// ProviderName for the SNMP PDU implementation.
+	ProviderName = "snmppdu"
+	// ProviderProtocol for the SNMP PDU implementation.
+	ProviderProtocol = "snmp"
+
+	// VersionV2c selects SNMPv2c community authentication.
+	VersionV2c = "2c"
+	// VersionV3 selects the SNMPv3 user security model.
+	VersionV3 = "3"
+
+	defaultPort              = 161
+	defaultTimeoutSeconds    = 5
+	defaultRetries           = 2
+	defaultCycleDelaySeconds = 5
+)
+
+// Features implemented by the SNMP PDU provider.
+var Features = registrar.Features{
+	providers.FeaturePowerSet,
+	providers.FeaturePowerState,
+}
+
+// compile-time assertions that the provider implements the interfaces.
+var (
+	_ bmc.PowerStateGetter = (*Config)(nil)
+	_ bmc.PowerSetter      = (*Config)(nil)
+)
+
+// authProtocols maps the SNMPv3 authentication protocol names to gosnmp.
+var authProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+	"":       gosnmp.NoAuth,
+	"MD5":    gosnmp.MD5,
+	"SHA":    gosnmp.SHA,
+	"SHA224": gosnmp.SHA224,
+	"SHA256": gosnmp.SHA256,
+	"SHA384": gosnmp.SHA384,
+	"SHA512": gosnmp.SHA512,
+}
+
+// privProtocols maps the SNMPv3 privacy protocol names to gosnmp.
+var privProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+	"":       gosnmp.NoPriv,
+	"DES":    gosnmp.DES,
+	"AES":    gosnmp.AES,
+	"AES192": gosnmp.AES192,
+	"AES256": gosnmp.AES256,
+}
+
+// Config holds the configuration for the SNMP PDU provider.
+type Config struct {
+	// Host is the address of the PDU.
+	Host string
+	// Port is the SNMP agent port of the PDU, defaults to 161.
+	Port uint16
+	// Profile is the outlet MIB of the PDU.
+	Profile Profile
+	// Outlets are the outlet indexes of the machine in the outlet table of the
+	// Profile, they are switched off and on in the order listed.
+	Outlets []string
+	// Version is the SNMP version, VersionV2c (the default) or VersionV3.
+	Version string
+	// Community is the SNMPv2c write community.
+	Community string
+	// Username is the SNMPv3 user.
+	Username string
+	// AuthProtocol is the SNMPv3 authentication protocol: MD5, SHA, SHA224,
+	// SHA256, SHA384 or SHA512, no authentication when empty.
+	AuthProtocol string
+	// AuthPassphrase is the SNMPv3 authentication passphrase.
+	AuthPassphrase string
+	// PrivProtocol is the SNMPv3 privacy protocol: DES, AES, AES192 or AES256,
+	// no privacy when empty.
+	PrivProtocol string
+	// PrivPassphrase is the SNMPv3 privacy passphrase.
+	PrivPassphrase string
+	// TimeoutSeconds is the timeout of an SNMP request, defaults to 5 seconds.
+	TimeoutSeconds uint32
+	// Retries is the number of retries of an SNMP request, defaults to 2.
+	Retries int
+	// CycleDelaySeconds is the time the outlets are kept off by a power cycle,
+	// defaults to 5 seconds.
+	CycleDelaySeconds uint32
+	Logger            logr.Logger
+}
+
+// New returns a new Config containing all the defaults for the SNMP PDU provider.
+func New(host, community string, profile Profile, outlets ...string) *Config { //nolint:gocritic // profiles are passed by value like the built-in profile vars
+	return &Config{
+		Host:              host,
+		Port:              defaultPort,
+		Profile:           profile,
+		Outlets:           outlets,
+		Version:           VersionV2c,
+		Community:         community,
+		TimeoutSeconds:    defaultTimeoutSeconds,
+		Retries:           defaultRetries,
+		CycleDelaySeconds: defaultCycleDelaySeconds,
+		Logger:            logr.Discard(),
+	}
+}
+
+// Name returns the name of this SNMP PDU provider.
+// Implements bmc.Provider interface
+func (p *Config) Name() string {
+	return ProviderName
+}
+
+// Open validates the configuration and that the outlet states of the machine
+// can be read from the PDU.
+func (p *Config) Open(ctx context.Context) error {
+	if len(p.Outlets) == 0 {
+		return errors.New("no PDU outlets configured")
+	}
+
+	if p.Profile.StateOID == "" || p.Profile.On.OID == "" || p.Profile.Off.OID == "" {
+		return errors.New("PDU profile outlet OIDs not configured")
+	}
+
+	_, err := p.outletStates(ctx)
+
+	return err
+}
+
+// Close a connection to the PDU, the provider holds no session.
+func (p *Config) Close(_ context.Context) (err error) {
+	return nil
+}
+
+// PowerStateGet reads the outlet states of the machine from the StateOID
+// column of the Profile in a single GET, it returns "on" when any outlet is on.
+func (p *Config) PowerStateGet(ctx context.Context) (state string, err error) {
+	states, err := p.outletStates(ctx)
+	if err != nil {
+		return "unknown", err
+	}
+
+	return pdu.PowerState(states), nil
+}
+
+// PowerSet sets the power state of the machine with the On and Off commands
+// of the Profile, see pdu.PowerSet for the power states.
+func (p *Config) PowerSet(ctx context.Context, state string) (ok bool, err error) {
+	return pdu.PowerSet(ctx, p.Logger, p.switchOutlets, time.Duration(p.CycleDelaySeconds)*time.Second, state)
+}
+
+// switchOutlets sets the On or Off command of the Profile on the outlets in the
+// order configured, one SET per outlet.
+func (p *Config) switchOutlets(ctx context.Context, on bool) error {
+	command := p.Profile.Off
+	if on {
+		command = p.Profile.On
+	}
+
+	client, err := p.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Conn.Close() }()
+
+	for _, outlet := range p.Outlets {
+		pdu := gosnmp.SnmpPDU{
+			Name:  oid(command.OID, outlet),
+			Type:  gosnmp.Integer,
+			Value: command.Value,
+		}
+
+		result, err := client.Set([]gosnmp.SnmpPDU{pdu})
+		if err != nil {
+			return fmt.Errorf("failed to switch PDU outlet %s: %w", outlet, err)
+		}
+
+		if result.Error != gosnmp.NoError {
+			return fmt.Errorf("failed to switch PDU outlet %s: %s", outlet, result.Error)
+		}
+
+		p.Logger.V(1).Info("switched PDU outlet", "outlet", outlet, "oid", pdu.Name, "value", command.Value)
+	}
+
+	return nil
+}
+
+// outletStates returns whether each outlet of the machine is on.
+func (p *Config) outletStates(ctx context.Context) ([]bool, error) {
+	client, err := p.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Conn.Close() }()
+
+	oids := make([]string, 0, len(p.Outlets))
+	for _, outlet := range p.Outlets {
+		oids = append(oids, oid(p.Profile.StateOID, outlet))
+	}
+
+	result, err := client.Get(oids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PDU outlet states: %w", err)
+	}
+
+	if result.Error != gosnmp.NoError {
+		return nil, fmt.Errorf("failed to get PDU outlet states: %s", result.Error)
+	}
+
+	states := make([]bool, 0, len(result.Variables))
+
+	for i, variable := range result.Variables {
+		switch variable.Type {
+		case gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.Null:
+			return nil, fmt.Errorf("PDU outlet %s not found: %s", p.Outlets[i], variable.Name)
+		}
+
+		states = append(states, gosnmp.ToBigInt(variable.Value).Int64() == int64(p.Profile.OnState))
+	}
+
+	return states, nil
+}
+
+// connect returns a gosnmp client connected to the PDU.
+func (p *Config) connect(ctx context.Context) (*gosnmp.GoSNMP, error) {
+	client := &gosnmp.GoSNMP{
+		Target:  p.Host,
+		Port:    p.Port,
+		Timeout: time.Duration(p.TimeoutSeconds) * time.Second,
+		Retries: p.Retries,
+		Context: ctx,
+		MaxOids: gosnmp.MaxOids,
+	}
+
+	switch p.Version {
+	case VersionV2c, "":
+		client.Version = gosnmp.Version2c
+		client.Community = p.Community
+	case VersionV3:
+		if err := p.v3(client); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported SNMP version: %s", p.Version)
+	}
+
+	if err := client.Connect(); err != nil {
+		return nil, fmt.Errorf("failed to connect to PDU: %w", err)
+	}
+
+	return client, nil
+}
+
+// v3 configures the SNMPv3 user security model on the client.
+func (p *Config) v3(client *gosnmp.GoSNMP) error {
+	auth, ok := authProtocols[strings.ToUpper(p.AuthProtocol)]
+	if !ok {
+		return fmt.Errorf("unsupported SNMPv3 authentication protocol: %s", p.AuthProtocol)
+	}
+
+	priv, ok := privProtocols[strings.ToUpper(p.PrivProtocol)]
+	if !ok {
+		return fmt.Errorf("unsupported SNMPv3 privacy protocol: %s", p.PrivProtocol)
+	}
+
+	flags := gosnmp.NoAuthNoPriv
+
+	switch {
+	case auth != gosnmp.NoAuth && priv != gosnmp.NoPriv:
+		flags = gosnmp.AuthPriv
+	case auth != gosnmp.NoAuth:
+		flags = gosnmp.AuthNoPriv
+	case priv != gosnmp.NoPriv:
+		return errors.New("SNMPv3 privacy requires an authentication protocol")
+	}
+
+	client.Version = gosnmp.Version3
+	client.SecurityModel = gosnmp.UserSecurityModel
+	client.MsgFlags = flags
+	client.SecurityParameters = &gosnmp.UsmSecurityParameters{
+		UserName:                 p.Username,
+		AuthenticationProtocol:   auth,
+		AuthenticationPassphrase: p.AuthPassphrase,
+		PrivacyProtocol:          priv,
+		PrivacyPassphrase:        p.PrivPassphrase,
+	}
+
+	return nil
+}
+
+// oid returns the OID of the outlet in the table column.
+func oid(column, outlet string) string {
+	return strings.TrimSuffix(column, ".") + "." + strings.TrimPrefix(outlet, ".")
+}
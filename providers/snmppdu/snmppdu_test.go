@@ -0,0 +1,263 @@
+package snmppdu
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// agent is a UDP SNMPv2c agent stand-in for a PDU, it serves the integer
+// values of an OID table and records the SETs in the order received.
+type agent struct {
+	conn *net.UDPConn
+
+	mu     sync.Mutex
+	values map[string]int
+	sets   []string
+}
+
+// newAgent starts an agent on a local UDP port serving the OID values, the
+// write community is "private".
+func newAgent(t *testing.T, values map[string]int) *agent {
+	t.Helper()
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	a := &agent{conn: conn, values: values}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go a.serve()
+
+	return a
+}
+
+// serve answers the GET and SET requests until the connection is closed,
+// requests with another community are dropped like a PDU does.
+func (a *agent) serve() {
+	decoder := &gosnmp.GoSNMP{Version: gosnmp.Version2c}
+	buf := make([]byte, 65535)
+
+	for {
+		n, addr, err := a.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+
+		req, err := decoder.SnmpDecodePacket(buf[:n])
+		if err != nil || req.Community != "private" {
+			continue
+		}
+
+		resp := &gosnmp.SnmpPacket{
+			Version:   gosnmp.Version2c,
+			Community: req.Community,
+			PDUType:   gosnmp.GetResponse,
+			RequestID: req.RequestID,
+		}
+
+		a.mu.Lock()
+		for _, v := range req.Variables {
+			value, ok := a.values[v.Name]
+
+			switch {
+			case !ok:
+				resp.Variables = append(resp.Variables, gosnmp.SnmpPDU{Name: v.Name, Type: gosnmp.NoSuchInstance})
+				continue
+			case req.PDUType == gosnmp.SetRequest:
+				value = int(gosnmp.ToBigInt(v.Value).Int64())
+				a.values[v.Name] = value
+				a.sets = append(a.sets, fmt.Sprintf("%s=%d", v.Name, value))
+			}
+
+			resp.Variables = append(resp.Variables, gosnmp.SnmpPDU{Name: v.Name, Type: gosnmp.Integer, Value: value})
+		}
+		a.mu.Unlock()
+
+		out, err := resp.MarshalMsg()
+		if err != nil {
+			continue
+		}
+
+		_, _ = a.conn.WriteToUDP(out, addr)
+	}
+}
+
+func (a *agent) setRequests() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.sets
+}
+
+// provider returns a provider for the outlets on the agent, with no cycle delay.
+func (a *agent) provider(profile Profile, outlets ...string) *Config {
+	addr := a.conn.LocalAddr().(*net.UDPAddr)
+
+	p := New(addr.IP.String(), "private", profile, outlets...)
+	p.Port = uint16(addr.Port)
+	p.TimeoutSeconds = 1
+	p.Retries = 0
+	p.CycleDelaySeconds = 0
+
+	return p
+}
+
+// Requirement: Provider identity and registration.
+func TestName(t *testing.T) {
+	if got := New("127.0.0.1", "private", ProfileAPC).Name(); got != ProviderName {
+		t.Fatalf("Name() = %q, want %q", got, ProviderName)
+	}
+}
+
+// Requirement: Open validates the configuration and the outlets exist.
+func TestOpen(t *testing.T) {
+	a := newAgent(t, map[string]int{
+		ProfileAPC.StateOID + ".3": 1,
+	})
+
+	if err := a.provider(ProfileAPC, "3").Open(context.Background()); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	if err := a.provider(ProfileAPC, "9").Open(context.Background()); err == nil {
+		t.Fatal("expected an error for an unknown outlet")
+	}
+
+	if err := a.provider(ProfileAPC).Open(context.Background()); err == nil {
+		t.Fatal("expected an error without outlets")
+	}
+
+	if err := a.provider(Profile{}, "3").Open(context.Background()); err == nil {
+		t.Fatal("expected an error without profile OIDs")
+	}
+}
+
+// Requirement: the machine is on while any of its outlets is on, the outlet
+// state values are read with the profile of the PDU.
+func TestPowerStateGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile Profile
+		values  map[string]int
+		outlets []string
+		want    string
+	}{
+		{
+			"apc both on", ProfileAPC,
+			map[string]int{ProfileAPC.StateOID + ".3": 1, ProfileAPC.StateOID + ".4": 1},
+			[]string{"3", "4"}, "on",
+		},
+		{
+			"apc one on", ProfileAPC,
+			map[string]int{ProfileAPC.StateOID + ".3": 2, ProfileAPC.StateOID + ".4": 1},
+			[]string{"3", "4"}, "on",
+		},
+		{
+			"apc both off", ProfileAPC,
+			map[string]int{ProfileAPC.StateOID + ".3": 2, ProfileAPC.StateOID + ".4": 2},
+			[]string{"3", "4"}, "off",
+		},
+		{
+			"eaton pending on is off", ProfileEaton,
+			map[string]int{ProfileEaton.StateOID + ".0.3": 3},
+			[]string{"0.3"}, "off",
+		},
+		{
+			"servertech on", ProfileServerTech,
+			map[string]int{ProfileServerTech.StateOID + ".1.1.3": 1},
+			[]string{"1.1.3"}, "on",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newAgent(t, tc.values)
+
+			state, err := a.provider(tc.profile, tc.outlets...).PowerStateGet(context.Background())
+			if err != nil {
+				t.Fatalf("PowerStateGet: %v", err)
+			}
+			if state != tc.want {
+				t.Fatalf("PowerStateGet = %q, want %q", state, tc.want)
+			}
+		})
+	}
+}
+
+// Requirement: the outlets are switched in the configured order with the
+// profile commands, a cycle switches all of them off before switching them on.
+func TestPowerSet(t *testing.T) {
+	on := ProfileEaton.On.OID
+	off := ProfileEaton.Off.OID
+
+	tests := []struct {
+		state string
+		want  []string
+	}{
+		{"off", []string{off + ".0.3=0", off + ".1.3=0"}},
+		{"on", []string{on + ".0.3=0", on + ".1.3=0"}},
+		{"cycle", []string{off + ".0.3=0", off + ".1.3=0", on + ".0.3=0", on + ".1.3=0"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.state, func(t *testing.T) {
+			a := newAgent(t, map[string]int{
+				on + ".0.3": 0, on + ".1.3": 0,
+				off + ".0.3": 0, off + ".1.3": 0,
+			})
+
+			ok, err := a.provider(ProfileEaton, "0.3", "1.3").PowerSet(context.Background(), tc.state)
+			if err != nil || !ok {
+				t.Fatalf("PowerSet(%s) = (%v, %v), want (true, nil)", tc.state, ok, err)
+			}
+
+			if got := a.setRequests(); strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Fatalf("SETs = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("soft", func(t *testing.T) {
+		a := newAgent(t, map[string]int{})
+
+		if ok, err := a.provider(ProfileEaton, "0.3").PowerSet(context.Background(), "soft"); err == nil || ok {
+			t.Fatalf("PowerSet(soft) = (%v, %v), want an error", ok, err)
+		}
+	})
+}
+
+// Requirement: SNMPv3 security levels follow the configured protocols.
+func TestV3(t *testing.T) {
+	p := New("127.0.0.1", "", ProfileAPC, "3")
+	p.Version = VersionV3
+	p.Username = "pdu"
+	p.AuthProtocol = "sha"
+	p.AuthPassphrase = "authpass"
+	p.PrivProtocol = "AES"
+	p.PrivPassphrase = "privpass"
+
+	client := &gosnmp.GoSNMP{}
+	if err := p.v3(client); err != nil {
+		t.Fatalf("v3: %v", err)
+	}
+	if client.MsgFlags != gosnmp.AuthPriv || client.Version != gosnmp.Version3 {
+		t.Fatalf("v3 client = %v %v, want AuthPriv SNMPv3", client.MsgFlags, client.Version)
+	}
+
+	p.AuthProtocol = ""
+	if err := p.v3(&gosnmp.GoSNMP{}); err == nil {
+		t.Fatal("expected an error for privacy without authentication")
+	}
+
+	p.AuthProtocol = "SHA3"
+	if err := p.v3(&gosnmp.GoSNMP{}); err == nil {
+		t.Fatal("expected an error for an unsupported authentication protocol")
+	}
+}
@@ -0,0 +1,322 @@
+package sshclp
+
+import (
+	"bufio"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// clpTarget is a target of the CLP show output with its properties, the
+// property names are lower cased.
+type clpTarget struct {
+	Path       string
+	Properties map[string]string
+}
+
+var (
+	// clpAccount and clpRecord match the account and log record targets,
+	// the submatch is the instance number.
+	clpAccount = regexp.MustCompile(`/account(\d+)$`)
+	clpRecord  = regexp.MustCompile(`/record(\d+)$`)
+	// racadmKey matches the key line of a racadm get group instance, the
+	// submatch is the instance number.
+	racadmKey = regexp.MustCompile(`^\[Key=\S+\.(\d+)\]$`)
+)
+
+// parseCLPTargets parses the targets of a CLP show output, a target starts
+// with its path and its properties follow as "name=value" lines.
+func parseCLPTargets(output string) []clpTarget {
+	var targets []clpTarget
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "/") {
+			targets = append(targets, clpTarget{Path: line, Properties: map[string]string{}})
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, "=")
+		if !ok || len(targets) == 0 {
+			continue
+		}
+
+		targets[len(targets)-1].Properties[strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(value)
+	}
+
+	return targets
+}
+
+// parseCLPUsers returns the users of the account targets of the CLP
+// Simple Identity Management profile.
+func parseCLPUsers(output string) []map[string]string {
+	var users []map[string]string
+
+	for _, target := range parseCLPTargets(output) {
+		match := clpAccount.FindStringSubmatch(target.Path)
+		if match == nil || target.Properties["userid"] == "" {
+			continue
+		}
+
+		user := map[string]string{
+			"ID":   match[1],
+			"Name": target.Properties["userid"],
+		}
+
+		if state, ok := target.Properties["enabledstate"]; ok {
+			user["Enabled"] = strconv.FormatBool(strings.EqualFold(state, "enabled"))
+		}
+
+		users = append(users, user)
+	}
+
+	return users
+}
+
+// parseCLPSEL returns the entries of the record targets of the CLP Record
+// Log profile.
+func parseCLPSEL(output string) []bmc.SystemEventLogEntry {
+	var entries []bmc.SystemEventLogEntry
+
+	for _, target := range parseCLPTargets(output) {
+		match := clpRecord.FindStringSubmatch(target.Path)
+		if match == nil {
+			continue
+		}
+
+		message := target.Properties["description"]
+		if message == "" {
+			message = target.Properties["recorddata"]
+		}
+
+		var raw strings.Builder
+		raw.WriteString(target.Path)
+
+		for _, name := range slices.Sorted(maps.Keys(target.Properties)) {
+			raw.WriteString("\n" + name + "=" + target.Properties[name])
+		}
+
+		entries = append(entries, bmc.SystemEventLogEntry{
+			RecordID:  match[1],
+			Timestamp: parseCIMDateTime(target.Properties["creationtimestamp"]),
+			Severity:  parseCIMSeverity(target.Properties["perceivedseverity"]),
+			Message:   message,
+			Raw:       []byte(raw.String()),
+		})
+	}
+
+	return entries
+}
+
+// parseCIMDateTime parses a CIM datetime, e.g. "20130815112233.000000+060"
+// where the suffix is the UTC offset in minutes. The zero time is returned for
+// an invalid datetime.
+func parseCIMDateTime(value string) time.Time {
+	if len(value) < len("20060102150405") {
+		return time.Time{}
+	}
+
+	t, err := time.Parse("20060102150405", value[:14])
+	if err != nil {
+		return time.Time{}
+	}
+
+	if len(value) == 25 {
+		if offset, err := strconv.Atoi(value[21:]); err == nil {
+			t = t.Add(-time.Duration(offset) * time.Minute)
+		}
+	}
+
+	return t
+}
+
+// parseCIMSeverity normalizes a CIM PerceivedSeverity, the value or its name.
+func parseCIMSeverity(value string) bmc.SystemEventLogSeverity {
+	switch value {
+	case "2":
+		return bmc.SystemEventLogSeverityInfo
+	case "3", "4":
+		return bmc.SystemEventLogSeverityWarning
+	case "5", "6", "7":
+		return bmc.SystemEventLogSeverityCritical
+	}
+
+	return bmc.ParseSystemEventLogSeverity(value)
+}
+
+// parseRacadmUsers returns the users of racadm get iDRAC.Users outputs, the
+// empty user slots are skipped.
+func parseRacadmUsers(output string) []map[string]string {
+	var users []map[string]string
+
+	var user map[string]string
+
+	add := func() {
+		if user != nil && user["Name"] != "" {
+			users = append(users, user)
+		}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if match := racadmKey.FindStringSubmatch(line); match != nil {
+			add()
+
+			user = map[string]string{"ID": match[1]}
+
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, "=")
+		if !ok || user == nil {
+			continue
+		}
+
+		switch name {
+		case "UserName":
+			user["Name"] = value
+		case "Privilege":
+			user["RoleID"] = value
+		case "Enable":
+			user["Enabled"] = strconv.FormatBool(value == "Enabled")
+		}
+	}
+
+	add()
+
+	return users
+}
+
+// parseRacadmSEL returns the entries of the racadm getsel output, records of
+// "Name: value" lines separated by dashed lines.
+func parseRacadmSEL(output string) []bmc.SystemEventLogEntry {
+	var entries []bmc.SystemEventLogEntry
+
+	var record []string
+
+	add := func() {
+		if len(record) == 0 {
+			return
+		}
+
+		entry := bmc.SystemEventLogEntry{Raw: []byte(strings.Join(record, "\n"))}
+
+		for _, line := range record {
+			name, value, _ := strings.Cut(line, ":")
+			value = strings.TrimSpace(value)
+
+			switch strings.TrimSpace(name) {
+			case "Record":
+				entry.RecordID = value
+			case "Date/Time":
+				entry.Timestamp, _ = time.Parse("01/02/2006 15:04:05", value)
+			case "Severity":
+				entry.Severity = bmc.ParseSystemEventLogSeverity(value)
+			case "Description":
+				entry.Message = value
+			}
+		}
+
+		entries = append(entries, entry)
+		record = nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "---"):
+			add()
+		case line != "":
+			record = append(record, line)
+		}
+	}
+
+	add()
+
+	return entries
+}
+
+// parseIMMUsers returns the users of the IMM users table, the rows follow the
+// dashed line under the header.
+func parseIMMUsers(output string) []map[string]string {
+	var users []map[string]string
+
+	rows := false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if !rows {
+			rows = len(fields) > 0 && strings.HasPrefix(fields[0], "---")
+			continue
+		}
+
+		if len(fields) < 4 {
+			continue
+		}
+
+		users = append(users, map[string]string{
+			"ID":     fields[0],
+			"Name":   fields[1],
+			"RoleID": fields[3],
+		})
+	}
+
+	return users
+}
+
+// immSeverities maps the severity column of the IMM event log.
+var immSeverities = map[string]bmc.SystemEventLogSeverity{
+	"I": bmc.SystemEventLogSeverityInfo,
+	"W": bmc.SystemEventLogSeverityWarning,
+	"E": bmc.SystemEventLogSeverityCritical,
+}
+
+// parseIMMSEL returns the entries of the IMM event log, rows of the number,
+// severity, source, date, time and text.
+func parseIMMSEL(output string) []bmc.SystemEventLogEntry {
+	var entries []bmc.SystemEventLogEntry
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		fields := strings.Fields(line)
+		if len(fields) < 6 {
+			continue
+		}
+
+		if _, err := strconv.Atoi(fields[0]); err != nil {
+			continue
+		}
+
+		severity, ok := immSeverities[fields[1]]
+		if !ok {
+			severity = bmc.SystemEventLogSeverityUnknown
+		}
+
+		timestamp, _ := time.Parse("01/02/06 15:04:05", fields[3]+" "+fields[4])
+
+		entries = append(entries, bmc.SystemEventLogEntry{
+			RecordID:  fields[0],
+			Timestamp: timestamp,
+			Severity:  severity,
+			Message:   strings.Join(fields[5:], " "),
+			Raw:       []byte(line),
+		})
+	}
+
+	return entries
+}
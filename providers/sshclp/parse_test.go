@@ -0,0 +1,104 @@
+package sshclp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// Requirement: the CLP account targets are read as users.
+func TestParseCLPUsers(t *testing.T) {
+	output := `/map1/accounts1
+  Targets:
+    account1
+    account2
+/map1/accounts1/account1
+  Properties:
+    userid=ADMIN
+    enabledstate=enabled
+/map1/accounts1/account2
+  Properties:
+    userid=
+/map1/accounts1/account3
+  Properties:
+    UserID=operator
+    EnabledState=Disabled
+`
+
+	want := []map[string]string{
+		{"ID": "1", "Name": "ADMIN", "Enabled": "true"},
+		{"ID": "3", "Name": "operator", "Enabled": "false"},
+	}
+
+	if users := parseCLPUsers(output); fmt.Sprint(users) != fmt.Sprint(want) {
+		t.Fatalf("parseCLPUsers = %v, want %v", users, want)
+	}
+}
+
+// Requirement: the CLP log records are read as SEL entries with their CIM
+// timestamp and severity.
+func TestParseCLPSEL(t *testing.T) {
+	output := `/system1/log1
+  Properties:
+    enabledstate=enabled
+/system1/log1/record1
+  Properties:
+    creationtimestamp=20130815112233.000000+060
+    description=Power Supply 1 AC lost
+    perceivedseverity=6
+`
+
+	entries := parseCLPSEL(output)
+	if len(entries) != 1 {
+		t.Fatalf("parseCLPSEL = %+v", entries)
+	}
+
+	entry := entries[0]
+	if entry.RecordID != "1" || entry.Message != "Power Supply 1 AC lost" || entry.Severity != bmc.SystemEventLogSeverityCritical {
+		t.Fatalf("entry = %+v", entry)
+	}
+
+	if want := time.Date(2013, 8, 15, 10, 22, 33, 0, time.UTC); !entry.Timestamp.Equal(want) {
+		t.Fatalf("timestamp = %v, want %v", entry.Timestamp, want)
+	}
+}
+
+// Requirement: the IMM users table rows are read as users.
+func TestParseIMMUsers(t *testing.T) {
+	output := `Account Login ID         Advanced Attribute Role          Password Expires
+------- --------         ------------------ ------        ----------------
+1       USERID           Native             Administrator 90 day(s)
+2       ops              Native             Operator      90 day(s)
+`
+
+	want := []map[string]string{
+		{"ID": "1", "Name": "USERID", "RoleID": "Administrator"},
+		{"ID": "2", "Name": "ops", "RoleID": "Operator"},
+	}
+
+	if users := parseIMMUsers(output); fmt.Sprint(users) != fmt.Sprint(want) {
+		t.Fatalf("parseIMMUsers = %v, want %v", users, want)
+	}
+}
+
+// Requirement: the IMM event log rows are read as SEL entries.
+func TestParseIMMSEL(t *testing.T) {
+	output := `Num  Sev  Source   Date/Time          Text
+1    I    SERVPROC 08/15/13 11:22:33  Remote Login Successful user USERID from SSH at IP address 10.0.0.5.
+2    E    SERVPROC 08/15/13 11:25:01  Sensor Planar 3.3V has transitioned to critical from a less severe state.
+`
+
+	entries := parseIMMSEL(output)
+	if len(entries) != 2 {
+		t.Fatalf("parseIMMSEL = %+v", entries)
+	}
+
+	entry := entries[1]
+	if entry.RecordID != "2" || entry.Severity != bmc.SystemEventLogSeverityCritical ||
+		entry.Message != "Sensor Planar 3.3V has transitioned to critical from a less severe state." ||
+		!entry.Timestamp.Equal(time.Date(2013, 8, 15, 11, 25, 1, 0, time.UTC)) {
+		t.Fatalf("entry = %+v", entry)
+	}
+}
@@ -0,0 +1,97 @@
+package sshclp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// compile-time assertions that the provider implements the interfaces.
+var (
+	_ bmc.PowerStateGetter = (*Config)(nil)
+	_ bmc.PowerSetter      = (*Config)(nil)
+	_ bmc.BootDeviceSetter = (*Config)(nil)
+	_ bmc.BMCResetter      = (*Config)(nil)
+)
+
+// PowerStateGet returns "on" when the power state output of the profile
+// matches PoweredOn and "off" otherwise.
+func (p *Config) PowerStateGet(ctx context.Context) (state string, err error) {
+	output, err := p.run(ctx, p.Profile.PowerState)
+	if err != nil {
+		return "", fmt.Errorf("failed to get power state: %w", err)
+	}
+
+	if p.Profile.PoweredOn.MatchString(output) {
+		return "on", nil
+	}
+
+	return "off", nil
+}
+
+// PowerSet runs the profile commands of the power state: on, off, soft, reset
+// or cycle.
+func (p *Config) PowerSet(ctx context.Context, state string) (ok bool, err error) {
+	commands, ok := p.Profile.Power[strings.ToLower(state)]
+	if !ok {
+		return false, fmt.Errorf("invalid power state: %s", state)
+	}
+
+	if err := p.exec(ctx, commands...); err != nil {
+		return false, fmt.Errorf("failed to set power state %s: %w", state, err)
+	}
+
+	return true, nil
+}
+
+// BootDeviceSet sets the boot device of the next boot, or of all boots when
+// setPersistent is true. efiBoot is not supported by the command lines and is
+// ignored.
+func (p *Config) BootDeviceSet(ctx context.Context, bootDevice string, setPersistent, _ bool) (ok bool, err error) {
+	if p.Profile.BootDevice == "" {
+		return false, fmt.Errorf("boot device override not supported by the %s profile", p.Profile.Name)
+	}
+
+	device, ok := p.Profile.BootDevices[strings.ToLower(bootDevice)]
+	if !ok {
+		return false, fmt.Errorf("unsupported boot device: %s", bootDevice)
+	}
+
+	var commands []string
+
+	switch {
+	case setPersistent && p.Profile.BootPersistent != "":
+		commands = append(commands, p.Profile.BootPersistent)
+	case !setPersistent && p.Profile.BootOnce == "":
+		return false, fmt.Errorf("one time boot device override not supported by the %s profile", p.Profile.Name)
+	case !setPersistent:
+		commands = append(commands, p.Profile.BootOnce)
+	}
+
+	commands = append(commands, fmt.Sprintf(p.Profile.BootDevice, device))
+
+	if err := p.exec(ctx, commands...); err != nil {
+		return false, fmt.Errorf("failed to set boot device %s: %w", bootDevice, err)
+	}
+
+	return true, nil
+}
+
+// BmcReset runs the profile commands of the reset type, warm or cold. The SSH
+// connection is closed as the BMC drops it when it resets.
+func (p *Config) BmcReset(ctx context.Context, resetType string) (ok bool, err error) {
+	commands, ok := p.Profile.BMCReset[strings.ToLower(resetType)]
+	if !ok {
+		return false, fmt.Errorf("invalid BMC reset type: %s", resetType)
+	}
+
+	if err := p.exec(ctx, commands...); err != nil {
+		return false, fmt.Errorf("failed to reset BMC: %w", err)
+	}
+
+	_ = p.Close(ctx)
+
+	return true, nil
+}
@@ -0,0 +1,145 @@
+package sshclp
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// Profile is the command line of a BMC family.
+type Profile struct {
+	// Name names the command line, e.g. "racadm".
+	Name string
+	// PowerState prints the power state of the host, the host is on when the
+	// output matches PoweredOn.
+	PowerState string
+	PoweredOn  *regexp.Regexp
+	// Power maps the power states on, off, soft, reset and cycle to the
+	// commands that set them.
+	Power map[string][]string
+	// BMCReset maps the reset types warm and cold to the commands that reset
+	// the BMC.
+	BMCReset map[string][]string
+	// BootDevices maps the bmclib boot devices to the device names of
+	// BootDevice, boot device overrides are not supported when it is empty.
+	BootDevices map[string]string
+	// BootDevice sets the boot device, %s is the device name.
+	BootDevice string
+	// BootOnce makes the boot device apply to the next boot only, one time
+	// overrides are not supported when it is empty.
+	BootOnce string
+	// BootPersistent makes the boot device apply to all boots.
+	BootPersistent string
+	// Users list the BMC users, ParseUsers parses their joined output.
+	Users      []string
+	ParseUsers func(output string) []map[string]string
+	// SEL prints the System Event Log, ParseSEL parses its output.
+	SEL      string
+	ParseSEL func(output string) []bmc.SystemEventLogEntry
+	// Failed matches the output of a failed command, for shells that exit
+	// successfully when a command fails.
+	Failed *regexp.Regexp
+}
+
+var (
+	// ProfileCLP is the DMTF SM CLP (DSP0214) of the SMASH conformant BMCs,
+	// e.g. early Supermicro and the iDRAC SM CLP shell. The CLP does not
+	// standardize a boot device override.
+	ProfileCLP = Profile{
+		Name:       "clp",
+		PowerState: "show /system1 enabledstate",
+		PoweredOn:  regexp.MustCompile(`(?im)^\s*enabledstate\s*=\s*enabled\b`),
+		Power: map[string][]string{
+			"on":    {"start /system1"},
+			"off":   {"stop -force /system1"},
+			"soft":  {"stop /system1"},
+			"reset": {"reset /system1"},
+			"cycle": {"stop -force /system1", "start /system1"},
+		},
+		BMCReset: map[string][]string{
+			"warm": {"reset /map1"},
+			"cold": {"reset /map1"},
+		},
+		Users:      []string{"show -l all /map1/accounts1"},
+		ParseUsers: parseCLPUsers,
+		SEL:        "show -l all /system1/log1",
+		ParseSEL:   parseCLPSEL,
+		Failed:     regexp.MustCompile(`(?im)^\s*(command status:\s*failed|status\s*=\s*[1-9]|error:)`),
+	}
+
+	// ProfileRacadm is the racadm shell of the iDRAC7 and later.
+	ProfileRacadm = Profile{
+		Name:       "racadm",
+		PowerState: "racadm serveraction powerstatus",
+		PoweredOn:  regexp.MustCompile(`(?i)power status:\s*on\b`),
+		Power: map[string][]string{
+			"on":    {"racadm serveraction powerup"},
+			"off":   {"racadm serveraction powerdown"},
+			"soft":  {"racadm serveraction graceshutdown"},
+			"reset": {"racadm serveraction hardreset"},
+			"cycle": {"racadm serveraction powercycle"},
+		},
+		BMCReset: map[string][]string{
+			"warm": {"racadm racreset soft"},
+			"cold": {"racadm racreset hard"},
+		},
+		BootDevices: map[string]string{
+			"pxe":    "PXE",
+			"disk":   "HDD",
+			"cdrom":  "VCD-DVD",
+			"floppy": "vFDD",
+			"bios":   "BIOS",
+		},
+		BootDevice:     "racadm set iDRAC.ServerBoot.FirstBootDevice %s",
+		BootOnce:       "racadm set iDRAC.ServerBoot.BootOnce Enabled",
+		BootPersistent: "racadm set iDRAC.ServerBoot.BootOnce Disabled",
+		Users:          racadmUsers(),
+		ParseUsers:     parseRacadmUsers,
+		SEL:            "racadm getsel",
+		ParseSEL:       parseRacadmSEL,
+		Failed:         regexp.MustCompile(`(?m)^\s*ERROR:`),
+	}
+
+	// ProfileIMM is the CLI of the IBM and Lenovo IMM2. The IMM2 only sets the
+	// boot order persistently.
+	ProfileIMM = Profile{
+		Name:       "imm",
+		PowerState: "power state",
+		PoweredOn:  regexp.MustCompile(`(?i)power:\s*on\b`),
+		Power: map[string][]string{
+			"on":    {"power on"},
+			"off":   {"power off"},
+			"soft":  {"power off -s"},
+			"reset": {"reset"},
+			"cycle": {"power cycle"},
+		},
+		BMCReset: map[string][]string{
+			"warm": {"resetsp"},
+			"cold": {"resetsp"},
+		},
+		BootDevices: map[string]string{
+			"pxe":    "nw",
+			"disk":   "hd0",
+			"cdrom":  "cd",
+			"floppy": "fd",
+		},
+		BootDevice: "bootseq %s",
+		Users:      []string{"users"},
+		ParseUsers: parseIMMUsers,
+		SEL:        "eventlog -a",
+		ParseSEL:   parseIMMSEL,
+		Failed:     regexp.MustCompile(`(?im)^\s*(command not recognized|invalid|error)`),
+	}
+)
+
+// racadmUsers returns the commands that print the iDRAC user slots, slot 1 is
+// reserved.
+func racadmUsers() []string {
+	commands := make([]string, 0, 15)
+	for slot := 2; slot <= 16; slot++ {
+		commands = append(commands, fmt.Sprintf("racadm get iDRAC.Users.%d", slot))
+	}
+
+	return commands
+}
@@ -0,0 +1,24 @@
+package sshclp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+var _ bmc.SystemEventLogEntriesGetter = (*Config)(nil)
+
+// GetSystemEventLogEntries returns the System Event Log entries.
+func (p *Config) GetSystemEventLogEntries(ctx context.Context) (entries []bmc.SystemEventLogEntry, err error) {
+	if p.Profile.SEL == "" || p.Profile.ParseSEL == nil {
+		return nil, fmt.Errorf("system event log read not supported by the %s profile", p.Profile.Name)
+	}
+
+	output, err := p.run(ctx, p.Profile.SEL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system event log: %w", err)
+	}
+
+	return p.Profile.ParseSEL(output), nil
+}
@@ -0,0 +1,234 @@
+// Package sshclp implements a bmclib provider for BMCs that are managed through
+// their SSH command line, the DMTF SM CLP (SMASH) or a vendor shell.
+//
+// It is meant for older BMCs with a broken Redfish service and IPMI over LAN
+// disabled. Every command runs in its own SSH exec session. The commands and
+// how their output is parsed come from a Profile. Profiles are provided for
+// the DMTF SM CLP, the iDRAC racadm shell and the IMM2 CLI.
+package sshclp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/jacobweinstock/registrar"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/bmc-toolbox/bmclib/v2/providers"
+)
+
+const (
+	// ProviderName for the SSH CLP implementation.
+	ProviderName = "sshclp"
+	// ProviderProtocol for the SSH CLP implementation.
+	ProviderProtocol = "ssh"
+
+	defaultPort           = 22
+	defaultTimeoutSeconds = 30
+)
+
+// Features implemented by the SSH CLP provider.
+var Features = registrar.Features{
+	providers.FeaturePowerSet,
+	providers.FeaturePowerState,
+	providers.FeatureBootDeviceSet,
+	providers.FeatureBmcReset,
+	providers.FeatureUserRead,
+	providers.FeatureGetSystemEventLogEntries,
+}
+
+// errNotOpen is returned when the provider is used before Open.
+var errNotOpen = errors.New("ssh connection not open")
+
+// Config holds the configuration for the SSH CLP provider.
+type Config struct {
+	// Host is the address of the BMC.
+	Host string
+	// Port is the SSH port of the BMC, defaults to 22.
+	Port uint16
+	// Username and Password are the BMC credentials. Both password and
+	// keyboard-interactive authentication are tried.
+	Username string
+	Password string
+	// Profile is the command line of the BMC.
+	Profile Profile
+	// LegacyAlgorithms also enables the insecure key exchanges, ciphers and
+	// MACs that old BMC SSH servers require, e.g. diffie-hellman-group1-sha1
+	// and aes128-cbc.
+	LegacyAlgorithms bool
+	// HostKeyCallback verifies the host key of the BMC. Any key is accepted
+	// when it is nil.
+	HostKeyCallback ssh.HostKeyCallback
+	// TimeoutSeconds is the timeout of the SSH connection and login,
+	// defaults to 30 seconds.
+	TimeoutSeconds uint32
+	Logger         logr.Logger
+
+	client *ssh.Client
+}
+
+// New returns a new Config containing all the defaults for the SSH CLP provider.
+func New(host, user, pass string, profile Profile) *Config { //nolint:gocritic // profiles are passed by value like the built-in profile vars
+	return &Config{
+		Host:           host,
+		Port:           defaultPort,
+		Username:       user,
+		Password:       pass,
+		Profile:        profile,
+		TimeoutSeconds: defaultTimeoutSeconds,
+		Logger:         logr.Discard(),
+	}
+}
+
+// Name returns the name of this SSH CLP provider.
+// Implements bmc.Provider interface
+func (p *Config) Name() string {
+	return ProviderName
+}
+
+// Open logs in to the BMC over SSH.
+func (p *Config) Open(ctx context.Context) error {
+	if p.Profile.Name == "" {
+		return errors.New("ssh command line profile not configured")
+	}
+
+	if p.client != nil {
+		return nil
+	}
+
+	timeout := time.Duration(p.TimeoutSeconds) * time.Second
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	address := net.JoinHostPort(p.Host, strconv.Itoa(int(p.Port)))
+
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+
+	// the SSH handshake does not take a context, the deadline bounds it.
+	deadline, _ := ctx.Deadline()
+	_ = conn.SetDeadline(deadline)
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, address, p.clientConfig(timeout))
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("ssh login to %s failed: %w", address, err)
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+	p.client = ssh.NewClient(sshConn, chans, reqs)
+
+	return nil
+}
+
+// clientConfig returns the SSH client configuration of the BMC.
+func (p *Config) clientConfig(timeout time.Duration) *ssh.ClientConfig {
+	hostKeyCallback := p.HostKeyCallback
+	if hostKeyCallback == nil {
+		hostKeyCallback = ssh.InsecureIgnoreHostKey() //nolint:gosec // BMC host keys are self generated, like their TLS certificates
+	}
+
+	config := &ssh.ClientConfig{
+		User: p.Username,
+		Auth: []ssh.AuthMethod{
+			ssh.Password(p.Password),
+			ssh.KeyboardInteractive(func(_, _ string, questions []string, _ []bool) ([]string, error) {
+				answers := make([]string, len(questions))
+				for i := range answers {
+					answers[i] = p.Password
+				}
+
+				return answers, nil
+			}),
+		},
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         timeout,
+	}
+
+	if p.LegacyAlgorithms {
+		supported, insecure := ssh.SupportedAlgorithms(), ssh.InsecureAlgorithms()
+		config.KeyExchanges = append(supported.KeyExchanges, insecure.KeyExchanges...)
+		config.Ciphers = append(supported.Ciphers, insecure.Ciphers...)
+		config.MACs = append(supported.MACs, insecure.MACs...)
+		config.HostKeyAlgorithms = append(supported.HostKeys, insecure.HostKeys...)
+	}
+
+	return config
+}
+
+// Close closes the SSH connection.
+func (p *Config) Close(_ context.Context) error {
+	if p.client == nil {
+		return nil
+	}
+
+	err := p.client.Close()
+	p.client = nil
+
+	return err
+}
+
+// run runs the command in a new session and returns its combined output.
+func (p *Config) run(ctx context.Context, command string) (string, error) {
+	if p.client == nil {
+		return "", errNotOpen
+	}
+
+	session, err := p.client.NewSession()
+	if err != nil {
+		return "", fmt.Errorf("failed to open ssh session: %w", err)
+	}
+	defer session.Close()
+
+	p.Logger.V(1).Info("running command", "command", command)
+
+	type result struct {
+		output []byte
+		err    error
+	}
+
+	done := make(chan result, 1)
+
+	go func() {
+		output, err := session.CombinedOutput(command)
+		done <- result{output, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case r := <-done:
+		if r.err != nil {
+			return "", fmt.Errorf("%s: %w: %s", command, r.err, strings.TrimSpace(string(r.output)))
+		}
+
+		return string(r.output), nil
+	}
+}
+
+// exec runs the commands in order, the output of a command the profile
+// recognizes as failed is an error.
+func (p *Config) exec(ctx context.Context, commands ...string) error {
+	for _, command := range commands {
+		output, err := p.run(ctx, command)
+		if err != nil {
+			return err
+		}
+
+		if p.Profile.Failed != nil && p.Profile.Failed.MatchString(output) {
+			return fmt.Errorf("%s: %s", command, strings.TrimSpace(output))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,395 @@
+package sshclp
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// sshServer is an in-process SSH server stand-in of a BMC command line, it
+// answers the exec requests with the outputs of the commands. An unknown
+// command exits with status 127.
+type sshServer struct {
+	host string
+	port uint16
+
+	mu       sync.Mutex
+	outputs  map[string]string
+	commands []string
+}
+
+func newSSHServer(t *testing.T, outputs map[string]string) *sshServer {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "root" && string(password) == "calvin" {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("password rejected for %s", conn.User())
+		},
+	}
+	config.AddHostKey(signer)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { l.Close() })
+
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	s := &sshServer{host: host, port: uint16(portNumber), outputs: outputs}
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go s.serve(conn, config)
+		}
+	}()
+
+	return s
+}
+
+func (s *sshServer) serve(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+
+		go s.session(channel, requests)
+	}
+}
+
+func (s *sshServer) session(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+
+	for req := range requests {
+		if req.Type != "exec" || len(req.Payload) < 4 {
+			_ = req.Reply(false, nil)
+			continue
+		}
+
+		command := string(req.Payload[4:])
+		_ = req.Reply(true, nil)
+
+		s.mu.Lock()
+		s.commands = append(s.commands, command)
+		output, ok := s.outputs[command]
+		s.mu.Unlock()
+
+		status := uint32(0)
+		if !ok {
+			output, status = command+": command not found\n", 127
+		}
+
+		_, _ = channel.Write([]byte(output))
+
+		payload := make([]byte, 4)
+		binary.BigEndian.PutUint32(payload, status)
+		_, _ = channel.SendRequest("exit-status", false, payload)
+
+		return
+	}
+}
+
+// ran returns the commands run since the last call.
+func (s *sshServer) ran() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	commands := s.commands
+	s.commands = nil
+
+	return commands
+}
+
+// openedProvider returns a provider of the profile logged in to the server.
+func (s *sshServer) openedProvider(t *testing.T, profile Profile) *Config { //nolint:gocritic // profiles are passed by value like the built-in profile vars
+	t.Helper()
+
+	p := New(s.host, "root", "calvin", profile)
+	p.Port = s.port
+
+	if err := p.Open(context.Background()); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	t.Cleanup(func() { _ = p.Close(context.Background()) })
+
+	return p
+}
+
+func equalCommands(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Requirement: Open fails with the wrong credentials and without a profile.
+func TestOpen(t *testing.T) {
+	s := newSSHServer(t, nil)
+
+	p := New(s.host, "root", "wrong", ProfileRacadm)
+	p.Port = s.port
+
+	if err := p.Open(context.Background()); err == nil {
+		t.Fatal("Open with the wrong password succeeded")
+	}
+
+	if _, err := p.PowerStateGet(context.Background()); err == nil {
+		t.Fatal("PowerStateGet without a connection succeeded")
+	}
+
+	p = New(s.host, "root", "calvin", Profile{})
+	p.Port = s.port
+
+	if err := p.Open(context.Background()); err == nil {
+		t.Fatal("Open without a profile succeeded")
+	}
+}
+
+// Requirement: the power state is read from, and set by, the commands of the
+// profile.
+func TestPower(t *testing.T) {
+	s := newSSHServer(t, map[string]string{
+		"racadm serveraction powerstatus": "Server power status: ON\n",
+		"racadm serveraction powercycle":  "Server power operation successful\n",
+		"racadm serveraction powerdown":   "ERROR: Unable to perform the requested action.\n",
+		"stop -force /system1":            "system1 has been stopped successfully\n",
+		"start /system1":                  "system1 has been started successfully\n",
+		"show /system1 enabledstate":      "/system1\n  Properties:\n    enabledstate=disabled\n",
+	})
+
+	p := s.openedProvider(t, ProfileRacadm)
+
+	state, err := p.PowerStateGet(context.Background())
+	if err != nil || state != "on" {
+		t.Fatalf("PowerStateGet = %q, %v, want on", state, err)
+	}
+
+	s.ran()
+
+	if ok, err := p.PowerSet(context.Background(), "cycle"); err != nil || !ok {
+		t.Fatalf("PowerSet(cycle) = %v, %v", ok, err)
+	}
+
+	if commands := s.ran(); !equalCommands(commands, []string{"racadm serveraction powercycle"}) {
+		t.Fatalf("commands = %v", commands)
+	}
+
+	if _, err := p.PowerSet(context.Background(), "off"); err == nil {
+		t.Fatal("PowerSet(off) with an ERROR output succeeded")
+	}
+
+	if _, err := p.PowerSet(context.Background(), "soft"); err == nil {
+		t.Fatal("PowerSet(soft) of a failing command succeeded")
+	}
+
+	if _, err := p.PowerSet(context.Background(), "hibernate"); err == nil {
+		t.Fatal("PowerSet(hibernate) succeeded")
+	}
+
+	clp := s.openedProvider(t, ProfileCLP)
+
+	state, err = clp.PowerStateGet(context.Background())
+	if err != nil || state != "off" {
+		t.Fatalf("CLP PowerStateGet = %q, %v, want off", state, err)
+	}
+
+	s.ran()
+
+	if ok, err := clp.PowerSet(context.Background(), "cycle"); err != nil || !ok {
+		t.Fatalf("CLP PowerSet(cycle) = %v, %v", ok, err)
+	}
+
+	if commands := s.ran(); !equalCommands(commands, []string{"stop -force /system1", "start /system1"}) {
+		t.Fatalf("CLP commands = %v", commands)
+	}
+}
+
+// Requirement: the boot device is set once or persistently when the profile
+// supports it.
+func TestBootDeviceSet(t *testing.T) {
+	s := newSSHServer(t, map[string]string{
+		"racadm set iDRAC.ServerBoot.BootOnce Enabled":        "Object value modified successfully\n",
+		"racadm set iDRAC.ServerBoot.FirstBootDevice PXE":     "Object value modified successfully\n",
+		"racadm set iDRAC.ServerBoot.BootOnce Disabled":       "Object value modified successfully\n",
+		"racadm set iDRAC.ServerBoot.FirstBootDevice VCD-DVD": "Object value modified successfully\n",
+		"bootseq nw": "ok\n",
+	})
+
+	tests := []struct {
+		name       string
+		profile    Profile
+		device     string
+		persistent bool
+		commands   []string
+	}{
+		{"racadm once", ProfileRacadm, "pxe", false, []string{"racadm set iDRAC.ServerBoot.BootOnce Enabled", "racadm set iDRAC.ServerBoot.FirstBootDevice PXE"}},
+		{"racadm persistent", ProfileRacadm, "cdrom", true, []string{"racadm set iDRAC.ServerBoot.BootOnce Disabled", "racadm set iDRAC.ServerBoot.FirstBootDevice VCD-DVD"}},
+		{"imm persistent", ProfileIMM, "pxe", true, []string{"bootseq nw"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := s.openedProvider(t, tc.profile)
+
+			ok, err := p.BootDeviceSet(context.Background(), tc.device, tc.persistent, false)
+			if err != nil || !ok {
+				t.Fatalf("BootDeviceSet = %v, %v", ok, err)
+			}
+
+			if commands := s.ran(); !equalCommands(commands, tc.commands) {
+				t.Fatalf("commands = %v, want %v", commands, tc.commands)
+			}
+		})
+	}
+
+	if _, err := s.openedProvider(t, ProfileIMM).BootDeviceSet(context.Background(), "pxe", false, false); err == nil {
+		t.Fatal("IMM one time BootDeviceSet succeeded")
+	}
+
+	if _, err := s.openedProvider(t, ProfileCLP).BootDeviceSet(context.Background(), "pxe", true, false); err == nil {
+		t.Fatal("CLP BootDeviceSet succeeded")
+	}
+
+	if _, err := s.openedProvider(t, ProfileRacadm).BootDeviceSet(context.Background(), "usb", true, false); err == nil {
+		t.Fatal("BootDeviceSet(usb) succeeded")
+	}
+}
+
+// Requirement: a BMC reset runs the command of the reset type and drops the
+// connection.
+func TestBmcReset(t *testing.T) {
+	s := newSSHServer(t, map[string]string{
+		"racadm racreset hard": "RAC reset operation initiated successfully.\n",
+	})
+
+	p := s.openedProvider(t, ProfileRacadm)
+
+	ok, err := p.BmcReset(context.Background(), "cold")
+	if err != nil || !ok {
+		t.Fatalf("BmcReset = %v, %v", ok, err)
+	}
+
+	if commands := s.ran(); !equalCommands(commands, []string{"racadm racreset hard"}) {
+		t.Fatalf("commands = %v", commands)
+	}
+
+	if p.client != nil {
+		t.Fatal("connection not closed after the BMC reset")
+	}
+
+	if _, err := p.BmcReset(context.Background(), "lukewarm"); err == nil {
+		t.Fatal("BmcReset(lukewarm) succeeded")
+	}
+}
+
+// Requirement: the users of the iDRAC user slots are read, empty slots are
+// skipped.
+func TestUserRead(t *testing.T) {
+	outputs := map[string]string{}
+	for slot := 2; slot <= 16; slot++ {
+		outputs[fmt.Sprintf("racadm get iDRAC.Users.%d", slot)] = fmt.Sprintf("[Key=iDRAC.Embedded.1#Users.%d]\nEnable=Disabled\nPrivilege=0x0\nUserName=\n", slot)
+	}
+
+	outputs["racadm get iDRAC.Users.2"] = "[Key=iDRAC.Embedded.1#Users.2]\nEnable=Enabled\nIpmiLanPrivilege=4\n!!Password=******** (Write-Only)\nPrivilege=0x1ff\nUserName=root\n"
+	outputs["racadm get iDRAC.Users.3"] = "[Key=iDRAC.Embedded.1#Users.3]\nEnable=Disabled\nPrivilege=0x1\nUserName=readonly\n"
+
+	p := newSSHServer(t, outputs).openedProvider(t, ProfileRacadm)
+
+	users, err := p.UserRead(context.Background())
+	if err != nil {
+		t.Fatalf("UserRead: %v", err)
+	}
+
+	want := []map[string]string{
+		{"ID": "2", "Name": "root", "RoleID": "0x1ff", "Enabled": "true"},
+		{"ID": "3", "Name": "readonly", "RoleID": "0x1", "Enabled": "false"},
+	}
+
+	if fmt.Sprint(users) != fmt.Sprint(want) {
+		t.Fatalf("UserRead = %v, want %v", users, want)
+	}
+}
+
+// Requirement: the System Event Log is read with the SEL command of the
+// profile.
+func TestGetSystemEventLogEntries(t *testing.T) {
+	sel := `Record:      1
+Date/Time:   08/15/2013 11:22:33
+Source:      system
+Severity:    Ok
+Description: Log cleared.
+-------------------------------------------------------------------------------
+Record:      2
+Date/Time:   08/15/2013 11:25:01
+Source:      system
+Severity:    Critical
+Description: The system board PS1 PG Fail voltage is outside of range.
+-------------------------------------------------------------------------------
+`
+
+	p := newSSHServer(t, map[string]string{"racadm getsel": sel}).openedProvider(t, ProfileRacadm)
+
+	entries, err := p.GetSystemEventLogEntries(context.Background())
+	if err != nil {
+		t.Fatalf("GetSystemEventLogEntries: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("entries = %+v", entries)
+	}
+
+	if entries[1].RecordID != "2" || entries[1].Severity != "critical" || entries[1].Timestamp.Format("2006-01-02 15:04:05") != "2013-08-15 11:25:01" ||
+		entries[1].Message != "The system board PS1 PG Fail voltage is outside of range." {
+		t.Fatalf("entries[1] = %+v", entries[1])
+	}
+}
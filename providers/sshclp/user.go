@@ -0,0 +1,35 @@
+package sshclp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+var _ bmc.UserReader = (*Config)(nil)
+
+// UserRead returns the BMC users with their "ID", "Name" and, when the command
+// line reports them, "RoleID" and "Enabled".
+func (p *Config) UserRead(ctx context.Context) (users []map[string]string, err error) {
+	if len(p.Profile.Users) == 0 || p.Profile.ParseUsers == nil {
+		return nil, fmt.Errorf("user read not supported by the %s profile", p.Profile.Name)
+	}
+
+	outputs := make([]string, 0, len(p.Profile.Users))
+
+	for _, command := range p.Profile.Users {
+		output, err := p.run(ctx, command)
+		if err != nil {
+			return nil, errors.Wrap(bmclibErrs.ErrRetrievingUserAccounts, err.Error())
+		}
+
+		outputs = append(outputs, output)
+	}
+
+	return p.Profile.ParseUsers(strings.Join(outputs, "\n")), nil
+}
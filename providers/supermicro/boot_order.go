@@ -0,0 +1,172 @@
+package supermicro
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/stmcginnis/gofish/schemas"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+const (
+	// fixedBootOrderPath is the Supermicro OEM boot order resource, relative to the system.
+	fixedBootOrderPath = "/Oem/Supermicro/FixedBootOrder"
+	// fixedBootOrderDisabled fills the boot order slots that are not assigned a boot device.
+	fixedBootOrderDisabled = "Disabled"
+)
+
+// errFixedBootOrderNotSupported is returned when the BMC does not implement the FixedBootOrder resource.
+var errFixedBootOrderNotSupported = errors.New("FixedBootOrder is not supported")
+
+// fixedBootOrder is the Supermicro OEM FixedBootOrder resource.
+//
+// The entries are formatted as "<boot device type>:<boot device name>", the boot order keeps a fixed
+// number of slots, slots without a boot device are set to "Disabled".
+type fixedBootOrder struct {
+	FixedBootOrder             []string `json:"FixedBootOrder"`
+	FixedBootOrderDisabledItem []string `json:"FixedBootOrderDisabledItem"`
+}
+
+// GetBootOrder returns the persistent boot order.
+//
+// Supermicro BMCs report a read only Boot.BootOrder, the boot order is managed through the OEM
+// FixedBootOrder resource which is read when present.
+func (c *Client) GetBootOrder(ctx context.Context) (order []bmc.BootOption, err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return nil, errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	current, _, err := c.fixedBootOrder()
+	if err != nil {
+		if errors.Is(err, errFixedBootOrderNotSupported) {
+			return c.serviceClient.redfish.GetBootOrder(ctx)
+		}
+
+		return nil, err
+	}
+
+	for _, entry := range current.FixedBootOrder {
+		if entry == fixedBootOrderDisabled {
+			continue
+		}
+
+		order = append(order, fixedBootOrderOption(entry, true))
+	}
+
+	for _, entry := range current.FixedBootOrderDisabledItem {
+		order = append(order, fixedBootOrderOption(entry, false))
+	}
+
+	return order, nil
+}
+
+// SetBootOrder sets the persistent boot order, the change is applied by the BIOS on the next host reboot.
+//
+// The boot order is written to the OEM FixedBootOrder resource when present, the BMC requires all of
+// the boot order slots to be included and the If-Match header to be set.
+func (c *Client) SetBootOrder(ctx context.Context, order []string) (err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	current, etag, err := c.fixedBootOrder()
+	if err != nil {
+		if errors.Is(err, errFixedBootOrderNotSupported) {
+			return c.serviceClient.redfish.SetBootOrder(ctx, order)
+		}
+
+		return err
+	}
+
+	next, err := nextFixedBootOrder(current.FixedBootOrder, order)
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{}
+	if etag != "" {
+		headers["If-Match"] = etag
+	}
+
+	system, err := c.serviceClient.redfish.System()
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.serviceClient.redfish.PatchWithHeaders(ctx, system.ODataID+fixedBootOrderPath, map[string]any{"FixedBootOrder": next}, headers)
+	if err != nil {
+		return errors.Wrap(err, "error setting the FixedBootOrder")
+	}
+
+	return resp.Body.Close()
+}
+
+// fixedBootOrder returns the OEM FixedBootOrder resource and its ETag.
+func (c *Client) fixedBootOrder() (current *fixedBootOrder, etag string, err error) {
+	system, err := c.serviceClient.redfish.System()
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := c.serviceClient.redfish.Get(system.ODataID + fixedBootOrderPath)
+	if err != nil {
+		var rfErr *schemas.Error
+		if errors.As(err, &rfErr) && rfErr.HTTPReturnedStatusCode == http.StatusNotFound {
+			return nil, "", errFixedBootOrderNotSupported
+		}
+
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	current = &fixedBootOrder{}
+	if err := json.Unmarshal(body, current); err != nil {
+		return nil, "", errors.Wrap(ErrUnexpectedResponse, err.Error())
+	}
+
+	return current, resp.Header.Get("ETag"), nil
+}
+
+// nextFixedBootOrder returns the FixedBootOrder with the requested entries moved to the front,
+// the number of boot order slots is kept, unassigned slots are filled with "Disabled".
+func nextFixedBootOrder(current, order []string) ([]string, error) {
+	assigned := make([]string, 0, len(current))
+	for _, entry := range current {
+		if entry != fixedBootOrderDisabled {
+			assigned = append(assigned, entry)
+		}
+	}
+
+	next, err := bmc.CompleteBootOrder(assigned, order)
+	if err != nil {
+		return nil, err
+	}
+
+	for len(next) < len(current) {
+		next = append(next, fixedBootOrderDisabled)
+	}
+
+	return next, nil
+}
+
+// fixedBootOrderOption returns the boot option for a FixedBootOrder entry, the display name is the
+// entry without the boot device type prefix.
+func fixedBootOrderOption(entry string, enabled bool) bmc.BootOption {
+	name := entry
+	if _, after, found := strings.Cut(entry, ":"); found && after != "" {
+		name = after
+	}
+
+	return bmc.BootOption{Reference: entry, DisplayName: name, Enabled: enabled}
+}
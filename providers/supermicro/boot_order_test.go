@@ -0,0 +1,67 @@
+package supermicro
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+func TestNextFixedBootOrder(t *testing.T) {
+	current := []string{
+		"UEFI Hard Disk:UEFI OS (NVMe Samsung SSD 980 PRO)",
+		"UEFI USB CD/DVD",
+		"UEFI Network:(B4/D0/F0) UEFI PXE IP4 Intel(R) Ethernet Controller X710",
+		"Disabled",
+		"Disabled",
+	}
+
+	testCases := []struct {
+		name     string
+		order    []string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:  "network first",
+			order: []string{"UEFI Network:(B4/D0/F0) UEFI PXE IP4 Intel(R) Ethernet Controller X710"},
+			expected: []string{
+				"UEFI Network:(B4/D0/F0) UEFI PXE IP4 Intel(R) Ethernet Controller X710",
+				"UEFI Hard Disk:UEFI OS (NVMe Samsung SSD 980 PRO)",
+				"UEFI USB CD/DVD",
+				"Disabled",
+				"Disabled",
+			},
+		},
+		{
+			name:    "unknown entry",
+			order:   []string{"UEFI Shell"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			next, err := nextFixedBootOrder(current, tc.order)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, next)
+		})
+	}
+}
+
+func TestFixedBootOrderOption(t *testing.T) {
+	assert.Equal(t,
+		bmc.BootOption{Reference: "UEFI Hard Disk:UEFI OS (NVMe)", DisplayName: "UEFI OS (NVMe)", Enabled: true},
+		fixedBootOrderOption("UEFI Hard Disk:UEFI OS (NVMe)", true),
+	)
+	assert.Equal(t,
+		bmc.BootOption{Reference: "UEFI USB CD/DVD", DisplayName: "UEFI USB CD/DVD"},
+		fixedBootOrderOption("UEFI USB CD/DVD", false),
+	)
+}
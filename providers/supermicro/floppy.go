@@ -61,10 +61,10 @@ func (c *Client) MountFloppyImage(ctx context.Context, image io.Reader) error {
 		},
 	}
 
-	if c.serviceClient.csrfToken != "" {
+	if csrfToken := c.serviceClient.getCsrfToken(); csrfToken != "" {
 		formParts = append(formParts, form{
 			name: "csrf-token",
-			data: bytes.NewBufferString(c.serviceClient.csrfToken),
+			data: bytes.NewBufferString(csrfToken),
 		})
 	}
 
@@ -16,6 +16,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bmc-toolbox/common"
@@ -63,6 +64,24 @@ var Features = registrar.Features{
 	providers.FeatureGetSecureBoot,
 	providers.FeatureSetSecureBoot,
 	providers.FeatureResetSecureBootKeys,
+	providers.FeatureGetSystemEventLogEntries,
+	providers.FeatureSensorsRead,
+	providers.FeaturePowerConsumption,
+	providers.FeatureSetPowerLimit,
+	providers.FeatureSetIdentifyLED,
+	providers.FeatureGetIdentifyLED,
+	providers.FeatureGetBootOrder,
+	providers.FeatureSetBootOrder,
+	providers.FeatureGetBMCNetwork,
+	providers.FeatureSetBMCNetwork,
+	providers.FeatureGenerateCSR,
+	providers.FeatureReplaceCertificate,
+	providers.FeatureListCertificates,
+	providers.FeatureGetBMCTime,
+	providers.FeatureSetBMCTime,
+	providers.FeatureSetNTPServers,
+	providers.FeatureGetDirectoryService,
+	providers.FeatureSetDirectoryService,
 }
 
 // supports
@@ -158,10 +177,15 @@ func NewClient(host, user, pass string, log logr.Logger, opts ...Option) *Client
 		httpclient.Build(defaultConfig.httpClientSetupFuncs...),
 	)
 
-	return &Client{
+	client := &Client{
 		serviceClient: serviceClient,
 		log:           log,
 	}
+
+	// an expired web session is renewed by logging in again
+	serviceClient.relogin = client.webSession
+
+	return client
 }
 
 func (c *Client) login(ctx context.Context, encodeCreds bool) error {
@@ -200,33 +224,27 @@ func (c *Client) login(ctx context.Context, encodeCreds bool) error {
 	return nil
 }
 
-// Open a connection to a Supermicro BMC using the vendor API.
-func (c *Client) Open(ctx context.Context) (err error) {
-	// called after a session was opened but further login dependencies failed
-	closeWithError := func(ctx context.Context, err error) error {
-		_ = c.Close(ctx)
-		return err
-	}
-
+// webSession logs into the BMC web interface and sets up the CSRF token of the session.
+func (c *Client) webSession(ctx context.Context) error {
 	// first attempt login with base64 encoded user,pass
 	if err := c.login(ctx, true); err != nil {
 		if !errors.Is(err, ErrUnexpectedResponse) && !errors.Is(err, ErrUnexpectedStatusCode) {
-			return closeWithError(ctx, errors.Wrap(bmclibErrs.ErrLoginFailed, err.Error()))
+			return errors.Wrap(bmclibErrs.ErrLoginFailed, err.Error())
 		}
 
 		// retry with plain text user, pass
 		if err2 := c.login(ctx, false); err2 != nil {
-			return closeWithError(ctx, errors.Wrap(bmclibErrs.ErrLoginFailed, err2.Error()))
+			return errors.Wrap(bmclibErrs.ErrLoginFailed, err2.Error())
 		}
 	}
 
 	contentsTopMenu, status, err := c.serviceClient.query(ctx, "cgi/url_redirect.cgi?url_name=topmenu", http.MethodGet, nil, nil, 0)
 	if err != nil {
-		return closeWithError(ctx, errors.Wrap(bmclibErrs.ErrLoginFailed, err.Error()))
+		return errors.Wrap(bmclibErrs.ErrLoginFailed, err.Error())
 	}
 
 	if status != 200 {
-		return closeWithError(ctx, errors.Wrap(bmclibErrs.ErrLoginFailed, strconv.Itoa(status)))
+		return errors.Wrap(bmclibErrs.ErrLoginFailed, strconv.Itoa(status))
 	}
 
 	// Note: older firmware version on the X11s don't use a CSRF token
@@ -236,6 +254,21 @@ func (c *Client) Open(ctx context.Context) (err error) {
 	csrfToken := parseToken(contentsTopMenu)
 	c.serviceClient.setCsrfToken(csrfToken)
 
+	return nil
+}
+
+// Open a connection to a Supermicro BMC using the vendor API.
+func (c *Client) Open(ctx context.Context) (err error) {
+	// called after a session was opened but further login dependencies failed
+	closeWithError := func(ctx context.Context, err error) error {
+		_ = c.Close(ctx)
+		return err
+	}
+
+	if err := c.webSession(ctx); err != nil {
+		return closeWithError(ctx, err)
+	}
+
 	c.bmc, err = c.bmcQueryor(ctx)
 	if err != nil {
 		return closeWithError(ctx, errors.Wrap(bmclibErrs.ErrLoginFailed, err.Error()))
@@ -511,14 +544,18 @@ func (c *Client) initScreenPreview(ctx context.Context) error {
 }
 
 type serviceClient struct {
-	host      string
-	port      string
-	user      string
-	pass      string
+	host string
+	port string
+	user string
+	pass string
+	// csrfMu guards csrfToken, the session keepalive re-establishes the web session while queries run.
+	csrfMu    sync.RWMutex
 	csrfToken string
 	client    *http.Client
 	redfish   *redfishwrapper.Client
 	sum       *sum.Sum
+	// relogin re-establishes the web session when the BMC rejects it as expired.
+	relogin func(ctx context.Context) error
 }
 
 func newBmcServiceClient(host, port, user, pass string, client *http.Client) *serviceClient {
@@ -545,9 +582,20 @@ func newBmcServiceClient(host, port, user, pass string, client *http.Client) *se
 }
 
 func (c *serviceClient) setCsrfToken(t string) {
+	c.csrfMu.Lock()
+	defer c.csrfMu.Unlock()
+
 	c.csrfToken = t
 }
 
+// getCsrfToken returns the CSRF token of the current web session.
+func (c *serviceClient) getCsrfToken() string {
+	c.csrfMu.RLock()
+	defer c.csrfMu.RUnlock()
+
+	return c.csrfToken
+}
+
 func (c *serviceClient) redfishSession(ctx context.Context) (err error) {
 	if c.redfish != nil && c.redfish.SessionActive() == nil {
 		return nil
@@ -581,7 +629,49 @@ func (c *serviceClient) supportsFirmwareInstall(model string) error {
 	return errors.Wrap(ErrModelUnsupported, "firmware install not supported for: "+model)
 }
 
+// query runs the web interface query, when the BMC rejects the session as expired a new session is
+// opened and the query is retried once, queries with a payload are only retried when the payload can be rewound.
 func (c *serviceClient) query(ctx context.Context, endpoint, method string, payload io.Reader, headers map[string]string, contentLength int64) (body []byte, statusCode int, err error) {
+	// in memory payloads are read through a bytes.Reader to have them rewound on a retry
+	if buf, ok := payload.(*bytes.Buffer); ok {
+		payload = bytes.NewReader(buf.Bytes())
+	}
+
+	body, statusCode, err = c.doQuery(ctx, endpoint, method, payload, headers, contentLength)
+	if err != nil || statusCode != http.StatusUnauthorized || c.relogin == nil || sessionEndpoint(endpoint) {
+		return body, statusCode, err
+	}
+
+	if payload != nil {
+		seeker, ok := payload.(io.Seeker)
+		if !ok {
+			return body, statusCode, nil
+		}
+
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return body, statusCode, nil
+		}
+	}
+
+	if err := c.relogin(ctx); err != nil {
+		return body, statusCode, nil
+	}
+
+	return c.doQuery(ctx, endpoint, method, payload, headers, contentLength)
+}
+
+// sessionEndpoint returns true for the endpoints that open and close the web session, these are not retried.
+func sessionEndpoint(endpoint string) bool {
+	switch endpoint {
+	case "cgi/login.cgi", "cgi/logout.cgi", "cgi/url_redirect.cgi?url_name=topmenu":
+		return true
+	default:
+		return false
+	}
+}
+
+// doQuery runs a single web interface query, see query.
+func (c *serviceClient) doQuery(ctx context.Context, endpoint, method string, payload io.Reader, headers map[string]string, contentLength int64) (body []byte, statusCode int, err error) {
 	var req *http.Request
 
 	host := c.host
@@ -597,10 +687,10 @@ func (c *serviceClient) query(ctx context.Context, endpoint, method string, payl
 		return nil, 0, err
 	}
 
-	if c.csrfToken != "" {
-		req.Header.Add("Csrf-Token", c.csrfToken)
+	if csrfToken := c.getCsrfToken(); csrfToken != "" {
+		req.Header.Add("Csrf-Token", csrfToken)
 		// because old firmware
-		req.Header.Add("CSRF_TOKEN", c.csrfToken)
+		req.Header.Add("CSRF_TOKEN", csrfToken)
 	}
 
 	// required on  X11SCM-F with 1.23.06 and older BMC firmware
@@ -715,6 +805,164 @@ func (c *Client) ResetSecureBootKeys(ctx context.Context, resetType string) (err
 	return c.serviceClient.redfish.ResetSecureBootKeys(ctx, resetType)
 }
 
+// GetSystemEventLogEntries returns the System Event Log as typed entries
+func (c *Client) GetSystemEventLogEntries(ctx context.Context) (entries []bmc.SystemEventLogEntry, err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return nil, errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	return c.serviceClient.redfish.GetSystemEventLogEntries(ctx)
+}
+
+// Sensors returns the host sensor readings
+func (c *Client) Sensors(ctx context.Context) (sensors []bmc.SensorReading, err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return nil, errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	return c.serviceClient.redfish.Sensors(ctx)
+}
+
+// PowerConsumption returns the host power consumption
+func (c *Client) PowerConsumption(ctx context.Context) (consumption *bmc.PowerConsumption, err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return nil, errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	return c.serviceClient.redfish.PowerConsumption(ctx)
+}
+
+// SetPowerLimit sets the host power limit
+func (c *Client) SetPowerLimit(ctx context.Context, limit bmc.PowerLimit) (err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	return c.serviceClient.redfish.SetPowerLimit(ctx, limit)
+}
+
+// SetIdentifyLED turns the chassis identify LED on or off
+func (c *Client) SetIdentifyLED(ctx context.Context, on bool, duration time.Duration) (err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	return c.serviceClient.redfish.SetIdentifyLED(ctx, on, duration)
+}
+
+// GetIdentifyLED returns the chassis identify LED state
+func (c *Client) GetIdentifyLED(ctx context.Context) (on bool, err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return false, errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	return c.serviceClient.redfish.GetIdentifyLED(ctx)
+}
+
+// GetBMCNetwork returns the BMC management network configuration
+func (c *Client) GetBMCNetwork(ctx context.Context) (config *bmc.BMCNetworkConfig, err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return nil, errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	return c.serviceClient.redfish.GetBMCNetwork(ctx)
+}
+
+// SetBMCNetwork applies the configuration to the BMC management interface
+func (c *Client) SetBMCNetwork(ctx context.Context, config bmc.BMCNetworkConfig) (err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	return c.serviceClient.redfish.SetBMCNetwork(ctx, config)
+}
+
+// GenerateCSR has the BMC generate a key pair and returns the certificate signing request
+func (c *Client) GenerateCSR(ctx context.Context, request bmc.CSRRequest) (csr string, err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return "", errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	return c.serviceClient.redfish.GenerateCSR(ctx, request)
+}
+
+// ReplaceCertificate installs the certificate in place of the given BMC certificate
+func (c *Client) ReplaceCertificate(ctx context.Context, certificateID, certificate string) (err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	return c.serviceClient.redfish.ReplaceCertificate(ctx, certificateID, certificate)
+}
+
+// ListCertificates returns the BMC certificates
+func (c *Client) ListCertificates(ctx context.Context) (certificates []bmc.Certificate, err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return nil, errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	return c.serviceClient.redfish.ListCertificates(ctx)
+}
+
+// GetBMCTime returns the BMC clock and its NTP configuration
+func (c *Client) GetBMCTime(ctx context.Context) (bmcTime *bmc.BMCTime, err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return nil, errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	return c.serviceClient.redfish.GetBMCTime(ctx)
+}
+
+// SetBMCTime sets the BMC clock
+func (c *Client) SetBMCTime(ctx context.Context, t time.Time) (err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	return c.serviceClient.redfish.SetBMCTime(ctx, t)
+}
+
+// SetNTPServers sets the BMC NTP servers
+func (c *Client) SetNTPServers(ctx context.Context, servers []string) (err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	return c.serviceClient.redfish.SetNTPServers(ctx, servers)
+}
+
+// GetDirectoryService returns the BMC LDAP or Active Directory configuration
+func (c *Client) GetDirectoryService(ctx context.Context, serviceType bmc.DirectoryServiceType) (config *bmc.DirectoryServiceConfig, err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return nil, errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	return c.serviceClient.redfish.GetDirectoryService(ctx, serviceType)
+}
+
+// SetDirectoryService sets the BMC LDAP or Active Directory configuration
+func (c *Client) SetDirectoryService(ctx context.Context, config bmc.DirectoryServiceConfig) (err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil {
+		return errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	return c.serviceClient.redfish.SetDirectoryService(ctx, config)
+}
+
+// KeepSessionAlive refreshes the BMC web and redfish sessions, expired sessions are re-established.
+func (c *Client) KeepSessionAlive(ctx context.Context) (err error) {
+	if c.serviceClient == nil || c.serviceClient.redfish == nil || c.bmc == nil {
+		return errors.Wrap(bmclibErrs.ErrLoginFailed, "client not initialized")
+	}
+
+	// the device model is queried through the web interface on the X11s
+	if _, err := c.bmc.queryDeviceModel(ctx); err != nil {
+		return err
+	}
+
+	return c.serviceClient.redfish.KeepSessionAlive(ctx)
+}
+
 // SendNMI tells the BMC to issue an NMI to the device
 func (c *Client) SendNMI(ctx context.Context) error {
 	return c.serviceClient.redfish.SendNMI(ctx)
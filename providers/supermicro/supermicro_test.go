@@ -1,6 +1,7 @@
 package supermicro
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"log"
@@ -8,6 +9,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -239,6 +241,114 @@ func TestOpen(t *testing.T) {
 	}
 }
 
+func TestQuerySessionRenewal(t *testing.T) {
+	logins := 0
+	expired := true
+	var payloads []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cgi/login.cgi", func(w http.ResponseWriter, r *http.Request) {
+		logins++
+		expired = false
+		_, _ = w.Write([]byte(`self.location = "../cgi/url_redirect.cgi?url_name=mainmenu";`))
+	})
+	mux.HandleFunc("/cgi/url_redirect.cgi", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<script>SmcCsrfInsert ("CSRF-TOKEN", "A0v9gild518yF36XZ6jqNZNsOUrHiEpkvM+QHKKVTFw");</script>`))
+	})
+	mux.HandleFunc("/cgi/ipmi.cgi", func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		payloads = append(payloads, string(b))
+
+		if expired {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`<IPMI></IPMI>`))
+	})
+
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	parsedURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(parsedURL.Hostname(), "foo", "bar", logr.Discard(), WithPort(parsedURL.Port()))
+
+	_, status, err := client.serviceClient.query(context.Background(), "cgi/ipmi.cgi", http.MethodPost, bytes.NewBufferString("op=FRU_INFO.XML"), nil, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 1, logins)
+	assert.Equal(t, []string{"op=FRU_INFO.XML", "op=FRU_INFO.XML"}, payloads)
+	assert.Equal(t, "A0v9gild518yF36XZ6jqNZNsOUrHiEpkvM+QHKKVTFw", client.serviceClient.csrfToken)
+}
+
+// the web session is re-established by the keepalive while queries run, run with -race.
+func TestQueryConcurrentSessionRenewal(t *testing.T) {
+	var mu sync.Mutex
+	queries := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cgi/login.cgi", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`self.location = "../cgi/url_redirect.cgi?url_name=mainmenu";`))
+	})
+	mux.HandleFunc("/cgi/url_redirect.cgi", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<script>SmcCsrfInsert ("CSRF-TOKEN", "A0v9gild518yF36XZ6jqNZNsOUrHiEpkvM+QHKKVTFw");</script>`))
+	})
+	mux.HandleFunc("/cgi/ipmi.cgi", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		// the BMC drops the session every few queries
+		queries++
+		if queries%5 == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`<IPMI></IPMI>`))
+	})
+
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	parsedURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(parsedURL.Hostname(), "foo", "bar", logr.Discard(), WithPort(parsedURL.Port()))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				_ = client.webSession(context.Background())
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				_, _, _ = client.serviceClient.query(context.Background(), "cgi/ipmi.cgi", http.MethodPost, bytes.NewBufferString("op=FRU_INFO.XML"), nil, 0)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, "A0v9gild518yF36XZ6jqNZNsOUrHiEpkvM+QHKKVTFw", client.serviceClient.getCsrfToken())
+}
+
 func TestClose(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -205,10 +205,10 @@ func (c *x11) uploadBIOSFirmware(ctx context.Context, fwReader io.Reader) error
 		},
 	}
 
-	if c.csrfToken != "" {
+	if csrfToken := c.getCsrfToken(); csrfToken != "" {
 		formParts = append(formParts, form{
 			name: "csrf-token",
-			data: bytes.NewBufferString(c.csrfToken),
+			data: bytes.NewBufferString(csrfToken),
 		})
 	}
 
@@ -119,10 +119,10 @@ func (c *x11) uploadBMCFirmware(ctx context.Context, fwReader io.Reader) error {
 		},
 	}
 
-	if c.csrfToken != "" {
+	if csrfToken := c.getCsrfToken(); csrfToken != "" {
 		formParts = append(formParts, form{
 			name: "csrf-token",
-			data: bytes.NewBufferString(c.csrfToken),
+			data: bytes.NewBufferString(csrfToken),
 		})
 	}
 
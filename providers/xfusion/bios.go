@@ -0,0 +1,62 @@
+package xfusion
+
+import (
+	"context"
+
+	"github.com/stmcginnis/gofish/schemas"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+// compile-time assertions that the provider implements the BIOS configuration interfaces.
+var (
+	_ bmc.BiosConfigurationGetter   = (*Conn)(nil)
+	_ bmc.BiosConfigurationSetter   = (*Conn)(nil)
+	_ bmc.BiosConfigurationResetter = (*Conn)(nil)
+)
+
+// GetBiosConfiguration returns the current BIOS attributes as a key/value map,
+// read from the ComputerSystem Bios resource Attributes.
+//
+// Implements bmc.BiosConfigurationGetter.
+func (c *Conn) GetBiosConfiguration(ctx context.Context) (biosConfig map[string]string, err error) {
+	return c.redfishwrapper.GetBiosConfiguration(ctx)
+}
+
+// SetBiosConfiguration writes BIOS attributes to the Bios/Settings resource,
+// the iBMC applies them on the next host restart. The iBMC rejects the
+// "@Redfish.SettingsApplyTime" annotation, the attributes are written without
+// it.
+//
+// Implements bmc.BiosConfigurationSetter.
+func (c *Conn) SetBiosConfiguration(ctx context.Context, biosConfig map[string]string) (err error) {
+	sys, err := c.redfishwrapper.System()
+	if err != nil {
+		return err
+	}
+
+	bios, err := sys.Bios()
+	if err != nil {
+		return err
+	}
+
+	if bios == nil {
+		return bmclibErrs.ErrNoBiosAttributes
+	}
+
+	attributes := make(schemas.SettingsAttributes, len(biosConfig))
+	for name, value := range biosConfig {
+		attributes[name] = value
+	}
+
+	return bios.UpdateBiosAttributes(attributes)
+}
+
+// ResetBiosConfiguration restores BIOS settings to their default values via the
+// Bios.ResetBios action, the defaults are applied on the next host restart.
+//
+// Implements bmc.BiosConfigurationResetter.
+func (c *Conn) ResetBiosConfiguration(ctx context.Context) (err error) {
+	return c.redfishwrapper.ResetBiosConfiguration(ctx)
+}
@@ -0,0 +1,366 @@
+package xfusion
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/bmc-toolbox/common"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+)
+
+var _ bmc.InventoryGetter = (*Conn)(nil)
+
+// status is the Redfish status shape of the iBMC resources.
+type status struct {
+	Health string `json:"Health"`
+	State  string `json:"State"`
+}
+
+// storage is an iBMC Storage resource, its controllers are embedded.
+type storage struct {
+	ID                 string                   `json:"Id"`
+	StorageControllers []storageController      `json:"StorageControllers"`
+	Drives             []redfishwrapper.ODataID `json:"Drives"`
+}
+
+// storageController is an embedded StorageController of an iBMC Storage
+// resource.
+type storageController struct {
+	MemberID                 string   `json:"MemberId"`
+	Name                     string   `json:"Name"`
+	Manufacturer             string   `json:"Manufacturer"`
+	Model                    string   `json:"Model"`
+	SerialNumber             string   `json:"SerialNumber"`
+	FirmwareVersion          string   `json:"FirmwareVersion"`
+	SupportedDeviceProtocols []string `json:"SupportedDeviceProtocols"`
+	Status                   status   `json:"Status"`
+	Oem                      map[string]struct {
+		SupportedRAIDLevels []string `json:"SupportedRAIDLevels"`
+		Mode                string   `json:"Mode"`
+		MemorySizeMiB       int64    `json:"MemorySizeMiB"`
+		DriverInfo          struct {
+			DriverName    string `json:"DriverName"`
+			DriverVersion string `json:"DriverVersion"`
+		} `json:"DriverInfo"`
+	} `json:"Oem"`
+}
+
+// drive is an iBMC Drive resource.
+type drive struct {
+	SerialNumber string `json:"SerialNumber"`
+	Oem          map[string]struct {
+		DriveID        *int   `json:"DriveID"`
+		FirmwareStatus string `json:"FirmwareStatus"`
+	} `json:"Oem"`
+}
+
+// networkAdapter is an iBMC NetworkAdapter resource.
+type networkAdapter struct {
+	ID           string `json:"Id"`
+	Name         string `json:"Name"`
+	Manufacturer string `json:"Manufacturer"`
+	Model        string `json:"Model"`
+	Status       status `json:"Status"`
+	Controllers  []struct {
+		FirmwarePackageVersion string `json:"FirmwarePackageVersion"`
+		Link                   struct {
+			NetworkPorts []redfishwrapper.ODataID `json:"NetworkPorts"`
+		} `json:"Link"`
+	} `json:"Controllers"`
+	Oem map[string]struct {
+		CardManufacturer string `json:"CardManufacturer"`
+		CardModel        string `json:"CardModel"`
+		SerialNumber     string `json:"SerialNumber"`
+		DriverName       string `json:"DriverName"`
+		DriverVersion    string `json:"DriverVersion"`
+		RootBDF          string `json:"RootBDF"`
+	} `json:"Oem"`
+}
+
+// networkPort is an iBMC NetworkPort resource.
+type networkPort struct {
+	ID                         string   `json:"Id"`
+	PhysicalPortNumber         string   `json:"PhysicalPortNumber"`
+	LinkStatus                 string   `json:"LinkStatus"`
+	AssociatedNetworkAddresses []string `json:"AssociatedNetworkAddresses"`
+	Oem                        map[string]struct {
+		BDF string `json:"BDF"`
+	} `json:"Oem"`
+}
+
+// Inventory collects the hardware and firmware inventory of the iBMC managed
+// system into a *common.Device.
+//
+// The standard Redfish inventory is extended with the iBMC OEM properties of
+// the storage controllers (supported RAID levels, mode, cache size and
+// driver), the drives (the controller drive id) and the network adapters
+// (card model, driver and PCI address). Network adapters the standard
+// resources do not report are added from the Chassis NetworkAdapters.
+//
+// When the connection's failInventoryOnError is false (the default, set via
+// [WithFailInventoryOnError]), a failure reading one sub-resource does not abort
+// the whole inventory — the provider returns what it could collect. When true,
+// the first sub-resource error is returned.
+//
+// Implements bmc.InventoryGetter.
+func (c *Conn) Inventory(ctx context.Context) (device *common.Device, err error) {
+	device, err = c.redfishwrapper.Inventory(ctx, c.failInventoryOnError)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.collectStorage(device); err != nil && c.failInventoryOnError {
+		return nil, err
+	}
+
+	if err := c.collectNetworkAdapters(ctx, device); err != nil && c.failInventoryOnError {
+		return nil, err
+	}
+
+	return device, nil
+}
+
+// collectStorage extends the storage controllers and drives of the device with
+// the OEM properties of the iBMC Storages resources, storage controllers the
+// device does not list are added.
+func (c *Conn) collectStorage(device *common.Device) error {
+	sys, err := c.redfishwrapper.System()
+	if err != nil {
+		return err
+	}
+
+	members, err := c.redfishwrapper.CollectionMembers(sys.ODataID + "/Storages")
+	if err != nil {
+		if redfishwrapper.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, member := range members {
+		var s storage
+		if err := c.redfishwrapper.GetJSON(member, &s); err != nil {
+			return err
+		}
+
+		for i := range s.StorageControllers {
+			addStorageController(device, &s.StorageControllers[i])
+		}
+
+		for _, link := range s.Drives {
+			var d drive
+			if err := c.redfishwrapper.GetJSON(link.ODataID, &d); err != nil {
+				return err
+			}
+
+			extendDrive(device, &d)
+		}
+	}
+
+	return nil
+}
+
+// addStorageController sets the OEM properties of the controller on the
+// matching device storage controller, the controller is added when the device
+// does not list it.
+func addStorageController(device *common.Device, controller *storageController) {
+	var sc *common.StorageController
+	for _, existing := range device.StorageControllers {
+		if controller.SerialNumber != "" && strings.EqualFold(existing.Serial, controller.SerialNumber) {
+			sc = existing
+			break
+		}
+	}
+
+	if sc == nil {
+		sc = &common.StorageController{
+			Common: common.Common{
+				Description: controller.Name,
+				Vendor:      common.FormatVendorName(controller.Manufacturer),
+				Model:       controller.Model,
+				Serial:      controller.SerialNumber,
+				Firmware:    &common.Firmware{Installed: controller.FirmwareVersion},
+				Status:      &common.Status{Health: controller.Status.Health, State: controller.Status.State},
+			},
+			ID:                       controller.MemberID,
+			SupportedDeviceProtocols: strings.Join(controller.SupportedDeviceProtocols, ", "),
+		}
+
+		device.StorageControllers = append(device.StorageControllers, sc)
+	}
+
+	properties, ok := oem(controller.Oem)
+	if !ok {
+		return
+	}
+
+	sc.SupportedRAIDTypes = strings.Join(properties.SupportedRAIDLevels, ", ")
+	setMetadata(&sc.Common, "mode", properties.Mode)
+	setMetadata(&sc.Common, "driver_name", properties.DriverInfo.DriverName)
+	setMetadata(&sc.Common, "driver_version", properties.DriverInfo.DriverVersion)
+
+	if properties.MemorySizeMiB > 0 {
+		setMetadata(&sc.Common, "cache_size_mib", strconv.FormatInt(properties.MemorySizeMiB, 10))
+	}
+}
+
+// extendDrive sets the OEM properties of the drive on the matching device drive.
+func extendDrive(device *common.Device, d *drive) {
+	properties, ok := oem(d.Oem)
+	if !ok || d.SerialNumber == "" {
+		return
+	}
+
+	for _, existing := range device.Drives {
+		if !strings.EqualFold(existing.Serial, d.SerialNumber) {
+			continue
+		}
+
+		if properties.DriveID != nil {
+			existing.StorageControllerDriveID = *properties.DriveID
+		}
+
+		setMetadata(&existing.Common, "firmware_status", properties.FirmwareStatus)
+	}
+}
+
+// collectNetworkAdapters adds the Chassis NetworkAdapters to the device, or
+// extends the NICs of the device reporting the same MAC addresses with the OEM
+// properties of the adapter.
+func (c *Conn) collectNetworkAdapters(ctx context.Context, device *common.Device) error {
+	chassis, err := c.redfishwrapper.Chassis(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, ch := range chassis {
+		members, err := c.redfishwrapper.CollectionMembers(ch.ODataID + "/NetworkAdapters")
+		if err != nil {
+			if redfishwrapper.IsNotFound(err) {
+				continue
+			}
+
+			return err
+		}
+
+		for _, member := range members {
+			nic, err := c.networkAdapterNIC(member)
+			if err != nil {
+				return err
+			}
+
+			if existing := matchingNIC(device, nic); existing != nil {
+				for key, value := range nic.Metadata {
+					setMetadata(&existing.Common, key, value)
+				}
+
+				continue
+			}
+
+			device.NICs = append(device.NICs, nic)
+		}
+	}
+
+	return nil
+}
+
+// networkAdapterNIC returns the NIC of the network adapter resource.
+func (c *Conn) networkAdapterNIC(url string) (*common.NIC, error) {
+	var adapter networkAdapter
+	if err := c.redfishwrapper.GetJSON(url, &adapter); err != nil {
+		return nil, err
+	}
+
+	nic := &common.NIC{
+		Common: common.Common{
+			Description: adapter.Name,
+			Vendor:      common.FormatVendorName(adapter.Manufacturer),
+			Model:       adapter.Model,
+			Status:      &common.Status{Health: adapter.Status.Health, State: adapter.Status.State},
+		},
+		ID: adapter.ID,
+	}
+
+	if properties, ok := oem(adapter.Oem); ok {
+		nic.Serial = properties.SerialNumber
+		nic.ProductName = properties.CardModel
+		if properties.CardManufacturer != "" {
+			nic.Vendor = common.FormatVendorName(properties.CardManufacturer)
+		}
+
+		setMetadata(&nic.Common, "driver_name", properties.DriverName)
+		setMetadata(&nic.Common, "driver_version", properties.DriverVersion)
+		setMetadata(&nic.Common, "root_bdf", properties.RootBDF)
+	}
+
+	for _, controller := range adapter.Controllers {
+		if controller.FirmwarePackageVersion != "" {
+			nic.Firmware = &common.Firmware{Installed: controller.FirmwarePackageVersion}
+		}
+
+		for _, link := range controller.Link.NetworkPorts {
+			var port networkPort
+			if err := c.redfishwrapper.GetJSON(link.ODataID, &port); err != nil {
+				return nil, err
+			}
+
+			nicPort := &common.NICPort{
+				ID:         port.PhysicalPortNumber,
+				LinkStatus: port.LinkStatus,
+			}
+
+			if nicPort.ID == "" {
+				nicPort.ID = port.ID
+			}
+
+			if len(port.AssociatedNetworkAddresses) > 0 {
+				nicPort.MacAddress = port.AssociatedNetworkAddresses[0]
+			}
+
+			if properties, ok := oem(port.Oem); ok {
+				nicPort.BusInfo = properties.BDF
+			}
+
+			nic.NICPorts = append(nic.NICPorts, nicPort)
+		}
+	}
+
+	return nic, nil
+}
+
+// matchingNIC returns the NIC of the device with the same serial or a port with
+// one of the MAC addresses of nic.
+func matchingNIC(device *common.Device, nic *common.NIC) *common.NIC {
+	for _, existing := range device.NICs {
+		if nic.Serial != "" && strings.EqualFold(existing.Serial, nic.Serial) {
+			return existing
+		}
+
+		for _, existingPort := range existing.NICPorts {
+			for _, port := range nic.NICPorts {
+				if port.MacAddress != "" && strings.EqualFold(existingPort.MacAddress, port.MacAddress) {
+					return existing
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// setMetadata sets a non empty metadata value on the component.
+func setMetadata(component *common.Common, key, value string) {
+	if value == "" {
+		return
+	}
+
+	if component.Metadata == nil {
+		component.Metadata = map[string]string{}
+	}
+
+	component.Metadata[key] = value
+}
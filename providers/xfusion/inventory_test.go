@@ -0,0 +1,43 @@
+package xfusion
+
+import (
+	"context"
+	"testing"
+)
+
+// Requirement: inventory includes the iBMC OEM storage and NIC properties.
+func TestInventory(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	device, err := c.Inventory(context.Background())
+	if err != nil {
+		t.Fatalf("Inventory: %v", err)
+	}
+
+	if len(device.StorageControllers) != 1 {
+		t.Fatalf("got %d storage controllers, want 1", len(device.StorageControllers))
+	}
+	controller := device.StorageControllers[0]
+	if controller.SupportedRAIDTypes != "RAID0, RAID1, RAID5, RAID10" {
+		t.Fatalf("SupportedRAIDTypes = %q", controller.SupportedRAIDTypes)
+	}
+	if controller.Metadata["mode"] != "RAID" || controller.Metadata["driver_name"] != "megaraid_sas" || controller.Metadata["cache_size_mib"] != "2048" {
+		t.Fatalf("storage controller metadata = %v", controller.Metadata)
+	}
+
+	if len(device.Drives) != 1 || device.Drives[0].Metadata["firmware_status"] != "Online" {
+		t.Fatalf("drives = %+v", device.Drives)
+	}
+
+	if len(device.NICs) != 1 {
+		t.Fatalf("got %d NICs, want 1", len(device.NICs))
+	}
+	nic := device.NICs[0]
+	if nic.ProductName != "I350" || nic.Metadata["driver_name"] != "igb" || nic.Firmware.Installed != "1.0.7" {
+		t.Fatalf("NIC = %+v", nic)
+	}
+	if len(nic.NICPorts) != 1 || nic.NICPorts[0].MacAddress != "A4:BE:2B:3C:4D:60" || nic.NICPorts[0].BusInfo != "0000:3b:00.0" {
+		t.Fatalf("NIC ports = %+v", nic.NICPorts)
+	}
+}
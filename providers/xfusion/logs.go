@@ -0,0 +1,127 @@
+package xfusion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/stmcginnis/gofish/schemas"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+)
+
+// LogServiceSEL is the id of the system log service that holds the SEL.
+const LogServiceSEL = "Log1"
+
+var errNoSEL = errors.New("no SEL log service found")
+
+// compile-time assertions that the provider implements the interfaces.
+var (
+	_ bmc.SystemEventLog              = (*Conn)(nil)
+	_ bmc.SystemEventLogEntriesGetter = (*Conn)(nil)
+)
+
+// GetSystemEventLog returns the SEL entries as rows of
+// [id, created, severity, message].
+//
+// Implements bmc.SystemEventLog.
+func (c *Conn) GetSystemEventLog(ctx context.Context) (entries [][]string, err error) {
+	selEntries, err := c.selEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range selEntries {
+		entries = append(entries, []string{entry.ID, entry.Created, string(entry.Severity), entry.Message})
+	}
+
+	return entries, nil
+}
+
+// GetSystemEventLogEntries returns the SEL entries as typed entries.
+//
+// Implements bmc.SystemEventLogEntriesGetter.
+func (c *Conn) GetSystemEventLogEntries(ctx context.Context) (entries []bmc.SystemEventLogEntry, err error) {
+	selEntries, err := c.selEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range selEntries {
+		entries = append(entries, redfishwrapper.SystemEventLogEntryFromLogEntry(entry))
+	}
+
+	return entries, nil
+}
+
+// GetSystemEventLogRaw returns the raw JSON of the SEL entries.
+//
+// Implements bmc.SystemEventLog.
+func (c *Conn) GetSystemEventLogRaw(ctx context.Context) (eventlog string, err error) {
+	selEntries, err := c.selEntries()
+	if err != nil {
+		return "", err
+	}
+
+	raw, err := json.Marshal(selEntries)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+// ClearSystemEventLog clears the SEL through the LogService.ClearLog action.
+//
+// Implements bmc.SystemEventLog.
+func (c *Conn) ClearSystemEventLog(ctx context.Context) (err error) {
+	sel, err := c.selLogService()
+	if err != nil {
+		return err
+	}
+
+	if _, err := sel.ClearLog(""); err != nil {
+		return fmt.Errorf("clearing the SEL: %w", err)
+	}
+
+	return nil
+}
+
+// selEntries returns the entries of the SEL.
+func (c *Conn) selEntries() ([]*schemas.LogEntry, error) {
+	sel, err := c.selLogService()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := sel.Entries()
+	if err != nil {
+		return nil, fmt.Errorf("reading the SEL entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// selLogService returns the SEL log service of the system, the iBMC lists the
+// SEL under the system LogServices instead of the manager LogServices.
+func (c *Conn) selLogService() (*schemas.LogService, error) {
+	sys, err := c.redfishwrapper.System()
+	if err != nil {
+		return nil, err
+	}
+
+	logServices, err := sys.LogServices()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ls := range logServices {
+		if ls.ID == LogServiceSEL {
+			return ls, nil
+		}
+	}
+
+	return nil, errNoSEL
+}
@@ -0,0 +1,35 @@
+package xfusion
+
+import (
+	"context"
+	"testing"
+)
+
+// Requirement: the SEL is read from and cleared on the system LogServices.
+func TestSystemEventLog(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	entries, err := c.GetSystemEventLogEntries(context.Background())
+	if err != nil {
+		t.Fatalf("GetSystemEventLogEntries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].RecordID != "1" || entries[0].Severity != "warning" {
+		t.Fatalf("GetSystemEventLogEntries = %+v", entries)
+	}
+
+	rows, err := c.GetSystemEventLog(context.Background())
+	if err != nil {
+		t.Fatalf("GetSystemEventLog: %v", err)
+	}
+	if len(rows) != 1 || rows[0][3] != "The power supply 2 input voltage is lost." {
+		t.Fatalf("GetSystemEventLog = %v", rows)
+	}
+
+	if err := c.ClearSystemEventLog(context.Background()); err != nil {
+		t.Fatalf("ClearSystemEventLog: %v", err)
+	}
+	if !ts.didClearSEL() {
+		t.Fatal("expected the LogService.ClearLog action to be posted")
+	}
+}
@@ -0,0 +1,314 @@
+package xfusion
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+const fixturesDir = "./fixtures/v1"
+
+// testServer is an httptest-backed iBMC Redfish mock. It serves recorded JSON
+// fixtures and emulates Redfish session create/delete, the image transfer, the
+// SimpleUpdate action and the OEM VmmControl action so the provider can be
+// exercised entirely offline.
+type testServer struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	sessionCreated bool
+	sessionDeleted bool
+	// lastResetType records the ResetType posted to ComputerSystem.Reset.
+	lastResetType string
+	// biosPatchBody records the decoded body of the last Bios settings PATCH.
+	biosPatchBody map[string]any
+	// biosReset records whether the Bios.ResetBios action was posted.
+	biosReset bool
+	// selCleared records whether the SEL LogService.ClearLog action was posted.
+	selCleared bool
+	// vmmControls records the bodies posted to the VmmControl action in order.
+	vmmControls []map[string]any
+	// transferred records the images transferred to the iBMC by filename.
+	transferred map[string]string
+	// simpleUpdateBody records the decoded body posted to SimpleUpdate.
+	simpleUpdateBody map[string]any
+}
+
+// newTestServer builds and starts a TLS mock iBMC server.
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	ts := &testServer{transferred: map[string]string{}}
+
+	// path -> fixture file for plain GETs.
+	routes := map[string]string{
+		"/redfish/v1/":                                                       "serviceroot.json",
+		"/redfish/v1/Systems":                                                "systems.json",
+		"/redfish/v1/Systems/1":                                              "system.1.json",
+		"/redfish/v1/Systems/1/Bios":                                         "bios.json",
+		"/redfish/v1/Systems/1/Bios/Settings":                                "bios.settings.json",
+		"/redfish/v1/Systems/1/Storages":                                     "storages.json",
+		"/redfish/v1/Systems/1/Storages/RAIDStorage0":                        "storage.raidstorage0.json",
+		"/redfish/v1/Systems/1/LogServices":                                  "system.logservices.json",
+		"/redfish/v1/Systems/1/LogServices/Log1":                             "ls.log1.json",
+		"/redfish/v1/Systems/1/LogServices/Log1/Entries":                     "ls.log1.entries.json",
+		"/redfish/v1/Systems/1/LogServices/Log1/Entries/1":                   "ls.log1.entry.1.json",
+		"/redfish/v1/Chassis":                                                "chassis.json",
+		"/redfish/v1/Chassis/1":                                              "chassis.1.json",
+		"/redfish/v1/Chassis/1/Drives/HDDPlaneDisk0":                         "drive.hddplanedisk0.json",
+		"/redfish/v1/Chassis/1/NetworkAdapters":                              "networkadapters.json",
+		"/redfish/v1/Chassis/1/NetworkAdapters/mainboardNIC1":                "networkadapter.mainboardnic1.json",
+		"/redfish/v1/Chassis/1/NetworkAdapters/mainboardNIC1/NetworkPorts/1": "networkport.1.json",
+		"/redfish/v1/Managers":                                               "managers.json",
+		"/redfish/v1/Managers/1":                                             "manager.1.json",
+		"/redfish/v1/Managers/1/VirtualMedia":                                "virtualmedia.json",
+		"/redfish/v1/Managers/1/VirtualMedia/CD":                             "virtualmedia.cd.json",
+		"/redfish/v1/UpdateService":                                          "updateservice.json",
+		"/redfish/v1/UpdateService/FirmwareInventory/ActiveBMC":              "firmwareinventory.activebmc.json",
+		"/redfish/v1/TaskService":                                            "taskservice.json",
+		"/redfish/v1/TaskService/Tasks":                                      "tasks.json",
+		"/redfish/v1/TaskService/Tasks/1":                                    "task.1.json",
+	}
+
+	mux := http.NewServeMux()
+
+	// ComputerSystem.Reset action — records the requested ResetType.
+	mux.HandleFunc("/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			ResetType string `json:"ResetType"`
+		}
+		if body, err := io.ReadAll(r.Body); err == nil {
+			_ = json.Unmarshal(body, &payload)
+		}
+		ts.mu.Lock()
+		ts.lastResetType = payload.ResetType
+		ts.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// Bios.ResetBios action.
+	mux.HandleFunc("/redfish/v1/Systems/1/Bios/Actions/Bios.ResetBios", func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		ts.biosReset = true
+		ts.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// SEL LogService.ClearLog action.
+	mux.HandleFunc("/redfish/v1/Systems/1/LogServices/Log1/Actions/LogService.ClearLog", func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		ts.selCleared = true
+		ts.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// OEM VmmControl action.
+	mux.HandleFunc("/redfish/v1/Managers/1/VirtualMedia/CD/Oem/xFusion/Actions/VirtualMedia.VmmControl", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if b, err := io.ReadAll(r.Body); err == nil {
+			_ = json.Unmarshal(b, &body)
+		}
+		ts.mu.Lock()
+		ts.vmmControls = append(ts.vmmControls, body)
+		ts.mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"@odata.id":"/redfish/v1/TaskService/Tasks/2","Id":"2","TaskState":"Running"}`))
+	})
+
+	// Image transfer: records the "imgfile" part, GETs list the firmware
+	// inventory.
+	mux.HandleFunc("/redfish/v1/UpdateService/FirmwareInventory", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			body, _ := os.ReadFile(filepath.Join(fixturesDir, "firmwareinventory.json"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(body)
+			return
+		}
+
+		file, header, err := r.FormFile("imgfile")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		b, _ := io.ReadAll(file)
+		ts.mu.Lock()
+		ts.transferred[header.Filename] = string(b)
+		ts.mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	// UpdateService.SimpleUpdate action, the task is returned in the body.
+	mux.HandleFunc("/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if b, err := io.ReadAll(r.Body); err == nil {
+			_ = json.Unmarshal(b, &body)
+		}
+		ts.mu.Lock()
+		ts.simpleUpdateBody = body
+		ts.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"@odata.id":"/redfish/v1/TaskService/Tasks/1","Id":"1","Name":"Upgrade Task","TaskState":"Running"}`))
+	})
+
+	// Session create: returns an X-Auth-Token and the session Location.
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		ts.mu.Lock()
+		ts.sessionCreated = true
+		ts.mu.Unlock()
+
+		w.Header().Set("X-Auth-Token", "test-token")
+		w.Header().Set("Location", "/redfish/v1/SessionService/Sessions/1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"@odata.id":"/redfish/v1/SessionService/Sessions/1","Id":"1","Name":"Session"}`))
+	})
+
+	// A created session is deleted here on Close.
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			ts.mu.Lock()
+			ts.sessionDeleted = true
+			ts.mu.Unlock()
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Catch-all for the rest of the Redfish tree.
+	//
+	// GETs are served from fixtures. Writes on a known resource are accepted
+	// with 204 and the Bios settings PATCH body is recorded.
+	mux.HandleFunc("/redfish/v1/", func(w http.ResponseWriter, r *http.Request) {
+		file, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			ts.mu.Lock()
+			if r.URL.Path == "/redfish/v1/Systems/1/Bios/Settings" {
+				if b, err := io.ReadAll(r.Body); err == nil {
+					var body map[string]any
+					if json.Unmarshal(b, &body) == nil {
+						ts.biosPatchBody = body
+					}
+				}
+			}
+			ts.mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		body, err := os.ReadFile(filepath.Join(fixturesDir, file))
+		if err != nil {
+			t.Errorf("failed to read fixture %q for %s: %v", file, r.URL.Path, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	})
+
+	ts.Server = httptest.NewTLSServer(mux)
+
+	return ts
+}
+
+// client returns a *Conn pointed at the mock server. Extra options are appended
+// after the mandatory port option.
+func (ts *testServer) client(t *testing.T, opts ...Option) *Conn {
+	t.Helper()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("parse mock url: %v", err)
+	}
+	opts = append([]Option{WithPort(u.Port())}, opts...)
+	return New(u.Hostname(), "user", "pass", logr.Discard(), opts...)
+}
+
+// openedClient returns a *Conn with an established session and registers Close
+// + server shutdown for cleanup.
+func (ts *testServer) openedClient(t *testing.T, opts ...Option) *Conn {
+	t.Helper()
+	c := ts.client(t, opts...)
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = c.Close(context.Background())
+		ts.Close()
+	})
+	return c
+}
+
+func (ts *testServer) didCreateSession() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.sessionCreated
+}
+
+func (ts *testServer) didDeleteSession() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.sessionDeleted
+}
+
+func (ts *testServer) resetType() string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.lastResetType
+}
+
+func (ts *testServer) biosPatch() map[string]any {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.biosPatchBody
+}
+
+func (ts *testServer) didResetBios() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.biosReset
+}
+
+func (ts *testServer) didClearSEL() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.selCleared
+}
+
+func (ts *testServer) vmmControlBodies() []map[string]any {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.vmmControls
+}
+
+func (ts *testServer) transferredImage(filename string) (string, bool) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	image, ok := ts.transferred[filename]
+	return image, ok
+}
+
+func (ts *testServer) simpleUpdate() map[string]any {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.simpleUpdateBody
+}
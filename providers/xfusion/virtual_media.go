@@ -0,0 +1,120 @@
+package xfusion
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+)
+
+// vmmControlAction is the OEM VirtualMedia action that connects and
+// disconnects virtual media.
+const vmmControlAction = "#VirtualMedia.VmmControl"
+
+// VmmControlType values of the VmmControl action.
+const (
+	vmmConnect    = "Connect"
+	vmmDisconnect = "Disconnect"
+)
+
+// compile-time assertion that the provider implements the interface.
+var _ bmc.VirtualMediaSetter = (*Conn)(nil)
+
+// virtualMedia is an iBMC VirtualMedia resource.
+type virtualMedia struct {
+	ID         string   `json:"Id"`
+	MediaTypes []string `json:"MediaTypes"`
+	Inserted   bool     `json:"Inserted"`
+	Oem        map[string]struct {
+		Actions map[string]struct {
+			Target string `json:"target"`
+		} `json:"Actions"`
+	} `json:"Oem"`
+}
+
+// vmmControlTarget returns the target of the OEM VmmControl action.
+func (vm *virtualMedia) vmmControlTarget() string {
+	properties, _ := oem(vm.Oem)
+	return properties.Actions[vmmControlAction].Target
+}
+
+// SetVirtualMedia connects the media at mediaURL to the iBMC virtual media of
+// the given kind, an empty mediaURL disconnects the media.
+//
+// The iBMC does not implement the standard InsertMedia and EjectMedia actions,
+// the media is connected and disconnected through the OEM VmmControl action.
+// Connected media is disconnected before the new media is connected.
+//
+// Implements bmc.VirtualMediaSetter.
+func (c *Conn) SetVirtualMedia(ctx context.Context, kind, mediaURL string) (ok bool, err error) {
+	vm, err := c.virtualMedia(ctx, kind)
+	if err != nil {
+		return false, err
+	}
+
+	target := vm.vmmControlTarget()
+	if target == "" {
+		return false, fmt.Errorf("virtual media %s does not list the %s action", vm.ID, vmmControlAction)
+	}
+
+	if vm.Inserted {
+		if err := c.vmmControl(ctx, target, map[string]any{"VmmControlType": vmmDisconnect}); err != nil {
+			return false, errors.Wrap(err, "disconnecting virtual media")
+		}
+	}
+
+	if mediaURL == "" {
+		return true, nil
+	}
+
+	if err := c.vmmControl(ctx, target, map[string]any{"VmmControlType": vmmConnect, "Image": mediaURL}); err != nil {
+		return false, errors.Wrap(err, "connecting virtual media")
+	}
+
+	return true, nil
+}
+
+// virtualMedia returns the manager virtual media that supports the media kind.
+func (c *Conn) virtualMedia(ctx context.Context, kind string) (*virtualMedia, error) {
+	manager, err := c.redfishwrapper.Manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := c.redfishwrapper.CollectionMembers(manager.ODataID + "/VirtualMedia")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		vm := &virtualMedia{}
+		if err := c.redfishwrapper.GetJSON(member, vm); err != nil {
+			return nil, err
+		}
+
+		if slices.Contains(vm.MediaTypes, kind) {
+			return vm, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no virtual media supports the media type %q", kind)
+}
+
+// vmmControl posts the payload to the VmmControl action.
+func (c *Conn) vmmControl(ctx context.Context, target string, payload map[string]any) error {
+	resp, err := c.redfishwrapper.PostWithHeaders(ctx, target, payload, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.New("unexpected status code returned: " + resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,258 @@
+// Package xfusion implements a bmclib provider for xFusion (formerly Huawei)
+// FusionServer servers managed by the intelligent Baseboard Management
+// Controller (iBMC).
+//
+// iBMC implements the DMTF Redfish standard with OEM extensions under the
+// "Oem.xFusion" properties, or "Oem.Huawei" on iBMC releases shipped before
+// the xFusion spin-off. This provider is built on top of the shared
+// gofish-backed [redfishwrapper.Client] and layers the iBMC specific behavior
+// on top in dedicated files:
+//
+//   - inventory.go extends the storage controllers, drives and network
+//     adapters with the iBMC OEM properties.
+//
+//   - logs.go reads and clears the SEL of the system LogServices.
+//
+//   - virtual_media.go connects and disconnects virtual media through the
+//     OEM VmmControl action.
+//
+//   - firmware.go transfers firmware images to the iBMC and installs them
+//     through the UpdateService SimpleUpdate action.
+package xfusion
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/jacobweinstock/registrar"
+
+	"github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/bmc-toolbox/bmclib/v2/internal/httpclient"
+	"github.com/bmc-toolbox/bmclib/v2/internal/redfishwrapper"
+	"github.com/bmc-toolbox/bmclib/v2/providers"
+
+	bmclibErrs "github.com/bmc-toolbox/bmclib/v2/errors"
+)
+
+const (
+	// ProviderName is the registered name of this provider.
+	ProviderName = "xfusion"
+	// ProviderProtocol is the transport/protocol this provider speaks.
+	ProviderProtocol = "redfish"
+)
+
+// vendors are contained in the system manufacturer of the iBMC managed
+// systems, e.g. "xFusion" or "Huawei".
+var vendors = []string{"xfusion", "huawei"}
+
+// oemKeys are the keys of the iBMC OEM properties, in order of preference.
+var oemKeys = []string{"xFusion", "Huawei"}
+
+// Features is the set of bmclib features this provider implements.
+var Features = registrar.Features{
+	// power and boot
+	providers.FeaturePowerState,
+	providers.FeaturePowerSet,
+	providers.FeatureBootDeviceSet,
+	// virtual media
+	providers.FeatureVirtualMedia,
+	// SEL
+	providers.FeatureGetSystemEventLog,
+	providers.FeatureGetSystemEventLogRaw,
+	providers.FeatureGetSystemEventLogEntries,
+	providers.FeatureClearSystemEventLog,
+	// inventory
+	providers.FeatureInventoryRead,
+	// UpdateService firmware
+	providers.FeatureFirmwareUpload,
+	providers.FeatureFirmwareInstallUploaded,
+	providers.FeatureFirmwareTaskStatus,
+	providers.FeatureFirmwareInstallSteps,
+	// BIOS
+	providers.FeatureGetBiosConfiguration,
+	providers.FeatureSetBiosConfiguration,
+	providers.FeatureResetBiosConfiguration,
+}
+
+// Conn is a connection to a xFusion iBMC BMC.
+type Conn struct {
+	redfishwrapper *redfishwrapper.Client
+	// failInventoryOnError has Inventory fail on the first error reading the
+	// OEM storage and network adapter properties.
+	failInventoryOnError bool
+	Log                  logr.Logger
+}
+
+// Config is the configuration of an iBMC [Conn], it is built by [New] from
+// the given options.
+type Config struct {
+	// HTTPClient sends the iBMC requests, a client with the bmclib defaults is
+	// built when nil.
+	HTTPClient *http.Client
+	// Port is the TCP port the iBMC Redfish service listens on. Defaults to "443".
+	Port string
+	// VersionsNotCompatible are the Redfish versions of the iBMC firmware the
+	// provider is not to be used with.
+	VersionsNotCompatible []string
+	// RootCAs verifies the iBMC certificate against the pool, the certificate
+	// is not verified when nil.
+	RootCAs *x509.CertPool
+	// UseBasicAuth selects HTTP Basic authentication instead of Redfish session
+	// login.
+	UseBasicAuth bool
+	// FailInventoryOnError has Inventory fail on the first error reading a
+	// component or its OEM storage and network adapter properties, by default
+	// the components are inventoried without them.
+	FailInventoryOnError bool
+}
+
+// Option sets a setting of the iBMC [Config].
+type Option func(*Config)
+
+// WithHTTPClient sets the HTTP client for the iBMC requests.
+func WithHTTPClient(c *http.Client) Option {
+	return func(cfg *Config) { cfg.HTTPClient = c }
+}
+
+// WithPort sets the iBMC Redfish service port (default "443").
+func WithPort(port string) Option {
+	return func(cfg *Config) { cfg.Port = port }
+}
+
+// WithVersionsNotCompatible excludes the iBMC firmware reporting one of the
+// Redfish versions.
+func WithVersionsNotCompatible(versions []string) Option {
+	return func(cfg *Config) { cfg.VersionsNotCompatible = versions }
+}
+
+// WithRootCAs has the iBMC certificate verified against the pool.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(cfg *Config) { cfg.RootCAs = pool }
+}
+
+// WithUseBasicAuth authenticates the requests with HTTP Basic auth instead of
+// an iBMC Redfish session.
+func WithUseBasicAuth(use bool) Option {
+	return func(cfg *Config) { cfg.UseBasicAuth = use }
+}
+
+// WithFailInventoryOnError has Inventory fail on the first error reading the
+// OEM storage and network adapter properties.
+func WithFailInventoryOnError(fail bool) Option {
+	return func(cfg *Config) { cfg.FailInventoryOnError = fail }
+}
+
+// New returns a [Conn] for the given iBMC host. The connection is not opened
+// until [Conn.Open] is called.
+func New(host, user, pass string, log logr.Logger, opts ...Option) *Conn {
+	cfg := &Config{
+		HTTPClient:            httpclient.Build(),
+		Port:                  "443",
+		VersionsNotCompatible: []string{},
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	rfOpts := []redfishwrapper.Option{
+		redfishwrapper.WithHTTPClient(cfg.HTTPClient),
+		redfishwrapper.WithVersionsNotCompatible(cfg.VersionsNotCompatible),
+		redfishwrapper.WithBasicAuthEnabled(cfg.UseBasicAuth),
+	}
+
+	if cfg.RootCAs != nil {
+		rfOpts = append(rfOpts, redfishwrapper.WithSecureTLS(cfg.RootCAs))
+	}
+
+	return &Conn{
+		Log:                  log,
+		failInventoryOnError: cfg.FailInventoryOnError,
+		redfishwrapper:       redfishwrapper.NewClient(host, cfg.Port, user, pass, rfOpts...),
+	}
+}
+
+// Name returns the provider name ("xfusion").
+func (c *Conn) Name() string {
+	return ProviderName
+}
+
+// Open opens a Redfish session, or sets up Basic auth when [WithUseBasicAuth]
+// was given.
+func (c *Conn) Open(ctx context.Context) error {
+	return c.redfishwrapper.Open(ctx)
+}
+
+// Close releases the Redfish session.
+func (c *Conn) Close(ctx context.Context) error {
+	return c.redfishwrapper.Close(ctx)
+}
+
+// KeepSessionAlive refreshes the iBMC session, an expired session is re-established.
+//
+// Implements bmc.SessionKeeper.
+func (c *Conn) KeepSessionAlive(ctx context.Context) error {
+	return c.redfishwrapper.KeepSessionAlive(ctx)
+}
+
+// ProbeCapabilities probes the iBMC for the features it supports.
+//
+// Implements bmc.CapabilityProber.
+func (c *Conn) ProbeCapabilities(ctx context.Context) (*bmc.ProbedCapabilities, error) {
+	return c.redfishwrapper.ProbeCapabilities(ctx)
+}
+
+// Compatible reports whether the BMC is an iBMC managing a FusionServer
+// system.
+//
+// An iBMC session is opened for the check, the BMC is compatible when its
+// Redfish version is not excluded with [WithVersionsNotCompatible] and the
+// system manufacturer contains xFusion, or Huawei for the systems sold before
+// the spin-off.
+func (c *Conn) Compatible(ctx context.Context) bool {
+	if err := c.Open(ctx); err != nil {
+		c.Log.V(2).WithValues("provider", c.Name()).
+			Info(bmclibErrs.ErrCompatibilityCheck.Error(), "error", err.Error())
+
+		return false
+	}
+	defer func() { _ = c.Close(ctx) }()
+
+	if !c.redfishwrapper.VersionCompatible() {
+		c.Log.V(2).WithValues("provider", c.Name()).
+			Info(bmclibErrs.ErrCompatibilityCheck.Error(), "reason", "incompatible redfish version")
+
+		return false
+	}
+
+	vendor, _, err := c.redfishwrapper.DeviceVendorModel(ctx)
+	if err != nil {
+		c.Log.V(2).WithValues("provider", c.Name()).
+			Info(bmclibErrs.ErrCompatibilityCheck.Error(), "error", err.Error())
+
+		return false
+	}
+
+	for _, v := range vendors {
+		if strings.Contains(strings.ToLower(vendor), v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// oem returns the iBMC OEM properties of a resource.
+func oem[T any](properties map[string]T) (T, bool) {
+	for _, key := range oemKeys {
+		if p, ok := properties[key]; ok {
+			return p, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
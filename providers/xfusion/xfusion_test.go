@@ -0,0 +1,143 @@
+package xfusion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// Requirement: Provider identity and registration.
+func TestName(t *testing.T) {
+	if ProviderName != "xfusion" {
+		t.Fatalf("ProviderName = %q, want %q", ProviderName, "xfusion")
+	}
+	c := New("127.0.0.1", "u", "p", logr.Discard())
+	if got := c.Name(); got != ProviderName {
+		t.Fatalf("Name() = %q, want %q", got, ProviderName)
+	}
+}
+
+// Requirement: Connection lifecycle — Open creates a session, Close deletes it.
+func TestOpenClose(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.Close()
+
+	c := ts.client(t)
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if !ts.didCreateSession() {
+		t.Fatal("expected a session to be created on Open")
+	}
+
+	if err := c.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !ts.didDeleteSession() {
+		t.Fatal("expected the session to be deleted on Close")
+	}
+}
+
+// Requirement: Compatible identifies xFusion systems and honors excluded versions.
+func TestCompatible(t *testing.T) {
+	t.Run("xFusion system is compatible", func(t *testing.T) {
+		ts := newTestServer(t)
+		defer ts.Close()
+
+		if !ts.client(t).Compatible(context.Background()) {
+			t.Fatal("expected the iBMC to be compatible")
+		}
+	})
+
+	t.Run("excluded redfish version is not compatible", func(t *testing.T) {
+		ts := newTestServer(t)
+		defer ts.Close()
+
+		c := ts.client(t, WithVersionsNotCompatible([]string{"1.0.2"}))
+		if c.Compatible(context.Background()) {
+			t.Fatal("expected the excluded redfish version to be incompatible")
+		}
+	})
+}
+
+// Requirement: Power control via ComputerSystem.Reset.
+func TestPower(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	state, err := c.PowerStateGet(context.Background())
+	if err != nil {
+		t.Fatalf("PowerStateGet: %v", err)
+	}
+	if state != "On" {
+		t.Fatalf("PowerStateGet = %q, want %q", state, "On")
+	}
+
+	ok, err := c.PowerSet(context.Background(), "off")
+	if err != nil || !ok {
+		t.Fatalf("PowerSet(off) = (%v, %v), want (true, nil)", ok, err)
+	}
+	if rt := ts.resetType(); rt != "ForceOff" {
+		t.Fatalf("reset type = %q, want %q", rt, "ForceOff")
+	}
+}
+
+// Requirement: BIOS configuration get, set without an apply time, and reset.
+func TestBiosConfiguration(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	config, err := c.GetBiosConfiguration(context.Background())
+	if err != nil {
+		t.Fatalf("GetBiosConfiguration: %v", err)
+	}
+	if config["VTdSupport"] != "Enabled" {
+		t.Fatalf("VTdSupport = %q, want %q", config["VTdSupport"], "Enabled")
+	}
+
+	if err := c.SetBiosConfiguration(context.Background(), map[string]string{"VTdSupport": "Disabled"}); err != nil {
+		t.Fatalf("SetBiosConfiguration: %v", err)
+	}
+	attributes, _ := ts.biosPatch()["Attributes"].(map[string]any)
+	if attributes["VTdSupport"] != "Disabled" {
+		t.Fatalf("bios PATCH body = %v", ts.biosPatch())
+	}
+	if _, ok := ts.biosPatch()["@Redfish.SettingsApplyTime"]; ok {
+		t.Fatalf("bios PATCH body includes an apply time: %v", ts.biosPatch())
+	}
+
+	if err := c.ResetBiosConfiguration(context.Background()); err != nil {
+		t.Fatalf("ResetBiosConfiguration: %v", err)
+	}
+	if !ts.didResetBios() {
+		t.Fatal("expected the Bios.ResetBios action to be posted")
+	}
+}
+
+// Requirement: virtual media is connected through the OEM VmmControl action,
+// connected media is disconnected first.
+func TestSetVirtualMedia(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.openedClient(t)
+
+	ok, err := c.SetVirtualMedia(context.Background(), "CD", "nfs://10.0.0.1/images/new.iso")
+	if err != nil || !ok {
+		t.Fatalf("SetVirtualMedia = (%v, %v), want (true, nil)", ok, err)
+	}
+
+	controls := ts.vmmControlBodies()
+	if len(controls) != 2 {
+		t.Fatalf("got %d VmmControl requests, want 2", len(controls))
+	}
+	if controls[0]["VmmControlType"] != "Disconnect" {
+		t.Fatalf("first VmmControl = %v, want a Disconnect", controls[0])
+	}
+	if controls[1]["VmmControlType"] != "Connect" || controls[1]["Image"] != "nfs://10.0.0.1/images/new.iso" {
+		t.Fatalf("second VmmControl = %v, want a Connect of the image", controls[1])
+	}
+
+	if _, err := c.SetVirtualMedia(context.Background(), "Floppy", "nfs://10.0.0.1/images/new.img"); err == nil {
+		t.Fatal("expected an error for an unsupported media type")
+	}
+}